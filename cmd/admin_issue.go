@@ -0,0 +1,137 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/modules/private"
+	"code.gitea.io/gitea/modules/setting"
+
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	subcmdIssue = &cli.Command{
+		Name:  "issue",
+		Usage: "Modify issues",
+		Subcommands: []*cli.Command{
+			microcmdIssueBulkEdit,
+		},
+	}
+
+	microcmdIssueBulkEdit = &cli.Command{
+		Name:  "bulk-edit",
+		Usage: "Edit the issues and pull requests of a repository matching a search query",
+		Description: `The issues are edited in a single transaction by the running server, as the given user,
+and the usual notifications are sent. The labels, milestone and users are given by name.`,
+		Action: runIssueBulkEdit,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "repo",
+				Usage:    "{owner}/{repo} - the repository of the issues",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "doer",
+				Usage:    "Username of the user editing the issues",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "query",
+				Aliases:  []string{"q"},
+				Usage:    `Structured search query of the issues to edit, such as "is:open label:bug"`,
+				Required: true,
+			},
+			&cli.StringSliceFlag{
+				Name:  "add-label",
+				Usage: "Name of a label to add",
+			},
+			&cli.StringSliceFlag{
+				Name:  "remove-label",
+				Usage: "Name of a label to remove",
+			},
+			&cli.StringFlag{
+				Name:  "milestone",
+				Usage: "Name of the new milestone",
+			},
+			&cli.BoolFlag{
+				Name:  "remove-milestone",
+				Usage: "Remove the milestone",
+			},
+			&cli.StringSliceFlag{
+				Name:  "add-assignee",
+				Usage: "Username of a user to assign",
+			},
+			&cli.StringSliceFlag{
+				Name:  "remove-assignee",
+				Usage: "Username of a user to unassign",
+			},
+			&cli.StringFlag{
+				Name:  "state",
+				Usage: "New state of the issues, open or closed",
+			},
+			&cli.BoolFlag{
+				Name:  "no-wait",
+				Usage: "Do not wait for the end of the edition",
+			},
+		},
+	}
+)
+
+func runIssueBulkEdit(c *cli.Context) error {
+	ownerName, repoName, ok := strings.Cut(c.String("repo"), "/")
+	if !ok {
+		return errors.New("the repository must be given as {owner}/{repo}")
+	}
+	if c.IsSet("milestone") && c.Bool("remove-milestone") {
+		return errors.New("--milestone and --remove-milestone cannot be used together")
+	}
+
+	ctx, cancel := installSignals()
+	defer cancel()
+
+	setting.MustInstalled()
+
+	task, extra := private.BulkEditIssues(ctx, ownerName, repoName, private.BulkEditIssuesOptions{
+		Doer:            c.String("doer"),
+		Query:           c.String("query"),
+		AddLabels:       c.StringSlice("add-label"),
+		RemoveLabels:    c.StringSlice("remove-label"),
+		Milestone:       c.String("milestone"),
+		RemoveMilestone: c.Bool("remove-milestone"),
+		AddAssignees:    c.StringSlice("add-assignee"),
+		RemoveAssignees: c.StringSlice("remove-assignee"),
+		State:           c.String("state"),
+	})
+	if extra.HasError() {
+		return handleCliResponseExtra(extra)
+	}
+	fmt.Printf("Editing %d issues (task %d)\n", task.Total, task.ID)
+	if c.Bool("no-wait") {
+		return nil
+	}
+
+	for task.Status == "queued" || task.Status == "running" {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+		}
+		if task, extra = private.GetBulkEditIssuesTask(ctx, ownerName, repoName, task.ID); extra.HasError() {
+			return handleCliResponseExtra(extra)
+		}
+		if task.Status == "running" {
+			fmt.Printf("%d/%d issues edited\n", task.Done, task.Total)
+		}
+	}
+	if task.Status == "failed" {
+		return cli.Exit(fmt.Sprintf("Editing the issues failed: %s", task.Message), 1)
+	}
+	fmt.Printf("%d issues edited\n", task.Total)
+	return nil
+}
@@ -33,7 +33,9 @@ type Task struct {
 	StartTime      timeutil.TimeStamp
 	EndTime        timeutil.TimeStamp
 	PayloadContent string             `xorm:"TEXT"`
-	Message        string             `xorm:"TEXT"` // if task failed, saved the error reason, it could be a JSON string of TranslatableMessage or a plain message
+	Message        string             `xorm:"TEXT"`               // if task failed, saved the error reason, it could be a JSON string of TranslatableMessage or a plain message
+	ProgressDone   int64              `xorm:"NOT NULL DEFAULT 0"` // number of items processed by a task reporting its progress
+	ProgressTotal  int64              `xorm:"NOT NULL DEFAULT 0"` // number of items to process by a task reporting its progress
 	Created        timeutil.TimeStamp `xorm:"created"`
 }
 
@@ -200,6 +202,18 @@ func GetMigratingTaskByID(ctx context.Context, id, doerID int64) (*Task, *migrat
 	return &task, &opts, nil
 }
 
+// GetRepoTaskByID returns a task of a repository by its type and id
+func GetRepoTaskByID(ctx context.Context, repoID int64, taskType structs.TaskType, id int64) (*Task, error) {
+	task := new(Task)
+	has, err := db.GetEngine(ctx).Where("id=? AND repo_id=? AND type=?", id, repoID, taskType).Get(task)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrTaskDoesNotExist{id, repoID, taskType}
+	}
+	return task, nil
+}
+
 // CreateTask creates a task on database
 func CreateTask(ctx context.Context, task *Task) error {
 	return db.Insert(ctx, task)
@@ -0,0 +1,87 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package asymkey
+
+import (
+	"context"
+	"io"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+)
+
+// This file provides functions to verify detached signatures of arbitrary content (e.g. package files)
+
+// ErrDetachedSignatureNotVerified is returned when no key of the user verifies a detached signature
+var ErrDetachedSignatureNotVerified = util.NewInvalidArgumentErrorf("signature could not be verified with any key of the user")
+
+// VerifyDetachedGPGSignature checks an armored detached GPG signature of the content against the verified
+// GPG keys (and their sub keys) of the user and returns the key which made the signature.
+func VerifyDetachedGPGSignature(ctx context.Context, ownerID int64, content io.ReadSeeker, signature string) (*GPGKey, error) {
+	sig, err := extractSignature(signature)
+	if err != nil {
+		return nil, util.NewInvalidArgumentErrorf("%v", err)
+	}
+
+	keys, err := db.Find[GPGKey](ctx, FindGPGKeyOptions{
+		OwnerID:        ownerID,
+		IncludeSubKeys: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	primaryKeys := make(map[string]*GPGKey, len(keys))
+	for _, k := range keys {
+		if k.PrimaryKeyID == "" {
+			primaryKeys[k.KeyID] = k
+		}
+	}
+
+	issuer := tryGetKeyIDFromSignature(sig)
+	for _, k := range keys {
+		if issuer != "" && k.KeyID != issuer {
+			continue
+		}
+
+		primary := k
+		if k.PrimaryKeyID != "" {
+			primary = primaryKeys[k.PrimaryKeyID]
+		}
+		if primary == nil || !primary.Verified {
+			continue
+		}
+
+		verified, err := hashAndVerifyReader(sig, content, k)
+		if err != nil {
+			return nil, err
+		}
+		if verified {
+			return primary, nil
+		}
+	}
+
+	return nil, ErrDetachedSignatureNotVerified
+}
+
+func hashAndVerifyReader(sig *packet.Signature, content io.ReadSeeker, k *GPGKey) (bool, error) {
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return false, err
+	}
+
+	h := sig.Hash.New()
+	if _, err := io.Copy(h, content); err != nil {
+		return false, err
+	}
+
+	// We will ignore errors in verification as they don't need to be propagated up
+	if err := verifySign(sig, h, k); err != nil {
+		log.Trace("verifySign: %v", err)
+		return false, nil
+	}
+	return true, nil
+}
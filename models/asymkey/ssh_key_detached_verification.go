@@ -0,0 +1,44 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package asymkey
+
+import (
+	"context"
+	"io"
+
+	"code.gitea.io/gitea/models/db"
+
+	"github.com/42wim/sshsig"
+)
+
+// DetachedSSHSignatureNamespace is the namespace expected in detached SSH signatures,
+// it is the default of `ssh-keygen -Y sign -n file`.
+const DetachedSSHSignatureNamespace = "file"
+
+// VerifyDetachedSSHSignature checks an armored detached SSH signature of the content against the
+// verified SSH keys of the user and returns the key which made the signature.
+func VerifyDetachedSSHSignature(ctx context.Context, ownerID int64, content io.ReadSeeker, signature string) (*PublicKey, error) {
+	keys, err := db.Find[PublicKey](ctx, FindPublicKeyOptions{
+		OwnerID:    ownerID,
+		NotKeytype: KeyTypePrincipal,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, k := range keys {
+		if !k.Verified {
+			continue
+		}
+
+		if _, err := content.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		if err := sshsig.Verify(content, []byte(signature), []byte(k.Content), DetachedSSHSignatureNamespace); err == nil {
+			return k, nil
+		}
+	}
+
+	return nil, ErrDetachedSignatureNotVerified
+}
@@ -0,0 +1,71 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package asymkey
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/perm"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/42wim/sshsig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestVerifyDetachedSSHSignature(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	block, err := ssh.MarshalPrivateKey(priv, "")
+	require.NoError(t, err)
+	signer, err := ssh.NewSignerFromKey(priv)
+	require.NoError(t, err)
+
+	key := &PublicKey{
+		OwnerID:  2,
+		Name:     "package-signing",
+		Content:  strings.TrimSpace(string(ssh.MarshalAuthorizedKey(signer.PublicKey()))),
+		Mode:     perm.AccessModeWrite,
+		Type:     KeyTypeUser,
+		Verified: true,
+	}
+	require.NoError(t, db.Insert(db.DefaultContext, key))
+
+	content := []byte("package content")
+	signature, err := sshsig.Sign(pem.EncodeToMemory(block), bytes.NewReader(content), DetachedSSHSignatureNamespace)
+	require.NoError(t, err)
+
+	t.Run("Valid", func(t *testing.T) {
+		k, err := VerifyDetachedSSHSignature(db.DefaultContext, 2, bytes.NewReader(content), string(signature))
+		require.NoError(t, err)
+		assert.Equal(t, key.ID, k.ID)
+	})
+
+	t.Run("Modified content", func(t *testing.T) {
+		_, err := VerifyDetachedSSHSignature(db.DefaultContext, 2, bytes.NewReader([]byte("other content")), string(signature))
+		assert.ErrorIs(t, err, ErrDetachedSignatureNotVerified)
+	})
+
+	t.Run("Other user", func(t *testing.T) {
+		_, err := VerifyDetachedSSHSignature(db.DefaultContext, 4, bytes.NewReader(content), string(signature))
+		assert.ErrorIs(t, err, ErrDetachedSignatureNotVerified)
+	})
+
+	t.Run("Wrong namespace", func(t *testing.T) {
+		signature, err := sshsig.Sign(pem.EncodeToMemory(block), bytes.NewReader(content), "git")
+		require.NoError(t, err)
+
+		_, err = VerifyDetachedSSHSignature(db.DefaultContext, 2, bytes.NewReader(content), string(signature))
+		assert.ErrorIs(t, err, ErrDetachedSignatureNotVerified)
+	})
+}
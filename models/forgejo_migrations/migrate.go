@@ -78,6 +78,52 @@ var migrations = []*Migration{
 	NewMigration("Add external_url to attachment table", AddExternalURLColumnToAttachmentTable),
 	// v20 -> v21
 	NewMigration("Creating Quota-related tables", CreateQuotaTables),
+	// v21 -> v22
+	NewMigration("Create the `package_signature` table", CreatePackageSignatureTable),
+	// v22 -> v23
+	NewMigration("Add retention policies to package cleanup rules", AddPackageRetentionPolicies),
+	// v23 -> v24
+	NewMigration("Create the `package_registry_setting` and `package_promotion` tables", CreatePackageRegistrySettingAndPromotionTables),
+	// v24 -> v25
+	NewMigration("Create the `package_sbom` and `package_vulnerability` tables", CreatePackageSBOMAndVulnerabilityTables),
+	// v25 -> v26
+	NewMigration("Create the `package_download_stat` table", CreatePackageDownloadStatTable),
+	// v26 -> v27
+	NewMigration("Add merge queues of protected branches", AddMergeQueue),
+	// v27 -> v28
+	NewMigration("Add `parent_pull_id` to `pull_request` for stacked pull requests", AddParentPullIDToPullRequest),
+	// v28 -> v29
+	NewMigration("Add `require_code_owner_approval` to `protected_branch`", AddRequireCodeOwnerApprovalToProtectedBranch),
+	// v29 -> v30
+	NewMigration("Create the `pull_revision` and `pull_reviewed_revision` tables", CreatePullRevisionTables),
+	// v30 -> v31
+	NewMigration("Create the `org_ruleset` and `org_ruleset_violation` tables", CreateOrgRulesetTables),
+	// v31 -> v32
+	NewMigration("Create the `push_rule` table", CreatePushRuleTable),
+	// v32 -> v33
+	NewMigration("Create the `merge_message_template` table", CreateMergeMessageTemplateTable),
+	// v33 -> v34
+	NewMigration("Create the `commit_comment` table", CreateCommitCommentTable),
+	// v34 -> v35
+	NewMigration("Create the `custom_field` and `issue_custom_field_value` tables", CreateCustomFieldTables),
+	// v35 -> v36
+	NewMigration("Create the `saved_issue_search` table", CreateSavedIssueSearchTable),
+	// v36 -> v37
+	NewMigration("Create the `project_view` table", CreateProjectViewTable),
+	// v37 -> v38
+	NewMigration("Create the `project_automation` table", CreateProjectAutomationTable),
+	// v38 -> v39
+	NewMigration("Create the `sub_issue` table", CreateSubIssueTable),
+	// v39 -> v40
+	NewMigration("Add the progress columns to the `task` table", AddProgressToTask),
+	// v40 -> v41
+	NewMigration("Create the `issue_redirect` table", CreateIssueRedirectTable),
+	// v41 -> v42
+	NewMigration("Create the `sla_policy` and `issue_sla_breach` tables", CreateSLAPolicyTables),
+	// v42 -> v43
+	NewMigration("Create the `issue_form` table", CreateIssueFormTable),
+	// v43 -> v44
+	NewMigration("Create the `issue_schedule` table", CreateIssueScheduleTable),
 }
 
 // GetCurrentDBVersion returns the current Forgejo database version.
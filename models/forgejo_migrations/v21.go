@@ -0,0 +1,25 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package forgejo_migrations //nolint:revive
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func CreatePackageSignatureTable(x *xorm.Engine) error {
+	type PackageSignature struct {
+		ID          int64              `xorm:"pk autoincr"`
+		VersionID   int64              `xorm:"INDEX NOT NULL"`
+		FileID      int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		Type        string             `xorm:"UNIQUE(s) NOT NULL"`
+		SignerID    int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		KeyID       string             `xorm:"NOT NULL"`
+		Content     string             `xorm:"LONGTEXT NOT NULL"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL"`
+	}
+
+	return x.Sync(new(PackageSignature))
+}
@@ -0,0 +1,36 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package forgejo_migrations //nolint:revive
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddPackageRetentionPolicies(x *xorm.Engine) error {
+	type PackageCleanupRule struct {
+		KeepTagPattern     string `xorm:"NOT NULL DEFAULT ''"`
+		KeepPerMajorCount  int    `xorm:"NOT NULL DEFAULT 0"`
+		KeepDownloadedDays int    `xorm:"NOT NULL DEFAULT 0"`
+		RemoveUntagged     bool   `xorm:"NOT NULL DEFAULT false"`
+	}
+
+	type PackageVersion struct {
+		LastDownloadUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	}
+
+	type PackageCleanupRecord struct {
+		ID          int64              `xorm:"pk autoincr"`
+		RuleID      int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+		OwnerID     int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+		Type        string             `xorm:"NOT NULL"`
+		Name        string             `xorm:"NOT NULL"`
+		Version     string             `xorm:"NOT NULL"`
+		Reason      string             `xorm:"NOT NULL DEFAULT ''"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created INDEX NOT NULL"`
+	}
+
+	return x.Sync(new(PackageCleanupRule), new(PackageVersion), new(PackageCleanupRecord))
+}
@@ -0,0 +1,36 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package forgejo_migrations //nolint:revive
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func CreatePackageRegistrySettingAndPromotionTables(x *xorm.Engine) error {
+	type PackageRegistrySetting struct {
+		ID          int64              `xorm:"pk autoincr"`
+		OwnerID     int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		Type        string             `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		Immutable   bool               `xorm:"NOT NULL DEFAULT false"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL DEFAULT 0"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated NOT NULL DEFAULT 0"`
+	}
+
+	type PackagePromotion struct {
+		ID              int64              `xorm:"pk autoincr"`
+		Type            string             `xorm:"NOT NULL"`
+		Name            string             `xorm:"NOT NULL"`
+		Version         string             `xorm:"NOT NULL"`
+		SourceOwnerID   int64              `xorm:"INDEX NOT NULL"`
+		SourceVersionID int64              `xorm:"INDEX NOT NULL"`
+		TargetOwnerID   int64              `xorm:"INDEX NOT NULL"`
+		TargetVersionID int64              `xorm:"INDEX NOT NULL"`
+		DoerID          int64              `xorm:"NOT NULL DEFAULT 0"`
+		CreatedUnix     timeutil.TimeStamp `xorm:"created INDEX NOT NULL"`
+	}
+
+	return x.Sync(new(PackageRegistrySetting), new(PackagePromotion))
+}
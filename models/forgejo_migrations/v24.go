@@ -0,0 +1,36 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package forgejo_migrations //nolint:revive
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func CreatePackageSBOMAndVulnerabilityTables(x *xorm.Engine) error {
+	type PackageSBOM struct {
+		ID          int64              `xorm:"pk autoincr"`
+		VersionID   int64              `xorm:"UNIQUE NOT NULL"`
+		Format      string             `xorm:"NOT NULL"`
+		Content     string             `xorm:"LONGTEXT NOT NULL"`
+		CreatorID   int64              `xorm:"NOT NULL DEFAULT 0"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated NOT NULL"`
+	}
+
+	type PackageVulnerability struct {
+		ID           int64              `xorm:"pk autoincr"`
+		OSVID        string             `xorm:"'osv_id' UNIQUE(s) NOT NULL"`
+		Ecosystem    string             `xorm:"UNIQUE(s) INDEX(p) NOT NULL"`
+		LowerName    string             `xorm:"UNIQUE(s) INDEX(p) NOT NULL"`
+		Aliases      []string           `xorm:"TEXT JSON"`
+		Summary      string             `xorm:"TEXT"`
+		Severity     string             `xorm:"NOT NULL DEFAULT ''"`
+		AffectedJSON string             `xorm:"'affected_json' LONGTEXT"`
+		ModifiedUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	}
+
+	return x.Sync(new(PackageSBOM), new(PackageVulnerability))
+}
@@ -0,0 +1,23 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package forgejo_migrations //nolint:revive
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func CreatePackageDownloadStatTable(x *xorm.Engine) error {
+	type PackageDownloadStat struct {
+		ID            int64              `xorm:"pk autoincr"`
+		VersionID     int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		DayUnix       timeutil.TimeStamp `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		Client        string             `xorm:"UNIQUE(s) NOT NULL"`
+		Authenticated bool               `xorm:"UNIQUE(s) NOT NULL"`
+		Count         int64              `xorm:"NOT NULL DEFAULT 0"`
+	}
+
+	return x.Sync(new(PackageDownloadStat))
+}
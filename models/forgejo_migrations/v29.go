@@ -0,0 +1,33 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package forgejo_migrations //nolint:revive
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func CreatePullRevisionTables(x *xorm.Engine) error {
+	type PullRevision struct {
+		ID          int64              `xorm:"pk autoincr"`
+		PullID      int64              `xorm:"UNIQUE(s) NOT NULL"`
+		Index       int64              `xorm:"UNIQUE(s) NOT NULL"`
+		CommitID    string             `xorm:"VARCHAR(64) NOT NULL"`
+		MergeBase   string             `xorm:"VARCHAR(64)"`
+		PusherID    int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+		IsForcePush bool               `xorm:"NOT NULL DEFAULT false"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	}
+
+	type PullReviewedRevision struct {
+		ID            int64              `xorm:"pk autoincr"`
+		UserID        int64              `xorm:"UNIQUE(s) NOT NULL"`
+		PullID        int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		RevisionIndex int64              `xorm:"NOT NULL"`
+		UpdatedUnix   timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	return x.Sync(new(PullRevision), new(PullReviewedRevision))
+}
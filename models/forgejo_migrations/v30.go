@@ -0,0 +1,45 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package forgejo_migrations //nolint:revive
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func CreateOrgRulesetTables(x *xorm.Engine) error {
+	type OrgRuleset struct {
+		ID                     int64              `xorm:"pk autoincr"`
+		OrgID                  int64              `xorm:"INDEX NOT NULL"`
+		Name                   string             `xorm:"NOT NULL"`
+		Enforcement            int                `xorm:"NOT NULL DEFAULT 0"`
+		RepoNamePattern        string             `xorm:"TEXT"`
+		RepoTopics             []string           `xorm:"TEXT JSON"`
+		RefPattern             string             `xorm:"TEXT NOT NULL"`
+		RequirePullRequest     bool               `xorm:"NOT NULL DEFAULT false"`
+		RequiredApprovals      int64              `xorm:"NOT NULL DEFAULT 0"`
+		BlockOnRejectedReviews bool               `xorm:"NOT NULL DEFAULT false"`
+		DismissStaleApprovals  bool               `xorm:"NOT NULL DEFAULT false"`
+		RequireSignedCommits   bool               `xorm:"NOT NULL DEFAULT false"`
+		EnableStatusCheck      bool               `xorm:"NOT NULL DEFAULT false"`
+		StatusCheckContexts    []string           `xorm:"JSON TEXT"`
+		ApplyToAdmins          bool               `xorm:"NOT NULL DEFAULT false"`
+		CreatedUnix            timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix            timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	type OrgRulesetViolation struct {
+		ID          int64              `xorm:"pk autoincr"`
+		RulesetID   int64              `xorm:"INDEX NOT NULL"`
+		RepoID      int64              `xorm:"INDEX NOT NULL"`
+		BranchName  string             `xorm:"TEXT"`
+		PusherID    int64              `xorm:"NOT NULL DEFAULT 0"`
+		CommitID    string             `xorm:"VARCHAR(64)"`
+		Reason      string             `xorm:"TEXT"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created INDEX"`
+	}
+
+	return x.Sync(new(OrgRuleset), new(OrgRulesetViolation))
+}
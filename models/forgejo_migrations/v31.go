@@ -0,0 +1,27 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package forgejo_migrations //nolint:revive
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func CreatePushRuleTable(x *xorm.Engine) error {
+	type PushRule struct {
+		ID                    int64              `xorm:"pk autoincr"`
+		OwnerID               int64              `xorm:"UNIQUE(s) NOT NULL"`
+		RepoID                int64              `xorm:"UNIQUE(s) NOT NULL DEFAULT 0"`
+		CommitMessagePattern  string             `xorm:"TEXT"`
+		RequireVerifiedEmail  bool               `xorm:"NOT NULL DEFAULT false"`
+		MaxFileSize           int64              `xorm:"NOT NULL DEFAULT 0"`
+		ForbiddenPathPatterns string             `xorm:"TEXT"`
+		DetectSecrets         bool               `xorm:"NOT NULL DEFAULT false"`
+		CreatedUnix           timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix           timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	return x.Sync(new(PushRule))
+}
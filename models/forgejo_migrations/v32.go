@@ -0,0 +1,25 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package forgejo_migrations //nolint:revive
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func CreateMergeMessageTemplateTable(x *xorm.Engine) error {
+	type MergeMessageTemplate struct {
+		ID            int64              `xorm:"pk autoincr"`
+		RepoID        int64              `xorm:"INDEX NOT NULL"`
+		BranchPattern string             `xorm:"NOT NULL"`
+		MergeStyle    string             `xorm:"VARCHAR(30) NOT NULL DEFAULT ''"`
+		Template      string             `xorm:"TEXT NOT NULL"`
+		AddTrailers   bool               `xorm:"NOT NULL DEFAULT false"`
+		CreatedUnix   timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix   timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	return x.Sync(new(MergeMessageTemplate))
+}
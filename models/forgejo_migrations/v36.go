@@ -0,0 +1,31 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package forgejo_migrations //nolint:revive
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func CreateProjectViewTable(x *xorm.Engine) error {
+	type ProjectView struct {
+		ID           int64              `xorm:"pk autoincr"`
+		ProjectID    int64              `xorm:"INDEX NOT NULL"`
+		Name         string             `xorm:"NOT NULL"`
+		Type         string             `xorm:"VARCHAR(20) NOT NULL"`
+		Filter       string             `xorm:"TEXT"`
+		GroupBy      string             `xorm:"VARCHAR(20) NOT NULL DEFAULT ''"`
+		GroupFieldID int64              `xorm:"NOT NULL DEFAULT 0"`
+		SortBy       string             `xorm:"VARCHAR(20) NOT NULL DEFAULT ''"`
+		SortFieldID  int64              `xorm:"NOT NULL DEFAULT 0"`
+		SortDesc     bool               `xorm:"NOT NULL DEFAULT false"`
+		Sorting      int64              `xorm:"NOT NULL DEFAULT 0"`
+		CreatorID    int64              `xorm:"NOT NULL"`
+		CreatedUnix  timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix  timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	return x.Sync(new(ProjectView))
+}
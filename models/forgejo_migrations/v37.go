@@ -0,0 +1,24 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package forgejo_migrations //nolint:revive
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func CreateProjectAutomationTable(x *xorm.Engine) error {
+	type ProjectAutomation struct {
+		ID          int64              `xorm:"pk autoincr"`
+		ProjectID   int64              `xorm:"INDEX NOT NULL"`
+		Event       string             `xorm:"VARCHAR(20) NOT NULL"`
+		LabelID     int64              `xorm:"NOT NULL DEFAULT 0"`
+		ColumnID    int64              `xorm:"NOT NULL"`
+		CreatorID   int64              `xorm:"NOT NULL"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	}
+
+	return x.Sync(new(ProjectAutomation))
+}
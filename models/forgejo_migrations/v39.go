@@ -0,0 +1,15 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package forgejo_migrations //nolint:revive
+
+import "xorm.io/xorm"
+
+func AddProgressToTask(x *xorm.Engine) error {
+	type Task struct {
+		ProgressDone  int64 `xorm:"NOT NULL DEFAULT 0"`
+		ProgressTotal int64 `xorm:"NOT NULL DEFAULT 0"`
+	}
+
+	return x.Sync(new(Task))
+}
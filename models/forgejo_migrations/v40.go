@@ -0,0 +1,22 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package forgejo_migrations //nolint:revive
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func CreateIssueRedirectTable(x *xorm.Engine) error {
+	type IssueRedirect struct {
+		ID          int64              `xorm:"pk autoincr"`
+		OldRepoID   int64              `xorm:"UNIQUE(s) NOT NULL"`
+		OldIndex    int64              `xorm:"UNIQUE(s) NOT NULL"`
+		IssueID     int64              `xorm:"INDEX NOT NULL"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	}
+
+	return x.Sync(new(IssueRedirect))
+}
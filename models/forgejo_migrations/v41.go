@@ -0,0 +1,33 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package forgejo_migrations //nolint:revive
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func CreateSLAPolicyTables(x *xorm.Engine) error {
+	type SLAPolicy struct {
+		ID                 int64              `xorm:"pk autoincr"`
+		RepoID             int64              `xorm:"INDEX NOT NULL"`
+		Name               string             `xorm:"NOT NULL"`
+		LabelID            int64              `xorm:"NOT NULL DEFAULT 0"`
+		FirstResponseHours int64              `xorm:"NOT NULL DEFAULT 0"`
+		ResolutionHours    int64              `xorm:"NOT NULL DEFAULT 0"`
+		CreatedUnix        timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix        timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	type IssueSLABreach struct {
+		ID          int64              `xorm:"pk autoincr"`
+		IssueID     int64              `xorm:"UNIQUE(s) NOT NULL"`
+		PolicyID    int64              `xorm:"UNIQUE(s) NOT NULL"`
+		Kind        string             `xorm:"VARCHAR(20) UNIQUE(s) NOT NULL"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	}
+
+	return x.Sync(new(SLAPolicy), new(IssueSLABreach))
+}
@@ -0,0 +1,22 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package forgejo_migrations //nolint:revive
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func CreateIssueFormTable(x *xorm.Engine) error {
+	type IssueForm struct {
+		ID           int64              `xorm:"pk autoincr"`
+		IssueID      int64              `xorm:"UNIQUE NOT NULL"`
+		TemplateFile string             `xorm:"NOT NULL"`
+		Answers      string             `xorm:"TEXT"`
+		CreatedUnix  timeutil.TimeStamp `xorm:"created"`
+	}
+
+	return x.Sync(new(IssueForm))
+}
@@ -0,0 +1,33 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package forgejo_migrations //nolint:revive
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func CreateIssueScheduleTable(x *xorm.Engine) error {
+	type IssueSchedule struct {
+		ID             int64              `xorm:"pk autoincr"`
+		RepoID         int64              `xorm:"INDEX NOT NULL"`
+		CreatorID      int64              `xorm:"NOT NULL"`
+		Title          string             `xorm:"NOT NULL DEFAULT ''"`
+		Content        string             `xorm:"LONGTEXT"`
+		TemplateFile   string             `xorm:"NOT NULL DEFAULT ''"`
+		Interval       string             `xorm:"VARCHAR(20) NOT NULL"`
+		StartUnix      timeutil.TimeStamp `xorm:"NOT NULL"`
+		NextRunUnix    timeutil.TimeStamp `xorm:"INDEX NOT NULL"`
+		LastRunUnix    timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+		LastIssueID    int64              `xorm:"NOT NULL DEFAULT 0"`
+		AssigneeTeamID int64              `xorm:"NOT NULL DEFAULT 0"`
+		RotationIndex  int64              `xorm:"NOT NULL DEFAULT 0"`
+		IsActive       bool               `xorm:"INDEX NOT NULL DEFAULT true"`
+		CreatedUnix    timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix    timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	return x.Sync(new(IssueSchedule))
+}
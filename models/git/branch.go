@@ -368,7 +368,7 @@ func RenameBranch(ctx context.Context, repo *repo_model.Repository, from, to str
 		}
 	} else {
 		// some glob protect rules may match this branch
-		protected, err := IsBranchProtected(ctx, repo.ID, from)
+		protected, err := IsBranchProtected(ctx, repo, from)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,163 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+
+	"code.gitea.io/gitea/models/db"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// CommitComment is a comment on a commit of a repository, outside of any pull request.
+// It is attached to the whole commit, to a file of the commit, or to a line of a file.
+type CommitComment struct {
+	ID        int64            `xorm:"pk autoincr"`
+	RepoID    int64            `xorm:"INDEX NOT NULL"`
+	CommitSHA string           `xorm:"VARCHAR(64) INDEX NOT NULL"`
+	PosterID  int64            `xorm:"INDEX NOT NULL"`
+	Poster    *user_model.User `xorm:"-"`
+	// TreePath is the file the comment is attached to, empty for the whole commit
+	TreePath string
+	// Line is the line of the file in the commit, negative for a line of the parent version, 0 for the whole file
+	Line            int64
+	Content         string        `xorm:"LONGTEXT"`
+	RenderedContent template.HTML `xorm:"-"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"INDEX updated"`
+}
+
+func init() {
+	db.RegisterModel(new(CommitComment))
+}
+
+// ErrCommitCommentNotExist represents a "CommitCommentNotExist" kind of error.
+type ErrCommitCommentNotExist struct {
+	ID int64
+}
+
+// IsErrCommitCommentNotExist checks if an error is a ErrCommitCommentNotExist.
+func IsErrCommitCommentNotExist(err error) bool {
+	_, ok := err.(ErrCommitCommentNotExist)
+	return ok
+}
+
+func (err ErrCommitCommentNotExist) Error() string {
+	return fmt.Sprintf("commit comment does not exist [id: %d]", err.ID)
+}
+
+func (err ErrCommitCommentNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// UnsignedLine returns the line of the comment without its sign
+func (c *CommitComment) UnsignedLine() uint64 {
+	if c.Line < 0 {
+		return uint64(c.Line * -1)
+	}
+	return uint64(c.Line)
+}
+
+// HashTag returns the anchor of the comment on the commit page
+func (c *CommitComment) HashTag() string {
+	return fmt.Sprintf("commitcomment-%d", c.ID)
+}
+
+// LoadPoster loads the poster of the comment
+func (c *CommitComment) LoadPoster(ctx context.Context) (err error) {
+	if c.Poster != nil {
+		return nil
+	}
+	c.Poster, err = user_model.GetPossibleUserByID(ctx, c.PosterID)
+	if user_model.IsErrUserNotExist(err) {
+		c.Poster = user_model.NewGhostUser()
+		err = nil
+	}
+	return err
+}
+
+// CommitCommentList is a list of commit comments
+type CommitCommentList []*CommitComment
+
+// LoadPosters loads the posters of the comments
+func (comments CommitCommentList) LoadPosters(ctx context.Context) error {
+	ids := container.FilterSlice(comments, func(c *CommitComment) (int64, bool) {
+		return c.PosterID, c.Poster == nil
+	})
+
+	usersMap := make(map[int64]*user_model.User, len(ids))
+	if err := db.GetEngine(ctx).In("id", ids).Find(&usersMap); err != nil {
+		return err
+	}
+	for _, c := range comments {
+		if c.Poster != nil {
+			continue
+		}
+		c.Poster = usersMap[c.PosterID]
+		if c.Poster == nil {
+			c.Poster = user_model.NewGhostUser()
+		}
+	}
+	return nil
+}
+
+// FindCommitCommentsOptions represents the options to find the comments of commits
+type FindCommitCommentsOptions struct {
+	db.ListOptions
+	RepoID    int64
+	CommitSHA string
+}
+
+func (opts FindCommitCommentsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if opts.CommitSHA != "" {
+		cond = cond.And(builder.Eq{"commit_sha": opts.CommitSHA})
+	}
+	return cond
+}
+
+func (opts FindCommitCommentsOptions) ToOrders() string {
+	return "created_unix ASC, id ASC"
+}
+
+// GetCommitCommentByID returns the comment of a repository by its ID
+func GetCommitCommentByID(ctx context.Context, repoID, id int64) (*CommitComment, error) {
+	c := &CommitComment{}
+	has, err := db.GetEngine(ctx).Where("id = ? AND repo_id = ?", id, repoID).Get(c)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrCommitCommentNotExist{ID: id}
+	}
+	return c, nil
+}
+
+// CreateCommitComment creates a commit comment
+func CreateCommitComment(ctx context.Context, c *CommitComment) error {
+	_, err := db.GetEngine(ctx).Insert(c)
+	return err
+}
+
+// UpdateCommitCommentContent updates the content of a commit comment
+func UpdateCommitCommentContent(ctx context.Context, c *CommitComment) error {
+	_, err := db.GetEngine(ctx).ID(c.ID).Cols("content").Update(c)
+	return err
+}
+
+// DeleteCommitComment deletes a commit comment
+func DeleteCommitComment(ctx context.Context, c *CommitComment) error {
+	_, err := db.GetEngine(ctx).ID(c.ID).NoAutoCondition().Delete(c)
+	return err
+}
@@ -0,0 +1,59 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git_test
+
+import (
+	"fmt"
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	git_model "code.gitea.io/gitea/models/git"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommitComments(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	const (
+		repoID = 1
+		sha    = "65f1bf27bc3bf70f64657658635e66094edbcb4d"
+	)
+	create := func(treePath string, line int64) *git_model.CommitComment {
+		c := &git_model.CommitComment{RepoID: repoID, CommitSHA: sha, PosterID: 2, TreePath: treePath, Line: line, Content: "comment"}
+		require.NoError(t, git_model.CreateCommitComment(db.DefaultContext, c))
+		return c
+	}
+	whole := create("", 0)
+	oldLine := create("README.md", -2)
+	require.NoError(t, git_model.CreateCommitComment(db.DefaultContext, &git_model.CommitComment{RepoID: 2, CommitSHA: sha, PosterID: 2, Content: "other repo"}))
+
+	assert.EqualValues(t, 2, oldLine.UnsignedLine())
+	assert.Equal(t, fmt.Sprintf("commitcomment-%d", whole.ID), whole.HashTag())
+
+	comments, err := db.Find[git_model.CommitComment](db.DefaultContext, git_model.FindCommitCommentsOptions{RepoID: repoID, CommitSHA: sha})
+	require.NoError(t, err)
+	require.Len(t, comments, 2)
+	assert.Equal(t, whole.ID, comments[0].ID)
+	assert.Equal(t, oldLine.ID, comments[1].ID)
+
+	require.NoError(t, git_model.CommitCommentList(comments).LoadPosters(db.DefaultContext))
+	assert.Equal(t, "user2", comments[0].Poster.Name)
+
+	_, err = git_model.GetCommitCommentByID(db.DefaultContext, 2, whole.ID)
+	assert.True(t, git_model.IsErrCommitCommentNotExist(err))
+
+	whole.Content = "edited"
+	require.NoError(t, git_model.UpdateCommitCommentContent(db.DefaultContext, whole))
+	c, err := git_model.GetCommitCommentByID(db.DefaultContext, repoID, whole.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "edited", c.Content)
+
+	require.NoError(t, git_model.DeleteCommitComment(db.DefaultContext, c))
+	count, err := db.Count[git_model.CommitComment](db.DefaultContext, git_model.FindCommitCommentsOptions{RepoID: repoID})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, count)
+}
@@ -0,0 +1,150 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/gobwas/glob"
+)
+
+// MergeMessageTemplate is the template of the commit message used when merging
+// a pull request into the branches matching BranchPattern.
+type MergeMessageTemplate struct {
+	ID            int64  `xorm:"pk autoincr"`
+	RepoID        int64  `xorm:"INDEX NOT NULL"`
+	BranchPattern string `xorm:"NOT NULL"`
+	// MergeStyle restricts the template to a merge style, empty for all the merge styles
+	MergeStyle repo_model.MergeStyle `xorm:"VARCHAR(30) NOT NULL DEFAULT ''"`
+	// Template is the first line of the message followed by its body, with ${Variable} placeholders
+	Template string `xorm:"TEXT NOT NULL"`
+	// AddTrailers appends Reviewed-by and Co-authored-by trailers to the message
+	AddTrailers bool `xorm:"NOT NULL DEFAULT false"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+
+	globRule    glob.Glob `xorm:"-"`
+	isPlainName bool      `xorm:"-"`
+}
+
+func init() {
+	db.RegisterModel(new(MergeMessageTemplate))
+}
+
+// ErrMergeMessageTemplateNotExist represents a "MergeMessageTemplateNotExist" kind of error.
+type ErrMergeMessageTemplateNotExist struct {
+	ID int64
+}
+
+// IsErrMergeMessageTemplateNotExist checks if an error is a ErrMergeMessageTemplateNotExist.
+func IsErrMergeMessageTemplateNotExist(err error) bool {
+	_, ok := err.(ErrMergeMessageTemplateNotExist)
+	return ok
+}
+
+func (err ErrMergeMessageTemplateNotExist) Error() string {
+	return fmt.Sprintf("merge message template does not exist [id: %d]", err.ID)
+}
+
+func (err ErrMergeMessageTemplateNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// Match tests if branchName and mergeStyle match the template
+func (tmpl *MergeMessageTemplate) Match(branchName string, mergeStyle repo_model.MergeStyle) bool {
+	if tmpl.MergeStyle != "" && tmpl.MergeStyle != mergeStyle {
+		return false
+	}
+	if tmpl.globRule == nil {
+		var err error
+		tmpl.globRule, err = glob.Compile(tmpl.BranchPattern, '/')
+		if err != nil {
+			log.Warn("Invalid glob rule for MergeMessageTemplate[%d]: %s %v", tmpl.ID, tmpl.BranchPattern, err)
+			tmpl.globRule = glob.MustCompile(glob.QuoteMeta(tmpl.BranchPattern), '/')
+		}
+		tmpl.isPlainName = !IsRuleNameSpecial(tmpl.BranchPattern)
+	}
+	if tmpl.isPlainName {
+		return strings.EqualFold(tmpl.BranchPattern, branchName)
+	}
+	return tmpl.globRule.Match(branchName)
+}
+
+// GetMergeMessageTemplates returns the merge message templates of a repository
+func GetMergeMessageTemplates(ctx context.Context, repoID int64) ([]*MergeMessageTemplate, error) {
+	tmpls := make([]*MergeMessageTemplate, 0, 5)
+	return tmpls, db.GetEngine(ctx).Where("repo_id = ?", repoID).Asc("id").Find(&tmpls)
+}
+
+// GetMergeMessageTemplateByID returns the merge message template of a repository by its ID
+func GetMergeMessageTemplateByID(ctx context.Context, repoID, id int64) (*MergeMessageTemplate, error) {
+	tmpl := &MergeMessageTemplate{}
+	has, err := db.GetEngine(ctx).Where("id = ? AND repo_id = ?", id, repoID).Get(tmpl)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrMergeMessageTemplateNotExist{ID: id}
+	}
+	return tmpl, nil
+}
+
+// FindMergeMessageTemplate returns the template to use when merging into a branch with a merge style, nil if there is none.
+// The templates naming the branch win over the ones with a pattern, and the ones for the merge style
+// over the ones for all the merge styles.
+func FindMergeMessageTemplate(ctx context.Context, repoID int64, branchName string, mergeStyle repo_model.MergeStyle) (*MergeMessageTemplate, error) {
+	tmpls, err := GetMergeMessageTemplates(ctx, repoID)
+	if err != nil {
+		return nil, err
+	}
+
+	matched := make([]*MergeMessageTemplate, 0, len(tmpls))
+	for _, tmpl := range tmpls {
+		if tmpl.Match(branchName, mergeStyle) {
+			matched = append(matched, tmpl)
+		}
+	}
+	if len(matched) == 0 {
+		return nil, nil
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		if matched[i].isPlainName != matched[j].isPlainName {
+			return matched[i].isPlainName
+		}
+		return matched[i].MergeStyle != "" && matched[j].MergeStyle == ""
+	})
+	return matched[0], nil
+}
+
+// CreateMergeMessageTemplate creates a merge message template
+func CreateMergeMessageTemplate(ctx context.Context, tmpl *MergeMessageTemplate) error {
+	_, err := db.GetEngine(ctx).Insert(tmpl)
+	return err
+}
+
+// UpdateMergeMessageTemplate updates a merge message template
+func UpdateMergeMessageTemplate(ctx context.Context, tmpl *MergeMessageTemplate) error {
+	_, err := db.GetEngine(ctx).ID(tmpl.ID).AllCols().Update(tmpl)
+	return err
+}
+
+// DeleteMergeMessageTemplate deletes the merge message template of a repository
+func DeleteMergeMessageTemplate(ctx context.Context, repoID, id int64) error {
+	affected, err := db.GetEngine(ctx).Where("id = ? AND repo_id = ?", id, repoID).Delete(&MergeMessageTemplate{})
+	if err != nil {
+		return err
+	} else if affected == 0 {
+		return ErrMergeMessageTemplateNotExist{ID: id}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	git_model "code.gitea.io/gitea/models/git"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindMergeMessageTemplate(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	const repoID = 1
+	create := func(pattern string, style repo_model.MergeStyle) *git_model.MergeMessageTemplate {
+		tmpl := &git_model.MergeMessageTemplate{RepoID: repoID, BranchPattern: pattern, MergeStyle: style, Template: pattern + " " + string(style)}
+		require.NoError(t, git_model.CreateMergeMessageTemplate(db.DefaultContext, tmpl))
+		return tmpl
+	}
+	anyRelease := create("release/*", "")
+	squashRelease := create("release/*", repo_model.MergeStyleSquash)
+	release1 := create("release/1.0", "")
+
+	find := func(branch string, style repo_model.MergeStyle) *git_model.MergeMessageTemplate {
+		tmpl, err := git_model.FindMergeMessageTemplate(db.DefaultContext, repoID, branch, style)
+		require.NoError(t, err)
+		return tmpl
+	}
+
+	assert.Nil(t, find("main", repo_model.MergeStyleMerge))
+	assert.Nil(t, find("release/1.0/hotfix", repo_model.MergeStyleMerge))
+	assert.Equal(t, anyRelease.ID, find("release/2.0", repo_model.MergeStyleMerge).ID)
+	assert.Equal(t, squashRelease.ID, find("release/2.0", repo_model.MergeStyleSquash).ID)
+	assert.Equal(t, release1.ID, find("release/1.0", repo_model.MergeStyleSquash).ID)
+
+	tmpl, err := git_model.FindMergeMessageTemplate(db.DefaultContext, 2, "release/2.0", repo_model.MergeStyleMerge)
+	require.NoError(t, err)
+	assert.Nil(t, tmpl)
+
+	require.NoError(t, git_model.DeleteMergeMessageTemplate(db.DefaultContext, repoID, release1.ID))
+	assert.Equal(t, squashRelease.ID, find("release/1.0", repo_model.MergeStyleSquash).ID)
+	assert.True(t, git_model.IsErrMergeMessageTemplateNotExist(git_model.DeleteMergeMessageTemplate(db.DefaultContext, 2, anyRelease.ID)))
+}
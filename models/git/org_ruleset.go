@@ -0,0 +1,302 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/gobwas/glob"
+	"xorm.io/builder"
+)
+
+// OrgRulesetEnforcement is the enforcement mode of an organization ruleset
+type OrgRulesetEnforcement int
+
+const (
+	// OrgRulesetDisabled means the ruleset is ignored
+	OrgRulesetDisabled OrgRulesetEnforcement = iota
+	// OrgRulesetActive means the ruleset is merged with the protected branch rules of the repositories
+	OrgRulesetActive
+	// OrgRulesetEvaluate means the violations of the ruleset are recorded but do not block
+	OrgRulesetEvaluate
+)
+
+func (e OrgRulesetEnforcement) String() string {
+	switch e {
+	case OrgRulesetDisabled:
+		return "disabled"
+	case OrgRulesetActive:
+		return "active"
+	case OrgRulesetEvaluate:
+		return "evaluate"
+	}
+	return "unknown"
+}
+
+// OrgRuleset is a set of branch protection rules applied to the repositories of an organization
+// whose name or topics match, for the branches matching RefPattern.
+type OrgRuleset struct {
+	ID          int64                 `xorm:"pk autoincr"`
+	OrgID       int64                 `xorm:"INDEX NOT NULL"`
+	Name        string                `xorm:"NOT NULL"`
+	Enforcement OrgRulesetEnforcement `xorm:"NOT NULL DEFAULT 0"`
+	// RepoNamePattern is a glob matched against the repository names, empty matches all repositories
+	RepoNamePattern string `xorm:"TEXT"`
+	// RepoTopics restricts the ruleset to the repositories having one of the topics
+	RepoTopics []string `xorm:"TEXT JSON"`
+	// RefPattern is a glob matched against the branch names
+	RefPattern string `xorm:"TEXT NOT NULL"`
+
+	RequirePullRequest     bool     `xorm:"NOT NULL DEFAULT false"`
+	RequiredApprovals      int64    `xorm:"NOT NULL DEFAULT 0"`
+	BlockOnRejectedReviews bool     `xorm:"NOT NULL DEFAULT false"`
+	DismissStaleApprovals  bool     `xorm:"NOT NULL DEFAULT false"`
+	RequireSignedCommits   bool     `xorm:"NOT NULL DEFAULT false"`
+	EnableStatusCheck      bool     `xorm:"NOT NULL DEFAULT false"`
+	StatusCheckContexts    []string `xorm:"JSON TEXT"`
+	ApplyToAdmins          bool     `xorm:"NOT NULL DEFAULT false"`
+
+	repoGlob glob.Glob `xorm:"-"`
+	refGlob  glob.Glob `xorm:"-"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// OrgRulesetViolation records a push which would have been rejected by a ruleset in evaluate mode
+type OrgRulesetViolation struct {
+	ID          int64                  `xorm:"pk autoincr"`
+	RulesetID   int64                  `xorm:"INDEX NOT NULL"`
+	RepoID      int64                  `xorm:"INDEX NOT NULL"`
+	Repo        *repo_model.Repository `xorm:"-"`
+	BranchName  string                 `xorm:"TEXT"`
+	PusherID    int64                  `xorm:"NOT NULL DEFAULT 0"`
+	Pusher      *user_model.User       `xorm:"-"`
+	CommitID    string                 `xorm:"VARCHAR(64)"`
+	Reason      string                 `xorm:"TEXT"`
+	CreatedUnix timeutil.TimeStamp     `xorm:"created INDEX"`
+}
+
+func init() {
+	db.RegisterModel(new(OrgRuleset))
+	db.RegisterModel(new(OrgRulesetViolation))
+}
+
+func compileRulesetGlob(rs *OrgRuleset, pattern string) glob.Glob {
+	g, err := glob.Compile(pattern, '/')
+	if err != nil {
+		log.Warn("Invalid glob pattern for OrgRuleset[%d]: %s %v", rs.ID, pattern, err)
+		g = glob.MustCompile(glob.QuoteMeta(pattern), '/')
+	}
+	return g
+}
+
+// MatchRepo tests if the ruleset applies to the repository
+func (rs *OrgRuleset) MatchRepo(repo *repo_model.Repository) bool {
+	if repo.OwnerID != rs.OrgID {
+		return false
+	}
+	if rs.RepoNamePattern != "" {
+		if rs.repoGlob == nil {
+			rs.repoGlob = compileRulesetGlob(rs, strings.ToLower(rs.RepoNamePattern))
+		}
+		if !rs.repoGlob.Match(repo.LowerName) {
+			return false
+		}
+	}
+	if len(rs.RepoTopics) > 0 {
+		for _, topic := range repo.Topics {
+			if slices.Contains(rs.RepoTopics, strings.ToLower(topic)) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// MatchBranch tests if the ruleset applies to the branch
+func (rs *OrgRuleset) MatchBranch(branchName string) bool {
+	if rs.refGlob == nil {
+		rs.refGlob = compileRulesetGlob(rs, rs.RefPattern)
+	}
+	return rs.refGlob.Match(branchName)
+}
+
+// ProtectedBranch returns a protected branch rule holding the rules of the ruleset
+func (rs *OrgRuleset) ProtectedBranch(repo *repo_model.Repository) *ProtectedBranch {
+	pb := &ProtectedBranch{
+		RepoID:   repo.ID,
+		Repo:     repo,
+		RuleName: rs.RefPattern,
+		CanPush:  true,
+	}
+	pb.ApplyOrgRuleset(rs)
+	return pb
+}
+
+// ApplyOrgRuleset merges the rules of an organization ruleset into the protected branch rule,
+// keeping the strictest setting of both
+func (protectBranch *ProtectedBranch) ApplyOrgRuleset(rs *OrgRuleset) {
+	if rs.RequirePullRequest {
+		protectBranch.CanPush = false
+	}
+	protectBranch.RequiredApprovals = max(protectBranch.RequiredApprovals, rs.RequiredApprovals)
+	protectBranch.BlockOnRejectedReviews = protectBranch.BlockOnRejectedReviews || rs.BlockOnRejectedReviews
+	protectBranch.DismissStaleApprovals = protectBranch.DismissStaleApprovals || rs.DismissStaleApprovals
+	protectBranch.RequireSignedCommits = protectBranch.RequireSignedCommits || rs.RequireSignedCommits
+	protectBranch.ApplyToAdmins = protectBranch.ApplyToAdmins || rs.ApplyToAdmins
+	if rs.EnableStatusCheck {
+		protectBranch.EnableStatusCheck = true
+		for _, statusContext := range rs.StatusCheckContexts {
+			if !slices.Contains(protectBranch.StatusCheckContexts, statusContext) {
+				protectBranch.StatusCheckContexts = append(protectBranch.StatusCheckContexts, statusContext)
+			}
+		}
+	}
+	protectBranch.OrgRulesets = append(protectBranch.OrgRulesets, rs)
+}
+
+// GetOrgRulesets returns the rulesets of an organization
+func GetOrgRulesets(ctx context.Context, orgID int64) ([]*OrgRuleset, error) {
+	rulesets := make([]*OrgRuleset, 0, 5)
+	return rulesets, db.GetEngine(ctx).Where("org_id = ?", orgID).Asc("id").Find(&rulesets)
+}
+
+// GetOrgRulesetByID returns a ruleset of an organization
+func GetOrgRulesetByID(ctx context.Context, orgID, id int64) (*OrgRuleset, error) {
+	rs := &OrgRuleset{}
+	has, err := db.GetEngine(ctx).Where("org_id = ? AND id = ?", orgID, id).Get(rs)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("organization ruleset does not exist [id: %d]", id)
+	}
+	return rs, nil
+}
+
+// FindRepoOrgRulesets returns the rulesets which are not disabled and target the repository
+func FindRepoOrgRulesets(ctx context.Context, repo *repo_model.Repository) ([]*OrgRuleset, error) {
+	rulesets := make([]*OrgRuleset, 0, 2)
+	if err := db.GetEngine(ctx).Where("org_id = ? AND enforcement <> ?", repo.OwnerID, OrgRulesetDisabled).Asc("id").Find(&rulesets); err != nil {
+		return nil, err
+	}
+	matched := rulesets[:0]
+	for _, rs := range rulesets {
+		if rs.MatchRepo(repo) {
+			matched = append(matched, rs)
+		}
+	}
+	return matched, nil
+}
+
+// FindMatchingOrgRulesets returns the rulesets with the given enforcement applying to the branch of the repository
+func FindMatchingOrgRulesets(ctx context.Context, repo *repo_model.Repository, branchName string, enforcement OrgRulesetEnforcement) ([]*OrgRuleset, error) {
+	rulesets := make([]*OrgRuleset, 0, 2)
+	if err := db.GetEngine(ctx).Where("org_id = ? AND enforcement = ?", repo.OwnerID, enforcement).Asc("id").Find(&rulesets); err != nil {
+		return nil, err
+	}
+	matched := rulesets[:0]
+	for _, rs := range rulesets {
+		if rs.MatchRepo(repo) && rs.MatchBranch(branchName) {
+			matched = append(matched, rs)
+		}
+	}
+	return matched, nil
+}
+
+// CreateOrgRuleset creates a new ruleset
+func CreateOrgRuleset(ctx context.Context, rs *OrgRuleset) error {
+	_, err := db.GetEngine(ctx).Insert(rs)
+	return err
+}
+
+// UpdateOrgRuleset updates a ruleset
+func UpdateOrgRuleset(ctx context.Context, rs *OrgRuleset) error {
+	_, err := db.GetEngine(ctx).ID(rs.ID).AllCols().Update(rs)
+	return err
+}
+
+// DeleteOrgRuleset deletes a ruleset and its recorded violations
+func DeleteOrgRuleset(ctx context.Context, orgID, id int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		deleted, err := db.GetEngine(ctx).Where("org_id = ? AND id = ?", orgID, id).Delete(new(OrgRuleset))
+		if err != nil {
+			return err
+		} else if deleted == 0 {
+			return util.NewNotExistErrorf("organization ruleset does not exist [id: %d]", id)
+		}
+		_, err = db.GetEngine(ctx).Where("ruleset_id = ?", id).Delete(new(OrgRulesetViolation))
+		return err
+	})
+}
+
+// DeleteOrgRulesetsByOrgID deletes the rulesets of an organization and their recorded violations
+func DeleteOrgRulesetsByOrgID(ctx context.Context, orgID int64) error {
+	if _, err := db.GetEngine(ctx).
+		In("ruleset_id", builder.Select("id").From("org_ruleset").Where(builder.Eq{"org_id": orgID})).
+		Delete(new(OrgRulesetViolation)); err != nil {
+		return err
+	}
+	_, err := db.GetEngine(ctx).Where("org_id = ?", orgID).Delete(new(OrgRuleset))
+	return err
+}
+
+// MaxOrgRulesetViolations is the number of violations kept for a ruleset, the oldest ones are pruned
+const MaxOrgRulesetViolations = 100
+
+// AddOrgRulesetViolation records a violation of a ruleset in evaluate mode
+// and prunes the violations exceeding MaxOrgRulesetViolations
+func AddOrgRulesetViolation(ctx context.Context, v *OrgRulesetViolation) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Insert(v); err != nil {
+			return err
+		}
+
+		var lastKeptID int64
+		has, err := db.GetEngine(ctx).Table("org_ruleset_violation").Cols("id").
+			Where("ruleset_id = ?", v.RulesetID).Desc("id").Limit(1, MaxOrgRulesetViolations-1).Get(&lastKeptID)
+		if err != nil || !has {
+			return err
+		}
+		_, err = db.GetEngine(ctx).Where("ruleset_id = ? AND id < ?", v.RulesetID, lastKeptID).Delete(new(OrgRulesetViolation))
+		return err
+	})
+}
+
+// GetOrgRulesetViolations returns the latest violations of a ruleset
+func GetOrgRulesetViolations(ctx context.Context, rulesetID int64, opts db.ListOptions) ([]*OrgRulesetViolation, error) {
+	violations := make([]*OrgRulesetViolation, 0, opts.PageSize)
+	sess := db.GetEngine(ctx).Where("ruleset_id = ?", rulesetID).Desc("created_unix", "id")
+	if opts.PageSize > 0 {
+		sess = db.SetSessionPagination(sess, &opts)
+	}
+	return violations, sess.Find(&violations)
+}
+
+// LoadAttributes loads the repository and the pusher of the violation
+func (v *OrgRulesetViolation) LoadAttributes(ctx context.Context) (err error) {
+	if v.Repo == nil {
+		v.Repo, err = repo_model.GetRepositoryByID(ctx, v.RepoID)
+		if err != nil && !repo_model.IsErrRepoNotExist(err) {
+			return err
+		}
+	}
+	if v.Pusher == nil {
+		if v.Pusher, err = user_model.GetPossibleUserByID(ctx, v.PusherID); err != nil {
+			return fmt.Errorf("GetPossibleUserByID: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	git_model "code.gitea.io/gitea/models/git"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrgRulesetMatch(t *testing.T) {
+	repo := &repo_model.Repository{OwnerID: 3, LowerName: "service-api", Topics: []string{"backend", "go"}}
+
+	rs := &git_model.OrgRuleset{OrgID: 3, RefPattern: "main"}
+	assert.True(t, rs.MatchRepo(repo))
+	assert.True(t, rs.MatchBranch("main"))
+	assert.False(t, rs.MatchBranch("develop"))
+
+	rs = &git_model.OrgRuleset{OrgID: 4, RefPattern: "main"}
+	assert.False(t, rs.MatchRepo(repo))
+
+	rs = &git_model.OrgRuleset{OrgID: 3, RepoNamePattern: "Service-*", RefPattern: "release/**"}
+	assert.True(t, rs.MatchRepo(repo))
+	assert.True(t, rs.MatchBranch("release/v1/hotfix"))
+	assert.False(t, rs.MatchBranch("main"))
+
+	rs = &git_model.OrgRuleset{OrgID: 3, RepoNamePattern: "web-*", RefPattern: "main"}
+	assert.False(t, rs.MatchRepo(repo))
+
+	rs = &git_model.OrgRuleset{OrgID: 3, RepoTopics: []string{"frontend", "backend"}, RefPattern: "main"}
+	assert.True(t, rs.MatchRepo(repo))
+
+	rs = &git_model.OrgRuleset{OrgID: 3, RepoTopics: []string{"frontend"}, RefPattern: "main"}
+	assert.False(t, rs.MatchRepo(repo))
+}
+
+func TestApplyOrgRuleset(t *testing.T) {
+	pb := &git_model.ProtectedBranch{
+		CanPush:             true,
+		RequiredApprovals:   1,
+		EnableStatusCheck:   true,
+		StatusCheckContexts: []string{"ci/build"},
+	}
+	pb.ApplyOrgRuleset(&git_model.OrgRuleset{
+		RequirePullRequest:   true,
+		RequiredApprovals:    2,
+		RequireSignedCommits: true,
+		EnableStatusCheck:    true,
+		StatusCheckContexts:  []string{"ci/build", "ci/lint"},
+	})
+	assert.False(t, pb.CanPush)
+	assert.EqualValues(t, 2, pb.RequiredApprovals)
+	assert.True(t, pb.RequireSignedCommits)
+	assert.Equal(t, []string{"ci/build", "ci/lint"}, pb.StatusCheckContexts)
+	assert.Len(t, pb.OrgRulesets, 1)
+
+	// the strictest setting is kept
+	pb.ApplyOrgRuleset(&git_model.OrgRuleset{RequiredApprovals: 1})
+	assert.EqualValues(t, 2, pb.RequiredApprovals)
+	assert.True(t, pb.RequireSignedCommits)
+}
+
+func TestGetFirstMatchProtectedBranchRuleWithOrgRulesets(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 3})
+
+	rule, err := git_model.GetFirstMatchProtectedBranchRule(db.DefaultContext, repo, "main")
+	require.NoError(t, err)
+	assert.Nil(t, rule)
+
+	active := &git_model.OrgRuleset{
+		OrgID:                repo.OwnerID,
+		Name:                 "main",
+		Enforcement:          git_model.OrgRulesetActive,
+		RefPattern:           "main",
+		RequiredApprovals:    2,
+		RequireSignedCommits: true,
+	}
+	require.NoError(t, git_model.CreateOrgRuleset(db.DefaultContext, active))
+	evaluate := &git_model.OrgRuleset{
+		OrgID:              repo.OwnerID,
+		Name:               "evaluate",
+		Enforcement:        git_model.OrgRulesetEvaluate,
+		RefPattern:         "*",
+		RequirePullRequest: true,
+	}
+	require.NoError(t, git_model.CreateOrgRuleset(db.DefaultContext, evaluate))
+
+	// the branch is protected by the active ruleset only
+	rule, err = git_model.GetFirstMatchProtectedBranchRule(db.DefaultContext, repo, "main")
+	require.NoError(t, err)
+	require.NotNil(t, rule)
+	assert.True(t, rule.CanPush)
+	assert.EqualValues(t, 2, rule.RequiredApprovals)
+	assert.True(t, rule.RequireSignedCommits)
+
+	rule, err = git_model.GetFirstMatchProtectedBranchRule(db.DefaultContext, repo, "develop")
+	require.NoError(t, err)
+	assert.Nil(t, rule)
+
+	// the rule of the repository is merged with the ruleset
+	require.NoError(t, git_model.UpdateProtectBranch(db.DefaultContext, repo, &git_model.ProtectedBranch{
+		RepoID:                 repo.ID,
+		RuleName:               "main",
+		RequiredApprovals:      1,
+		BlockOnRejectedReviews: true,
+	}, git_model.WhitelistOptions{}))
+	rule, err = git_model.GetFirstMatchProtectedBranchRule(db.DefaultContext, repo, "main")
+	require.NoError(t, err)
+	require.NotNil(t, rule)
+	assert.NotZero(t, rule.ID)
+	assert.EqualValues(t, 2, rule.RequiredApprovals)
+	assert.True(t, rule.BlockOnRejectedReviews)
+
+	rulesets, err := git_model.FindMatchingOrgRulesets(db.DefaultContext, repo, "develop", git_model.OrgRulesetEvaluate)
+	require.NoError(t, err)
+	require.Len(t, rulesets, 1)
+	assert.Equal(t, evaluate.ID, rulesets[0].ID)
+
+	require.NoError(t, git_model.AddOrgRulesetViolation(db.DefaultContext, &git_model.OrgRulesetViolation{
+		RulesetID:  evaluate.ID,
+		RepoID:     repo.ID,
+		BranchName: "develop",
+		Reason:     "push without pull request",
+	}))
+	violations, err := git_model.GetOrgRulesetViolations(db.DefaultContext, evaluate.ID, db.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, violations, 1)
+
+	require.NoError(t, git_model.DeleteOrgRuleset(db.DefaultContext, repo.OwnerID, evaluate.ID))
+	unittest.AssertNotExistsBean(t, &git_model.OrgRulesetViolation{RulesetID: evaluate.ID})
+}
+
+func TestOrgRulesetViolationsPruning(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	rs := &git_model.OrgRuleset{OrgID: 3, Name: "evaluate", Enforcement: git_model.OrgRulesetEvaluate, RefPattern: "*"}
+	require.NoError(t, git_model.CreateOrgRuleset(db.DefaultContext, rs))
+
+	var last *git_model.OrgRulesetViolation
+	for i := 0; i < git_model.MaxOrgRulesetViolations+5; i++ {
+		last = &git_model.OrgRulesetViolation{RulesetID: rs.ID, RepoID: 3, BranchName: "main", Reason: "force push"}
+		require.NoError(t, git_model.AddOrgRulesetViolation(db.DefaultContext, last))
+	}
+	assert.EqualValues(t, git_model.MaxOrgRulesetViolations, unittest.GetCount(t, &git_model.OrgRulesetViolation{RulesetID: rs.ID}))
+	unittest.AssertExistsAndLoadBean(t, &git_model.OrgRulesetViolation{ID: last.ID})
+
+	require.NoError(t, git_model.DeleteOrgRulesetsByOrgID(db.DefaultContext, 3))
+	unittest.AssertNotExistsBean(t, &git_model.OrgRuleset{OrgID: 3})
+	unittest.AssertNotExistsBean(t, &git_model.OrgRulesetViolation{RulesetID: rs.ID})
+}
@@ -8,6 +8,7 @@ import (
 	"sort"
 
 	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/modules/optional"
 
 	"github.com/gobwas/glob"
@@ -76,18 +77,32 @@ func FindAllMatchedBranches(ctx context.Context, repoID int64, ruleName string)
 	return results, nil
 }
 
-// GetFirstMatchProtectedBranchRule returns the first matched rules
-func GetFirstMatchProtectedBranchRule(ctx context.Context, repoID int64, branchName string) (*ProtectedBranch, error) {
-	rules, err := FindRepoProtectedBranchRules(ctx, repoID)
+// GetFirstMatchProtectedBranchRule returns the first matched rules, merged with the active rulesets
+// of the organization owning the repository
+func GetFirstMatchProtectedBranchRule(ctx context.Context, repo *repo_model.Repository, branchName string) (*ProtectedBranch, error) {
+	rules, err := FindRepoProtectedBranchRules(ctx, repo.ID)
 	if err != nil {
 		return nil, err
 	}
-	return rules.GetFirstMatched(branchName), nil
+	rule := rules.GetFirstMatched(branchName)
+
+	rulesets, err := FindMatchingOrgRulesets(ctx, repo, branchName, OrgRulesetActive)
+	if err != nil {
+		return nil, err
+	}
+	for _, rs := range rulesets {
+		if rule == nil {
+			rule = rs.ProtectedBranch(repo)
+			continue
+		}
+		rule.ApplyOrgRuleset(rs)
+	}
+	return rule, nil
 }
 
 // IsBranchProtected checks if branch is protected
-func IsBranchProtected(ctx context.Context, repoID int64, branchName string) (bool, error) {
-	rule, err := GetFirstMatchProtectedBranchRule(ctx, repoID, branchName)
+func IsBranchProtected(ctx context.Context, repo *repo_model.Repository, branchName string) (bool, error) {
+	rule, err := GetFirstMatchProtectedBranchRule(ctx, repo, branchName)
 	if err != nil {
 		return false, err
 	}
@@ -0,0 +1,138 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"github.com/gobwas/glob"
+)
+
+// PushRule is a content policy enforced on the commits pushed to a repository.
+// A rule with a RepoID of 0 applies to all the repositories of the owner.
+type PushRule struct {
+	ID      int64 `xorm:"pk autoincr"`
+	OwnerID int64 `xorm:"UNIQUE(s) NOT NULL"`
+	RepoID  int64 `xorm:"UNIQUE(s) NOT NULL DEFAULT 0"`
+	// CommitMessagePattern is a regular expression the commit messages must match
+	CommitMessagePattern string `xorm:"TEXT"`
+	// RequireVerifiedEmail requires the author and committer emails to be verified emails of users
+	RequireVerifiedEmail bool `xorm:"NOT NULL DEFAULT false"`
+	// MaxFileSize is the maximum size in bytes of the pushed files, 0 for no limit
+	MaxFileSize int64 `xorm:"NOT NULL DEFAULT 0"`
+	// ForbiddenPathPatterns are the glob patterns, separated by semicolons, of the paths which cannot be pushed
+	ForbiddenPathPatterns string `xorm:"TEXT"`
+	// DetectSecrets rejects the files containing private keys or access tokens
+	DetectSecrets bool `xorm:"NOT NULL DEFAULT false"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+
+	messageRegexp *regexp.Regexp `xorm:"-"`
+	pathGlobs     []glob.Glob    `xorm:"-"`
+}
+
+func init() {
+	db.RegisterModel(new(PushRule))
+}
+
+// IsEmpty returns true if the rule does not enforce anything
+func (rule *PushRule) IsEmpty() bool {
+	return rule.CommitMessagePattern == "" && !rule.RequireVerifiedEmail && rule.MaxFileSize == 0 &&
+		strings.TrimSpace(rule.ForbiddenPathPatterns) == "" && !rule.DetectSecrets
+}
+
+// MaxFileSizeMiB returns the maximum file size in MiB
+func (rule *PushRule) MaxFileSizeMiB() int64 {
+	return rule.MaxFileSize / (1024 * 1024)
+}
+
+// MatchCommitMessage tests if the commit message satisfies the rule
+func (rule *PushRule) MatchCommitMessage(message string) bool {
+	if rule.CommitMessagePattern == "" {
+		return true
+	}
+	if rule.messageRegexp == nil {
+		var err error
+		rule.messageRegexp, err = regexp.Compile(rule.CommitMessagePattern)
+		if err != nil {
+			log.Warn("Invalid commit message pattern for PushRule[%d]: %s %v", rule.ID, rule.CommitMessagePattern, err)
+			rule.messageRegexp = regexp.MustCompile(regexp.QuoteMeta(rule.CommitMessagePattern))
+		}
+	}
+	return rule.messageRegexp.MatchString(message)
+}
+
+// GetForbiddenPathPatterns returns the forbidden path patterns as globs
+func (rule *PushRule) GetForbiddenPathPatterns() []glob.Glob {
+	if rule.pathGlobs == nil {
+		rule.pathGlobs = getFilePatterns(rule.ForbiddenPathPatterns)
+	}
+	return rule.pathGlobs
+}
+
+// IsPathForbidden tests if the file path is forbidden by the rule
+func (rule *PushRule) IsPathForbidden(path string) bool {
+	path = strings.ToLower(path)
+	for _, pattern := range rule.GetForbiddenPathPatterns() {
+		if pattern.Match(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetPushRule returns the push rule of a repository, or of all the repositories of the owner if repoID is 0.
+// A new rule is returned if there is none.
+func GetPushRule(ctx context.Context, ownerID, repoID int64) (*PushRule, error) {
+	rule := &PushRule{}
+	sess := db.GetEngine(ctx).Where("repo_id = ?", repoID)
+	if repoID == 0 {
+		sess = sess.And("owner_id = ?", ownerID)
+	}
+	has, err := sess.Get(rule)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return &PushRule{OwnerID: ownerID, RepoID: repoID}, nil
+	}
+	// the repository may have been transferred
+	rule.OwnerID = ownerID
+	return rule, nil
+}
+
+// GetRepoPushRules returns the push rules applying to a repository, the rule of its owner first
+func GetRepoPushRules(ctx context.Context, repo *repo_model.Repository) ([]*PushRule, error) {
+	rules := make([]*PushRule, 0, 2)
+	if err := db.GetEngine(ctx).
+		Where("(owner_id = ? AND repo_id = 0) OR repo_id = ?", repo.OwnerID, repo.ID).
+		Asc("repo_id").
+		Find(&rules); err != nil {
+		return nil, err
+	}
+	applicable := rules[:0]
+	for _, rule := range rules {
+		if !rule.IsEmpty() {
+			applicable = append(applicable, rule)
+		}
+	}
+	return applicable, nil
+}
+
+// UpdatePushRule creates or updates a push rule
+func UpdatePushRule(ctx context.Context, rule *PushRule) error {
+	if rule.ID == 0 {
+		_, err := db.GetEngine(ctx).Insert(rule)
+		return err
+	}
+	_, err := db.GetEngine(ctx).ID(rule.ID).AllCols().Update(rule)
+	return err
+}
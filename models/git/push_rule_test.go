@@ -0,0 +1,73 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	git_model "code.gitea.io/gitea/models/git"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPushRuleMatch(t *testing.T) {
+	rule := &git_model.PushRule{}
+	assert.True(t, rule.IsEmpty())
+	assert.True(t, rule.MatchCommitMessage("anything"))
+	assert.False(t, rule.IsPathForbidden(".env"))
+
+	rule = &git_model.PushRule{
+		CommitMessagePattern:  `^(feat|fix): .+ \(#\d+\)`,
+		ForbiddenPathPatterns: "*.env;secrets/**",
+	}
+	assert.False(t, rule.IsEmpty())
+	assert.True(t, rule.MatchCommitMessage("fix: handle empty input (#12)\n\nDetails"))
+	assert.False(t, rule.MatchCommitMessage("handle empty input"))
+	assert.True(t, rule.IsPathForbidden("prod.ENV"))
+	assert.True(t, rule.IsPathForbidden("secrets/db/password.txt"))
+	assert.False(t, rule.IsPathForbidden("docs/secrets.md"))
+
+	rule = &git_model.PushRule{MaxFileSize: 5 * 1024 * 1024}
+	assert.False(t, rule.IsEmpty())
+	assert.EqualValues(t, 5, rule.MaxFileSizeMiB())
+}
+
+func TestGetRepoPushRules(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 3})
+
+	rules, err := git_model.GetRepoPushRules(db.DefaultContext, repo)
+	require.NoError(t, err)
+	assert.Empty(t, rules)
+
+	ownerRule, err := git_model.GetPushRule(db.DefaultContext, repo.OwnerID, 0)
+	require.NoError(t, err)
+	assert.Zero(t, ownerRule.ID)
+	ownerRule.DetectSecrets = true
+	require.NoError(t, git_model.UpdatePushRule(db.DefaultContext, ownerRule))
+
+	repoRule, err := git_model.GetPushRule(db.DefaultContext, repo.OwnerID, repo.ID)
+	require.NoError(t, err)
+	repoRule.MaxFileSize = 1024
+	require.NoError(t, git_model.UpdatePushRule(db.DefaultContext, repoRule))
+
+	// an empty rule of another repository of the owner is ignored
+	require.NoError(t, git_model.UpdatePushRule(db.DefaultContext, &git_model.PushRule{OwnerID: repo.OwnerID, RepoID: 32}))
+
+	rules, err = git_model.GetRepoPushRules(db.DefaultContext, repo)
+	require.NoError(t, err)
+	if assert.Len(t, rules, 2) {
+		assert.Equal(t, ownerRule.ID, rules[0].ID)
+		assert.Equal(t, repoRule.ID, rules[1].ID)
+	}
+
+	repoRule, err = git_model.GetPushRule(db.DefaultContext, repo.OwnerID, repo.ID)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1024, repoRule.MaxFileSize)
+}
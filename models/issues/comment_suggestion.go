@@ -0,0 +1,64 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Suggestion is a change of the commented line proposed by a ```suggestion block of a code comment
+type Suggestion struct {
+	// OldLine is the content of the commented line when the comment was created
+	OldLine string
+	// NewLines replace the commented line, it is removed if there are none
+	NewLines []string
+}
+
+var suggestionBlockStartPattern = regexp.MustCompile("(?m)^```suggestion[ \t]*\r?$")
+
+// ParseSuggestion returns the lines of the first ```suggestion block of the content of a comment
+func ParseSuggestion(content string) ([]string, bool) {
+	loc := suggestionBlockStartPattern.FindStringIndex(content)
+	if loc == nil || loc[1] == len(content) {
+		return nil, false
+	}
+
+	lines := strings.Split(content[loc[1]+1:], "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSuffix(line, "\r")
+		if strings.TrimRight(lines[i], " \t") == "```" {
+			if i == 0 {
+				return nil, true
+			}
+			return lines[:i], true
+		}
+	}
+	// the block is not closed
+	return nil, false
+}
+
+// Suggestion returns the change suggested by a code comment on the proposed side of the diff,
+// or nil if it does not contain a suggestion
+func (c *Comment) Suggestion() *Suggestion {
+	if c.Type != CommentTypeCode || c.Line <= 0 {
+		return nil
+	}
+	newLines, ok := ParseSuggestion(c.Content)
+	if !ok {
+		return nil
+	}
+
+	// the patch of a code comment ends with the commented line
+	patchLines := strings.Split(strings.TrimRight(c.Patch, "\n"), "\n")
+	lastLine := patchLines[len(patchLines)-1]
+	if lastLine == "" || (lastLine[0] != '+' && lastLine[0] != ' ') {
+		return nil
+	}
+
+	return &Suggestion{
+		OldLine:  strings.TrimSuffix(lastLine[1:], "\r"),
+		NewLines: newLines,
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues_test
+
+import (
+	"testing"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSuggestion(t *testing.T) {
+	kases := []struct {
+		content string
+		lines   []string
+		ok      bool
+	}{
+		{content: "no suggestion"},
+		{content: "```go\nfoo()\n```"},
+		{content: "```suggestion\nfoo()\n```", lines: []string{"foo()"}, ok: true},
+		{content: "Rename it:\r\n```suggestion\r\nfoo()\r\nbar()\r\n```\r\nThanks", lines: []string{"foo()", "bar()"}, ok: true},
+		{content: "```suggestion\n```", ok: true},
+		{content: "```suggestion\nfoo()"},
+		{content: "```suggestion\nfoo()\n```\n```suggestion\nbar()\n```", lines: []string{"foo()"}, ok: true},
+	}
+	for _, kase := range kases {
+		lines, ok := issues_model.ParseSuggestion(kase.content)
+		assert.Equal(t, kase.ok, ok, kase.content)
+		assert.Equal(t, kase.lines, lines, kase.content)
+	}
+}
+
+func TestCommentSuggestion(t *testing.T) {
+	patch := "diff --git a/README.md b/README.md\n--- a/README.md\n+++ b/README.md\n@@ -1,2 +1,2 @@\n # repo1\n+Description for repo1"
+	comment := &issues_model.Comment{
+		Type:    issues_model.CommentTypeCode,
+		Line:    2,
+		Patch:   patch,
+		Content: "```suggestion\nA description for repo1\n```",
+	}
+	assert.Equal(t, &issues_model.Suggestion{
+		OldLine:  "Description for repo1",
+		NewLines: []string{"A description for repo1"},
+	}, comment.Suggestion())
+
+	// only lines of the proposed side can be changed
+	comment.Line = -2
+	assert.Nil(t, comment.Suggestion())
+
+	comment.Line = 2
+	comment.Content = "no suggestion"
+	assert.Nil(t, comment.Suggestion())
+}
@@ -0,0 +1,446 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// CustomFieldType is the type of the values of a custom field
+type CustomFieldType string
+
+const (
+	// CustomFieldTypeSelect is a field whose value is one of the options of the field
+	CustomFieldTypeSelect CustomFieldType = "select"
+	// CustomFieldTypeNumber is a field whose value is a decimal number
+	CustomFieldTypeNumber CustomFieldType = "number"
+	// CustomFieldTypeDate is a field whose value is a date formatted as YYYY-MM-DD
+	CustomFieldTypeDate CustomFieldType = "date"
+	// CustomFieldTypeUser is a field whose value is a user, stored by ID
+	CustomFieldTypeUser CustomFieldType = "user"
+)
+
+// CustomFieldDateLayout is the layout of the values of the date custom fields
+const CustomFieldDateLayout = "2006-01-02"
+
+// CustomFieldTypes are all the supported custom field types
+var CustomFieldTypes = []CustomFieldType{CustomFieldTypeSelect, CustomFieldTypeNumber, CustomFieldTypeDate, CustomFieldTypeUser}
+
+// IsValid returns true if the type is supported
+func (t CustomFieldType) IsValid() bool {
+	return slices.Contains(CustomFieldTypes, t)
+}
+
+// ErrCustomFieldNotExist represents a "CustomFieldNotExist" kind of error.
+type ErrCustomFieldNotExist struct {
+	ID int64
+}
+
+// IsErrCustomFieldNotExist checks if an error is a ErrCustomFieldNotExist.
+func IsErrCustomFieldNotExist(err error) bool {
+	_, ok := err.(ErrCustomFieldNotExist)
+	return ok
+}
+
+func (err ErrCustomFieldNotExist) Error() string {
+	return fmt.Sprintf("custom field does not exist [id: %d]", err.ID)
+}
+
+func (err ErrCustomFieldNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// CustomField is a typed metadata field which can be set on the issues.
+// A field with a RepoID of 0 is defined by the owner for all its repositories.
+type CustomField struct {
+	ID          int64           `xorm:"pk autoincr"`
+	OwnerID     int64           `xorm:"INDEX NOT NULL"`
+	RepoID      int64           `xorm:"INDEX NOT NULL DEFAULT 0"`
+	Name        string          `xorm:"NOT NULL"`
+	Description string          `xorm:"TEXT"`
+	Type        CustomFieldType `xorm:"VARCHAR(20) NOT NULL"`
+	// Options are the allowed values of a select field, in display and sort order
+	Options []string `xorm:"JSON TEXT"`
+	// ShowOnCards shows the value of the field on the cards of the project boards
+	ShowOnCards bool `xorm:"NOT NULL DEFAULT false"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// IssueCustomFieldValue is the value of a custom field on an issue
+type IssueCustomFieldValue struct {
+	ID      int64 `xorm:"pk autoincr"`
+	IssueID int64 `xorm:"UNIQUE(s) NOT NULL"`
+	FieldID int64 `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	// Value is the normalized value: the option of a select, the decimal representation of a number,
+	// the YYYY-MM-DD representation of a date or the ID of a user
+	Value string `xorm:"VARCHAR(255) NOT NULL"`
+	// NumValue is used to sort the issues: the index of the option of a select, the number,
+	// the unix time of a date or the ID of a user
+	NumValue float64 `xorm:"NOT NULL DEFAULT 0"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+
+	Field *CustomField     `xorm:"-"`
+	User  *user_model.User `xorm:"-"`
+}
+
+func init() {
+	db.RegisterModel(new(CustomField))
+	db.RegisterModel(new(IssueCustomFieldValue))
+}
+
+// BelongsToOwner returns true if the field is defined for all the repositories of its owner
+func (f *CustomField) BelongsToOwner() bool {
+	return f.RepoID == 0
+}
+
+// Validate checks the definition of the field and normalizes its options
+func (f *CustomField) Validate() error {
+	f.Name = strings.TrimSpace(f.Name)
+	if f.Name == "" {
+		return util.NewInvalidArgumentErrorf("custom field name cannot be empty")
+	}
+	if !f.Type.IsValid() {
+		return util.NewInvalidArgumentErrorf("invalid custom field type %q", f.Type)
+	}
+
+	if f.Type != CustomFieldTypeSelect {
+		f.Options = nil
+		return nil
+	}
+
+	options := make([]string, 0, len(f.Options))
+	seen := make(container.Set[string], len(f.Options))
+	for _, option := range f.Options {
+		option = strings.TrimSpace(option)
+		if option == "" {
+			continue
+		}
+		if len(option) > 255 {
+			return util.NewInvalidArgumentErrorf("custom field option %q is too long", option)
+		}
+		if !seen.Add(option) {
+			return util.NewInvalidArgumentErrorf("duplicate custom field option %q", option)
+		}
+		options = append(options, option)
+	}
+	if len(options) == 0 {
+		return util.NewInvalidArgumentErrorf("a select custom field needs at least one option")
+	}
+	f.Options = options
+	return nil
+}
+
+// NormalizeValue checks a value entered for the field, the name of the user for the user fields,
+// and returns its stored form and its sort key
+func (f *CustomField) NormalizeValue(ctx context.Context, value string) (string, float64, error) {
+	value = strings.TrimSpace(value)
+	switch f.Type {
+	case CustomFieldTypeSelect:
+		idx := slices.Index(f.Options, value)
+		if idx < 0 {
+			return "", 0, util.NewInvalidArgumentErrorf("%q is not an option of the custom field %s", value, f.Name)
+		}
+		return value, float64(idx), nil
+	case CustomFieldTypeNumber:
+		num, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return "", 0, util.NewInvalidArgumentErrorf("%q is not a number", value)
+		}
+		return strconv.FormatFloat(num, 'f', -1, 64), num, nil
+	case CustomFieldTypeDate:
+		date, err := time.Parse(CustomFieldDateLayout, value)
+		if err != nil {
+			return "", 0, util.NewInvalidArgumentErrorf("%q is not a date formatted as YYYY-MM-DD", value)
+		}
+		return date.Format(CustomFieldDateLayout), float64(date.Unix()), nil
+	case CustomFieldTypeUser:
+		u, err := user_model.GetUserByName(ctx, value)
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				return "", 0, util.NewInvalidArgumentErrorf("user %q does not exist", value)
+			}
+			return "", 0, err
+		}
+		if u.IsOrganization() {
+			return "", 0, util.NewInvalidArgumentErrorf("%q is not an individual user", value)
+		}
+		return strconv.FormatInt(u.ID, 10), float64(u.ID), nil
+	}
+	return "", 0, util.NewInvalidArgumentErrorf("invalid custom field type %q", f.Type)
+}
+
+// DisplayValue returns the value as it is shown to the users
+func (v *IssueCustomFieldValue) DisplayValue() string {
+	if v.User != nil {
+		return v.User.Name
+	}
+	return v.Value
+}
+
+// NewCustomField creates a custom field
+func NewCustomField(ctx context.Context, f *CustomField) error {
+	if err := f.Validate(); err != nil {
+		return err
+	}
+	return db.Insert(ctx, f)
+}
+
+// UpdateCustomField updates the definition of a custom field, the type of a field cannot change.
+// The values which are not an option of a select field anymore are removed.
+func UpdateCustomField(ctx context.Context, f *CustomField) error {
+	if err := f.Validate(); err != nil {
+		return err
+	}
+
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).ID(f.ID).Cols("name", "description", "options", "show_on_cards").Update(f); err != nil {
+			return err
+		}
+		if f.Type != CustomFieldTypeSelect {
+			return nil
+		}
+
+		if _, err := db.GetEngine(ctx).Where(builder.Eq{"field_id": f.ID}.And(builder.NotIn("value", f.Options))).
+			Delete(new(IssueCustomFieldValue)); err != nil {
+			return err
+		}
+		for idx, option := range f.Options {
+			if _, err := db.GetEngine(ctx).Where(builder.Eq{"field_id": f.ID, "value": option}).
+				Cols("num_value").NoAutoTime().Update(&IssueCustomFieldValue{NumValue: float64(idx)}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteCustomField deletes a custom field and its values
+func DeleteCustomField(ctx context.Context, f *CustomField) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.DeleteByBean(ctx, &IssueCustomFieldValue{FieldID: f.ID}); err != nil {
+			return err
+		}
+		_, err := db.DeleteByID[CustomField](ctx, f.ID)
+		return err
+	})
+}
+
+// GetCustomFieldByID returns the custom field defined by an owner, or by one of its repositories, by its ID
+func GetCustomFieldByID(ctx context.Context, ownerID, id int64) (*CustomField, error) {
+	f, exist, err := db.Get[CustomField](ctx, builder.Eq{"id": id, "owner_id": ownerID})
+	if err != nil {
+		return nil, err
+	} else if !exist {
+		return nil, ErrCustomFieldNotExist{ID: id}
+	}
+	return f, nil
+}
+
+// GetCustomFieldInRepoByID returns a custom field usable in a repository by its ID
+func GetCustomFieldInRepoByID(ctx context.Context, ownerID, repoID, id int64) (*CustomField, error) {
+	f, err := GetCustomFieldByID(ctx, ownerID, id)
+	if err != nil {
+		return nil, err
+	}
+	if f.RepoID != 0 && f.RepoID != repoID {
+		return nil, ErrCustomFieldNotExist{ID: id}
+	}
+	return f, nil
+}
+
+// GetOwnerCustomFields returns the custom fields defined by an owner for all its repositories
+func GetOwnerCustomFields(ctx context.Context, ownerID int64) ([]*CustomField, error) {
+	fields := make([]*CustomField, 0, 5)
+	return fields, db.GetEngine(ctx).Where(builder.Eq{"owner_id": ownerID, "repo_id": 0}).Asc("name", "id").Find(&fields)
+}
+
+// GetRepoCustomFields returns the custom fields defined by a repository only
+func GetRepoCustomFields(ctx context.Context, repoID int64) ([]*CustomField, error) {
+	fields := make([]*CustomField, 0, 5)
+	return fields, db.GetEngine(ctx).Where(builder.Eq{"repo_id": repoID}).Asc("name", "id").Find(&fields)
+}
+
+// GetCustomFieldsForRepo returns the custom fields usable in a repository: the fields of its owner and its own fields
+func GetCustomFieldsForRepo(ctx context.Context, ownerID, repoID int64) ([]*CustomField, error) {
+	fields := make([]*CustomField, 0, 5)
+	return fields, db.GetEngine(ctx).
+		Where(builder.Eq{"owner_id": ownerID}.And(builder.In("repo_id", 0, repoID))).
+		Asc("name", "id").
+		Find(&fields)
+}
+
+// GetIssueIDsByCustomFieldID returns the IDs of the issues which have a value for a custom field
+func GetIssueIDsByCustomFieldID(ctx context.Context, fieldID int64) ([]int64, error) {
+	ids := make([]int64, 0, 10)
+	return ids, db.GetEngine(ctx).Table("issue_custom_field_value").Where("field_id = ?", fieldID).Cols("issue_id").Find(&ids)
+}
+
+// SetIssueCustomFieldValue sets the value of a custom field on an issue, an empty value removes it
+func SetIssueCustomFieldValue(ctx context.Context, issueID int64, field *CustomField, value string) error {
+	if strings.TrimSpace(value) == "" {
+		_, err := db.DeleteByBean(ctx, &IssueCustomFieldValue{IssueID: issueID, FieldID: field.ID})
+		return err
+	}
+
+	normalized, num, err := field.NormalizeValue(ctx, value)
+	if err != nil {
+		return err
+	}
+
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		v, exist, err := db.Get[IssueCustomFieldValue](ctx, builder.Eq{"issue_id": issueID, "field_id": field.ID})
+		if err != nil {
+			return err
+		}
+		if !exist {
+			return db.Insert(ctx, &IssueCustomFieldValue{IssueID: issueID, FieldID: field.ID, Value: normalized, NumValue: num})
+		}
+		v.Value, v.NumValue = normalized, num
+		_, err = db.GetEngine(ctx).ID(v.ID).Cols("value", "num_value").Update(v)
+		return err
+	})
+}
+
+// GetIssuesCustomFieldValues returns the values of the custom fields of the issues, by issue ID,
+// with their field and the user of the user fields, in the order of the fields
+func GetIssuesCustomFieldValues(ctx context.Context, issueIDs []int64) (map[int64][]*IssueCustomFieldValue, error) {
+	if len(issueIDs) == 0 {
+		return map[int64][]*IssueCustomFieldValue{}, nil
+	}
+
+	values := make([]*IssueCustomFieldValue, 0, len(issueIDs))
+	if err := db.GetEngine(ctx).In("issue_id", issueIDs).Find(&values); err != nil {
+		return nil, err
+	}
+
+	fieldIDs := container.FilterSlice(values, func(v *IssueCustomFieldValue) (int64, bool) {
+		return v.FieldID, true
+	})
+	fields := make(map[int64]*CustomField, len(fieldIDs))
+	if err := db.GetEngine(ctx).In("id", fieldIDs).Find(&fields); err != nil {
+		return nil, err
+	}
+
+	var userIDs []int64
+	for _, v := range values {
+		v.Field = fields[v.FieldID]
+		if v.Field != nil && v.Field.Type == CustomFieldTypeUser {
+			if id, err := strconv.ParseInt(v.Value, 10, 64); err == nil {
+				userIDs = append(userIDs, id)
+			}
+		}
+	}
+	users := make(map[int64]*user_model.User, len(userIDs))
+	if len(userIDs) > 0 {
+		if err := db.GetEngine(ctx).In("id", userIDs).Find(&users); err != nil {
+			return nil, err
+		}
+	}
+
+	ret := make(map[int64][]*IssueCustomFieldValue, len(issueIDs))
+	for _, v := range values {
+		if v.Field == nil {
+			continue
+		}
+		if v.Field.Type == CustomFieldTypeUser {
+			id, _ := strconv.ParseInt(v.Value, 10, 64)
+			if v.User = users[id]; v.User == nil {
+				v.User = user_model.NewGhostUser()
+			}
+		}
+		ret[v.IssueID] = append(ret[v.IssueID], v)
+	}
+	for _, issueValues := range ret {
+		slices.SortFunc(issueValues, func(a, b *IssueCustomFieldValue) int {
+			if c := strings.Compare(a.Field.Name, b.Field.Name); c != 0 {
+				return c
+			}
+			return int(a.FieldID - b.FieldID)
+		})
+	}
+	return ret, nil
+}
+
+// GetIssueCustomFieldValues returns the values of the custom fields of an issue
+func GetIssueCustomFieldValues(ctx context.Context, issueID int64) ([]*IssueCustomFieldValue, error) {
+	values, err := GetIssuesCustomFieldValues(ctx, []int64{issueID})
+	if err != nil {
+		return nil, err
+	}
+	return values[issueID], nil
+}
+
+// GetCardCustomFieldValues returns the values of the custom fields shown on the project cards of the issues, by issue ID
+func GetCardCustomFieldValues(ctx context.Context, issueIDs []int64) (map[int64][]*IssueCustomFieldValue, error) {
+	values, err := GetIssuesCustomFieldValues(ctx, issueIDs)
+	if err != nil {
+		return nil, err
+	}
+	for issueID, issueValues := range values {
+		issueValues = slices.DeleteFunc(issueValues, func(v *IssueCustomFieldValue) bool {
+			return !v.Field.ShowOnCards
+		})
+		if len(issueValues) == 0 {
+			delete(values, issueID)
+		} else {
+			values[issueID] = issueValues
+		}
+	}
+	return values, nil
+}
+
+// CustomFieldSortType returns the sort type of IssuesOptions sorting the issues by a custom field
+func CustomFieldSortType(fieldID int64, desc bool) string {
+	if desc {
+		return fmt.Sprintf("customfield-%d-desc", fieldID)
+	}
+	return fmt.Sprintf("customfield-%d-asc", fieldID)
+}
+
+// ParseCustomFieldSortType returns the field and the direction of a sort type built by CustomFieldSortType
+func ParseCustomFieldSortType(sortType string) (fieldID int64, desc, ok bool) {
+	rest, found := strings.CutPrefix(sortType, "customfield-")
+	if !found {
+		return 0, false, false
+	}
+	idStr, direction, found := strings.Cut(rest, "-")
+	if !found || (direction != "asc" && direction != "desc") {
+		return 0, false, false
+	}
+	fieldID, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || fieldID <= 0 {
+		return 0, false, false
+	}
+	return fieldID, direction == "desc", true
+}
+
+// TransferRepoCustomFields moves the fields of a repository to its new owner and removes
+// the values of the fields of the previous owner from the issues of the repository
+func TransferRepoCustomFields(ctx context.Context, repoID, newOwnerID int64) error {
+	if _, err := db.GetEngine(ctx).
+		In("issue_id", builder.Select("id").From("issue").Where(builder.Eq{"repo_id": repoID})).
+		In("field_id", builder.Select("id").From("custom_field").Where(builder.Eq{"repo_id": 0}.And(builder.Neq{"owner_id": newOwnerID}))).
+		Delete(new(IssueCustomFieldValue)); err != nil {
+		return err
+	}
+	_, err := db.GetEngine(ctx).Where("repo_id = ?", repoID).Cols("owner_id").NoAutoTime().Update(&CustomField{OwnerID: newOwnerID})
+	return err
+}
@@ -0,0 +1,166 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomFieldValidate(t *testing.T) {
+	field := &issues_model.CustomField{Name: " Severity ", Type: issues_model.CustomFieldTypeSelect, Options: []string{" high", "", "low "}}
+	require.NoError(t, field.Validate())
+	assert.Equal(t, "Severity", field.Name)
+	assert.Equal(t, []string{"high", "low"}, field.Options)
+
+	for _, field := range []*issues_model.CustomField{
+		{Name: " ", Type: issues_model.CustomFieldTypeNumber},
+		{Name: "Severity", Type: "color"},
+		{Name: "Severity", Type: issues_model.CustomFieldTypeSelect},
+		{Name: "Severity", Type: issues_model.CustomFieldTypeSelect, Options: []string{"high", "high"}},
+	} {
+		assert.ErrorIs(t, field.Validate(), util.ErrInvalidArgument, "%+v", field)
+	}
+
+	field = &issues_model.CustomField{Name: "Estimate", Type: issues_model.CustomFieldTypeNumber, Options: []string{"1"}}
+	require.NoError(t, field.Validate())
+	assert.Empty(t, field.Options)
+}
+
+func TestCustomFieldNormalizeValue(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	test := func(field *issues_model.CustomField, value, expected string, expectedNum float64) {
+		t.Helper()
+		normalized, num, err := field.NormalizeValue(db.DefaultContext, value)
+		require.NoError(t, err)
+		assert.Equal(t, expected, normalized)
+		assert.InDelta(t, expectedNum, num, 0)
+	}
+	testInvalid := func(field *issues_model.CustomField, value string) {
+		t.Helper()
+		_, _, err := field.NormalizeValue(db.DefaultContext, value)
+		assert.ErrorIs(t, err, util.ErrInvalidArgument)
+	}
+
+	selectField := &issues_model.CustomField{Type: issues_model.CustomFieldTypeSelect, Options: []string{"high", "low"}}
+	test(selectField, " low", "low", 1)
+	testInvalid(selectField, "medium")
+
+	numberField := &issues_model.CustomField{Type: issues_model.CustomFieldTypeNumber}
+	test(numberField, "2.50", "2.5", 2.5)
+	testInvalid(numberField, "two")
+
+	dateField := &issues_model.CustomField{Type: issues_model.CustomFieldTypeDate}
+	test(dateField, "2024-03-01", "2024-03-01", 1709251200)
+	testInvalid(dateField, "01/03/2024")
+
+	userField := &issues_model.CustomField{Type: issues_model.CustomFieldTypeUser}
+	test(userField, "user2", "2", 2)
+	testInvalid(userField, "org3")
+	testInvalid(userField, "nobody")
+}
+
+func TestIssueCustomFieldValues(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	severity := &issues_model.CustomField{OwnerID: 2, Name: "Severity", Type: issues_model.CustomFieldTypeSelect, Options: []string{"high", "low"}, ShowOnCards: true}
+	require.NoError(t, issues_model.NewCustomField(db.DefaultContext, severity))
+	reviewer := &issues_model.CustomField{OwnerID: 2, RepoID: 1, Name: "Reviewer", Type: issues_model.CustomFieldTypeUser}
+	require.NoError(t, issues_model.NewCustomField(db.DefaultContext, reviewer))
+	other := &issues_model.CustomField{OwnerID: 2, RepoID: 2, Name: "Other", Type: issues_model.CustomFieldTypeNumber}
+	require.NoError(t, issues_model.NewCustomField(db.DefaultContext, other))
+
+	fields, err := issues_model.GetCustomFieldsForRepo(db.DefaultContext, 2, 1)
+	require.NoError(t, err)
+	require.Len(t, fields, 2)
+	assert.Equal(t, reviewer.ID, fields[0].ID)
+	assert.Equal(t, severity.ID, fields[1].ID)
+
+	_, err = issues_model.GetCustomFieldInRepoByID(db.DefaultContext, 2, 1, other.ID)
+	assert.True(t, issues_model.IsErrCustomFieldNotExist(err))
+
+	require.NoError(t, issues_model.SetIssueCustomFieldValue(db.DefaultContext, 1, severity, "low"))
+	require.NoError(t, issues_model.SetIssueCustomFieldValue(db.DefaultContext, 1, reviewer, "user4"))
+	require.NoError(t, issues_model.SetIssueCustomFieldValue(db.DefaultContext, 2, severity, "high"))
+	assert.ErrorIs(t, issues_model.SetIssueCustomFieldValue(db.DefaultContext, 3, severity, "medium"), util.ErrInvalidArgument)
+
+	values, err := issues_model.GetIssueCustomFieldValues(db.DefaultContext, 1)
+	require.NoError(t, err)
+	require.Len(t, values, 2)
+	assert.Equal(t, "Reviewer", values[0].Field.Name)
+	assert.Equal(t, "user4", values[0].DisplayValue())
+	assert.Equal(t, "low", values[1].DisplayValue())
+
+	cardValues, err := issues_model.GetCardCustomFieldValues(db.DefaultContext, []int64{1, 2, 3})
+	require.NoError(t, err)
+	require.Len(t, cardValues, 2)
+	require.Len(t, cardValues[1], 1)
+	assert.Equal(t, "low", cardValues[1][0].Value)
+
+	t.Run("Filter", func(t *testing.T) {
+		issues, err := issues_model.Issues(db.DefaultContext, &issues_model.IssuesOptions{
+			RepoIDs:           []int64{1},
+			CustomFieldValues: map[int64]string{severity.ID: "high"},
+		})
+		require.NoError(t, err)
+		require.Len(t, issues, 1)
+		assert.EqualValues(t, 2, issues[0].ID)
+
+		stats, err := issues_model.GetIssueStats(db.DefaultContext, &issues_model.IssuesOptions{
+			RepoIDs:           []int64{1},
+			CustomFieldValues: map[int64]string{severity.ID: "low"},
+		})
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, stats.OpenCount)
+		assert.EqualValues(t, 0, stats.ClosedCount)
+	})
+
+	t.Run("Sort", func(t *testing.T) {
+		for desc, expected := range map[bool][]int64{false: {2, 1}, true: {1, 2}} {
+			issues, err := issues_model.Issues(db.DefaultContext, &issues_model.IssuesOptions{
+				RepoIDs:  []int64{1},
+				SortType: issues_model.CustomFieldSortType(severity.ID, desc),
+			})
+			require.NoError(t, err)
+			require.Len(t, issues, 5)
+			assert.Equal(t, expected, []int64{issues[0].ID, issues[1].ID}, "desc: %v", desc)
+		}
+
+		fieldID, desc, ok := issues_model.ParseCustomFieldSortType(issues_model.CustomFieldSortType(severity.ID, true))
+		assert.True(t, ok)
+		assert.True(t, desc)
+		assert.Equal(t, severity.ID, fieldID)
+		_, _, ok = issues_model.ParseCustomFieldSortType("customfield-x-asc")
+		assert.False(t, ok)
+	})
+
+	t.Run("RemoveOption", func(t *testing.T) {
+		severity.Options = []string{"critical", "high"}
+		require.NoError(t, issues_model.UpdateCustomField(db.DefaultContext, severity))
+
+		issueIDs, err := issues_model.GetIssueIDsByCustomFieldID(db.DefaultContext, severity.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{2}, issueIDs)
+		unittest.AssertExistsAndLoadBean(t, &issues_model.IssueCustomFieldValue{IssueID: 2, FieldID: severity.ID, Value: "high", NumValue: 1})
+	})
+
+	t.Run("Unset", func(t *testing.T) {
+		require.NoError(t, issues_model.SetIssueCustomFieldValue(db.DefaultContext, 1, reviewer, ""))
+		unittest.AssertNotExistsBean(t, &issues_model.IssueCustomFieldValue{IssueID: 1, FieldID: reviewer.ID})
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, issues_model.DeleteCustomField(db.DefaultContext, severity))
+		unittest.AssertNotExistsBean(t, &issues_model.CustomField{ID: severity.ID})
+		unittest.AssertNotExistsBean(t, &issues_model.IssueCustomFieldValue{FieldID: severity.ID})
+	})
+}
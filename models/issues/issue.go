@@ -143,6 +143,9 @@ type Issue struct {
 	Reactions           ReactionList             `xorm:"-"`
 	TotalTrackedTime    int64                    `xorm:"-"`
 	Assignees           []*user_model.User       `xorm:"-"`
+	// Form holds the answers to the issue form the issue was created with, if any
+	Form         *IssueForm `xorm:"-"`
+	isFormLoaded bool       `xorm:"-"`
 
 	// IsLocked limits commenting abilities to users on an issue
 	// with write access
@@ -373,6 +376,7 @@ func (issue *Issue) ResetAttributesLoaded() {
 	issue.isMilestoneLoaded = false
 	issue.isAttachmentsLoaded = false
 	issue.isAssigneeLoaded = false
+	issue.isFormLoaded = false
 }
 
 // GetIsRead load the `IsRead` field of the issue
@@ -0,0 +1,86 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// IssueForm records the answers to the issue form an issue was created with,
+// which are otherwise only rendered as markdown in its content.
+type IssueForm struct {
+	ID      int64 `xorm:"pk autoincr"`
+	IssueID int64 `xorm:"UNIQUE NOT NULL"`
+	// TemplateFile is the path of the form in the default branch of the repository
+	TemplateFile string                 `xorm:"NOT NULL"`
+	Answers      []*api.IssueFormAnswer `xorm:"JSON TEXT"`
+	CreatedUnix  timeutil.TimeStamp     `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(IssueForm))
+}
+
+// LoadForm loads the answers to the issue form of the issue, Form is nil if it was not created with a form
+func (issue *Issue) LoadForm(ctx context.Context) error {
+	if issue.isFormLoaded || issue.Form != nil {
+		return nil
+	}
+
+	form := &IssueForm{}
+	has, err := db.GetEngine(ctx).Where("issue_id = ?", issue.ID).Get(form)
+	if err != nil {
+		return err
+	}
+	if has {
+		issue.Form = form
+	}
+	issue.isFormLoaded = true
+	return nil
+}
+
+// LoadForms loads the answers to the issue forms of the issues
+func (issues IssueList) LoadForms(ctx context.Context) error {
+	issueIDs := make([]int64, 0, len(issues))
+	for _, issue := range issues {
+		if !issue.isFormLoaded && issue.Form == nil {
+			issueIDs = append(issueIDs, issue.ID)
+		}
+	}
+	if len(issueIDs) == 0 {
+		return nil
+	}
+
+	forms := make(map[int64]*IssueForm, len(issueIDs))
+	for len(issueIDs) > 0 {
+		limit := min(db.DefaultMaxInSize, len(issueIDs))
+		chunk := make([]*IssueForm, 0, limit)
+		if err := db.GetEngine(ctx).In("issue_id", issueIDs[:limit]).Find(&chunk); err != nil {
+			return err
+		}
+		for _, form := range chunk {
+			forms[form.IssueID] = form
+		}
+		issueIDs = issueIDs[limit:]
+	}
+
+	for _, issue := range issues {
+		if !issue.isFormLoaded && issue.Form == nil {
+			issue.Form = forms[issue.ID]
+			issue.isFormLoaded = true
+		}
+	}
+	return nil
+}
+
+// insertIssueForm records the answers to the issue form of a new issue
+func insertIssueForm(ctx context.Context, issue *Issue) error {
+	issue.Form.IssueID = issue.ID
+	issue.isFormLoaded = true
+	return db.Insert(ctx, issue.Form)
+}
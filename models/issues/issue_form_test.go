@@ -0,0 +1,53 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssueForm(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	answers := []*api.IssueFormAnswer{
+		{ID: "version", Type: api.IssueFormFieldTypeInput, Label: "Version", Value: "1.2"},
+		{ID: "os", Type: api.IssueFormFieldTypeDropdown, Label: "OS", Options: []string{"Linux"}},
+	}
+
+	issue := &issues_model.Issue{
+		RepoID:   repo.ID,
+		PosterID: user.ID,
+		Poster:   user,
+		Title:    "bug report",
+		Form:     &issues_model.IssueForm{TemplateFile: ".forgejo/issue_template/bug.yaml", Answers: answers},
+	}
+	require.NoError(t, issues_model.NewIssue(db.DefaultContext, repo, issue, nil, nil))
+
+	issue = unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: issue.ID})
+	require.NoError(t, issue.LoadForm(db.DefaultContext))
+	require.NotNil(t, issue.Form)
+	assert.Equal(t, ".forgejo/issue_template/bug.yaml", issue.Form.TemplateFile)
+	assert.Equal(t, answers, issue.Form.Answers)
+
+	issues := issues_model.IssueList{
+		unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1}),
+		unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: issue.ID}),
+	}
+	require.NoError(t, issues.LoadForms(db.DefaultContext))
+	assert.Nil(t, issues[0].Form)
+	require.NotNil(t, issues[1].Form)
+	assert.Equal(t, answers, issues[1].Form.Answers)
+}
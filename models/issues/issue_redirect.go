@@ -0,0 +1,51 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// IssueRedirect records the former repository and index of an issue transferred to another repository,
+// so that the links to its old location keep working.
+type IssueRedirect struct {
+	ID          int64              `xorm:"pk autoincr"`
+	OldRepoID   int64              `xorm:"UNIQUE(s) NOT NULL"`
+	OldIndex    int64              `xorm:"UNIQUE(s) NOT NULL"`
+	IssueID     int64              `xorm:"INDEX NOT NULL"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(IssueRedirect))
+}
+
+// NewIssueRedirect records that the issue with the given index of a repository was moved to the issue issueID
+func NewIssueRedirect(ctx context.Context, oldRepoID, oldIndex, issueID int64) error {
+	return db.Insert(ctx, &IssueRedirect{
+		OldRepoID: oldRepoID,
+		OldIndex:  oldIndex,
+		IssueID:   issueID,
+	})
+}
+
+// LookupIssueRedirect returns the ID of the issue that was moved from the given index of a repository,
+// or 0 if there is none.
+func LookupIssueRedirect(ctx context.Context, oldRepoID, oldIndex int64) (int64, error) {
+	redirect := &IssueRedirect{}
+	has, err := db.GetEngine(ctx).Where("old_repo_id = ? AND old_index = ?", oldRepoID, oldIndex).Get(redirect)
+	if err != nil || !has {
+		return 0, err
+	}
+	return redirect.IssueID, nil
+}
+
+// DeleteIssueRedirectsByRepoID deletes the redirects from the issues of a repository
+func DeleteIssueRedirectsByRepoID(ctx context.Context, repoID int64) error {
+	_, err := db.GetEngine(ctx).Where("old_repo_id = ?", repoID).Delete(&IssueRedirect{})
+	return err
+}
@@ -0,0 +1,235 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// IssueScheduleInterval is the time between the issues created by a schedule
+type IssueScheduleInterval string
+
+const (
+	// IssueScheduleIntervalOnce creates a single issue at the start of the schedule
+	IssueScheduleIntervalOnce IssueScheduleInterval = "once"
+	// IssueScheduleIntervalDaily creates an issue every day
+	IssueScheduleIntervalDaily IssueScheduleInterval = "daily"
+	// IssueScheduleIntervalWeekly creates an issue every week
+	IssueScheduleIntervalWeekly IssueScheduleInterval = "weekly"
+	// IssueScheduleIntervalMonthly creates an issue every month
+	IssueScheduleIntervalMonthly IssueScheduleInterval = "monthly"
+	// IssueScheduleIntervalQuarterly creates an issue every three months
+	IssueScheduleIntervalQuarterly IssueScheduleInterval = "quarterly"
+	// IssueScheduleIntervalYearly creates an issue every year
+	IssueScheduleIntervalYearly IssueScheduleInterval = "yearly"
+)
+
+// IssueScheduleIntervals are all the supported intervals
+var IssueScheduleIntervals = []IssueScheduleInterval{
+	IssueScheduleIntervalOnce,
+	IssueScheduleIntervalDaily,
+	IssueScheduleIntervalWeekly,
+	IssueScheduleIntervalMonthly,
+	IssueScheduleIntervalQuarterly,
+	IssueScheduleIntervalYearly,
+}
+
+// IsValid returns true if the interval is supported
+func (i IssueScheduleInterval) IsValid() bool {
+	return slices.Contains(IssueScheduleIntervals, i)
+}
+
+// occurrence returns the time of the n-th issue of a schedule started at start
+func (i IssueScheduleInterval) occurrence(start time.Time, n int) time.Time {
+	switch i {
+	case IssueScheduleIntervalDaily:
+		return start.AddDate(0, 0, n)
+	case IssueScheduleIntervalWeekly:
+		return start.AddDate(0, 0, 7*n)
+	case IssueScheduleIntervalMonthly:
+		return start.AddDate(0, n, 0)
+	case IssueScheduleIntervalQuarterly:
+		return start.AddDate(0, 3*n, 0)
+	case IssueScheduleIntervalYearly:
+		return start.AddDate(n, 0, 0)
+	}
+	return start
+}
+
+// ErrIssueScheduleNotExist represents a "IssueScheduleNotExist" kind of error.
+type ErrIssueScheduleNotExist struct {
+	ID int64
+}
+
+// IsErrIssueScheduleNotExist checks if an error is a ErrIssueScheduleNotExist.
+func IsErrIssueScheduleNotExist(err error) bool {
+	_, ok := err.(ErrIssueScheduleNotExist)
+	return ok
+}
+
+func (err ErrIssueScheduleNotExist) Error() string {
+	return fmt.Sprintf("issue schedule does not exist [id: %d]", err.ID)
+}
+
+func (err ErrIssueScheduleNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// IssueSchedule creates issues in a repository at a given date, once or at a regular interval.
+// The title, content and labels of the issues may come from an issue template of the default branch.
+type IssueSchedule struct {
+	ID     int64 `xorm:"pk autoincr"`
+	RepoID int64 `xorm:"INDEX NOT NULL"`
+	// CreatorID is the poster of the issues
+	CreatorID int64  `xorm:"NOT NULL"`
+	Title     string `xorm:"NOT NULL DEFAULT ''"`
+	Content   string `xorm:"LONGTEXT"`
+	// TemplateFile is the path of an issue template of the default branch, which provides the title,
+	// the content and the labels of the issues when the schedule does not
+	TemplateFile string                `xorm:"NOT NULL DEFAULT ''"`
+	Interval     IssueScheduleInterval `xorm:"VARCHAR(20) NOT NULL"`
+	// StartUnix is the time of the first issue, the next ones are created at the same time of the day
+	StartUnix   timeutil.TimeStamp `xorm:"NOT NULL"`
+	NextRunUnix timeutil.TimeStamp `xorm:"INDEX NOT NULL"`
+	LastRunUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	LastIssueID int64              `xorm:"NOT NULL DEFAULT 0"`
+	// AssigneeTeamID is the team of the owner of the repository whose members are assigned to the issues in turn
+	AssigneeTeamID int64 `xorm:"NOT NULL DEFAULT 0"`
+	// RotationIndex is the number of issues assigned so far, to pick the next assignee
+	RotationIndex int64 `xorm:"NOT NULL DEFAULT 0"`
+	IsActive      bool  `xorm:"INDEX NOT NULL DEFAULT true"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(IssueSchedule))
+}
+
+// Validate checks the definition of the schedule
+func (s *IssueSchedule) Validate() error {
+	s.Title = strings.TrimSpace(s.Title)
+	s.TemplateFile = strings.TrimSpace(s.TemplateFile)
+	if s.Title == "" && s.TemplateFile == "" {
+		return util.NewInvalidArgumentErrorf("either the title or the template of the issues is required")
+	}
+	if !s.Interval.IsValid() {
+		return util.NewInvalidArgumentErrorf("invalid issue schedule interval %q", s.Interval)
+	}
+	if s.StartUnix <= 0 {
+		return util.NewInvalidArgumentErrorf("the start of the issue schedule is required")
+	}
+	return nil
+}
+
+// NextRunAfter returns the time of the first issue of the schedule after t, or 0 if there is none
+func (s *IssueSchedule) NextRunAfter(t timeutil.TimeStamp) timeutil.TimeStamp {
+	if s.StartUnix > t {
+		return s.StartUnix
+	}
+	if s.Interval == IssueScheduleIntervalOnce {
+		return 0
+	}
+
+	start := s.StartUnix.AsTime()
+	for n := 1; ; n++ {
+		if next := timeutil.TimeStamp(s.Interval.occurrence(start, n).Unix()); next > t {
+			return next
+		}
+	}
+}
+
+// Reschedule computes the time of the next issue from now after a change of the start or of the interval,
+// the schedule is deactivated if there is none
+func (s *IssueSchedule) Reschedule(now timeutil.TimeStamp) {
+	s.NextRunUnix = s.NextRunAfter(now - 1)
+	if s.NextRunUnix == 0 {
+		s.IsActive = false
+	}
+}
+
+// GetIssueSchedules returns the issue schedules of a repository
+func GetIssueSchedules(ctx context.Context, repoID int64) ([]*IssueSchedule, error) {
+	schedules := make([]*IssueSchedule, 0, 5)
+	return schedules, db.GetEngine(ctx).Where("repo_id = ?", repoID).OrderBy("id").Find(&schedules)
+}
+
+// GetIssueScheduleByID returns the issue schedule of a repository by its ID
+func GetIssueScheduleByID(ctx context.Context, repoID, id int64) (*IssueSchedule, error) {
+	schedule := &IssueSchedule{}
+	has, err := db.GetEngine(ctx).Where("id = ? AND repo_id = ?", id, repoID).Get(schedule)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrIssueScheduleNotExist{ID: id}
+	}
+	return schedule, nil
+}
+
+// FindDueIssueSchedules returns the active schedules whose next issue should have been created at now
+func FindDueIssueSchedules(ctx context.Context, now timeutil.TimeStamp) ([]*IssueSchedule, error) {
+	schedules := make([]*IssueSchedule, 0, 10)
+	return schedules, db.GetEngine(ctx).
+		Where("is_active = ? AND next_run_unix <= ?", true, now).
+		OrderBy("next_run_unix, id").
+		Find(&schedules)
+}
+
+// CreateIssueSchedule inserts a new issue schedule
+func CreateIssueSchedule(ctx context.Context, schedule *IssueSchedule) error {
+	if err := schedule.Validate(); err != nil {
+		return err
+	}
+	schedule.IsActive = true
+	schedule.Reschedule(timeutil.TimeStampNow())
+	if !schedule.IsActive {
+		return util.NewInvalidArgumentErrorf("the start of a one-time issue schedule must be in the future")
+	}
+	return db.Insert(ctx, schedule)
+}
+
+// UpdateIssueSchedule updates the definition of an issue schedule, whose issues are then posted by the doer.
+// The next issue is only moved if the start, the interval or the activation of the schedule change.
+func UpdateIssueSchedule(ctx context.Context, doer *user_model.User, schedule *IssueSchedule) error {
+	if err := schedule.Validate(); err != nil {
+		return err
+	}
+	old, err := GetIssueScheduleByID(ctx, schedule.RepoID, schedule.ID)
+	if err != nil {
+		return err
+	}
+	if schedule.StartUnix != old.StartUnix || schedule.Interval != old.Interval || schedule.IsActive != old.IsActive {
+		schedule.Reschedule(timeutil.TimeStampNow())
+	}
+	schedule.CreatorID = doer.ID
+	_, err = db.GetEngine(ctx).ID(schedule.ID).
+		Cols("creator_id", "title", "content", "template_file", "interval", "start_unix", "next_run_unix", "assignee_team_id", "is_active").
+		Update(schedule)
+	return err
+}
+
+// UpdateIssueScheduleRun records an issue created by a schedule and the time of the next one
+func UpdateIssueScheduleRun(ctx context.Context, schedule *IssueSchedule) error {
+	_, err := db.GetEngine(ctx).ID(schedule.ID).
+		Cols("next_run_unix", "last_run_unix", "last_issue_id", "rotation_index", "is_active").
+		NoAutoTime().
+		Update(schedule)
+	return err
+}
+
+// DeleteIssueSchedule deletes an issue schedule of a repository, the issues it created are kept
+func DeleteIssueSchedule(ctx context.Context, repoID, id int64) error {
+	_, err := db.GetEngine(ctx).Where("id = ? AND repo_id = ?", id, repoID).Delete(new(IssueSchedule))
+	return err
+}
@@ -0,0 +1,115 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues_test
+
+import (
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssueScheduleNextRunAfter(t *testing.T) {
+	start := time.Date(2024, time.January, 15, 9, 0, 0, 0, time.Local)
+	at := func(year int, month time.Month, day int) timeutil.TimeStamp {
+		return timeutil.TimeStamp(time.Date(year, month, day, 9, 0, 0, 0, time.Local).Unix())
+	}
+
+	schedule := &issues_model.IssueSchedule{StartUnix: timeutil.TimeStamp(start.Unix()), Interval: issues_model.IssueScheduleIntervalQuarterly}
+	assert.Equal(t, at(2024, time.January, 15), schedule.NextRunAfter(at(2023, time.December, 1)))
+	assert.Equal(t, at(2024, time.April, 15), schedule.NextRunAfter(at(2024, time.January, 15)))
+	assert.Equal(t, at(2025, time.January, 15), schedule.NextRunAfter(at(2024, time.November, 30)))
+
+	schedule.Interval = issues_model.IssueScheduleIntervalWeekly
+	assert.Equal(t, at(2024, time.January, 22), schedule.NextRunAfter(at(2024, time.January, 16)))
+
+	schedule.Interval = issues_model.IssueScheduleIntervalOnce
+	assert.Equal(t, at(2024, time.January, 15), schedule.NextRunAfter(at(2024, time.January, 14)))
+	assert.EqualValues(t, 0, schedule.NextRunAfter(at(2024, time.January, 15)))
+}
+
+func TestCreateIssueSchedule(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	now := timeutil.TimeStampNow()
+	schedule := &issues_model.IssueSchedule{
+		RepoID:    1,
+		CreatorID: 2,
+		Title:     " Rotate certificates ",
+		Interval:  issues_model.IssueScheduleIntervalDaily,
+		StartUnix: now - 3600,
+	}
+	require.NoError(t, issues_model.CreateIssueSchedule(db.DefaultContext, schedule))
+	assert.Equal(t, "Rotate certificates", schedule.Title)
+	assert.True(t, schedule.IsActive)
+	// the past occurrences are skipped
+	assert.Equal(t, now-3600+24*3600, schedule.NextRunUnix)
+
+	schedules, err := issues_model.GetIssueSchedules(db.DefaultContext, 1)
+	require.NoError(t, err)
+	require.Len(t, schedules, 1)
+	assert.Equal(t, schedule.ID, schedules[0].ID)
+
+	due, err := issues_model.FindDueIssueSchedules(db.DefaultContext, now)
+	require.NoError(t, err)
+	assert.Empty(t, due)
+	due, err = issues_model.FindDueIssueSchedules(db.DefaultContext, schedule.NextRunUnix)
+	require.NoError(t, err)
+	assert.Len(t, due, 1)
+
+	_, err = issues_model.GetIssueScheduleByID(db.DefaultContext, 2, schedule.ID)
+	assert.True(t, issues_model.IsErrIssueScheduleNotExist(err))
+
+	// changing the start moves the next issue
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	schedule.StartUnix = now + 3600
+	require.NoError(t, issues_model.UpdateIssueSchedule(db.DefaultContext, doer, schedule))
+	schedule, err = issues_model.GetIssueScheduleByID(db.DefaultContext, 1, schedule.ID)
+	require.NoError(t, err)
+	assert.Equal(t, now+3600, schedule.NextRunUnix)
+
+	// changing the title keeps the next issue, which is then posted by the user editing the schedule
+	user4 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+	schedule.NextRunUnix = now + 7200
+	_, err = db.GetEngine(db.DefaultContext).ID(schedule.ID).Cols("next_run_unix").Update(schedule)
+	require.NoError(t, err)
+	schedule.Title = "Renew certificates"
+	require.NoError(t, issues_model.UpdateIssueSchedule(db.DefaultContext, user4, schedule))
+	schedule, err = issues_model.GetIssueScheduleByID(db.DefaultContext, 1, schedule.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Renew certificates", schedule.Title)
+	assert.Equal(t, now+7200, schedule.NextRunUnix)
+	assert.EqualValues(t, 4, schedule.CreatorID)
+
+	// reactivating the schedule moves the next issue
+	schedule.IsActive = false
+	require.NoError(t, issues_model.UpdateIssueSchedule(db.DefaultContext, doer, schedule))
+	schedule.IsActive = true
+	require.NoError(t, issues_model.UpdateIssueSchedule(db.DefaultContext, doer, schedule))
+	schedule, err = issues_model.GetIssueScheduleByID(db.DefaultContext, 1, schedule.ID)
+	require.NoError(t, err)
+	assert.True(t, schedule.IsActive)
+	assert.Equal(t, now+3600, schedule.NextRunUnix)
+
+	for name, invalid := range map[string]*issues_model.IssueSchedule{
+		"no title":      {RepoID: 1, CreatorID: 2, Interval: issues_model.IssueScheduleIntervalDaily, StartUnix: now},
+		"bad interval":  {RepoID: 1, CreatorID: 2, Title: "title", Interval: "hourly", StartUnix: now},
+		"past one-time": {RepoID: 1, CreatorID: 2, Title: "title", Interval: issues_model.IssueScheduleIntervalOnce, StartUnix: now - 3600},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.ErrorIs(t, issues_model.CreateIssueSchedule(db.DefaultContext, invalid), util.ErrInvalidArgument)
+		})
+	}
+
+	require.NoError(t, issues_model.DeleteIssueSchedule(db.DefaultContext, 1, schedule.ID))
+	unittest.AssertNotExistsBean(t, &issues_model.IssueSchedule{ID: schedule.ID})
+}
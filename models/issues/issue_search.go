@@ -42,10 +42,12 @@ type IssuesOptions struct { //nolint
 	IncludedLabelNames []string
 	ExcludedLabelNames []string
 	IncludeMilestones  []string
-	SortType           string
-	IssueIDs           []int64
-	UpdatedAfterUnix   int64
-	UpdatedBeforeUnix  int64
+	// CustomFieldValues filters the issues by the normalized values of custom fields, keyed by field ID
+	CustomFieldValues map[int64]string
+	SortType          string
+	IssueIDs          []int64
+	UpdatedAfterUnix  int64
+	UpdatedBeforeUnix int64
 	// prioritize issues from this repo
 	PriorityRepoID int64
 	IsArchived     optional.Option[bool]
@@ -97,10 +99,28 @@ func applySorts(sess *xorm.Session, sortType string, priorityRepoID int64) {
 	case "project-column-sorting":
 		sess.Asc("project_issue.sorting").Desc("issue.created_unix").Desc("issue.id")
 	default:
+		if fieldID, desc, ok := ParseCustomFieldSortType(sortType); ok {
+			applyCustomFieldSort(sess, fieldID, desc)
+			return
+		}
 		sess.Desc("issue.created_unix").Desc("issue.id")
 	}
 }
 
+// applyCustomFieldSort sorts the issues by the value of a custom field, the issues without value come last
+func applyCustomFieldSort(sess *xorm.Session, fieldID int64, desc bool) {
+	direction := "ASC"
+	if desc {
+		direction = "DESC"
+	}
+	sess.Join("LEFT", "issue_custom_field_value", "issue_custom_field_value.issue_id = issue.id AND issue_custom_field_value.field_id = ?", fieldID).
+		OrderBy("CASE WHEN issue_custom_field_value.id IS NULL THEN 1 ELSE 0 END ASC").
+		OrderBy("issue_custom_field_value.num_value " + direction).
+		OrderBy("issue_custom_field_value.value " + direction).
+		Desc("issue.created_unix").
+		Desc("issue.id")
+}
+
 func applyLimit(sess *xorm.Session, opts *IssuesOptions) {
 	if opts.Paginator == nil || opts.Paginator.IsListAll() {
 		return
@@ -267,11 +287,20 @@ func applyConditions(sess *xorm.Session, opts *IssuesOptions) {
 
 	applyLabelsCondition(sess, opts)
 
+	applyCustomFieldValuesCondition(sess, opts)
+
 	if opts.User != nil {
 		sess.And(issuePullAccessibleRepoCond("issue.repo_id", opts.User.ID, opts.Org, opts.Team, opts.IsPull.Value()))
 	}
 }
 
+func applyCustomFieldValuesCondition(sess *xorm.Session, opts *IssuesOptions) {
+	for fieldID, value := range opts.CustomFieldValues {
+		sess.In("issue.id", builder.Select("issue_id").From("issue_custom_field_value").
+			Where(builder.Eq{"field_id": fieldID, "value": value}))
+	}
+}
+
 // teamUnitsRepoCond returns query condition for those repo id in the special org team with special units access
 func teamUnitsRepoCond(id string, userID, orgID, teamID int64, units ...unit.Type) builder.Cond {
 	return builder.In(id,
@@ -0,0 +1,478 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+	"fmt"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// SLAPolicy is a service level agreement on the time taken to respond to and to resolve
+// the issues of a repository, or only the ones having a label.
+type SLAPolicy struct {
+	ID     int64  `xorm:"pk autoincr"`
+	RepoID int64  `xorm:"INDEX NOT NULL"`
+	Name   string `xorm:"NOT NULL"`
+	// LabelID restricts the policy to the issues having the label, 0 for all the issues
+	LabelID int64 `xorm:"NOT NULL DEFAULT 0"`
+	// FirstResponseHours is the time allowed for someone else than the poster to comment, 0 for no target
+	FirstResponseHours int64 `xorm:"NOT NULL DEFAULT 0"`
+	// ResolutionHours is the time allowed to close the issue, 0 for no target
+	ResolutionHours int64 `xorm:"NOT NULL DEFAULT 0"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+
+	Label *Label `xorm:"-"`
+}
+
+// SLAKind is the target of an SLA policy
+type SLAKind string
+
+const (
+	// SLAKindFirstResponse is the first response to an issue by someone else than its poster
+	SLAKindFirstResponse SLAKind = "first_response"
+	// SLAKindResolution is the closing of an issue
+	SLAKindResolution SLAKind = "resolution"
+)
+
+// IssueSLABreach records that an issue missed a target of an SLA policy, so that it is notified only once
+type IssueSLABreach struct {
+	ID          int64              `xorm:"pk autoincr"`
+	IssueID     int64              `xorm:"UNIQUE(s) NOT NULL"`
+	PolicyID    int64              `xorm:"UNIQUE(s) NOT NULL"`
+	Kind        SLAKind            `xorm:"VARCHAR(20) UNIQUE(s) NOT NULL"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+
+	Issue  *Issue     `xorm:"-"`
+	Policy *SLAPolicy `xorm:"-"`
+}
+
+func init() {
+	db.RegisterModel(new(SLAPolicy))
+	db.RegisterModel(new(IssueSLABreach))
+}
+
+// ErrSLAPolicyNotExist represents a "SLAPolicyNotExist" kind of error.
+type ErrSLAPolicyNotExist struct {
+	ID int64
+}
+
+// IsErrSLAPolicyNotExist checks if an error is a ErrSLAPolicyNotExist.
+func IsErrSLAPolicyNotExist(err error) bool {
+	_, ok := err.(ErrSLAPolicyNotExist)
+	return ok
+}
+
+func (err ErrSLAPolicyNotExist) Error() string {
+	return fmt.Sprintf("SLA policy does not exist [id: %d]", err.ID)
+}
+
+func (err ErrSLAPolicyNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// Applies tests if the policy covers an issue, whose labels must be loaded
+func (p *SLAPolicy) Applies(issue *Issue) bool {
+	if issue.IsPull {
+		return false
+	}
+	if p.LabelID == 0 {
+		return true
+	}
+	for _, label := range issue.Labels {
+		if label.ID == p.LabelID {
+			return true
+		}
+	}
+	return false
+}
+
+// Deadline returns the deadline of a target of the policy for an issue
+func (p *SLAPolicy) Deadline(issue *Issue, kind SLAKind) timeutil.TimeStamp {
+	hours := p.ResolutionHours
+	if kind == SLAKindFirstResponse {
+		hours = p.FirstResponseHours
+	}
+	return issue.CreatedUnix.Add(hours * 3600)
+}
+
+// GetSLAPolicies returns the SLA policies of a repository
+func GetSLAPolicies(ctx context.Context, repoID int64) ([]*SLAPolicy, error) {
+	policies := make([]*SLAPolicy, 0, 5)
+	return policies, db.GetEngine(ctx).Where("repo_id = ?", repoID).Asc("id").Find(&policies)
+}
+
+// LoadSLAPoliciesLabels loads the labels of SLA policies
+func LoadSLAPoliciesLabels(ctx context.Context, policies []*SLAPolicy) error {
+	labelIDs := make([]int64, 0, len(policies))
+	for _, p := range policies {
+		if p.LabelID > 0 {
+			labelIDs = append(labelIDs, p.LabelID)
+		}
+	}
+	if len(labelIDs) == 0 {
+		return nil
+	}
+	labels, err := GetLabelsByIDs(ctx, labelIDs)
+	if err != nil {
+		return err
+	}
+	labelMap := make(map[int64]*Label, len(labels))
+	for _, label := range labels {
+		labelMap[label.ID] = label
+	}
+	for _, p := range policies {
+		p.Label = labelMap[p.LabelID]
+	}
+	return nil
+}
+
+// GetSLAPolicyByID returns the SLA policy of a repository by its ID
+func GetSLAPolicyByID(ctx context.Context, repoID, id int64) (*SLAPolicy, error) {
+	policy := &SLAPolicy{}
+	has, err := db.GetEngine(ctx).Where("id = ? AND repo_id = ?", id, repoID).Get(policy)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrSLAPolicyNotExist{ID: id}
+	}
+	return policy, nil
+}
+
+// GetRepoIDsWithSLAPolicies returns the IDs of the repositories having SLA policies
+func GetRepoIDsWithSLAPolicies(ctx context.Context) ([]int64, error) {
+	repoIDs := make([]int64, 0, 10)
+	return repoIDs, db.GetEngine(ctx).Table("sla_policy").Distinct("repo_id").Find(&repoIDs)
+}
+
+// CreateSLAPolicy creates an SLA policy
+func CreateSLAPolicy(ctx context.Context, policy *SLAPolicy) error {
+	_, err := db.GetEngine(ctx).Insert(policy)
+	return err
+}
+
+// UpdateSLAPolicy updates an SLA policy. The breaches already recorded for it are kept.
+func UpdateSLAPolicy(ctx context.Context, policy *SLAPolicy) error {
+	_, err := db.GetEngine(ctx).ID(policy.ID).AllCols().Update(policy)
+	return err
+}
+
+// DeleteSLAPolicy deletes the SLA policy of a repository and its breaches
+func DeleteSLAPolicy(ctx context.Context, repoID, id int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		affected, err := db.GetEngine(ctx).Where("id = ? AND repo_id = ?", id, repoID).Delete(&SLAPolicy{})
+		if err != nil {
+			return err
+		} else if affected == 0 {
+			return ErrSLAPolicyNotExist{ID: id}
+		}
+		_, err = db.GetEngine(ctx).Where("policy_id = ?", id).Delete(&IssueSLABreach{})
+		return err
+	})
+}
+
+// deleteSLAPoliciesByLabelID deletes the SLA policies restricted to a label and their breaches
+func deleteSLAPoliciesByLabelID(ctx context.Context, labelID int64) error {
+	if _, err := db.GetEngine(ctx).
+		Where(builder.In("policy_id", builder.Select("id").From("sla_policy").Where(builder.Eq{"label_id": labelID}))).
+		Delete(&IssueSLABreach{}); err != nil {
+		return err
+	}
+	_, err := db.GetEngine(ctx).Where("label_id = ?", labelID).Delete(&SLAPolicy{})
+	return err
+}
+
+// IssueSLAStatus is the progress of an issue toward a target of an SLA policy
+type IssueSLAStatus struct {
+	Policy   *SLAPolicy
+	Kind     SLAKind
+	Deadline timeutil.TimeStamp
+	// DoneUnix is the time of the first response or of the resolution, 0 if it did not happen yet
+	DoneUnix timeutil.TimeStamp
+}
+
+// IsPending tests if the target is not reached yet
+func (s *IssueSLAStatus) IsPending() bool {
+	return s.DoneUnix == 0
+}
+
+// IsBreached tests if the target was reached late, or is not reached yet past its deadline
+func (s *IssueSLAStatus) IsBreached() bool {
+	if s.DoneUnix != 0 {
+		return s.DoneUnix > s.Deadline
+	}
+	return timeutil.TimeStampNow() > s.Deadline
+}
+
+// IssueSLAStatuses is the progress of an issue toward the targets of the SLA policies covering it
+type IssueSLAStatuses []*IssueSLAStatus
+
+// Urgent returns the pending target with the closest deadline, nil if all the targets are reached
+func (statuses IssueSLAStatuses) Urgent() *IssueSLAStatus {
+	var urgent *IssueSLAStatus
+	for _, s := range statuses {
+		if s.IsPending() && (urgent == nil || s.Deadline < urgent.Deadline) {
+			urgent = s
+		}
+	}
+	return urgent
+}
+
+// getIssuesFirstResponses returns the time of the first comment or review on issues by someone else than their poster
+func getIssuesFirstResponses(ctx context.Context, issueIDs []int64) (map[int64]timeutil.TimeStamp, error) {
+	responses := make(map[int64]timeutil.TimeStamp, len(issueIDs))
+	for len(issueIDs) > 0 {
+		limit := min(db.DefaultMaxInSize, len(issueIDs))
+		rows := make([]struct {
+			IssueID     int64
+			CreatedUnix timeutil.TimeStamp
+		}, 0, limit)
+		if err := db.GetEngine(ctx).Table("comment").
+			Join("INNER", "issue", "issue.id = comment.issue_id").
+			Select("comment.issue_id, MIN(comment.created_unix) AS created_unix").
+			In("comment.issue_id", issueIDs[:limit]).
+			In("comment.type", CommentTypeComment, CommentTypeCode, CommentTypeReview).
+			And("comment.poster_id <> issue.poster_id").
+			GroupBy("comment.issue_id").
+			Find(&rows); err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			responses[row.IssueID] = row.CreatedUnix
+		}
+		issueIDs = issueIDs[limit:]
+	}
+	return responses, nil
+}
+
+// GetIssuesSLAStatuses returns the progress of issues of a repository toward the targets of its SLA policies,
+// by issue ID. Closing an issue counts as its first response if nobody else than its poster commented before.
+func GetIssuesSLAStatuses(ctx context.Context, repoID int64, issues IssueList) (map[int64]IssueSLAStatuses, error) {
+	policies, err := GetSLAPolicies(ctx, repoID)
+	if err != nil || len(policies) == 0 {
+		return nil, err
+	}
+	return getIssuesSLAStatuses(ctx, policies, issues)
+}
+
+func getIssuesSLAStatuses(ctx context.Context, policies []*SLAPolicy, issues IssueList) (map[int64]IssueSLAStatuses, error) {
+	if err := issues.LoadLabels(ctx); err != nil {
+		return nil, err
+	}
+	responses, err := getIssuesFirstResponses(ctx, issues.getIssueIDs())
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make(map[int64]IssueSLAStatuses, len(issues))
+	for _, issue := range issues {
+		firstResponse := responses[issue.ID]
+		var resolution timeutil.TimeStamp
+		if issue.IsClosed {
+			// the issues closed before the closing time was recorded were last updated when closed
+			resolution = issue.ClosedUnix
+			if resolution == 0 {
+				resolution = issue.UpdatedUnix
+			}
+			if firstResponse == 0 || resolution < firstResponse {
+				firstResponse = resolution
+			}
+		}
+
+		for _, p := range policies {
+			if !p.Applies(issue) {
+				continue
+			}
+			if p.FirstResponseHours > 0 {
+				statuses[issue.ID] = append(statuses[issue.ID], &IssueSLAStatus{
+					Policy:   p,
+					Kind:     SLAKindFirstResponse,
+					Deadline: p.Deadline(issue, SLAKindFirstResponse),
+					DoneUnix: firstResponse,
+				})
+			}
+			if p.ResolutionHours > 0 {
+				statuses[issue.ID] = append(statuses[issue.ID], &IssueSLAStatus{
+					Policy:   p,
+					Kind:     SLAKindResolution,
+					Deadline: p.Deadline(issue, SLAKindResolution),
+					DoneUnix: resolution,
+				})
+			}
+		}
+	}
+	return statuses, nil
+}
+
+// FindNewSLABreaches returns the targets of the SLA policies of a repository that its open issues
+// missed and that are not recorded yet, with their issue and policy loaded
+func FindNewSLABreaches(ctx context.Context, repoID int64) ([]*IssueSLABreach, error) {
+	policies, err := GetSLAPolicies(ctx, repoID)
+	if err != nil || len(policies) == 0 {
+		return nil, err
+	}
+
+	var shortest int64
+	for _, p := range policies {
+		for _, hours := range []int64{p.FirstResponseHours, p.ResolutionHours} {
+			if hours > 0 && (shortest == 0 || hours < shortest) {
+				shortest = hours
+			}
+		}
+	}
+	if shortest == 0 {
+		return nil, nil
+	}
+	createdBefore := timeutil.TimeStampNow().Add(-shortest * 3600)
+
+	breaches := make([]*IssueSLABreach, 0, 10)
+	for page := 1; ; page++ {
+		issues := make(IssueList, 0, db.DefaultMaxInSize)
+		if err := db.GetEngine(ctx).
+			Where("repo_id = ? AND is_closed = ? AND is_pull = ? AND created_unix < ?", repoID, false, false, createdBefore).
+			Asc("id").
+			Limit(db.DefaultMaxInSize, (page-1)*db.DefaultMaxInSize).
+			Find(&issues); err != nil {
+			return nil, err
+		}
+		if len(issues) == 0 {
+			break
+		}
+
+		statuses, err := getIssuesSLAStatuses(ctx, policies, issues)
+		if err != nil {
+			return nil, err
+		}
+		recorded := make([]*IssueSLABreach, 0, 10)
+		if err := db.GetEngine(ctx).In("issue_id", issues.getIssueIDs()).Find(&recorded); err != nil {
+			return nil, err
+		}
+		isRecorded := make(map[string]bool, len(recorded))
+		for _, b := range recorded {
+			isRecorded[fmt.Sprintf("%d-%d-%s", b.IssueID, b.PolicyID, b.Kind)] = true
+		}
+
+		for _, issue := range issues {
+			for _, s := range statuses[issue.ID] {
+				if !s.IsPending() || !s.IsBreached() || isRecorded[fmt.Sprintf("%d-%d-%s", issue.ID, s.Policy.ID, s.Kind)] {
+					continue
+				}
+				breaches = append(breaches, &IssueSLABreach{
+					IssueID:  issue.ID,
+					PolicyID: s.Policy.ID,
+					Kind:     s.Kind,
+					Issue:    issue,
+					Policy:   s.Policy,
+				})
+			}
+		}
+	}
+	return breaches, nil
+}
+
+// RecordSLABreach records a breach, it returns false if it was already recorded
+func RecordSLABreach(ctx context.Context, breach *IssueSLABreach) (bool, error) {
+	has, err := db.GetEngine(ctx).Exist(&IssueSLABreach{IssueID: breach.IssueID, PolicyID: breach.PolicyID, Kind: breach.Kind})
+	if err != nil || has {
+		return false, err
+	}
+	return true, db.Insert(ctx, breach)
+}
+
+// SLAMetrics aggregates how the issues of a repository met the targets of its SLA policies
+type SLAMetrics struct {
+	FirstResponseMet      int64
+	FirstResponseBreached int64
+	ResolutionMet         int64
+	ResolutionBreached    int64
+	// AvgFirstResponse and AvgResolution are in seconds, over the issues covered by a policy
+	AvgFirstResponse int64
+	AvgResolution    int64
+}
+
+// FirstResponseMetPercent returns the percentage of first responses in time among the decided ones
+func (m *SLAMetrics) FirstResponseMetPercent() int {
+	return percent(m.FirstResponseMet, m.FirstResponseMet+m.FirstResponseBreached)
+}
+
+// ResolutionMetPercent returns the percentage of resolutions in time among the decided ones
+func (m *SLAMetrics) ResolutionMetPercent() int {
+	return percent(m.ResolutionMet, m.ResolutionMet+m.ResolutionBreached)
+}
+
+func percent(n, total int64) int {
+	if total == 0 {
+		return 0
+	}
+	return int(n * 100 / total)
+}
+
+// GetSLAMetrics returns the SLA metrics of the issues of a repository created since a time,
+// nil if the repository has no SLA policies. Pending targets that are not breached yet are not counted.
+func GetSLAMetrics(ctx context.Context, repoID int64, since timeutil.TimeStamp) (*SLAMetrics, error) {
+	policies, err := GetSLAPolicies(ctx, repoID)
+	if err != nil || len(policies) == 0 {
+		return nil, err
+	}
+
+	metrics := &SLAMetrics{}
+	var responded, firstResponseTotal, resolved, resolutionTotal int64
+	for page := 1; ; page++ {
+		issues := make(IssueList, 0, db.DefaultMaxInSize)
+		if err := db.GetEngine(ctx).
+			Where("repo_id = ? AND is_pull = ? AND created_unix >= ?", repoID, false, since).
+			Asc("id").
+			Limit(db.DefaultMaxInSize, (page-1)*db.DefaultMaxInSize).
+			Find(&issues); err != nil {
+			return nil, err
+		}
+		if len(issues) == 0 {
+			break
+		}
+
+		statuses, err := getIssuesSLAStatuses(ctx, policies, issues)
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range issues {
+			var firstResponse, resolution timeutil.TimeStamp
+			for _, s := range statuses[issue.ID] {
+				met, breached := &metrics.ResolutionMet, &metrics.ResolutionBreached
+				if s.Kind == SLAKindFirstResponse {
+					met, breached = &metrics.FirstResponseMet, &metrics.FirstResponseBreached
+					firstResponse = s.DoneUnix
+				} else {
+					resolution = s.DoneUnix
+				}
+				if s.IsBreached() {
+					*breached++
+				} else if !s.IsPending() {
+					*met++
+				}
+			}
+			if firstResponse != 0 {
+				responded++
+				firstResponseTotal += int64(firstResponse - issue.CreatedUnix)
+			}
+			if resolution != 0 {
+				resolved++
+				resolutionTotal += int64(resolution - issue.CreatedUnix)
+			}
+		}
+	}
+	if responded > 0 {
+		metrics.AvgFirstResponse = firstResponseTotal / responded
+	}
+	if resolved > 0 {
+		metrics.AvgResolution = resolutionTotal / resolved
+	}
+	return metrics, nil
+}
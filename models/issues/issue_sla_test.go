@@ -0,0 +1,111 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestSLAPolicies(t *testing.T) (*issues_model.SLAPolicy, *issues_model.SLAPolicy) {
+	all := &issues_model.SLAPolicy{RepoID: 1, Name: "all", FirstResponseHours: 1, ResolutionHours: 9000}
+	require.NoError(t, issues_model.CreateSLAPolicy(db.DefaultContext, all))
+	labelled := &issues_model.SLAPolicy{RepoID: 1, Name: "label1", LabelID: 1, FirstResponseHours: 2}
+	require.NoError(t, issues_model.CreateSLAPolicy(db.DefaultContext, labelled))
+	return all, labelled
+}
+
+func TestGetIssuesSLAStatuses(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	all, labelled := createTestSLAPolicies(t)
+
+	issues, err := issues_model.GetIssuesByIDs(db.DefaultContext, []int64{1, 2, 5})
+	require.NoError(t, err)
+	statuses, err := issues_model.GetIssuesSLAStatuses(db.DefaultContext, 1, issues)
+	require.NoError(t, err)
+
+	// the pull request 2 is not covered
+	assert.Len(t, statuses, 2)
+
+	// the issue 1 got a response from another user 11 seconds after it was opened, and is still open
+	if assert.Len(t, statuses[1], 3) {
+		assert.Equal(t, all.ID, statuses[1][0].Policy.ID)
+		assert.Equal(t, issues_model.SLAKindFirstResponse, statuses[1][0].Kind)
+		assert.EqualValues(t, 946684811, statuses[1][0].DoneUnix)
+		assert.False(t, statuses[1][0].IsBreached())
+		assert.Equal(t, issues_model.SLAKindResolution, statuses[1][1].Kind)
+		assert.True(t, statuses[1][1].IsPending())
+		assert.True(t, statuses[1][1].IsBreached())
+		assert.Equal(t, labelled.ID, statuses[1][2].Policy.ID)
+		assert.EqualValues(t, 946684800+2*3600, statuses[1][2].Deadline)
+		assert.Equal(t, statuses[1][1], statuses[1].Urgent())
+	}
+
+	// the issue 5 does not have the label 1, and its closing is its first response
+	if assert.Len(t, statuses[5], 2) {
+		assert.EqualValues(t, 978307200, statuses[5][0].DoneUnix)
+		assert.True(t, statuses[5][0].IsBreached())
+		assert.False(t, statuses[5][1].IsBreached())
+		assert.Nil(t, statuses[5].Urgent())
+	}
+}
+
+func TestFindNewSLABreaches(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	all, _ := createTestSLAPolicies(t)
+
+	breaches, err := issues_model.FindNewSLABreaches(db.DefaultContext, 1)
+	require.NoError(t, err)
+	if assert.Len(t, breaches, 1) {
+		assert.EqualValues(t, 1, breaches[0].IssueID)
+		assert.Equal(t, all.ID, breaches[0].PolicyID)
+		assert.Equal(t, issues_model.SLAKindResolution, breaches[0].Kind)
+	}
+
+	recorded, err := issues_model.RecordSLABreach(db.DefaultContext, breaches[0])
+	require.NoError(t, err)
+	assert.True(t, recorded)
+	recorded, err = issues_model.RecordSLABreach(db.DefaultContext, &issues_model.IssueSLABreach{IssueID: 1, PolicyID: all.ID, Kind: issues_model.SLAKindResolution})
+	require.NoError(t, err)
+	assert.False(t, recorded)
+
+	breaches, err = issues_model.FindNewSLABreaches(db.DefaultContext, 1)
+	require.NoError(t, err)
+	assert.Empty(t, breaches)
+
+	require.NoError(t, issues_model.DeleteSLAPolicy(db.DefaultContext, 1, all.ID))
+	unittest.AssertNotExistsBean(t, &issues_model.IssueSLABreach{PolicyID: all.ID})
+}
+
+func TestGetSLAMetrics(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	metrics, err := issues_model.GetSLAMetrics(db.DefaultContext, 1, 0)
+	require.NoError(t, err)
+	assert.Nil(t, metrics)
+
+	createTestSLAPolicies(t)
+	metrics, err = issues_model.GetSLAMetrics(db.DefaultContext, 1, 0)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, metrics.FirstResponseMet)
+	assert.EqualValues(t, 1, metrics.FirstResponseBreached)
+	assert.EqualValues(t, 1, metrics.ResolutionMet)
+	assert.EqualValues(t, 1, metrics.ResolutionBreached)
+	assert.Equal(t, 66, metrics.FirstResponseMetPercent())
+	assert.EqualValues(t, (11+978307200-946684840)/2, metrics.AvgFirstResponse)
+	assert.EqualValues(t, 978307200-946684840, metrics.AvgResolution)
+
+	// the issues created since then are not counted
+	metrics, err = issues_model.GetSLAMetrics(db.DefaultContext, 1, timeutil.TimeStamp(946684830))
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, metrics.FirstResponseBreached)
+	assert.EqualValues(t, 0, metrics.FirstResponseMet)
+}
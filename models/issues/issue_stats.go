@@ -151,6 +151,8 @@ func applyIssuesOptions(sess *xorm.Session, opts *IssuesOptions, issueIDs []int6
 
 	applyProjectCondition(sess, opts)
 
+	applyCustomFieldValuesCondition(sess, opts)
+
 	if opts.AssigneeID > 0 {
 		applyAssigneeCondition(sess, opts.AssigneeID)
 	} else if opts.AssigneeID == db.NoConditionID {
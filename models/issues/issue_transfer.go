@@ -0,0 +1,223 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+	"fmt"
+
+	"code.gitea.io/gitea/models/db"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	project_model "code.gitea.io/gitea/models/project"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/container"
+
+	"xorm.io/builder"
+)
+
+// TransferIssue moves an issue to another repository, where it gets a new index. The comments, reactions,
+// attachments, tracked times and subscriptions follow the issue. The labels and the milestone are replaced
+// by the ones of the same name in the new repository, or dropped if there are none, and so are the assignees,
+// projects, custom field values and sub-issues that are not available in the new repository.
+// A redirect is left at the former index of the issue.
+func TransferIssue(ctx context.Context, doer *user_model.User, issue *Issue, newRepo *repo_model.Repository) (*Comment, error) {
+	if issue.IsPull {
+		return nil, fmt.Errorf("pull requests cannot be transferred [issue id: %d]", issue.ID)
+	}
+	if err := issue.LoadRepo(ctx); err != nil {
+		return nil, err
+	}
+	oldRepo := issue.Repo
+	oldIndex := issue.Index
+
+	var comment *Comment
+	err := db.WithTx(ctx, func(ctx context.Context) error {
+		if err := issue.Unpin(ctx, doer); err != nil {
+			return err
+		}
+
+		if err := transferIssueLabels(ctx, issue, newRepo); err != nil {
+			return err
+		}
+
+		oldMilestoneID := issue.MilestoneID
+		if issue.MilestoneID > 0 {
+			if err := issue.LoadMilestone(ctx); err != nil {
+				return err
+			}
+			issue.MilestoneID = 0
+			if issue.Milestone != nil {
+				milestone, err := GetMilestoneByRepoIDANDName(ctx, newRepo.ID, issue.Milestone.Name)
+				if err != nil && !IsErrMilestoneNotExist(err) {
+					return err
+				} else if err == nil {
+					issue.MilestoneID = milestone.ID
+				}
+			}
+			issue.Milestone = nil
+			issue.isMilestoneLoaded = false
+		}
+
+		index, err := db.GetNextResourceIndex(ctx, "issue_index", newRepo.ID)
+		if err != nil {
+			return err
+		}
+		issue.RepoID = newRepo.ID
+		issue.Repo = newRepo
+		issue.Index = index
+		// the branch or tag of the issue does not exist in the new repository
+		issue.Ref = ""
+		if _, err := db.GetEngine(ctx).ID(issue.ID).Cols("repo_id", "index", "milestone_id", "ref").NoAutoTime().Update(issue); err != nil {
+			return err
+		}
+
+		for _, repoID := range []int64{oldRepo.ID, newRepo.ID} {
+			if err := repo_model.UpdateRepoIssueNumbers(ctx, repoID, false, false); err != nil {
+				return err
+			}
+			if err := repo_model.UpdateRepoIssueNumbers(ctx, repoID, false, true); err != nil {
+				return err
+			}
+		}
+		for _, milestoneID := range []int64{oldMilestoneID, issue.MilestoneID} {
+			if milestoneID > 0 {
+				if err := UpdateMilestoneCounters(ctx, milestoneID); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := transferIssueAssignees(ctx, issue, newRepo); err != nil {
+			return err
+		}
+
+		if _, err := db.GetEngine(ctx).Where("issue_id = ?", issue.ID).Cols("repo_id").
+			Update(&repo_model.Attachment{RepoID: newRepo.ID}); err != nil {
+			return err
+		}
+
+		// only the projects of the owner of the new repository are kept
+		if _, err := db.GetEngine(ctx).Where("issue_id = ?", issue.ID).
+			NotIn("project_id", builder.Select("id").From("project").Where(builder.Eq{"repo_id": 0, "owner_id": newRepo.OwnerID})).
+			Delete(&project_model.ProjectIssue{}); err != nil {
+			return err
+		}
+
+		// only the values of the fields of the owner of the new repository are kept
+		if _, err := db.GetEngine(ctx).Where("issue_id = ?", issue.ID).
+			NotIn("field_id", builder.Select("id").From("custom_field").Where(builder.Eq{"repo_id": 0, "owner_id": newRepo.OwnerID})).
+			Delete(new(IssueCustomFieldValue)); err != nil {
+			return err
+		}
+
+		// sub-issues and their parents must belong to repositories of the same owner
+		if oldRepo.OwnerID != newRepo.OwnerID {
+			if _, err := db.GetEngine(ctx).Where("issue_id = ? OR parent_id = ?", issue.ID, issue.ID).Delete(&SubIssue{}); err != nil {
+				return err
+			}
+		}
+
+		// the breaches were recorded against the SLA policies of the former repository
+		if _, err := db.GetEngine(ctx).Where("issue_id = ?", issue.ID).Delete(new(IssueSLABreach)); err != nil {
+			return err
+		}
+
+		if err := NewIssueRedirect(ctx, oldRepo.ID, oldIndex, issue.ID); err != nil {
+			return err
+		}
+
+		comment, err = CreateComment(ctx, &CreateCommentOptions{
+			Type:   CommentTypeTransferIssue,
+			Doer:   doer,
+			Repo:   newRepo,
+			Issue:  issue,
+			OldRef: fmt.Sprintf("%s#%d", oldRepo.FullName(), oldIndex),
+			NewRef: fmt.Sprintf("%s#%d", newRepo.FullName(), issue.Index),
+		})
+		return err
+	})
+	if err != nil {
+		issue.RepoID = oldRepo.ID
+		issue.Repo = oldRepo
+		issue.Index = oldIndex
+		return nil, err
+	}
+	issue.ResetAttributesLoaded()
+	issue.Labels = nil
+	issue.Assignees = nil
+	return comment, nil
+}
+
+// transferIssueLabels replaces the labels of an issue by the labels of the same name of a repository,
+// keeping the labels of its owner.
+func transferIssueLabels(ctx context.Context, issue *Issue, newRepo *repo_model.Repository) error {
+	if err := issue.LoadLabels(ctx); err != nil {
+		return err
+	}
+	if len(issue.Labels) == 0 {
+		return nil
+	}
+
+	candidates, err := GetLabelsByRepoID(ctx, newRepo.ID, "", db.ListOptions{})
+	if err != nil {
+		return err
+	}
+	orgLabels, err := GetLabelsByOrgID(ctx, newRepo.OwnerID, "", db.ListOptions{})
+	if err != nil {
+		return err
+	}
+	candidates = append(candidates, orgLabels...)
+
+	newLabelIDs := make(container.Set[int64], len(issue.Labels))
+	for _, label := range issue.Labels {
+		if label.OrgID != 0 && label.OrgID == newRepo.OwnerID {
+			newLabelIDs.Add(label.ID)
+			continue
+		}
+		for _, candidate := range candidates {
+			if candidate.Name == label.Name {
+				newLabelIDs.Add(candidate.ID)
+				break
+			}
+		}
+	}
+
+	if _, err := db.GetEngine(ctx).Where("issue_id = ?", issue.ID).Delete(&IssueLabel{}); err != nil {
+		return err
+	}
+	changed := make([]*Label, 0, len(issue.Labels)+len(newLabelIDs))
+	changed = append(changed, issue.Labels...)
+	for labelID := range newLabelIDs {
+		if err := db.Insert(ctx, &IssueLabel{IssueID: issue.ID, LabelID: labelID}); err != nil {
+			return err
+		}
+		changed = append(changed, &Label{ID: labelID})
+	}
+	for _, label := range changed {
+		if err := updateLabelCols(ctx, label, "num_issues", "num_closed_issue"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// transferIssueAssignees removes the assignees of an issue who cannot be assigned in a repository
+func transferIssueAssignees(ctx context.Context, issue *Issue, newRepo *repo_model.Repository) error {
+	if err := issue.LoadAssignees(ctx); err != nil {
+		return err
+	}
+	for _, assignee := range issue.Assignees {
+		ok, err := access_model.CanBeAssigned(ctx, assignee, newRepo, false)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			if _, err := db.GetEngine(ctx).Delete(&IssueAssignees{IssueID: issue.ID, AssigneeID: assignee.ID}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -13,6 +13,7 @@ import (
 	"code.gitea.io/gitea/models/perm"
 	access_model "code.gitea.io/gitea/models/perm/access"
 	project_model "code.gitea.io/gitea/models/project"
+	pull_model "code.gitea.io/gitea/models/pull"
 	repo_model "code.gitea.io/gitea/models/repo"
 	system_model "code.gitea.io/gitea/models/system"
 	"code.gitea.io/gitea/models/unit"
@@ -338,6 +339,12 @@ func NewIssueWithIndex(ctx context.Context, doer *user_model.User, opts NewIssue
 		return err
 	}
 
+	if opts.Issue.Form != nil {
+		if err := insertIssueForm(ctx, opts.Issue); err != nil {
+			return err
+		}
+	}
+
 	if opts.Issue.MilestoneID > 0 {
 		if err := UpdateMilestoneCounters(ctx, opts.Issue.MilestoneID); err != nil {
 			return err
@@ -701,11 +708,54 @@ func DeleteIssuesByRepoID(ctx context.Context, repoID int64) (attachmentPaths []
 			return nil, err
 		}
 
+		// Sub-issues of issues in this repository, and their links to parents in other repositories
+		_, err = sess.In("issue_id", issueIDs).Delete(&SubIssue{})
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = sess.In("parent_id", issueIDs).Delete(&SubIssue{})
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = sess.In("issue_id", issueIDs).Delete(&IssueCustomFieldValue{})
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = sess.In("issue_id", issueIDs).Delete(&IssueRedirect{})
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = sess.In("issue_id", issueIDs).Delete(&IssueSLABreach{})
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = sess.In("issue_id", issueIDs).Delete(&IssueForm{})
+		if err != nil {
+			return nil, err
+		}
+
 		_, err = sess.In("issue_id", issueIDs).Delete(&IssueUser{})
 		if err != nil {
 			return nil, err
 		}
 
+		// Revisions of the pull requests
+		pullIDs := builder.Select("id").From("pull_request").Where(builder.In("issue_id", issueIDs))
+		_, err = sess.In("pull_id", pullIDs).Delete(&pull_model.Revision{})
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = sess.In("pull_id", pullIDs).Delete(&pull_model.ReviewedRevision{})
+		if err != nil {
+			return nil, err
+		}
+
 		_, err = sess.In("issue_id", issueIDs).Delete(&Reaction{})
 		if err != nil {
 			return nil, err
@@ -757,6 +807,11 @@ func DeleteIssuesByRepoID(ctx context.Context, repoID int64) (attachmentPaths []
 		}
 	}
 
+	// Redirects from the issues transferred out of this repository
+	if err := DeleteIssueRedirectsByRepoID(ctx, repoID); err != nil {
+		return nil, err
+	}
+
 	return attachmentPaths, err
 }
 
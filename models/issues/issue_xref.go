@@ -255,6 +255,44 @@ func (c *Comment) AddCrossReferences(stdCtx context.Context, doer *user_model.Us
 	return c.Issue.createCrossReferences(stdCtx, ctx, "", c.Content)
 }
 
+// TransferCrossReferences updates the cross references of an issue transferred from another repository.
+// The references made by the issue now come from its new repository, and the references to the issues
+// of its former repository in its title, content and comments are qualified with the name of that repository
+// so that they keep pointing to the same issues.
+func (issue *Issue) TransferCrossReferences(ctx context.Context, oldRepo *repo_model.Repository) error {
+	if _, err := db.GetEngine(ctx).Where("ref_issue_id = ?", issue.ID).Cols("ref_repo_id").NoAutoTime().
+		Update(&Comment{RefRepoID: issue.RepoID}); err != nil {
+		return err
+	}
+
+	qualify := func(content string) string {
+		return references.QualifyIssueReferences(content, oldRepo.OwnerName, oldRepo.Name)
+	}
+
+	title, content := qualify(issue.Title), qualify(issue.Content)
+	if title != issue.Title || content != issue.Content {
+		issue.Title, issue.Content = title, content
+		if _, err := db.GetEngine(ctx).ID(issue.ID).Cols("name", "content").NoAutoTime().Update(issue); err != nil {
+			return err
+		}
+	}
+
+	comments := make([]*Comment, 0, 10)
+	if err := db.GetEngine(ctx).Where("issue_id = ?", issue.ID).In("type", CommentTypeComment, CommentTypeCode).
+		Find(&comments); err != nil {
+		return err
+	}
+	for _, c := range comments {
+		if content := qualify(c.Content); content != c.Content {
+			c.Content = content
+			if _, err := db.GetEngine(ctx).ID(c.ID).Cols("content").NoAutoTime().Update(c); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (c *Comment) neuterCrossReferences(ctx context.Context) error {
 	return neuterCrossReferences(ctx, c.IssueID, c.ID)
 }
@@ -281,6 +281,10 @@ func DeleteLabel(ctx context.Context, id, labelID int64) error {
 		return err
 	}
 
+	if err = deleteSLAPoliciesByLabelID(ctx, labelID); err != nil {
+		return err
+	}
+
 	return committer.Commit()
 }
 
@@ -175,6 +175,10 @@ type PullRequest struct {
 	MergeBase           string `xorm:"VARCHAR(64)"`
 	AllowMaintainerEdit bool   `xorm:"NOT NULL DEFAULT false"`
 
+	// ParentPullID is the pull request this one is stacked on, its base branch is the head branch of the parent
+	ParentPullID int64        `xorm:"INDEX NOT NULL DEFAULT 0"`
+	ParentPull   *PullRequest `xorm:"-"`
+
 	HasMerged      bool               `xorm:"INDEX"`
 	MergedCommitID string             `xorm:"VARCHAR(64)"`
 	MergerID       int64              `xorm:"INDEX"`
@@ -206,6 +210,16 @@ func DeletePullsByBaseRepoID(ctx context.Context, repoID int64) error {
 		return err
 	}
 
+	// Delete revisions and the revisions reviewed by the users
+	if _, err := db.GetEngine(ctx).In("pull_id", deleteCond).
+		Delete(&pull_model.Revision{}); err != nil {
+		return err
+	}
+	if _, err := db.GetEngine(ctx).In("pull_id", deleteCond).
+		Delete(&pull_model.ReviewedRevision{}); err != nil {
+		return err
+	}
+
 	_, err := db.DeleteByBean(ctx, &PullRequest{BaseRepoID: repoID})
 	return err
 }
@@ -372,15 +386,37 @@ func (pr *PullRequest) GetApprovers(ctx context.Context) string {
 }
 
 func (pr *PullRequest) getReviewedByLines(ctx context.Context, writer io.Writer) error {
+	approvers, err := pr.GetApproverUsers(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, approver := range approvers {
+		if _, err := writer.Write([]byte("Reviewed-by: ")); err != nil {
+			return err
+		}
+		if _, err := writer.Write([]byte(approver.NewGitSig().String())); err != nil {
+			return err
+		}
+		if _, err := writer.Write([]byte{'\n'}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetApproverUsers returns the users who approved the pull request, limited to the approvers
+// to mention in the merge message
+func (pr *PullRequest) GetApproverUsers(ctx context.Context) ([]*user_model.User, error) {
 	maxReviewers := setting.Repository.PullRequest.DefaultMergeMessageMaxApprovers
 
 	if maxReviewers == 0 {
-		return nil
+		return nil, nil
 	}
 
 	ctx, committer, err := db.TxContext(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer committer.Close()
 
@@ -392,34 +428,24 @@ func (pr *PullRequest) getReviewedByLines(ctx context.Context, writer io.Writer)
 	})
 	if err != nil {
 		log.Error("Unable to FindReviews for PR ID %d: %v", pr.ID, err)
-		return err
+		return nil, err
 	}
 
-	reviewersWritten := 0
-
+	approvers := make([]*user_model.User, 0, len(reviews))
 	for _, review := range reviews {
-		if maxReviewers > 0 && reviewersWritten > maxReviewers {
+		if maxReviewers > 0 && len(approvers) > maxReviewers {
 			break
 		}
 
 		if err := review.LoadReviewer(ctx); err != nil && !user_model.IsErrUserNotExist(err) {
 			log.Error("Unable to LoadReviewer[%d] for PR ID %d : %v", review.ReviewerID, pr.ID, err)
-			return err
+			return nil, err
 		} else if review.Reviewer == nil {
 			continue
 		}
-		if _, err := writer.Write([]byte("Reviewed-by: ")); err != nil {
-			return err
-		}
-		if _, err := writer.Write([]byte(review.Reviewer.NewGitSig().String())); err != nil {
-			return err
-		}
-		if _, err := writer.Write([]byte{'\n'}); err != nil {
-			return err
-		}
-		reviewersWritten++
+		approvers = append(approvers, review.Reviewer)
 	}
-	return committer.Commit()
+	return approvers, committer.Commit()
 }
 
 // GetGitRefName returns git ref for hidden pull request branch
@@ -894,6 +920,20 @@ func MergeBlockedByOfficialReviewRequests(ctx context.Context, protectBranch *gi
 	return has
 }
 
+// GetApprovingReviewerIDs returns the IDs of the users who approved pr and whose approval is not dismissed
+// or, if the protected branch ignores them, stale. Unlike GetGrantedApprovalsCount, approvals which are not
+// official are included.
+func GetApprovingReviewerIDs(ctx context.Context, protectBranch *git_model.ProtectedBranch, pr *PullRequest) ([]int64, error) {
+	sess := db.GetEngine(ctx).Table("review").Where("issue_id = ?", pr.IssueID).
+		And("type = ?", ReviewTypeApprove).
+		And("dismissed = ?", false)
+	if protectBranch.IgnoreStaleApprovals {
+		sess = sess.And("stale = ?", false)
+	}
+	reviewerIDs := make([]int64, 0, 2)
+	return reviewerIDs, sess.Distinct("reviewer_id").Find(&reviewerIDs)
+}
+
 // MergeBlockedByOutdatedBranch returns true if merge is blocked by an outdated head branch
 func MergeBlockedByOutdatedBranch(protectBranch *git_model.ProtectedBranch, pr *PullRequest) bool {
 	return protectBranch.BlockOnOutdatedBranch && pr.CommitsBehind > 0
@@ -936,15 +976,22 @@ func GetCodeOwnersFromContent(ctx context.Context, data string) ([]*CodeOwnerRul
 }
 
 type CodeOwnerRule struct {
+	Pattern  string
 	Rule     *regexp.Regexp
 	Negative bool
 	Users    []*user_model.User
 	Teams    []*org_model.Team
 }
 
+// Match returns true if the file is owned by the owners of the rule
+func (rule *CodeOwnerRule) Match(file string) bool {
+	return rule.Rule.MatchString(file) != rule.Negative
+}
+
 func ParseCodeOwnersLine(ctx context.Context, tokens []string) (*CodeOwnerRule, []string) {
 	var err error
 	rule := &CodeOwnerRule{
+		Pattern:  tokens[0],
 		Users:    make([]*user_model.User, 0),
 		Teams:    make([]*org_model.Team, 0),
 		Negative: strings.HasPrefix(tokens[0], "!"),
@@ -0,0 +1,179 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+	"fmt"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// maxStackDepth limits the number of pull requests walked when loading a stack
+const maxStackDepth = 50
+
+// ErrInvalidStackParent represents an error if a pull request cannot be stacked on another one
+type ErrInvalidStackParent struct {
+	PullID   int64
+	ParentID int64
+	Reason   string
+}
+
+// IsErrInvalidStackParent checks if an error is a ErrInvalidStackParent.
+func IsErrInvalidStackParent(err error) bool {
+	_, ok := err.(ErrInvalidStackParent)
+	return ok
+}
+
+func (err ErrInvalidStackParent) Error() string {
+	return fmt.Sprintf("pull request cannot be stacked on the parent [pull_id: %d, parent_id: %d]: %s", err.PullID, err.ParentID, err.Reason)
+}
+
+func (err ErrInvalidStackParent) Unwrap() error {
+	return util.ErrInvalidArgument
+}
+
+// LoadParentPull loads the pull request this one is stacked on, if any
+func (pr *PullRequest) LoadParentPull(ctx context.Context) error {
+	if pr.ParentPull != nil || pr.ParentPullID == 0 {
+		return nil
+	}
+	parent := new(PullRequest)
+	has, err := db.GetEngine(ctx).ID(pr.ParentPullID).Get(parent)
+	if err != nil {
+		return err
+	} else if !has {
+		return ErrPullRequestNotExist{ID: pr.ParentPullID}
+	}
+	pr.ParentPull = parent
+	return nil
+}
+
+// GetStackParentCandidate returns the open pull request whose head branch is the given branch of the
+// repository and which can therefore be the parent of a pull request targeting that branch
+func GetStackParentCandidate(ctx context.Context, repoID int64, branch string) (*PullRequest, error) {
+	pr := new(PullRequest)
+	has, err := db.GetEngine(ctx).
+		Join("INNER", "issue", "issue.id = pull_request.issue_id").
+		Where("pull_request.base_repo_id = ? AND pull_request.head_repo_id = ? AND pull_request.head_branch = ?", repoID, repoID, branch).
+		And("pull_request.has_merged = ? AND issue.is_closed = ? AND pull_request.flow = ?", false, false, PullRequestFlowGithub).
+		OrderBy("pull_request.`index` ASC").
+		Get(pr)
+	if err != nil || !has {
+		return nil, err
+	}
+	return pr, nil
+}
+
+// GetStackedPullRequests returns the open pull requests stacked on the given one
+func GetStackedPullRequests(ctx context.Context, parentID int64) (PullRequestList, error) {
+	prs := make(PullRequestList, 0, 2)
+	return prs, db.GetEngine(ctx).
+		Join("INNER", "issue", "issue.id = pull_request.issue_id").
+		Where("pull_request.parent_pull_id = ? AND pull_request.has_merged = ? AND issue.is_closed = ?", parentID, false, false).
+		OrderBy("pull_request.`index` ASC").
+		Find(&prs)
+}
+
+// CheckStackParent checks if the pull request can be stacked on the parent: the parent has to be an open
+// pull request of the same repository whose head branch is the base branch of the pull request.
+func CheckStackParent(ctx context.Context, pr, parent *PullRequest) error {
+	invalid := func(reason string) error {
+		return ErrInvalidStackParent{PullID: pr.ID, ParentID: parent.ID, Reason: reason}
+	}
+
+	if parent.ID == pr.ID {
+		return invalid("a pull request cannot be stacked on itself")
+	}
+	if parent.BaseRepoID != pr.BaseRepoID || parent.HeadRepoID != parent.BaseRepoID || parent.Flow != PullRequestFlowGithub {
+		return invalid("the parent has to be a pull request between branches of the same repository")
+	}
+	if parent.HeadBranch != pr.BaseBranch {
+		return invalid("the base branch has to be the head branch of the parent")
+	}
+	if err := parent.LoadIssue(ctx); err != nil {
+		return err
+	}
+	if parent.HasMerged || parent.Issue.IsClosed {
+		return invalid("the parent is not open")
+	}
+
+	// walk up the stack of the parent to prevent cycles
+	current := parent
+	for i := 0; current.ParentPullID != 0; i++ {
+		if current.ParentPullID == pr.ID || i >= maxStackDepth {
+			return invalid("the stack would contain a cycle")
+		}
+		next := new(PullRequest)
+		has, err := db.GetEngine(ctx).ID(current.ParentPullID).Get(next)
+		if err != nil {
+			return err
+		} else if !has {
+			break
+		}
+		current = next
+	}
+	return nil
+}
+
+// SetStackParent stacks the pull request on the parent, or removes it from its stack if parent is nil
+func SetStackParent(ctx context.Context, pr, parent *PullRequest) error {
+	if parent == nil {
+		pr.ParentPullID = 0
+		pr.ParentPull = nil
+	} else {
+		if err := CheckStackParent(ctx, pr, parent); err != nil {
+			return err
+		}
+		pr.ParentPullID = parent.ID
+		pr.ParentPull = parent
+	}
+	return pr.UpdateCols(ctx, "parent_pull_id")
+}
+
+// GetPullRequestStack returns the stack of the pull request from the bottom to the top:
+// the pull requests it is stacked on, the pull request itself and the open pull requests stacked on it
+func GetPullRequestStack(ctx context.Context, pr *PullRequest) (PullRequestList, error) {
+	seen := map[int64]bool{pr.ID: true}
+
+	ancestors := make(PullRequestList, 0, 2)
+	for parentID := pr.ParentPullID; parentID != 0 && !seen[parentID] && len(ancestors) < maxStackDepth; {
+		parent := new(PullRequest)
+		has, err := db.GetEngine(ctx).ID(parentID).Get(parent)
+		if err != nil {
+			return nil, err
+		} else if !has {
+			break
+		}
+		seen[parent.ID] = true
+		ancestors = append(ancestors, parent)
+		parentID = parent.ParentPullID
+	}
+
+	stack := make(PullRequestList, 0, len(ancestors)+2)
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		stack = append(stack, ancestors[i])
+	}
+	stack = append(stack, pr)
+
+	// the descendants are added breadth first
+	for i := len(stack) - 1; i < len(stack) && len(stack) < 2*maxStackDepth; i++ {
+		children, err := GetStackedPullRequests(ctx, stack[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, child := range children {
+			if !seen[child.ID] {
+				seen[child.ID] = true
+				stack = append(stack, child)
+			}
+		}
+	}
+
+	if _, err := stack.LoadIssues(ctx); err != nil {
+		return nil, err
+	}
+	return stack, nil
+}
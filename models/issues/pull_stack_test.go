@@ -0,0 +1,60 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullRequestStack(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	// pull request 5 targets branch2, the head branch of pull request 2
+	parent := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: 2})
+	child := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: 5})
+
+	candidate, err := issues_model.GetStackParentCandidate(db.DefaultContext, 1, "branch2")
+	require.NoError(t, err)
+	require.NotNil(t, candidate)
+	assert.EqualValues(t, 2, candidate.ID)
+
+	candidate, err = issues_model.GetStackParentCandidate(db.DefaultContext, 1, "master")
+	require.NoError(t, err)
+	assert.Nil(t, candidate)
+
+	require.NoError(t, issues_model.SetStackParent(db.DefaultContext, child, parent))
+	unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: 5, ParentPullID: 2})
+
+	children, err := issues_model.GetStackedPullRequests(db.DefaultContext, parent.ID)
+	require.NoError(t, err)
+	require.Len(t, children, 1)
+	assert.EqualValues(t, 5, children[0].ID)
+
+	stack, err := issues_model.GetPullRequestStack(db.DefaultContext, parent)
+	require.NoError(t, err)
+	require.Len(t, stack, 2)
+	assert.EqualValues(t, 2, stack[0].ID)
+	assert.EqualValues(t, 5, stack[1].ID)
+	assert.NotNil(t, stack[1].Issue)
+
+	stack, err = issues_model.GetPullRequestStack(db.DefaultContext, child)
+	require.NoError(t, err)
+	require.Len(t, stack, 2)
+	assert.EqualValues(t, 2, stack[0].ID)
+	assert.EqualValues(t, 5, stack[1].ID)
+
+	// the base branch of pull request 2 is not the head branch of pull request 5
+	err = issues_model.SetStackParent(db.DefaultContext, parent, child)
+	assert.True(t, issues_model.IsErrInvalidStackParent(err))
+
+	require.NoError(t, issues_model.SetStackParent(db.DefaultContext, child, nil))
+	assert.Zero(t, unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: 5}).ParentPullID)
+}
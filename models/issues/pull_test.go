@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"code.gitea.io/gitea/models/db"
+	git_model "code.gitea.io/gitea/models/git"
 	issues_model "code.gitea.io/gitea/models/issues"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unittest"
@@ -435,6 +436,26 @@ func TestGetApprovers(t *testing.T) {
 	assert.EqualValues(t, expected, approvers)
 }
 
+func TestGetApprovingReviewerIDs(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	// approvals from the same reviewer are deduplicated
+	pr := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: 5})
+	reviewerIDs, err := issues_model.GetApprovingReviewerIDs(db.DefaultContext, &git_model.ProtectedBranch{}, pr)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int64{5, 6}, reviewerIDs)
+
+	// stale approvals only count if the protected branch does not ignore them
+	pr = unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: 2})
+	reviewerIDs, err = issues_model.GetApprovingReviewerIDs(db.DefaultContext, &git_model.ProtectedBranch{}, pr)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{4}, reviewerIDs)
+
+	reviewerIDs, err = issues_model.GetApprovingReviewerIDs(db.DefaultContext, &git_model.ProtectedBranch{IgnoreStaleApprovals: true}, pr)
+	require.NoError(t, err)
+	assert.Empty(t, reviewerIDs)
+}
+
 func TestGetPullRequestByMergedCommit(t *testing.T) {
 	require.NoError(t, unittest.PrepareTestDatabase())
 	pr, err := issues_model.GetPullRequestByMergedCommit(db.DefaultContext, 1, "1a8823cd1a9549fde083f992f6b9b87a7ab74fb3")
@@ -261,16 +261,15 @@ func IsOfficialReviewer(ctx context.Context, issue *Issue, reviewer *user_model.
 	}
 
 	pr := issue.PullRequest
-	rule, err := git_model.GetFirstMatchProtectedBranchRule(ctx, pr.BaseRepoID, pr.BaseBranch)
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return false, err
+	}
+	rule, err := git_model.GetFirstMatchProtectedBranchRule(ctx, pr.BaseRepo, pr.BaseBranch)
 	if err != nil {
 		return false, err
 	}
 	if rule == nil {
 		// if no rule is found, then user with write access can make official reviews
-		err := pr.LoadBaseRepo(ctx)
-		if err != nil {
-			return false, err
-		}
 		writeAccess, err := access_model.HasAccessUnit(ctx, reviewer, pr.BaseRepo, unit.TypeCode, perm.AccessModeWrite)
 		if err != nil {
 			return false, err
@@ -291,7 +290,10 @@ func IsOfficialReviewerTeam(ctx context.Context, issue *Issue, team *organizatio
 	if err := issue.LoadPullRequest(ctx); err != nil {
 		return false, err
 	}
-	pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, issue.PullRequest.BaseRepoID, issue.PullRequest.BaseBranch)
+	if err := issue.PullRequest.LoadBaseRepo(ctx); err != nil {
+		return false, err
+	}
+	pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, issue.PullRequest.BaseRepo, issue.PullRequest.BaseBranch)
 	if err != nil {
 		return false, err
 	}
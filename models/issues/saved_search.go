@@ -0,0 +1,97 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// ErrSavedIssueSearchNotExist represents a "SavedIssueSearchNotExist" kind of error.
+type ErrSavedIssueSearchNotExist struct {
+	ID int64
+}
+
+// IsErrSavedIssueSearchNotExist checks if an error is a ErrSavedIssueSearchNotExist.
+func IsErrSavedIssueSearchNotExist(err error) bool {
+	_, ok := err.(ErrSavedIssueSearchNotExist)
+	return ok
+}
+
+func (err ErrSavedIssueSearchNotExist) Error() string {
+	return fmt.Sprintf("saved issue search does not exist [id: %d]", err.ID)
+}
+
+func (err ErrSavedIssueSearchNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// SavedIssueSearch is a structured issue search query saved by a user
+type SavedIssueSearch struct {
+	ID     int64 `xorm:"pk autoincr"`
+	UserID int64 `xorm:"INDEX NOT NULL"`
+	// the repository the search was saved in, 0 for a search listed in all the repositories
+	RepoID      int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+	Name        string             `xorm:"NOT NULL"`
+	Query       string             `xorm:"TEXT NOT NULL"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(SavedIssueSearch))
+}
+
+// CreateSavedIssueSearch saves a search of a user, the query being checked by the caller
+func CreateSavedIssueSearch(ctx context.Context, s *SavedIssueSearch) error {
+	s.Name = strings.TrimSpace(s.Name)
+	s.Query = strings.TrimSpace(s.Query)
+	if s.Name == "" {
+		return util.NewInvalidArgumentErrorf("saved search name cannot be empty")
+	}
+	if s.Query == "" {
+		return util.NewInvalidArgumentErrorf("saved search query cannot be empty")
+	}
+	return db.Insert(ctx, s)
+}
+
+// GetSavedIssueSearchByID returns a saved search of a user by its ID
+func GetSavedIssueSearchByID(ctx context.Context, userID, id int64) (*SavedIssueSearch, error) {
+	s := &SavedIssueSearch{}
+	has, err := db.GetEngine(ctx).Where(builder.Eq{"id": id, "user_id": userID}).Get(s)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrSavedIssueSearchNotExist{ID: id}
+	}
+	return s, nil
+}
+
+// GetSavedIssueSearches returns the saved searches of a user listed in a repository, including the searches
+// listed in all the repositories, or all the saved searches of the user if repoID is -1
+func GetSavedIssueSearches(ctx context.Context, userID, repoID int64) ([]*SavedIssueSearch, error) {
+	cond := builder.NewCond().And(builder.Eq{"user_id": userID})
+	if repoID >= 0 {
+		cond = cond.And(builder.In("repo_id", []int64{0, repoID}))
+	}
+	searches := make([]*SavedIssueSearch, 0, 10)
+	return searches, db.GetEngine(ctx).Where(cond).Asc("name", "id").Find(&searches)
+}
+
+// DeleteSavedIssueSearch deletes a saved search of a user
+func DeleteSavedIssueSearch(ctx context.Context, userID, id int64) error {
+	n, err := db.GetEngine(ctx).Where(builder.Eq{"id": id, "user_id": userID}).Delete(&SavedIssueSearch{})
+	if err != nil {
+		return err
+	} else if n == 0 {
+		return ErrSavedIssueSearchNotExist{ID: id}
+	}
+	return nil
+}
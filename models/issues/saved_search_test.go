@@ -0,0 +1,51 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSavedIssueSearches(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	mine := &issues_model.SavedIssueSearch{UserID: 2, RepoID: 1, Name: " Mine ", Query: "is:open assignee:@me"}
+	require.NoError(t, issues_model.CreateSavedIssueSearch(db.DefaultContext, mine))
+	assert.Equal(t, "Mine", mine.Name)
+	bugs := &issues_model.SavedIssueSearch{UserID: 2, Name: "Bugs", Query: "label:bug"}
+	require.NoError(t, issues_model.CreateSavedIssueSearch(db.DefaultContext, bugs))
+	other := &issues_model.SavedIssueSearch{UserID: 2, RepoID: 2, Name: "Other", Query: "no:label"}
+	require.NoError(t, issues_model.CreateSavedIssueSearch(db.DefaultContext, other))
+
+	assert.ErrorIs(t, issues_model.CreateSavedIssueSearch(db.DefaultContext, &issues_model.SavedIssueSearch{UserID: 2, Name: " ", Query: "is:open"}), util.ErrInvalidArgument)
+	assert.ErrorIs(t, issues_model.CreateSavedIssueSearch(db.DefaultContext, &issues_model.SavedIssueSearch{UserID: 2, Name: "Empty"}), util.ErrInvalidArgument)
+
+	searches, err := issues_model.GetSavedIssueSearches(db.DefaultContext, 2, 1)
+	require.NoError(t, err)
+	require.Len(t, searches, 2)
+	assert.Equal(t, bugs.ID, searches[0].ID)
+	assert.Equal(t, mine.ID, searches[1].ID)
+
+	searches, err = issues_model.GetSavedIssueSearches(db.DefaultContext, 2, -1)
+	require.NoError(t, err)
+	assert.Len(t, searches, 3)
+
+	search, err := issues_model.GetSavedIssueSearchByID(db.DefaultContext, 2, mine.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "is:open assignee:@me", search.Query)
+	_, err = issues_model.GetSavedIssueSearchByID(db.DefaultContext, 4, mine.ID)
+	assert.True(t, issues_model.IsErrSavedIssueSearchNotExist(err))
+
+	assert.True(t, issues_model.IsErrSavedIssueSearchNotExist(issues_model.DeleteSavedIssueSearch(db.DefaultContext, 4, mine.ID)))
+	require.NoError(t, issues_model.DeleteSavedIssueSearch(db.DefaultContext, 2, mine.ID))
+	unittest.AssertNotExistsBean(t, &issues_model.SavedIssueSearch{ID: mine.ID})
+}
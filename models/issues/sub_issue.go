@@ -0,0 +1,328 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+	"fmt"
+
+	"code.gitea.io/gitea/models/db"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// ErrSubIssueNotExist represents a "SubIssueNotExist" kind of error.
+type ErrSubIssueNotExist struct {
+	ParentID int64
+	IssueID  int64
+}
+
+// IsErrSubIssueNotExist checks if an error is a ErrSubIssueNotExist.
+func IsErrSubIssueNotExist(err error) bool {
+	_, ok := err.(ErrSubIssueNotExist)
+	return ok
+}
+
+func (err ErrSubIssueNotExist) Error() string {
+	return fmt.Sprintf("sub-issue does not exist [parent id: %d, issue id: %d]", err.ParentID, err.IssueID)
+}
+
+func (err ErrSubIssueNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// ErrSubIssueHasParent represents an error where an issue is added as a sub-issue while it already has a parent.
+type ErrSubIssueHasParent struct {
+	IssueID int64
+}
+
+// IsErrSubIssueHasParent checks if an error is a ErrSubIssueHasParent.
+func IsErrSubIssueHasParent(err error) bool {
+	_, ok := err.(ErrSubIssueHasParent)
+	return ok
+}
+
+func (err ErrSubIssueHasParent) Error() string {
+	return fmt.Sprintf("issue already has a parent issue [issue id: %d]", err.IssueID)
+}
+
+func (err ErrSubIssueHasParent) Unwrap() error {
+	return util.ErrAlreadyExist
+}
+
+// ErrCircularSubIssue represents an error where an issue is added as a sub-issue of one of its own sub-issues.
+type ErrCircularSubIssue struct {
+	ParentID int64
+	IssueID  int64
+}
+
+// IsErrCircularSubIssue checks if an error is a ErrCircularSubIssue.
+func IsErrCircularSubIssue(err error) bool {
+	_, ok := err.(ErrCircularSubIssue)
+	return ok
+}
+
+func (err ErrCircularSubIssue) Error() string {
+	return fmt.Sprintf("issue is an ancestor of its parent issue [parent id: %d, issue id: %d]", err.ParentID, err.IssueID)
+}
+
+func (err ErrCircularSubIssue) Unwrap() error {
+	return util.ErrInvalidArgument
+}
+
+// SubIssue links an issue to its parent issue. An issue has at most one parent, which is an issue of a
+// repository of the same owner.
+type SubIssue struct {
+	ID          int64              `xorm:"pk autoincr"`
+	ParentID    int64              `xorm:"INDEX NOT NULL"`
+	IssueID     int64              `xorm:"UNIQUE NOT NULL"`
+	CreatorID   int64              `xorm:"NOT NULL"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(SubIssue))
+}
+
+// AddSubIssue makes an issue a sub-issue of a parent issue
+func AddSubIssue(ctx context.Context, doer *user_model.User, parent, issue *Issue) error {
+	if parent.IsPull || issue.IsPull {
+		return util.NewInvalidArgumentErrorf("pull requests cannot have sub-issues or be sub-issues")
+	}
+	if parent.ID == issue.ID {
+		return util.NewInvalidArgumentErrorf("an issue cannot be a sub-issue of itself")
+	}
+	if err := parent.LoadRepo(ctx); err != nil {
+		return err
+	}
+	if err := issue.LoadRepo(ctx); err != nil {
+		return err
+	}
+	if parent.Repo.OwnerID != issue.Repo.OwnerID {
+		return util.NewInvalidArgumentErrorf("a sub-issue must belong to a repository of the owner of its parent")
+	}
+
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		exist, err := db.GetEngine(ctx).Exist(&SubIssue{IssueID: issue.ID})
+		if err != nil {
+			return err
+		} else if exist {
+			return ErrSubIssueHasParent{IssueID: issue.ID}
+		}
+
+		// the issue must not be an ancestor of its parent
+		for ancestorID := parent.ID; ancestorID != 0; {
+			if ancestorID == issue.ID {
+				return ErrCircularSubIssue{ParentID: parent.ID, IssueID: issue.ID}
+			}
+			link := new(SubIssue)
+			has, err := db.GetEngine(ctx).Where("issue_id=?", ancestorID).Get(link)
+			if err != nil {
+				return err
+			} else if !has {
+				break
+			}
+			ancestorID = link.ParentID
+		}
+
+		if err := db.Insert(ctx, &SubIssue{ParentID: parent.ID, IssueID: issue.ID, CreatorID: doer.ID}); err != nil {
+			return err
+		}
+		return createSubIssueComment(ctx, doer, parent, issue, true)
+	})
+}
+
+// RemoveSubIssue removes an issue from the sub-issues of its parent issue
+func RemoveSubIssue(ctx context.Context, doer *user_model.User, parent, issue *Issue) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		n, err := db.GetEngine(ctx).Delete(&SubIssue{ParentID: parent.ID, IssueID: issue.ID})
+		if err != nil {
+			return err
+		} else if n == 0 {
+			return ErrSubIssueNotExist{ParentID: parent.ID, IssueID: issue.ID}
+		}
+		return createSubIssueComment(ctx, doer, parent, issue, false)
+	})
+}
+
+func createSubIssueComment(ctx context.Context, doer *user_model.User, parent, issue *Issue, add bool) error {
+	cType := CommentTypeAddSubIssue
+	if !add {
+		cType = CommentTypeRemoveSubIssue
+	}
+	if err := parent.LoadRepo(ctx); err != nil {
+		return err
+	}
+	_, err := CreateComment(ctx, &CreateCommentOptions{
+		Type:             cType,
+		Doer:             doer,
+		Repo:             parent.Repo,
+		Issue:            parent,
+		DependentIssueID: issue.ID,
+	})
+	return err
+}
+
+// GetParentIssue returns the parent issue of an issue, nil if the issue has no parent
+func GetParentIssue(ctx context.Context, issueID int64) (*Issue, error) {
+	issue := new(Issue)
+	has, err := db.GetEngine(ctx).
+		Join("INNER", "sub_issue", "sub_issue.parent_id = issue.id").
+		Where("sub_issue.issue_id = ?", issueID).
+		Get(issue)
+	if err != nil || !has {
+		return nil, err
+	}
+	return issue, nil
+}
+
+// GetSubIssues returns the sub-issues of an issue, in the order they were added
+func GetSubIssues(ctx context.Context, parentID int64, opts db.ListOptions) (IssueList, error) {
+	sess := db.GetEngine(ctx).
+		Join("INNER", "sub_issue", "sub_issue.issue_id = issue.id").
+		Where("sub_issue.parent_id = ?", parentID).
+		OrderBy("sub_issue.id")
+	if opts.Page > 0 {
+		sess = db.SetSessionPagination(sess, &opts)
+	}
+	issues := make(IssueList, 0, 10)
+	return issues, sess.Find(&issues)
+}
+
+// SubIssueNode is an issue of a tree of sub-issues, with its own sub-issues
+type SubIssueNode struct {
+	Issue    *Issue
+	Children []*SubIssueNode
+}
+
+// SubIssueTree is the tree of the sub-issues of an issue
+type SubIssueTree []*SubIssueNode
+
+// Walk calls fn for every issue of the tree, parents before their sub-issues
+func (tree SubIssueTree) Walk(fn func(*SubIssueNode)) {
+	for _, node := range tree {
+		fn(node)
+		SubIssueTree(node.Children).Walk(fn)
+	}
+}
+
+// Issues returns the issues of the tree, parents before their sub-issues
+func (tree SubIssueTree) Issues() IssueList {
+	issues := make(IssueList, 0, len(tree))
+	tree.Walk(func(node *SubIssueNode) {
+		issues = append(issues, node.Issue)
+	})
+	return issues
+}
+
+// Count returns the number of closed issues and the number of issues of the tree
+func (tree SubIssueTree) Count() (closed, total int) {
+	tree.Walk(func(node *SubIssueNode) {
+		total++
+		if node.Issue.IsClosed {
+			closed++
+		}
+	})
+	return closed, total
+}
+
+// NumSubIssues returns the number of sub-issues of the node, at any depth
+func (node *SubIssueNode) NumSubIssues() int {
+	_, total := SubIssueTree(node.Children).Count()
+	return total
+}
+
+// NumClosedSubIssues returns the number of closed sub-issues of the node, at any depth
+func (node *SubIssueNode) NumClosedSubIssues() int {
+	closed, _ := SubIssueTree(node.Children).Count()
+	return closed
+}
+
+// GetSubIssueTree returns the sub-issues of an issue, at any depth
+func GetSubIssueTree(ctx context.Context, issueID int64) (SubIssueTree, error) {
+	root := &SubIssueNode{}
+	nodes := map[int64]*SubIssueNode{issueID: root}
+	parentIDs := []int64{issueID}
+	for len(parentIDs) > 0 {
+		links := make([]*SubIssue, 0, len(parentIDs))
+		if err := db.GetEngine(ctx).In("parent_id", parentIDs).OrderBy("id").Find(&links); err != nil {
+			return nil, err
+		}
+		issueIDs := make([]int64, 0, len(links))
+		for _, link := range links {
+			// the links are acyclic, this only guards against corrupted data
+			if _, ok := nodes[link.IssueID]; !ok {
+				issueIDs = append(issueIDs, link.IssueID)
+			}
+		}
+		issues, err := GetIssuesByIDs(ctx, issueIDs)
+		if err != nil {
+			return nil, err
+		}
+		byID := make(map[int64]*Issue, len(issues))
+		for _, issue := range issues {
+			byID[issue.ID] = issue
+		}
+
+		parentIDs = parentIDs[:0]
+		for _, link := range links {
+			issue, ok := byID[link.IssueID]
+			if !ok || nodes[link.IssueID] != nil {
+				continue
+			}
+			node := &SubIssueNode{Issue: issue}
+			nodes[issue.ID] = node
+			nodes[link.ParentID].Children = append(nodes[link.ParentID].Children, node)
+			parentIDs = append(parentIDs, issue.ID)
+		}
+	}
+	return root.Children, nil
+}
+
+// SubIssueProgress is the rollup of the sub-issues of an issue, at any depth
+type SubIssueProgress struct {
+	Closed int
+	Total  int
+	// TrackedTime is the time tracked on the sub-issues, in seconds
+	TrackedTime int64
+}
+
+// Percent returns the percentage of closed sub-issues
+func (p *SubIssueProgress) Percent() int {
+	if p.Total == 0 {
+		return 0
+	}
+	return p.Closed * 100 / p.Total
+}
+
+// GetSubIssueProgress returns the rollup of a tree of sub-issues
+func GetSubIssueProgress(ctx context.Context, tree SubIssueTree) (*SubIssueProgress, error) {
+	progress := &SubIssueProgress{}
+	progress.Closed, progress.Total = tree.Count()
+	if progress.Total == 0 {
+		return progress, nil
+	}
+
+	trackedTime, err := GetIssueTotalTrackedTime(ctx, &IssuesOptions{IssueIDs: tree.Issues().getIssueIDs()}, optional.None[bool]())
+	if err != nil {
+		return nil, err
+	}
+	progress.TrackedTime = trackedTime
+	return progress, nil
+}
+
+// TransferRepoSubIssues removes the links between the issues of a repository and the issues of the other repositories,
+// when the repository is transferred to another owner
+func TransferRepoSubIssues(ctx context.Context, repoID int64) error {
+	repoIssues := builder.Select("id").From("issue").Where(builder.Eq{"repo_id": repoID})
+	_, err := db.GetEngine(ctx).Where(builder.Or(
+		builder.In("issue_id", repoIssues).And(builder.NotIn("parent_id", repoIssues)),
+		builder.In("parent_id", repoIssues).And(builder.NotIn("issue_id", repoIssues)),
+	)).Delete(new(SubIssue))
+	return err
+}
@@ -0,0 +1,87 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubIssues(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	issue := func(id int64) *issues_model.Issue {
+		return unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: id})
+	}
+
+	// issue 1 is in repo1, issues 4 and 7 in repo2, all owned by user2
+	require.NoError(t, issues_model.AddSubIssue(db.DefaultContext, doer, issue(1), issue(4)))
+	require.NoError(t, issues_model.AddSubIssue(db.DefaultContext, doer, issue(1), issue(5)))
+	require.NoError(t, issues_model.AddSubIssue(db.DefaultContext, doer, issue(4), issue(7)))
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{Type: issues_model.CommentTypeAddSubIssue, IssueID: 1, DependentIssueID: 4})
+
+	t.Run("Invalid", func(t *testing.T) {
+		// issue 2 is a pull request, issue 6 belongs to a repository of user3
+		for _, ids := range [][2]int64{{1, 1}, {1, 2}, {2, 1}, {1, 6}} {
+			err := issues_model.AddSubIssue(db.DefaultContext, doer, issue(ids[0]), issue(ids[1]))
+			assert.ErrorIs(t, err, util.ErrInvalidArgument, ids)
+		}
+		assert.True(t, issues_model.IsErrSubIssueHasParent(issues_model.AddSubIssue(db.DefaultContext, doer, issue(7), issue(5))))
+		assert.True(t, issues_model.IsErrCircularSubIssue(issues_model.AddSubIssue(db.DefaultContext, doer, issue(7), issue(1))))
+	})
+
+	t.Run("Tree", func(t *testing.T) {
+		parent, err := issues_model.GetParentIssue(db.DefaultContext, 7)
+		require.NoError(t, err)
+		assert.EqualValues(t, 4, parent.ID)
+		parent, err = issues_model.GetParentIssue(db.DefaultContext, 1)
+		require.NoError(t, err)
+		assert.Nil(t, parent)
+
+		subIssues, err := issues_model.GetSubIssues(db.DefaultContext, 1, db.ListOptions{})
+		require.NoError(t, err)
+		if assert.Len(t, subIssues, 2) {
+			assert.EqualValues(t, 4, subIssues[0].ID)
+			assert.EqualValues(t, 5, subIssues[1].ID)
+		}
+
+		tree, err := issues_model.GetSubIssueTree(db.DefaultContext, 1)
+		require.NoError(t, err)
+		ids := make([]int64, 0, 3)
+		for _, issue := range tree.Issues() {
+			ids = append(ids, issue.ID)
+		}
+		assert.Equal(t, []int64{4, 7, 5}, ids)
+		assert.Equal(t, 1, tree[0].NumSubIssues())
+		assert.Equal(t, 0, tree[0].NumClosedSubIssues())
+
+		// issues 4 and 5 are closed, and have 75 and 1 seconds of tracked time
+		progress, err := issues_model.GetSubIssueProgress(db.DefaultContext, tree)
+		require.NoError(t, err)
+		assert.Equal(t, &issues_model.SubIssueProgress{Closed: 2, Total: 3, TrackedTime: 76}, progress)
+		assert.Equal(t, 66, progress.Percent())
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		assert.True(t, issues_model.IsErrSubIssueNotExist(issues_model.RemoveSubIssue(db.DefaultContext, doer, issue(1), issue(7))))
+		require.NoError(t, issues_model.RemoveSubIssue(db.DefaultContext, doer, issue(1), issue(5)))
+		unittest.AssertNotExistsBean(t, &issues_model.SubIssue{IssueID: 5})
+		unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{Type: issues_model.CommentTypeRemoveSubIssue, IssueID: 1, DependentIssueID: 5})
+	})
+
+	t.Run("Transfer", func(t *testing.T) {
+		// the link between issue 1 and issue 4 is removed, the link between issues 4 and 7 of repo2 is kept
+		require.NoError(t, issues_model.TransferRepoSubIssues(db.DefaultContext, 2))
+		unittest.AssertNotExistsBean(t, &issues_model.SubIssue{IssueID: 4})
+		unittest.AssertExistsAndLoadBean(t, &issues_model.SubIssue{ParentID: 4, IssueID: 7})
+	})
+}
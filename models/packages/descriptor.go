@@ -69,6 +69,12 @@ type PackageFileDescriptor struct {
 	File       *PackageFile
 	Blob       *PackageBlob
 	Properties PackagePropertyList
+	Signatures []*PackageSignature
+}
+
+// IsVerified returns true if the file carries at least one verified signature
+func (pfd *PackageFileDescriptor) IsVerified() bool {
+	return len(pfd.Signatures) > 0
 }
 
 // PackageWebLink returns the relative package web link
@@ -91,6 +97,47 @@ func (pd *PackageDescriptor) VersionHTMLURL() string {
 	return fmt.Sprintf("%s/%s", pd.PackageHTMLURL(), url.PathEscape(pd.Version.LowerVersion))
 }
 
+// IsVerified returns true if all files of the version are signed.
+// Container images only need signed manifests because these reference all layers by digest.
+func (pd *PackageDescriptor) IsVerified() bool {
+	signed := 0
+	for _, pfd := range pd.Files {
+		if pd.Package.Type == TypeContainer && !pfd.File.IsLead {
+			continue
+		}
+		if !pfd.IsVerified() {
+			return false
+		}
+		signed++
+	}
+	return signed > 0
+}
+
+// Licenses returns the licenses declared in the metadata of the version
+func (pd *PackageDescriptor) Licenses() []string {
+	var licenses []string
+	switch m := pd.Metadata.(type) {
+	case *cargo.Metadata:
+		licenses = []string{m.License}
+	case *composer.Metadata:
+		licenses = m.License
+	case *maven.Metadata:
+		licenses = m.Licenses
+	case *npm.Metadata:
+		licenses = []string{m.License}
+	case *pypi.Metadata:
+		licenses = []string{m.License}
+	}
+
+	result := make([]string, 0, len(licenses))
+	for _, license := range licenses {
+		if license != "" {
+			result = append(result, license)
+		}
+	}
+	return result
+}
+
 // CalculateBlobSize returns the total blobs size in bytes
 func (pd *PackageDescriptor) CalculateBlobSize() int64 {
 	size := int64(0)
@@ -226,10 +273,20 @@ func GetPackageFileDescriptor(ctx context.Context, pf *PackageFile) (*PackageFil
 	if err != nil {
 		return nil, err
 	}
+	pss, err := GetSignaturesByFileID(ctx, pf.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, ps := range pss {
+		if err := ps.LoadSigner(ctx); err != nil {
+			return nil, err
+		}
+	}
 	return &PackageFileDescriptor{
 		pf,
 		pb,
 		PackagePropertyList(pfps),
+		pss,
 	}, nil
 }
 
@@ -0,0 +1,60 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+func init() {
+	db.RegisterModel(new(PackageCleanupRecord))
+}
+
+// PackageCleanupRecord is an audit entry of a package version removed by a cleanup rule
+type PackageCleanupRecord struct {
+	ID          int64              `xorm:"pk autoincr"`
+	RuleID      int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+	OwnerID     int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+	Type        Type               `xorm:"NOT NULL"`
+	Name        string             `xorm:"NOT NULL"`
+	Version     string             `xorm:"NOT NULL"`
+	Reason      string             `xorm:"NOT NULL DEFAULT ''"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created INDEX NOT NULL"`
+}
+
+// InsertCleanupRecord inserts an audit entry
+func InsertCleanupRecord(ctx context.Context, pcr *PackageCleanupRecord) error {
+	return db.Insert(ctx, pcr)
+}
+
+// FindCleanupRecordsOptions are the options to search cleanup audit entries
+type FindCleanupRecordsOptions struct {
+	db.ListOptions
+	OwnerID int64
+	RuleID  int64
+	Type    Type
+}
+
+func (opts FindCleanupRecordsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.OwnerID > 0 {
+		cond = cond.And(builder.Eq{"owner_id": opts.OwnerID})
+	}
+	if opts.RuleID > 0 {
+		cond = cond.And(builder.Eq{"rule_id": opts.RuleID})
+	}
+	if opts.Type != "" {
+		cond = cond.And(builder.Eq{"type": opts.Type})
+	}
+	return cond
+}
+
+func (opts FindCleanupRecordsOptions) ToOrders() string {
+	return "created_unix DESC, id DESC"
+}
@@ -23,23 +23,28 @@ func init() {
 
 // PackageCleanupRule represents a rule which describes when to clean up package versions
 type PackageCleanupRule struct {
-	ID                   int64              `xorm:"pk autoincr"`
-	Enabled              bool               `xorm:"INDEX NOT NULL DEFAULT false"`
-	OwnerID              int64              `xorm:"UNIQUE(s) INDEX NOT NULL DEFAULT 0"`
-	Type                 Type               `xorm:"UNIQUE(s) INDEX NOT NULL"`
-	KeepCount            int                `xorm:"NOT NULL DEFAULT 0"`
-	KeepPattern          string             `xorm:"NOT NULL DEFAULT ''"`
-	KeepPatternMatcher   *regexp.Regexp     `xorm:"-"`
-	RemoveDays           int                `xorm:"NOT NULL DEFAULT 0"`
-	RemovePattern        string             `xorm:"NOT NULL DEFAULT ''"`
-	RemovePatternMatcher *regexp.Regexp     `xorm:"-"`
-	MatchFullName        bool               `xorm:"NOT NULL DEFAULT false"`
-	CreatedUnix          timeutil.TimeStamp `xorm:"created NOT NULL DEFAULT 0"`
-	UpdatedUnix          timeutil.TimeStamp `xorm:"updated NOT NULL DEFAULT 0"`
+	ID                    int64              `xorm:"pk autoincr"`
+	Enabled               bool               `xorm:"INDEX NOT NULL DEFAULT false"`
+	OwnerID               int64              `xorm:"UNIQUE(s) INDEX NOT NULL DEFAULT 0"`
+	Type                  Type               `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	KeepCount             int                `xorm:"NOT NULL DEFAULT 0"`
+	KeepPattern           string             `xorm:"NOT NULL DEFAULT ''"`
+	KeepPatternMatcher    *regexp.Regexp     `xorm:"-"`
+	KeepTagPattern        string             `xorm:"NOT NULL DEFAULT ''"`
+	KeepTagPatternMatcher *regexp.Regexp     `xorm:"-"`
+	KeepPerMajorCount     int                `xorm:"NOT NULL DEFAULT 0"`
+	KeepDownloadedDays    int                `xorm:"NOT NULL DEFAULT 0"`
+	RemoveDays            int                `xorm:"NOT NULL DEFAULT 0"`
+	RemovePattern         string             `xorm:"NOT NULL DEFAULT ''"`
+	RemovePatternMatcher  *regexp.Regexp     `xorm:"-"`
+	RemoveUntagged        bool               `xorm:"NOT NULL DEFAULT false"`
+	MatchFullName         bool               `xorm:"NOT NULL DEFAULT false"`
+	CreatedUnix           timeutil.TimeStamp `xorm:"created NOT NULL DEFAULT 0"`
+	UpdatedUnix           timeutil.TimeStamp `xorm:"updated NOT NULL DEFAULT 0"`
 }
 
 func (pcr *PackageCleanupRule) CompiledPattern() error {
-	if pcr.KeepPatternMatcher != nil || pcr.RemovePatternMatcher != nil {
+	if pcr.KeepPatternMatcher != nil || pcr.KeepTagPatternMatcher != nil || pcr.RemovePatternMatcher != nil {
 		return nil
 	}
 
@@ -51,6 +56,14 @@ func (pcr *PackageCleanupRule) CompiledPattern() error {
 		}
 	}
 
+	if pcr.KeepTagPattern != "" {
+		var err error
+		pcr.KeepTagPatternMatcher, err = regexp.Compile(fmt.Sprintf(`(?i)\A%s\z`, pcr.KeepTagPattern))
+		if err != nil {
+			return err
+		}
+	}
+
 	if pcr.RemovePattern != "" {
 		var err error
 		pcr.RemovePatternMatcher, err = regexp.Compile(fmt.Sprintf(`(?i)\A%s\z`, pcr.RemovePattern))
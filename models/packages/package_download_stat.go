@@ -0,0 +1,102 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"context"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+func init() {
+	db.RegisterModel(new(PackageDownloadStat))
+}
+
+// PackageDownloadStat counts the downloads of a package version per day, client and authentication.
+// There is one row per bucket instead of one row per download.
+type PackageDownloadStat struct {
+	ID            int64              `xorm:"pk autoincr"`
+	VersionID     int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	DayUnix       timeutil.TimeStamp `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Client        string             `xorm:"UNIQUE(s) NOT NULL"`
+	Authenticated bool               `xorm:"UNIQUE(s) NOT NULL"`
+	Count         int64              `xorm:"NOT NULL DEFAULT 0"`
+}
+
+// DownloadStatDay returns the start of the UTC day of the time, which is used as bucket key
+func DownloadStatDay(t time.Time) timeutil.TimeStamp {
+	return timeutil.TimeStamp(t.UTC().Truncate(24 * time.Hour).Unix())
+}
+
+// IncrementDownloadStat increments the download counter of the bucket of the current day
+func IncrementDownloadStat(ctx context.Context, versionID int64, client string, authenticated bool) error {
+	day := DownloadStatDay(time.Now())
+
+	increment := func() (int64, error) {
+		res, err := db.GetEngine(ctx).Exec("UPDATE `package_download_stat` SET `count` = `count` + 1 WHERE `version_id` = ? AND `day_unix` = ? AND `client` = ? AND `authenticated` = ?", versionID, day, client, authenticated)
+		if err != nil {
+			return 0, err
+		}
+		return res.RowsAffected()
+	}
+
+	if n, err := increment(); err != nil || n > 0 {
+		return err
+	}
+
+	if err := db.Insert(ctx, &PackageDownloadStat{
+		VersionID:     versionID,
+		DayUnix:       day,
+		Client:        client,
+		Authenticated: authenticated,
+		Count:         1,
+	}); err != nil {
+		// a concurrent download may have created the bucket in the meantime
+		if n, err2 := increment(); err2 != nil || n == 0 {
+			return err
+		}
+	}
+	return nil
+}
+
+// FindDownloadStatsOptions are the options to search download buckets
+type FindDownloadStatsOptions struct {
+	VersionID int64
+	// Since and Before limit the days of the buckets, Before is exclusive
+	Since  timeutil.TimeStamp
+	Before timeutil.TimeStamp
+}
+
+func (opts *FindDownloadStatsOptions) toConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.VersionID > 0 {
+		cond = cond.And(builder.Eq{"version_id": opts.VersionID})
+	}
+	if opts.Since > 0 {
+		cond = cond.And(builder.Gte{"day_unix": opts.Since})
+	}
+	if opts.Before > 0 {
+		cond = cond.And(builder.Lt{"day_unix": opts.Before})
+	}
+	return cond
+}
+
+// FindDownloadStats gets the download buckets ordered by day
+func FindDownloadStats(ctx context.Context, opts *FindDownloadStatsOptions) ([]*PackageDownloadStat, error) {
+	stats := make([]*PackageDownloadStat, 0, 30)
+	return stats, db.GetEngine(ctx).
+		Where(opts.toConds()).
+		OrderBy("day_unix ASC, client ASC, authenticated ASC").
+		Find(&stats)
+}
+
+// DeleteDownloadStatsByVersionID deletes the download buckets of a version
+func DeleteDownloadStatsByVersionID(ctx context.Context, versionID int64) error {
+	_, err := db.GetEngine(ctx).Where("version_id = ?", versionID).Delete(&PackageDownloadStat{})
+	return err
+}
@@ -0,0 +1,56 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages_test
+
+import (
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageDownloadStat(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	require.NoError(t, packages_model.IncrementDownloadStat(db.DefaultContext, 1, "npm", false))
+	require.NoError(t, packages_model.IncrementDownloadStat(db.DefaultContext, 1, "npm", false))
+	require.NoError(t, packages_model.IncrementDownloadStat(db.DefaultContext, 1, "npm", true))
+	require.NoError(t, packages_model.IncrementDownloadStat(db.DefaultContext, 1, "curl", false))
+	require.NoError(t, packages_model.IncrementDownloadStat(db.DefaultContext, 2, "npm", false))
+
+	today := packages_model.DownloadStatDay(time.Now())
+
+	stats, err := packages_model.FindDownloadStats(db.DefaultContext, &packages_model.FindDownloadStatsOptions{VersionID: 1})
+	require.NoError(t, err)
+	require.Len(t, stats, 3)
+	for _, s := range stats {
+		assert.Equal(t, today, s.DayUnix)
+	}
+	assert.Equal(t, "curl", stats[0].Client)
+	assert.EqualValues(t, 1, stats[0].Count)
+	assert.Equal(t, "npm", stats[1].Client)
+	assert.False(t, stats[1].Authenticated)
+	assert.EqualValues(t, 2, stats[1].Count)
+	assert.True(t, stats[2].Authenticated)
+	assert.EqualValues(t, 1, stats[2].Count)
+
+	stats, err = packages_model.FindDownloadStats(db.DefaultContext, &packages_model.FindDownloadStatsOptions{VersionID: 1, Before: today})
+	require.NoError(t, err)
+	assert.Empty(t, stats)
+
+	require.NoError(t, packages_model.DeleteDownloadStatsByVersionID(db.DefaultContext, 1))
+
+	stats, err = packages_model.FindDownloadStats(db.DefaultContext, &packages_model.FindDownloadStatsOptions{VersionID: 1})
+	require.NoError(t, err)
+	assert.Empty(t, stats)
+
+	stats, err = packages_model.FindDownloadStats(db.DefaultContext, &packages_model.FindDownloadStatsOptions{VersionID: 2})
+	require.NoError(t, err)
+	assert.Len(t, stats, 1)
+}
@@ -0,0 +1,60 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+func init() {
+	db.RegisterModel(new(PackagePromotion))
+}
+
+// PackagePromotion is an audit entry of a package version copied to another owner.
+// The names are stored because the versions may be removed later.
+type PackagePromotion struct {
+	ID              int64              `xorm:"pk autoincr"`
+	Type            Type               `xorm:"NOT NULL"`
+	Name            string             `xorm:"NOT NULL"`
+	Version         string             `xorm:"NOT NULL"`
+	SourceOwnerID   int64              `xorm:"INDEX NOT NULL"`
+	SourceVersionID int64              `xorm:"INDEX NOT NULL"`
+	TargetOwnerID   int64              `xorm:"INDEX NOT NULL"`
+	TargetVersionID int64              `xorm:"INDEX NOT NULL"`
+	DoerID          int64              `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix     timeutil.TimeStamp `xorm:"created INDEX NOT NULL"`
+}
+
+// InsertPromotion inserts an audit entry
+func InsertPromotion(ctx context.Context, pp *PackagePromotion) error {
+	return db.Insert(ctx, pp)
+}
+
+// FindPromotionsOptions are the options to search promotion audit entries
+type FindPromotionsOptions struct {
+	db.ListOptions
+	// OwnerID matches entries where the owner is either the source or the target
+	OwnerID         int64
+	TargetVersionID int64
+}
+
+func (opts FindPromotionsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.OwnerID > 0 {
+		cond = cond.And(builder.Or(builder.Eq{"source_owner_id": opts.OwnerID}, builder.Eq{"target_owner_id": opts.OwnerID}))
+	}
+	if opts.TargetVersionID > 0 {
+		cond = cond.And(builder.Eq{"target_version_id": opts.TargetVersionID})
+	}
+	return cond
+}
+
+func (opts FindPromotionsOptions) ToOrders() string {
+	return "created_unix DESC, id DESC"
+}
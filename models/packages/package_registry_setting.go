@@ -0,0 +1,70 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+func init() {
+	db.RegisterModel(new(PackageRegistrySetting))
+}
+
+// PackageRegistrySetting holds the settings of the registry of a package type of an owner
+type PackageRegistrySetting struct {
+	ID          int64              `xorm:"pk autoincr"`
+	OwnerID     int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Type        Type               `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Immutable   bool               `xorm:"NOT NULL DEFAULT false"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL DEFAULT 0"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated NOT NULL DEFAULT 0"`
+}
+
+// GetRegistrySetting gets the settings of a registry. If none are stored the defaults are returned
+func GetRegistrySetting(ctx context.Context, ownerID int64, packageType Type) (*PackageRegistrySetting, error) {
+	prs := &PackageRegistrySetting{OwnerID: ownerID, Type: packageType}
+
+	has, err := db.GetEngine(ctx).Get(prs)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return &PackageRegistrySetting{OwnerID: ownerID, Type: packageType}, nil
+	}
+	return prs, nil
+}
+
+// GetRegistrySettingsByOwner gets the stored registry settings of an owner
+func GetRegistrySettingsByOwner(ctx context.Context, ownerID int64) ([]*PackageRegistrySetting, error) {
+	prs := make([]*PackageRegistrySetting, 0, 10)
+	return prs, db.GetEngine(ctx).Where("owner_id = ?", ownerID).OrderBy("type").Find(&prs)
+}
+
+// UpsertRegistrySetting inserts or updates the settings of a registry
+func UpsertRegistrySetting(ctx context.Context, prs *PackageRegistrySetting) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		existing, err := GetRegistrySetting(ctx, prs.OwnerID, prs.Type)
+		if err != nil {
+			return err
+		}
+		if existing.ID == 0 {
+			return db.Insert(ctx, prs)
+		}
+		prs.ID = existing.ID
+		_, err = db.GetEngine(ctx).ID(prs.ID).Cols("immutable").Update(prs)
+		return err
+	})
+}
+
+// IsRegistryImmutable checks if published versions of a registry must not be changed
+func IsRegistryImmutable(ctx context.Context, ownerID int64, packageType Type) (bool, error) {
+	prs, err := GetRegistrySetting(ctx, ownerID, packageType)
+	if err != nil {
+		return false, err
+	}
+	return prs.Immutable, nil
+}
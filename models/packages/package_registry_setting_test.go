@@ -0,0 +1,48 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageRegistrySetting(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	immutable, err := packages_model.IsRegistryImmutable(db.DefaultContext, 2, packages_model.TypeGeneric)
+	require.NoError(t, err)
+	assert.False(t, immutable)
+
+	require.NoError(t, packages_model.UpsertRegistrySetting(db.DefaultContext, &packages_model.PackageRegistrySetting{
+		OwnerID:   2,
+		Type:      packages_model.TypeGeneric,
+		Immutable: true,
+	}))
+
+	immutable, err = packages_model.IsRegistryImmutable(db.DefaultContext, 2, packages_model.TypeGeneric)
+	require.NoError(t, err)
+	assert.True(t, immutable)
+
+	immutable, err = packages_model.IsRegistryImmutable(db.DefaultContext, 2, packages_model.TypeNpm)
+	require.NoError(t, err)
+	assert.False(t, immutable)
+
+	require.NoError(t, packages_model.UpsertRegistrySetting(db.DefaultContext, &packages_model.PackageRegistrySetting{
+		OwnerID:   2,
+		Type:      packages_model.TypeGeneric,
+		Immutable: false,
+	}))
+
+	prs, err := packages_model.GetRegistrySettingsByOwner(db.DefaultContext, 2)
+	require.NoError(t, err)
+	require.Len(t, prs, 1)
+	assert.False(t, prs[0].Immutable)
+}
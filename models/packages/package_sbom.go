@@ -0,0 +1,66 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+)
+
+func init() {
+	db.RegisterModel(new(PackageSBOM))
+}
+
+// ErrPackageSBOMNotExist indicates a package SBOM not exist error
+var ErrPackageSBOMNotExist = util.NewNotExistErrorf("package SBOM does not exist")
+
+// PackageSBOM is a software bill of materials uploaded for a package version
+type PackageSBOM struct {
+	ID          int64              `xorm:"pk autoincr"`
+	VersionID   int64              `xorm:"UNIQUE NOT NULL"`
+	Format      string             `xorm:"NOT NULL"`
+	Content     string             `xorm:"LONGTEXT NOT NULL"`
+	CreatorID   int64              `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated NOT NULL"`
+}
+
+// SetSBOM inserts the SBOM of a version or replaces the existing one
+func SetSBOM(ctx context.Context, ps *PackageSBOM) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		existing, err := GetSBOMByVersionID(ctx, ps.VersionID)
+		if err != nil && err != ErrPackageSBOMNotExist {
+			return err
+		}
+		if existing == nil {
+			return db.Insert(ctx, ps)
+		}
+		ps.ID = existing.ID
+		_, err = db.GetEngine(ctx).ID(ps.ID).Cols("format", "content", "creator_id").Update(ps)
+		return err
+	})
+}
+
+// GetSBOMByVersionID gets the SBOM of a version
+func GetSBOMByVersionID(ctx context.Context, versionID int64) (*PackageSBOM, error) {
+	ps := &PackageSBOM{}
+
+	has, err := db.GetEngine(ctx).Where("version_id = ?", versionID).Get(ps)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrPackageSBOMNotExist
+	}
+	return ps, nil
+}
+
+// DeleteSBOMByVersionID deletes the SBOM of a version
+func DeleteSBOMByVersionID(ctx context.Context, versionID int64) error {
+	_, err := db.GetEngine(ctx).Where("version_id = ?", versionID).Delete(&PackageSBOM{})
+	return err
+}
@@ -0,0 +1,109 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+)
+
+func init() {
+	db.RegisterModel(new(PackageSignature))
+}
+
+// ErrDuplicatePackageSignature indicates a duplicated package signature error
+var ErrDuplicatePackageSignature = util.NewAlreadyExistErrorf("package signature already exists")
+
+// SignatureType specifies the kind of a detached package signature
+type SignatureType string
+
+const (
+	// SignatureTypePGP is an armored detached OpenPGP signature
+	SignatureTypePGP SignatureType = "pgp"
+	// SignatureTypeSSH is an armored detached SSH signature (ssh-keygen -Y sign -n file)
+	SignatureTypeSSH SignatureType = "ssh"
+)
+
+// IsValid checks if the signature type is known
+func (st SignatureType) IsValid() bool {
+	switch st {
+	case SignatureTypePGP, SignatureTypeSSH:
+		return true
+	}
+	return false
+}
+
+// PackageSignature represents a verified detached signature of a package file.
+// Signatures are only stored after they were verified against a key of the signer,
+// who is the uploader of the file.
+type PackageSignature struct {
+	ID          int64              `xorm:"pk autoincr"`
+	VersionID   int64              `xorm:"INDEX NOT NULL"`
+	FileID      int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Type        SignatureType      `xorm:"UNIQUE(s) NOT NULL"`
+	SignerID    int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	KeyID       string             `xorm:"NOT NULL"`
+	Content     string             `xorm:"LONGTEXT NOT NULL"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL"`
+
+	Signer *user_model.User `xorm:"-"`
+}
+
+// LoadSigner loads the owner of the key which verified the signature
+func (ps *PackageSignature) LoadSigner(ctx context.Context) (err error) {
+	if ps.Signer != nil {
+		return nil
+	}
+	ps.Signer, err = user_model.GetPossibleUserByID(ctx, ps.SignerID)
+	if err != nil {
+		if !user_model.IsErrUserNotExist(err) {
+			return err
+		}
+		ps.Signer = user_model.NewGhostUser()
+	}
+	return nil
+}
+
+// InsertSignature inserts a signature. If the signer has signed the file with the same kind of key already ErrDuplicatePackageSignature is returned
+func InsertSignature(ctx context.Context, ps *PackageSignature) (*PackageSignature, error) {
+	e := db.GetEngine(ctx)
+
+	has, err := e.Exist(&PackageSignature{
+		FileID:   ps.FileID,
+		Type:     ps.Type,
+		SignerID: ps.SignerID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if has {
+		return nil, ErrDuplicatePackageSignature
+	}
+	if _, err = e.Insert(ps); err != nil {
+		return nil, err
+	}
+	return ps, nil
+}
+
+// GetSignaturesByVersionID gets all signatures of the files of a version
+func GetSignaturesByVersionID(ctx context.Context, versionID int64) ([]*PackageSignature, error) {
+	pss := make([]*PackageSignature, 0, 10)
+	return pss, db.GetEngine(ctx).Where("version_id = ?", versionID).OrderBy("id").Find(&pss)
+}
+
+// GetSignaturesByFileID gets all signatures of a file
+func GetSignaturesByFileID(ctx context.Context, fileID int64) ([]*PackageSignature, error) {
+	pss := make([]*PackageSignature, 0, 2)
+	return pss, db.GetEngine(ctx).Where("file_id = ?", fileID).OrderBy("id").Find(&pss)
+}
+
+// DeleteSignaturesByFileID deletes all signatures of a file
+func DeleteSignaturesByFileID(ctx context.Context, fileID int64) error {
+	_, err := db.GetEngine(ctx).Where("file_id = ?", fileID).Delete(&PackageSignature{})
+	return err
+}
@@ -0,0 +1,81 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	packages_model "code.gitea.io/gitea/models/packages"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageSignature(t *testing.T) {
+	p := prepareExamplePackage(t)
+	defer deletePackage(t, p)
+
+	pv, err := packages_model.GetOrInsertVersion(db.DefaultContext, &packages_model.PackageVersion{
+		PackageID:    p.ID,
+		Version:      "1.0.0",
+		LowerVersion: "1.0.0",
+	})
+	require.NoError(t, err)
+
+	pb, _, err := packages_model.GetOrInsertBlob(db.DefaultContext, &packages_model.PackageBlob{
+		HashMD5:    "md5",
+		HashSHA1:   "sha1",
+		HashSHA256: "sha256",
+		HashSHA512: "sha512",
+	})
+	require.NoError(t, err)
+
+	pfs := make([]*packages_model.PackageFile, 0, 2)
+	for _, name := range []string{"a.tar.gz", "b.tar.gz"} {
+		pf, err := packages_model.TryInsertFile(db.DefaultContext, &packages_model.PackageFile{
+			VersionID: pv.ID,
+			BlobID:    pb.ID,
+			Name:      name,
+			LowerName: name,
+		})
+		require.NoError(t, err)
+		pfs = append(pfs, pf)
+	}
+
+	sign := func(pf *packages_model.PackageFile) error {
+		_, err := packages_model.InsertSignature(db.DefaultContext, &packages_model.PackageSignature{
+			VersionID: pv.ID,
+			FileID:    pf.ID,
+			Type:      packages_model.SignatureTypeSSH,
+			SignerID:  2,
+			KeyID:     "SHA256:test",
+			Content:   "signature",
+		})
+		return err
+	}
+
+	require.NoError(t, sign(pfs[0]))
+	assert.ErrorIs(t, sign(pfs[0]), packages_model.ErrDuplicatePackageSignature)
+
+	pd, err := packages_model.GetPackageDescriptor(db.DefaultContext, pv)
+	require.NoError(t, err)
+	assert.False(t, pd.IsVerified())
+
+	require.NoError(t, sign(pfs[1]))
+
+	pd, err = packages_model.GetPackageDescriptor(db.DefaultContext, pv)
+	require.NoError(t, err)
+	assert.True(t, pd.IsVerified())
+
+	pss, err := packages_model.GetSignaturesByVersionID(db.DefaultContext, pv.ID)
+	require.NoError(t, err)
+	assert.Len(t, pss, 2)
+
+	require.NoError(t, packages_model.DeleteSignaturesByFileID(db.DefaultContext, pfs[0].ID))
+
+	pss, err = packages_model.GetSignaturesByVersionID(db.DefaultContext, pv.ID)
+	require.NoError(t, err)
+	assert.Len(t, pss, 1)
+}
@@ -0,0 +1,66 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"context"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+func init() {
+	db.RegisterModel(new(PackageVulnerability))
+}
+
+// PackageVulnerability is a known vulnerability of a package imported from an OSV database.
+// An OSV entry affecting multiple packages is stored once per package.
+type PackageVulnerability struct {
+	ID        int64    `xorm:"pk autoincr"`
+	OSVID     string   `xorm:"'osv_id' UNIQUE(s) NOT NULL"`
+	Ecosystem string   `xorm:"UNIQUE(s) INDEX(p) NOT NULL"`
+	LowerName string   `xorm:"UNIQUE(s) INDEX(p) NOT NULL"`
+	Aliases   []string `xorm:"TEXT JSON"`
+	Summary   string   `xorm:"TEXT"`
+	Severity  string   `xorm:"NOT NULL DEFAULT ''"`
+	// AffectedJSON is the OSV "affected" object of the package
+	AffectedJSON string             `xorm:"'affected_json' LONGTEXT"`
+	ModifiedUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+}
+
+// UpsertVulnerability inserts a vulnerability or replaces the stored one with the same id and package
+func UpsertVulnerability(ctx context.Context, pv *PackageVulnerability) error {
+	pv.LowerName = strings.ToLower(pv.LowerName)
+
+	e := db.GetEngine(ctx)
+
+	existing := &PackageVulnerability{}
+	has, err := e.Where("osv_id = ? AND ecosystem = ? AND lower_name = ?", pv.OSVID, pv.Ecosystem, pv.LowerName).Get(existing)
+	if err != nil {
+		return err
+	}
+	if !has {
+		_, err = e.Insert(pv)
+		return err
+	}
+
+	pv.ID = existing.ID
+	_, err = e.ID(pv.ID).AllCols().Update(pv)
+	return err
+}
+
+// GetVulnerabilitiesByPackage gets the known vulnerabilities of a package
+func GetVulnerabilitiesByPackage(ctx context.Context, ecosystem, name string) ([]*PackageVulnerability, error) {
+	pvs := make([]*PackageVulnerability, 0, 5)
+	return pvs, db.GetEngine(ctx).
+		Where("ecosystem = ? AND lower_name = ?", ecosystem, strings.ToLower(name)).
+		OrderBy("osv_id").
+		Find(&pvs)
+}
+
+// CountVulnerabilities counts the imported vulnerabilities
+func CountVulnerabilities(ctx context.Context) (int64, error) {
+	return db.GetEngine(ctx).Count(&PackageVulnerability{})
+}
@@ -0,0 +1,197 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"context"
+	"fmt"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// AutomationEvent is the event of an issue which triggers an automation rule of its project
+type AutomationEvent string
+
+const (
+	// AutomationEventIssueAdded is triggered when an issue is added to the project, including when it is opened in the project
+	AutomationEventIssueAdded AutomationEvent = "issue_added"
+	// AutomationEventPullAdded is triggered when a pull request is added to the project, including when it is opened in the project
+	AutomationEventPullAdded AutomationEvent = "pull_added"
+	// AutomationEventPullLinked is triggered on an issue of the project when a pull request which closes it is opened or edited
+	AutomationEventPullLinked AutomationEvent = "pull_linked"
+	// AutomationEventClosed is triggered when an issue or a pull request of the project is closed,
+	// or when a pull request is merged and there is no rule for the merged event
+	AutomationEventClosed AutomationEvent = "closed"
+	// AutomationEventMerged is triggered when a pull request of the project is merged
+	AutomationEventMerged AutomationEvent = "merged"
+	// AutomationEventReopened is triggered when an issue or a pull request of the project is reopened
+	AutomationEventReopened AutomationEvent = "reopened"
+	// AutomationEventLabelAdded is triggered when the label of the rule is added to an issue or a pull request of the project
+	AutomationEventLabelAdded AutomationEvent = "label_added"
+)
+
+// AutomationEvents are the events which can trigger an automation rule, in display order
+var AutomationEvents = []AutomationEvent{
+	AutomationEventIssueAdded, AutomationEventPullAdded, AutomationEventPullLinked, AutomationEventClosed,
+	AutomationEventMerged, AutomationEventReopened, AutomationEventLabelAdded,
+}
+
+// ErrProjectAutomationNotExist represents a "ProjectAutomationNotExist" kind of error.
+type ErrProjectAutomationNotExist struct {
+	ID int64
+}
+
+// IsErrProjectAutomationNotExist checks if an error is a ErrProjectAutomationNotExist
+func IsErrProjectAutomationNotExist(err error) bool {
+	_, ok := err.(ErrProjectAutomationNotExist)
+	return ok
+}
+
+func (err ErrProjectAutomationNotExist) Error() string {
+	return fmt.Sprintf("project automation does not exist [id: %d]", err.ID)
+}
+
+func (err ErrProjectAutomationNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// Automation is a rule of a project moving its issues to a column when an event happens on them
+type Automation struct {
+	ID        int64           `xorm:"pk autoincr"`
+	ProjectID int64           `xorm:"INDEX NOT NULL"`
+	Event     AutomationEvent `xorm:"VARCHAR(20) NOT NULL"`
+	// LabelID is the label of a rule triggered when a label is added
+	LabelID   int64 `xorm:"NOT NULL DEFAULT 0"`
+	ColumnID  int64 `xorm:"NOT NULL"`
+	CreatorID int64 `xorm:"NOT NULL"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+}
+
+// TableName return the real table name
+func (Automation) TableName() string {
+	return "project_automation"
+}
+
+func init() {
+	db.RegisterModel(new(Automation))
+}
+
+// NewAutomation creates an automation rule of a project. There can be only one rule for an event,
+// or for a label, the label being checked by the caller.
+func NewAutomation(ctx context.Context, a *Automation) error {
+	found := false
+	for _, event := range AutomationEvents {
+		found = found || event == a.Event
+	}
+	if !found {
+		return util.NewInvalidArgumentErrorf("invalid project automation event %q", a.Event)
+	}
+	if a.Event != AutomationEventLabelAdded {
+		a.LabelID = 0
+	} else if a.LabelID <= 0 {
+		return util.NewInvalidArgumentErrorf("the label of the automation is missing")
+	}
+
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		column, err := GetColumn(ctx, a.ColumnID)
+		if err != nil {
+			return err
+		}
+		if column.ProjectID != a.ProjectID {
+			return util.NewInvalidArgumentErrorf("column %d is not a column of project %d", a.ColumnID, a.ProjectID)
+		}
+
+		exist, err := db.GetEngine(ctx).Exist(&Automation{ProjectID: a.ProjectID, Event: a.Event, LabelID: a.LabelID})
+		if err != nil {
+			return err
+		} else if exist {
+			return util.NewAlreadyExistErrorf("there is already an automation for this event in the project")
+		}
+		return db.Insert(ctx, a)
+	})
+}
+
+// GetAutomations returns the automation rules of a project
+func GetAutomations(ctx context.Context, projectID int64) ([]*Automation, error) {
+	automations := make([]*Automation, 0, 5)
+	return automations, db.GetEngine(ctx).Where("project_id=?", projectID).OrderBy("id").Find(&automations)
+}
+
+// DeleteAutomation deletes an automation rule of a project
+func DeleteAutomation(ctx context.Context, projectID, id int64) error {
+	n, err := db.GetEngine(ctx).Where(builder.Eq{"id": id, "project_id": projectID}).Delete(new(Automation))
+	if err != nil {
+		return err
+	} else if n == 0 {
+		return ErrProjectAutomationNotExist{ID: id}
+	}
+	return nil
+}
+
+func deleteAutomationsByProjectCond(ctx context.Context, projectCond builder.Cond) error {
+	_, err := db.GetEngine(ctx).Where(builder.In("project_id", builder.Select("id").From("project").Where(projectCond))).Delete(new(Automation))
+	return err
+}
+
+func deleteAutomationsByColumnID(ctx context.Context, columnID int64) error {
+	_, err := db.GetEngine(ctx).Where("column_id=?", columnID).Delete(new(Automation))
+	return err
+}
+
+// ApplyAutomation moves an issue to the column of the automation rule of its project triggered by the event, at the end
+// of the column. The label is the label added for AutomationEventLabelAdded. It returns the applied rule, nil if the
+// issue isn't in a project, if there is no rule for the event, or if the issue already is in the column.
+// The rule of AutomationEventClosed applies to a merged pull request when there is no rule for AutomationEventMerged.
+func ApplyAutomation(ctx context.Context, issueID int64, event AutomationEvent, labelID int64) (*Automation, error) {
+	if event != AutomationEventLabelAdded {
+		labelID = 0
+	}
+
+	var applied *Automation
+	err := db.WithTx(ctx, func(ctx context.Context) error {
+		projectIssue := new(ProjectIssue)
+		if has, err := db.GetEngine(ctx).Where("issue_id=?", issueID).Get(projectIssue); err != nil || !has {
+			return err
+		}
+
+		events := []AutomationEvent{event}
+		if event == AutomationEventMerged {
+			events = append(events, AutomationEventClosed)
+		}
+		automations := make([]*Automation, 0, len(events))
+		if err := db.GetEngine(ctx).Where(builder.Eq{"project_id": projectIssue.ProjectID, "label_id": labelID}.And(builder.In("event", events))).Find(&automations); err != nil {
+			return err
+		}
+		if len(automations) == 0 {
+			return nil
+		}
+		// the rule of the merged event takes precedence over the rule of the closed event
+		a := automations[0]
+		for _, automation := range automations {
+			if automation.Event == event {
+				a = automation
+			}
+		}
+		if a.ColumnID == projectIssue.ProjectColumnID {
+			return nil
+		}
+
+		var maxSorting int64
+		if _, err := db.GetEngine(ctx).Table("project_issue").Where("project_board_id=?", a.ColumnID).Select("COALESCE(MAX(sorting), 0)").Get(&maxSorting); err != nil {
+			return err
+		}
+		if _, err := db.GetEngine(ctx).ID(projectIssue.ID).Cols("project_board_id", "sorting").
+			Update(&ProjectIssue{ProjectColumnID: a.ColumnID, Sorting: maxSorting + 1}); err != nil {
+			return err
+		}
+		applied = a
+		return nil
+	})
+	return applied, err
+}
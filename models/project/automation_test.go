@@ -0,0 +1,100 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAutomation(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	for _, a := range []*Automation{
+		{ProjectID: 1, Event: "commented", ColumnID: 3},
+		{ProjectID: 1, Event: AutomationEventLabelAdded, ColumnID: 3},
+		// column 5 is a column of project 2
+		{ProjectID: 1, Event: AutomationEventClosed, ColumnID: 5},
+	} {
+		assert.ErrorIs(t, NewAutomation(db.DefaultContext, a), util.ErrInvalidArgument, a)
+	}
+
+	closed := &Automation{ProjectID: 1, Event: AutomationEventClosed, LabelID: 4, ColumnID: 3, CreatorID: 2}
+	require.NoError(t, NewAutomation(db.DefaultContext, closed))
+	assert.EqualValues(t, 0, closed.LabelID)
+	assert.ErrorIs(t, NewAutomation(db.DefaultContext, &Automation{ProjectID: 1, Event: AutomationEventClosed, ColumnID: 2}), util.ErrAlreadyExist)
+
+	// there can be a rule for each label
+	require.NoError(t, NewAutomation(db.DefaultContext, &Automation{ProjectID: 1, Event: AutomationEventLabelAdded, LabelID: 1, ColumnID: 2}))
+	require.NoError(t, NewAutomation(db.DefaultContext, &Automation{ProjectID: 1, Event: AutomationEventLabelAdded, LabelID: 2, ColumnID: 3}))
+	assert.ErrorIs(t, NewAutomation(db.DefaultContext, &Automation{ProjectID: 1, Event: AutomationEventLabelAdded, LabelID: 2, ColumnID: 1}), util.ErrAlreadyExist)
+
+	automations, err := GetAutomations(db.DefaultContext, 1)
+	require.NoError(t, err)
+	assert.Len(t, automations, 3)
+
+	assert.True(t, IsErrProjectAutomationNotExist(DeleteAutomation(db.DefaultContext, 2, closed.ID)))
+	require.NoError(t, DeleteAutomation(db.DefaultContext, 1, closed.ID))
+	unittest.AssertNotExistsBean(t, &Automation{ID: closed.ID})
+}
+
+func TestApplyAutomation(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	apply := func(t *testing.T, issueID int64, event AutomationEvent, labelID int64) *Automation {
+		t.Helper()
+		a, err := ApplyAutomation(db.DefaultContext, issueID, event, labelID)
+		require.NoError(t, err)
+		return a
+	}
+	columnID := func(issueID int64) int64 {
+		return unittest.AssertExistsAndLoadBean(t, &ProjectIssue{IssueID: issueID}).ProjectColumnID
+	}
+
+	// issue 1 is in column 1 of project 1
+	assert.Nil(t, apply(t, 1, AutomationEventClosed, 0))
+	assert.EqualValues(t, 1, columnID(1))
+
+	closed := &Automation{ProjectID: 1, Event: AutomationEventClosed, ColumnID: 3, CreatorID: 2}
+	require.NoError(t, NewAutomation(db.DefaultContext, closed))
+	label := &Automation{ProjectID: 1, Event: AutomationEventLabelAdded, LabelID: 1, ColumnID: 2, CreatorID: 2}
+	require.NoError(t, NewAutomation(db.DefaultContext, label))
+
+	assert.Nil(t, apply(t, 1, AutomationEventLabelAdded, 2))
+	if a := apply(t, 1, AutomationEventLabelAdded, 1); assert.NotNil(t, a) {
+		assert.Equal(t, label.ID, a.ID)
+	}
+	assert.EqualValues(t, 2, columnID(1))
+	// the issue already is in the column
+	assert.Nil(t, apply(t, 1, AutomationEventLabelAdded, 1))
+
+	// the rule of the closed event applies to the merged pull requests without rule for the merged event
+	if a := apply(t, 1, AutomationEventMerged, 0); assert.NotNil(t, a) {
+		assert.Equal(t, closed.ID, a.ID)
+	}
+	assert.EqualValues(t, 3, columnID(1))
+
+	merged := &Automation{ProjectID: 1, Event: AutomationEventMerged, ColumnID: 1, CreatorID: 2}
+	require.NoError(t, NewAutomation(db.DefaultContext, merged))
+	if a := apply(t, 1, AutomationEventMerged, 0); assert.NotNil(t, a) {
+		assert.Equal(t, merged.ID, a.ID)
+	}
+	assert.EqualValues(t, 1, columnID(1))
+
+	// issue 4 isn't in a project
+	assert.Nil(t, apply(t, 4, AutomationEventClosed, 0))
+
+	// the rules are deleted with their column, and with their project
+	require.NoError(t, DeleteColumnByID(db.DefaultContext, 2))
+	unittest.AssertNotExistsBean(t, &Automation{ID: label.ID})
+	unittest.AssertExistsAndLoadBean(t, &Automation{ID: closed.ID})
+	require.NoError(t, DeleteProjectByID(db.DefaultContext, 1))
+	unittest.AssertNotExistsBean(t, &Automation{ID: closed.ID})
+}
@@ -34,6 +34,19 @@ func deleteProjectIssuesByProjectID(ctx context.Context, projectID int64) error
 	return err
 }
 
+// GetIssueColumnIDs returns the IDs of the columns of the issues of a project, by issue ID
+func GetIssueColumnIDs(ctx context.Context, projectID int64) (map[int64]int64, error) {
+	projectIssues := make([]*ProjectIssue, 0, 10)
+	if err := db.GetEngine(ctx).Where("project_id=?", projectID).Find(&projectIssues); err != nil {
+		return nil, err
+	}
+	columnIDs := make(map[int64]int64, len(projectIssues))
+	for _, projectIssue := range projectIssues {
+		columnIDs[projectIssue.IssueID] = projectIssue.ProjectColumnID
+	}
+	return columnIDs, nil
+}
+
 // NumIssues return counter of all issues assigned to a project
 func (p *Project) NumIssues(ctx context.Context) int {
 	c, err := db.GetEngine(ctx).Table("project_issue").
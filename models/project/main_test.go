@@ -15,6 +15,7 @@ func TestMain(m *testing.M) {
 	unittest.MainTest(m, &unittest.TestOptions{
 		FixtureFiles: []string{
 			"project.yml",
+			"project_automation.yml",
 			"project_board.yml",
 			"project_issue.yml",
 			"repository.yml",
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"html/template"
+	"strings"
 
 	"code.gitea.io/gitea/models/db"
 	repo_model "code.gitea.io/gitea/models/repo"
@@ -147,6 +148,11 @@ func (p *Project) Link(ctx context.Context) string {
 	return ""
 }
 
+// HTMLURL returns the project's absolute URL.
+func (p *Project) HTMLURL(ctx context.Context) string {
+	return setting.AppURL + strings.TrimPrefix(p.Link(ctx), setting.AppSubURL+"/")
+}
+
 func (p *Project) IconName() string {
 	if p.IsRepositoryProject() {
 		return "octicon-project"
@@ -405,6 +411,14 @@ func DeleteProjectByID(ctx context.Context, id int64) error {
 			return err
 		}
 
+		if err := deleteViewsByProjectCond(ctx, builder.Eq{"id": id}); err != nil {
+			return err
+		}
+
+		if err := deleteAutomationsByProjectCond(ctx, builder.Eq{"id": id}); err != nil {
+			return err
+		}
+
 		if _, err = db.GetEngine(ctx).ID(p.ID).Delete(new(Project)); err != nil {
 			return err
 		}
@@ -414,6 +428,13 @@ func DeleteProjectByID(ctx context.Context, id int64) error {
 }
 
 func DeleteProjectByRepoID(ctx context.Context, repoID int64) error {
+	if err := deleteViewsByProjectCond(ctx, builder.Eq{"repo_id": repoID}); err != nil {
+		return err
+	}
+	if err := deleteAutomationsByProjectCond(ctx, builder.Eq{"repo_id": repoID}); err != nil {
+		return err
+	}
+
 	switch {
 	case setting.Database.Type.IsSQLite3():
 		if _, err := db.GetEngine(ctx).Exec("DELETE FROM project_issue WHERE project_issue.id IN (SELECT project_issue.id FROM project_issue INNER JOIN project WHERE project.id = project_issue.project_id AND project.repo_id = ?)", repoID); err != nil {
@@ -0,0 +1,234 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// ViewType is the layout of a project view
+type ViewType string
+
+const (
+	// ViewTypeBoard shows the issues in the columns of the project
+	ViewTypeBoard ViewType = "board"
+	// ViewTypeTable shows the issues as the rows of a table, optionally grouped
+	ViewTypeTable ViewType = "table"
+)
+
+// ViewGroupBy is the attribute of the issues a table view is grouped by
+type ViewGroupBy string
+
+const (
+	ViewGroupByNone        ViewGroupBy = ""
+	ViewGroupByStatus      ViewGroupBy = "status"
+	ViewGroupByRepository  ViewGroupBy = "repository"
+	ViewGroupByColumn      ViewGroupBy = "column"
+	ViewGroupByAssignee    ViewGroupBy = "assignee"
+	ViewGroupByLabel       ViewGroupBy = "label"
+	ViewGroupByMilestone   ViewGroupBy = "milestone"
+	ViewGroupByCustomField ViewGroupBy = "custom_field"
+)
+
+// ViewGroupBys are the attributes a table view can be grouped by, in display order
+var ViewGroupBys = []ViewGroupBy{
+	ViewGroupByNone, ViewGroupByStatus, ViewGroupByRepository, ViewGroupByColumn,
+	ViewGroupByAssignee, ViewGroupByLabel, ViewGroupByMilestone, ViewGroupByCustomField,
+}
+
+// ViewSortBy is the attribute of the issues a view is sorted by
+type ViewSortBy string
+
+const (
+	// ViewSortByNone keeps the order of the issues in the project columns
+	ViewSortByNone        ViewSortBy = ""
+	ViewSortByCreated     ViewSortBy = "created"
+	ViewSortByUpdated     ViewSortBy = "updated"
+	ViewSortByComments    ViewSortBy = "comments"
+	ViewSortByDeadline    ViewSortBy = "deadline"
+	ViewSortByTitle       ViewSortBy = "title"
+	ViewSortByCustomField ViewSortBy = "custom_field"
+)
+
+// ViewSortBys are the attributes a view can be sorted by, in display order
+var ViewSortBys = []ViewSortBy{
+	ViewSortByNone, ViewSortByCreated, ViewSortByUpdated, ViewSortByComments,
+	ViewSortByDeadline, ViewSortByTitle, ViewSortByCustomField,
+}
+
+// ErrProjectViewNotExist represents a "ProjectViewNotExist" kind of error.
+type ErrProjectViewNotExist struct {
+	ID int64
+}
+
+// IsErrProjectViewNotExist checks if an error is a ErrProjectViewNotExist
+func IsErrProjectViewNotExist(err error) bool {
+	_, ok := err.(ErrProjectViewNotExist)
+	return ok
+}
+
+func (err ErrProjectViewNotExist) Error() string {
+	return fmt.Sprintf("project view does not exist [id: %d]", err.ID)
+}
+
+func (err ErrProjectViewNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// View is a named way to show the issues of a project: a layout, a filter, a grouping and a sort
+type View struct {
+	ID        int64    `xorm:"pk autoincr"`
+	ProjectID int64    `xorm:"INDEX NOT NULL"`
+	Name      string   `xorm:"NOT NULL"`
+	Type      ViewType `xorm:"VARCHAR(20) NOT NULL"`
+	// Filter is a structured issue search query, see issue_indexer.ParseQuery
+	Filter  string      `xorm:"TEXT"`
+	GroupBy ViewGroupBy `xorm:"VARCHAR(20) NOT NULL DEFAULT ''"`
+	// GroupFieldID is the custom field of a view grouped by custom field
+	GroupFieldID int64      `xorm:"NOT NULL DEFAULT 0"`
+	SortBy       ViewSortBy `xorm:"VARCHAR(20) NOT NULL DEFAULT ''"`
+	// SortFieldID is the custom field of a view sorted by custom field
+	SortFieldID int64 `xorm:"NOT NULL DEFAULT 0"`
+	SortDesc    bool  `xorm:"NOT NULL DEFAULT false"`
+	Sorting     int64 `xorm:"NOT NULL DEFAULT 0"`
+	CreatorID   int64 `xorm:"NOT NULL"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// TableName return the real table name
+func (View) TableName() string {
+	return "project_view"
+}
+
+func init() {
+	db.RegisterModel(new(View))
+}
+
+// IsTable returns true if the view shows the issues as a table
+func (v *View) IsTable() bool {
+	return v.Type == ViewTypeTable
+}
+
+// Validate checks and normalizes the attributes of the view, the filter and the custom fields being checked by the caller
+func (v *View) Validate() error {
+	v.Name = strings.TrimSpace(v.Name)
+	v.Filter = strings.TrimSpace(v.Filter)
+	if v.Name == "" {
+		return util.NewInvalidArgumentErrorf("project view name cannot be empty")
+	}
+
+	switch v.Type {
+	case ViewTypeBoard:
+		// the columns of the board are its groups, and the issues keep their order in the columns
+		v.GroupBy, v.GroupFieldID = ViewGroupByNone, 0
+		v.SortBy, v.SortFieldID, v.SortDesc = ViewSortByNone, 0, false
+	case ViewTypeTable:
+	default:
+		return util.NewInvalidArgumentErrorf("invalid project view type %q", v.Type)
+	}
+
+	found := false
+	for _, groupBy := range ViewGroupBys {
+		found = found || groupBy == v.GroupBy
+	}
+	if !found {
+		return util.NewInvalidArgumentErrorf("invalid project view grouping %q", v.GroupBy)
+	}
+	if v.GroupBy != ViewGroupByCustomField {
+		v.GroupFieldID = 0
+	} else if v.GroupFieldID <= 0 {
+		return util.NewInvalidArgumentErrorf("the custom field of the grouping is missing")
+	}
+
+	found = false
+	for _, sortBy := range ViewSortBys {
+		found = found || sortBy == v.SortBy
+	}
+	if !found {
+		return util.NewInvalidArgumentErrorf("invalid project view sort %q", v.SortBy)
+	}
+	if v.SortBy != ViewSortByCustomField {
+		v.SortFieldID = 0
+	} else if v.SortFieldID <= 0 {
+		return util.NewInvalidArgumentErrorf("the custom field of the sort is missing")
+	}
+	return nil
+}
+
+// NewView creates a view of a project, after the existing views
+func NewView(ctx context.Context, v *View) error {
+	if err := v.Validate(); err != nil {
+		return err
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		var maxSorting int64
+		if _, err := db.GetEngine(ctx).Table("project_view").Where("project_id=?", v.ProjectID).Select("COALESCE(MAX(sorting), 0)").Get(&maxSorting); err != nil {
+			return err
+		}
+		v.Sorting = maxSorting + 1
+		return db.Insert(ctx, v)
+	})
+}
+
+// UpdateView updates the attributes of a view
+func UpdateView(ctx context.Context, v *View) error {
+	if err := v.Validate(); err != nil {
+		return err
+	}
+	_, err := db.GetEngine(ctx).ID(v.ID).Cols(
+		"name",
+		"type",
+		"filter",
+		"group_by",
+		"group_field_id",
+		"sort_by",
+		"sort_field_id",
+		"sort_desc",
+	).Update(v)
+	return err
+}
+
+// GetViewByID returns a view of a project
+func GetViewByID(ctx context.Context, projectID, id int64) (*View, error) {
+	v := new(View)
+	has, err := db.GetEngine(ctx).Where(builder.Eq{"id": id, "project_id": projectID}).Get(v)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrProjectViewNotExist{ID: id}
+	}
+	return v, nil
+}
+
+// GetViews returns the views of a project, in display order
+func GetViews(ctx context.Context, projectID int64) ([]*View, error) {
+	views := make([]*View, 0, 5)
+	return views, db.GetEngine(ctx).Where("project_id=?", projectID).OrderBy("sorting, id").Find(&views)
+}
+
+// DeleteView deletes a view of a project
+func DeleteView(ctx context.Context, projectID, id int64) error {
+	n, err := db.GetEngine(ctx).Where(builder.Eq{"id": id, "project_id": projectID}).Delete(new(View))
+	if err != nil {
+		return err
+	} else if n == 0 {
+		return ErrProjectViewNotExist{ID: id}
+	}
+	return nil
+}
+
+func deleteViewsByProjectCond(ctx context.Context, projectCond builder.Cond) error {
+	_, err := db.GetEngine(ctx).Where(builder.In("project_id", builder.Select("id").From("project").Where(projectCond))).Delete(new(View))
+	return err
+}
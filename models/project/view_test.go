@@ -0,0 +1,83 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestViewValidate(t *testing.T) {
+	v := &View{Name: " Triage ", Type: ViewTypeTable, GroupBy: ViewGroupByLabel, GroupFieldID: 3, SortBy: ViewSortByUpdated, SortFieldID: 4, SortDesc: true}
+	require.NoError(t, v.Validate())
+	assert.Equal(t, "Triage", v.Name)
+	assert.EqualValues(t, 0, v.GroupFieldID)
+	assert.EqualValues(t, 0, v.SortFieldID)
+	assert.True(t, v.SortDesc)
+
+	// the grouping and the sort of a board are the columns of the project
+	v = &View{Name: "Board", Type: ViewTypeBoard, GroupBy: ViewGroupByAssignee, SortBy: ViewSortByTitle, SortDesc: true}
+	require.NoError(t, v.Validate())
+	assert.Equal(t, ViewGroupByNone, v.GroupBy)
+	assert.Equal(t, ViewSortByNone, v.SortBy)
+	assert.False(t, v.SortDesc)
+
+	for _, v := range []*View{
+		{Name: " ", Type: ViewTypeTable},
+		{Name: "View", Type: "list"},
+		{Name: "View", Type: ViewTypeTable, GroupBy: "priority"},
+		{Name: "View", Type: ViewTypeTable, SortBy: "stars"},
+		{Name: "View", Type: ViewTypeTable, GroupBy: ViewGroupByCustomField},
+		{Name: "View", Type: ViewTypeTable, SortBy: ViewSortByCustomField},
+	} {
+		assert.ErrorIs(t, v.Validate(), util.ErrInvalidArgument, v)
+	}
+}
+
+func TestViews(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	table := &View{ProjectID: 1, Name: "Open bugs", Type: ViewTypeTable, Filter: "is:open label:bug", GroupBy: ViewGroupByAssignee, CreatorID: 2}
+	require.NoError(t, NewView(db.DefaultContext, table))
+	board := &View{ProjectID: 1, Name: "Mine", Type: ViewTypeBoard, Filter: "assignee:@me", CreatorID: 2}
+	require.NoError(t, NewView(db.DefaultContext, board))
+	other := &View{ProjectID: 2, Name: "All", Type: ViewTypeTable, CreatorID: 3}
+	require.NoError(t, NewView(db.DefaultContext, other))
+	assert.EqualValues(t, 1, table.Sorting)
+	assert.EqualValues(t, 2, board.Sorting)
+	assert.EqualValues(t, 1, other.Sorting)
+
+	views, err := GetViews(db.DefaultContext, 1)
+	require.NoError(t, err)
+	if assert.Len(t, views, 2) {
+		assert.Equal(t, table.ID, views[0].ID)
+		assert.Equal(t, board.ID, views[1].ID)
+	}
+
+	_, err = GetViewByID(db.DefaultContext, 2, table.ID)
+	assert.True(t, IsErrProjectViewNotExist(err))
+
+	table.SortBy, table.SortDesc = ViewSortByTitle, true
+	require.NoError(t, UpdateView(db.DefaultContext, table))
+	view, err := GetViewByID(db.DefaultContext, 1, table.ID)
+	require.NoError(t, err)
+	assert.Equal(t, ViewSortByTitle, view.SortBy)
+	assert.True(t, view.SortDesc)
+	assert.Equal(t, "is:open label:bug", view.Filter)
+
+	assert.True(t, IsErrProjectViewNotExist(DeleteView(db.DefaultContext, 2, board.ID)))
+	require.NoError(t, DeleteView(db.DefaultContext, 1, board.ID))
+	unittest.AssertNotExistsBean(t, &View{ID: board.ID})
+
+	// the views are deleted with their project
+	require.NoError(t, DeleteProjectByID(db.DefaultContext, 1))
+	unittest.AssertNotExistsBean(t, &View{ID: table.ID})
+	unittest.AssertExistsAndLoadBean(t, &View{ID: other.ID})
+}
@@ -0,0 +1,167 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull
+
+import (
+	"context"
+	"fmt"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// MergeQueueStatus is the state of a pull request in the merge queue
+type MergeQueueStatus int
+
+const (
+	// MergeQueueStatusQueued means the speculative merge has not been built yet
+	MergeQueueStatusQueued MergeQueueStatus = iota
+	// MergeQueueStatusTesting means the speculative merge is waiting for its status checks
+	MergeQueueStatusTesting
+)
+
+func (s MergeQueueStatus) String() string {
+	switch s {
+	case MergeQueueStatusQueued:
+		return "queued"
+	case MergeQueueStatusTesting:
+		return "testing"
+	}
+	return "unknown"
+}
+
+// MergeQueueBranchPrefix is the prefix of the branches holding the speculative merges
+const MergeQueueBranchPrefix = "merge-queue/"
+
+// MergeQueueEntry represents a pull request waiting in the merge queue of its base branch.
+// The position in the queue is given by the order of the IDs.
+type MergeQueueEntry struct {
+	ID         int64                 `xorm:"pk autoincr"`
+	RepoID     int64                 `xorm:"INDEX(s) NOT NULL"`
+	BaseBranch string                `xorm:"INDEX(s) NOT NULL"`
+	PullID     int64                 `xorm:"UNIQUE NOT NULL"`
+	DoerID     int64                 `xorm:"INDEX NOT NULL"`
+	Doer       *user_model.User      `xorm:"-"`
+	MergeStyle repo_model.MergeStyle `xorm:"varchar(30)"`
+	Message    string                `xorm:"LONGTEXT"`
+	Status     MergeQueueStatus      `xorm:"NOT NULL DEFAULT 0"`
+	// ParentCommitID is the commit the speculative merge was built on
+	ParentCommitID string `xorm:"VARCHAR(64)"`
+	// SpeculativeCommitID is the commit of the base branch plus all queued pull requests up to this one
+	SpeculativeCommitID string             `xorm:"VARCHAR(64) INDEX"`
+	CreatedUnix         timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix         timeutil.TimeStamp `xorm:"updated"`
+}
+
+// TableName return database table name for xorm
+func (MergeQueueEntry) TableName() string {
+	return "pull_merge_queue"
+}
+
+func init() {
+	db.RegisterModel(new(MergeQueueEntry))
+}
+
+// SpeculativeBranch returns the name of the branch holding the speculative merge of the pull request with the given index
+func (e *MergeQueueEntry) SpeculativeBranch(pullIndex int64) string {
+	return fmt.Sprintf("%s%s/pr-%d", MergeQueueBranchPrefix, e.BaseBranch, pullIndex)
+}
+
+// LoadDoer loads the user who added the pull request to the queue
+func (e *MergeQueueEntry) LoadDoer(ctx context.Context) (err error) {
+	if e.Doer != nil {
+		return nil
+	}
+	e.Doer, err = user_model.GetPossibleUserByID(ctx, e.DoerID)
+	return err
+}
+
+// ErrAlreadyInMergeQueue represents a "PullRequestAlreadyInMergeQueue"-error
+type ErrAlreadyInMergeQueue struct {
+	PullID int64
+}
+
+func (err ErrAlreadyInMergeQueue) Error() string {
+	return fmt.Sprintf("pull request is already in the merge queue [pull_id: %d]", err.PullID)
+}
+
+// IsErrAlreadyInMergeQueue checks if an error is a ErrAlreadyInMergeQueue.
+func IsErrAlreadyInMergeQueue(err error) bool {
+	_, ok := err.(ErrAlreadyInMergeQueue)
+	return ok
+}
+
+// AddToMergeQueue appends a pull request to the merge queue of its base branch
+func AddToMergeQueue(ctx context.Context, e *MergeQueueEntry) error {
+	if exists, _, err := GetMergeQueueEntryByPullID(ctx, e.PullID); err != nil {
+		return err
+	} else if exists {
+		return ErrAlreadyInMergeQueue{PullID: e.PullID}
+	}
+
+	e.Status = MergeQueueStatusQueued
+	_, err := db.GetEngine(ctx).Insert(e)
+	return err
+}
+
+// GetMergeQueueEntryByPullID gets the merge queue entry of a pull request
+func GetMergeQueueEntryByPullID(ctx context.Context, pullID int64) (bool, *MergeQueueEntry, error) {
+	e := &MergeQueueEntry{}
+	exists, err := db.GetEngine(ctx).Where("pull_id = ?", pullID).Get(e)
+	if err != nil || !exists {
+		return false, nil, err
+	}
+	return true, e, nil
+}
+
+// GetMergeQueue returns the entries of the merge queue of a branch in queue order
+func GetMergeQueue(ctx context.Context, repoID int64, baseBranch string) ([]*MergeQueueEntry, error) {
+	entries := make([]*MergeQueueEntry, 0, 5)
+	return entries, db.GetEngine(ctx).
+		Where("repo_id = ? AND base_branch = ?", repoID, baseBranch).
+		OrderBy("id ASC").
+		Find(&entries)
+}
+
+// GetMergeQueueEntriesBySpeculativeCommitID returns the entries whose speculative merge is the given commit
+func GetMergeQueueEntriesBySpeculativeCommitID(ctx context.Context, repoID int64, commitID string) ([]*MergeQueueEntry, error) {
+	entries := make([]*MergeQueueEntry, 0, 1)
+	return entries, db.GetEngine(ctx).
+		Where("repo_id = ? AND speculative_commit_id = ?", repoID, commitID).
+		Find(&entries)
+}
+
+// GetMergeQueuePosition returns the 1-based position of the entry in the merge queue of its branch
+func GetMergeQueuePosition(ctx context.Context, e *MergeQueueEntry) (int64, error) {
+	count, err := db.GetEngine(ctx).
+		Where("repo_id = ? AND base_branch = ? AND id < ?", e.RepoID, e.BaseBranch, e.ID).
+		Count(new(MergeQueueEntry))
+	return count + 1, err
+}
+
+// UpdateMergeQueueEntry stores the speculative merge state of an entry
+func UpdateMergeQueueEntry(ctx context.Context, e *MergeQueueEntry) error {
+	_, err := db.GetEngine(ctx).ID(e.ID).Cols("status", "parent_commit_id", "speculative_commit_id").Update(e)
+	return err
+}
+
+// DeleteMergeQueueEntry removes a pull request from the merge queue
+func DeleteMergeQueueEntry(ctx context.Context, pullID int64) error {
+	exist, e, err := GetMergeQueueEntryByPullID(ctx, pullID)
+	if err != nil {
+		return err
+	} else if !exist {
+		return db.ErrNotExist{Resource: "merge_queue", ID: pullID}
+	}
+
+	_, err = db.GetEngine(ctx).ID(e.ID).Delete(&MergeQueueEntry{})
+	return err
+}
+
+// HasMergeQueue returns true if pull requests are waiting in the merge queue of the branch
+func HasMergeQueue(ctx context.Context, repoID int64, baseBranch string) (bool, error) {
+	return db.GetEngine(ctx).Where("repo_id = ? AND base_branch = ?", repoID, baseBranch).Exist(new(MergeQueueEntry))
+}
@@ -0,0 +1,91 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	pull_model "code.gitea.io/gitea/models/pull"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeQueue(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	add := func(t *testing.T, pullID int64, branch string) *pull_model.MergeQueueEntry {
+		e := &pull_model.MergeQueueEntry{
+			RepoID:     1,
+			BaseBranch: branch,
+			PullID:     pullID,
+			DoerID:     2,
+			MergeStyle: repo_model.MergeStyleMerge,
+		}
+		require.NoError(t, pull_model.AddToMergeQueue(db.DefaultContext, e))
+		return e
+	}
+
+	first := add(t, 1, "master")
+	other := add(t, 3, "branch2")
+	second := add(t, 2, "master")
+
+	t.Run("Duplicate", func(t *testing.T) {
+		err := pull_model.AddToMergeQueue(db.DefaultContext, &pull_model.MergeQueueEntry{RepoID: 1, BaseBranch: "master", PullID: 1, DoerID: 2})
+		assert.True(t, pull_model.IsErrAlreadyInMergeQueue(err))
+	})
+
+	t.Run("Order", func(t *testing.T) {
+		entries, err := pull_model.GetMergeQueue(db.DefaultContext, 1, "master")
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Equal(t, first.ID, entries[0].ID)
+		assert.Equal(t, second.ID, entries[1].ID)
+		assert.Equal(t, pull_model.MergeQueueStatusQueued, entries[0].Status)
+	})
+
+	t.Run("Position", func(t *testing.T) {
+		for e, position := range map[*pull_model.MergeQueueEntry]int64{first: 1, second: 2, other: 1} {
+			p, err := pull_model.GetMergeQueuePosition(db.DefaultContext, e)
+			require.NoError(t, err)
+			assert.Equal(t, position, p, "pull %d", e.PullID)
+		}
+	})
+
+	t.Run("SpeculativeCommit", func(t *testing.T) {
+		second.Status = pull_model.MergeQueueStatusTesting
+		second.ParentCommitID = "1111111111111111111111111111111111111111"
+		second.SpeculativeCommitID = "2222222222222222222222222222222222222222"
+		require.NoError(t, pull_model.UpdateMergeQueueEntry(db.DefaultContext, second))
+
+		entries, err := pull_model.GetMergeQueueEntriesBySpeculativeCommitID(db.DefaultContext, 1, second.SpeculativeCommitID)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, second.ID, entries[0].ID)
+		assert.Equal(t, pull_model.MergeQueueStatusTesting, entries[0].Status)
+		assert.Equal(t, "merge-queue/master/pr-2", entries[0].SpeculativeBranch(2))
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		require.NoError(t, pull_model.DeleteMergeQueueEntry(db.DefaultContext, first.PullID))
+		assert.True(t, db.IsErrNotExist(pull_model.DeleteMergeQueueEntry(db.DefaultContext, first.PullID)))
+
+		// the pull requests behind move up
+		p, err := pull_model.GetMergeQueuePosition(db.DefaultContext, second)
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, p)
+
+		require.NoError(t, pull_model.DeleteMergeQueueEntry(db.DefaultContext, second.PullID))
+		has, err := pull_model.HasMergeQueue(db.DefaultContext, 1, "master")
+		require.NoError(t, err)
+		assert.False(t, has)
+
+		has, err = pull_model.HasMergeQueue(db.DefaultContext, 1, "branch2")
+		require.NoError(t, err)
+		assert.True(t, has)
+	})
+}
@@ -0,0 +1,150 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull
+
+import (
+	"context"
+	"fmt"
+
+	"code.gitea.io/gitea/models/db"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// Revision is a head commit pushed to a pull request. Revisions are numbered from 1 in the order
+// they were pushed and their commits are kept reachable in the base repository by RefName.
+type Revision struct {
+	ID       int64  `xorm:"pk autoincr"`
+	PullID   int64  `xorm:"UNIQUE(s) NOT NULL"`
+	Index    int64  `xorm:"UNIQUE(s) NOT NULL"`
+	CommitID string `xorm:"VARCHAR(64) NOT NULL"`
+	// MergeBase is the merge base of the commit and the base branch when it was pushed
+	MergeBase   string             `xorm:"VARCHAR(64)"`
+	PusherID    int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+	Pusher      *user_model.User   `xorm:"-"`
+	IsForcePush bool               `xorm:"NOT NULL DEFAULT false"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+}
+
+// TableName return database table name for xorm
+func (Revision) TableName() string {
+	return "pull_revision"
+}
+
+// ReviewedRevision stores the last revision of a pull request a user submitted a review for
+type ReviewedRevision struct {
+	ID            int64              `xorm:"pk autoincr"`
+	UserID        int64              `xorm:"UNIQUE(s) NOT NULL"`
+	PullID        int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	RevisionIndex int64              `xorm:"NOT NULL"`
+	UpdatedUnix   timeutil.TimeStamp `xorm:"updated"`
+}
+
+// TableName return database table name for xorm
+func (ReviewedRevision) TableName() string {
+	return "pull_reviewed_revision"
+}
+
+func init() {
+	db.RegisterModel(new(Revision))
+	db.RegisterModel(new(ReviewedRevision))
+}
+
+// RevisionRefName returns the name of the reference keeping the commit of a revision of the pull request with the given index
+func RevisionRefName(pullIndex, revisionIndex int64) string {
+	return fmt.Sprintf("refs/pull/%d/revisions/%d", pullIndex, revisionIndex)
+}
+
+// LoadPusher loads the user who pushed the revision
+func (r *Revision) LoadPusher(ctx context.Context) (err error) {
+	if r.Pusher != nil {
+		return nil
+	}
+	r.Pusher, err = user_model.GetPossibleUserByID(ctx, r.PusherID)
+	return err
+}
+
+// AddRevision stores a new revision of a pull request, numbered after the existing ones
+func AddRevision(ctx context.Context, r *Revision) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		latest, err := GetLatestRevision(ctx, r.PullID)
+		if err != nil {
+			return err
+		}
+		r.Index = 1
+		if latest != nil {
+			r.Index = latest.Index + 1
+		}
+		_, err = db.GetEngine(ctx).Insert(r)
+		return err
+	})
+}
+
+// GetRevisions returns the revisions of a pull request in the order they were pushed
+func GetRevisions(ctx context.Context, pullID int64) ([]*Revision, error) {
+	revisions := make([]*Revision, 0, 5)
+	return revisions, db.GetEngine(ctx).Where("pull_id = ?", pullID).OrderBy("`index` ASC").Find(&revisions)
+}
+
+// CountRevisions returns the number of revisions of a pull request
+func CountRevisions(ctx context.Context, pullID int64) (int64, error) {
+	return db.GetEngine(ctx).Where("pull_id = ?", pullID).Count(new(Revision))
+}
+
+// GetRevisionByIndex returns the revision of a pull request with the given index
+func GetRevisionByIndex(ctx context.Context, pullID, index int64) (*Revision, error) {
+	r := &Revision{}
+	has, err := db.GetEngine(ctx).Where("pull_id = ? AND `index` = ?", pullID, index).Get(r)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, db.ErrNotExist{Resource: "pull_revision", ID: index}
+	}
+	return r, nil
+}
+
+// GetRevisionByCommitID returns the latest revision of a pull request whose head was the given commit, or nil
+func GetRevisionByCommitID(ctx context.Context, pullID int64, commitID string) (*Revision, error) {
+	r := &Revision{}
+	has, err := db.GetEngine(ctx).Where("pull_id = ? AND commit_id = ?", pullID, commitID).OrderBy("`index` DESC").Get(r)
+	if err != nil || !has {
+		return nil, err
+	}
+	return r, nil
+}
+
+// GetLatestRevision returns the last revision of a pull request, or nil if none was recorded
+func GetLatestRevision(ctx context.Context, pullID int64) (*Revision, error) {
+	r := &Revision{}
+	has, err := db.GetEngine(ctx).Where("pull_id = ?", pullID).OrderBy("`index` DESC").Get(r)
+	if err != nil || !has {
+		return nil, err
+	}
+	return r, nil
+}
+
+// GetReviewedRevision returns the last revision of the pull request reviewed by the user, or nil
+func GetReviewedRevision(ctx context.Context, userID, pullID int64) (*ReviewedRevision, error) {
+	r := &ReviewedRevision{}
+	has, err := db.GetEngine(ctx).Where("user_id = ? AND pull_id = ?", userID, pullID).Get(r)
+	if err != nil || !has {
+		return nil, err
+	}
+	return r, nil
+}
+
+// SetReviewedRevision records the revision of the pull request the user reviewed last
+func SetReviewedRevision(ctx context.Context, userID, pullID, revisionIndex int64) error {
+	r, err := GetReviewedRevision(ctx, userID, pullID)
+	if err != nil {
+		return err
+	}
+	if r == nil {
+		_, err = db.GetEngine(ctx).Insert(&ReviewedRevision{UserID: userID, PullID: pullID, RevisionIndex: revisionIndex})
+		return err
+	}
+	r.RevisionIndex = revisionIndex
+	_, err = db.GetEngine(ctx).ID(r.ID).Cols("revision_index").Update(r)
+	return err
+}
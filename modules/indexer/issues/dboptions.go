@@ -16,6 +16,8 @@ func ToSearchOptions(keyword string, opts *issues_model.IssuesOptions) *SearchOp
 		AllPublic: opts.AllPublic,
 		IsPull:    opts.IsPull,
 		IsClosed:  opts.IsClosed,
+
+		CustomFieldValues: opts.CustomFieldValues,
 	}
 
 	if len(opts.LabelIDs) == 1 && opts.LabelIDs[0] == 0 {
@@ -96,9 +98,13 @@ func ToSearchOptions(keyword string, opts *issues_model.IssuesOptions) *SearchOp
 		searchOpt.SortBy = SortByDeadlineDesc
 	case "priority", "priorityrepo", "project-column-sorting":
 		// Unsupported sort type for search
-		fallthrough
-	default:
 		searchOpt.SortBy = SortByUpdatedDesc
+	default:
+		if fieldID, desc, ok := issues_model.ParseCustomFieldSortType(opts.SortType); ok {
+			searchOpt.SortBy = SortByCustomField(fieldID, desc)
+		} else {
+			searchOpt.SortBy = SortByUpdatedDesc
+		}
 	}
 
 	return searchOpt
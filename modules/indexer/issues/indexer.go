@@ -268,6 +268,9 @@ func IsAvailable(ctx context.Context) bool {
 // SearchOptions indicates the options for searching issues
 type SearchOptions = internal.SearchOptions
 
+// SortBy indicates the order of the results of a search
+type SortBy = internal.SortBy
+
 const (
 	SortByCreatedDesc  = internal.SortByCreatedDesc
 	SortByUpdatedDesc  = internal.SortByUpdatedDesc
@@ -279,6 +282,11 @@ const (
 	SortByDeadlineAsc  = internal.SortByDeadlineAsc
 )
 
+// SortByCustomField returns the sort order by the value of a custom field
+func SortByCustomField(fieldID int64, desc bool) SortBy {
+	return internal.SortByCustomField(fieldID, desc)
+}
+
 // SearchIssues search issues by options.
 func SearchIssues(ctx context.Context, opts *SearchOptions) ([]int64, int64, error) {
 	indexer := *globalIndexer.Load()
@@ -291,6 +299,9 @@ func SearchIssues(ctx context.Context, opts *SearchOptions) ([]int64, int64, err
 		// Even worse, the external indexer like elastic search may not be available for a while,
 		// and the user may not be able to list issues completely until it is available again.
 		indexer = db.NewIndexer()
+	} else if _, _, ok := opts.SortBy.CustomField(); ok {
+		// The custom fields are not sortable in the other indexers.
+		indexer = db.NewIndexer()
 	}
 
 	result, err := indexer.Search(ctx, opts)
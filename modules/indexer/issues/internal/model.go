@@ -4,6 +4,9 @@
 package internal
 
 import (
+	"strconv"
+	"strings"
+
 	"code.gitea.io/gitea/models/db"
 	"code.gitea.io/gitea/modules/optional"
 	"code.gitea.io/gitea/modules/timeutil"
@@ -34,6 +37,7 @@ type IndexerData struct {
 	ReviewedIDs        []int64            `json:"reviewed_ids"`
 	ReviewRequestedIDs []int64            `json:"review_requested_ids"`
 	SubscriberIDs      []int64            `json:"subscriber_ids"`
+	CustomFieldValues  []string           `json:"custom_field_values"` // "<field id>:<normalized value>" of every custom field set on the issue
 	UpdatedUnix        timeutil.TimeStamp `json:"updated_unix"`
 
 	// Fields used for sorting
@@ -44,6 +48,11 @@ type IndexerData struct {
 	CommentCount int64              `json:"comment_count"`
 }
 
+// CustomFieldValueToken returns the representation of the normalized value of a custom field in IndexerData.CustomFieldValues
+func CustomFieldValueToken(fieldID int64, value string) string {
+	return strconv.FormatInt(fieldID, 10) + ":" + value
+}
+
 // Match represents on search result
 type Match struct {
 	ID    int64   `json:"id"`
@@ -103,6 +112,8 @@ type SearchOptions struct {
 
 	SubscriberID optional.Option[int64] // subscriber of the issues
 
+	CustomFieldValues map[int64]string // normalized values of the custom fields the issues have, by field ID
+
 	UpdatedAfterUnix  optional.Option[int64]
 	UpdatedBeforeUnix optional.Option[int64]
 
@@ -135,6 +146,8 @@ const (
 	SortByUpdatedAsc   SortBy = "updated_unix"
 	SortByCommentsAsc  SortBy = "comment_count"
 	SortByDeadlineAsc  SortBy = "deadline_unix"
+	// Sorting by a custom field is only supported by the db indexer, see SortByCustomField.
+	//
 	// Unsupported sort types which are supported by issues.IssuesOptions.SortType:
 	//
 	//  - "priorityrepo":
@@ -148,3 +161,29 @@ const (
 	//                    but what if the issue belongs to multiple projects?
 	//                    Since it's unsupported to search issues with keyword in project page, we don't need to support it.
 )
+
+const sortByCustomFieldPrefix = "custom_field."
+
+// SortByCustomField returns the sort order by the value of a custom field.
+// It's only supported by the db indexer, SearchIssues falls back to it when it's used.
+func SortByCustomField(fieldID int64, desc bool) SortBy {
+	s := SortBy(sortByCustomFieldPrefix + strconv.FormatInt(fieldID, 10))
+	if desc {
+		return "-" + s
+	}
+	return s
+}
+
+// CustomField returns the custom field and the direction of a sort order by a custom field
+func (s SortBy) CustomField() (fieldID int64, desc, ok bool) {
+	str, desc := strings.CutPrefix(string(s), "-")
+	idStr, found := strings.CutPrefix(str, sortByCustomFieldPrefix)
+	if !found {
+		return 0, false, false
+	}
+	fieldID, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return 0, false, false
+	}
+	return fieldID, desc, true
+}
@@ -513,6 +513,25 @@ var cases = []*testIndexerCase{
 			}), result.Total)
 		},
 	},
+	{
+		Name: "CustomFieldValues",
+		SearchOptions: &internal.SearchOptions{
+			Paginator: &db.ListOptions{
+				PageSize: 5,
+			},
+			CustomFieldValues: map[int64]string{1: `a "quoted" \ value`, 2: "1"},
+		},
+		Expected: func(t *testing.T, data map[int64]*internal.IndexerData, result *internal.SearchResult) {
+			assert.Len(t, result.Hits, 5)
+			for _, v := range result.Hits {
+				assert.Contains(t, data[v.ID].CustomFieldValues, `1:a "quoted" \ value`)
+				assert.Contains(t, data[v.ID].CustomFieldValues, "2:1")
+			}
+			assert.Equal(t, countIndexerData(data, func(v *internal.IndexerData) bool {
+				return slices.Contains(v.CustomFieldValues, `1:a "quoted" \ value`) && slices.Contains(v.CustomFieldValues, "2:1")
+			}), result.Total)
+		},
+	},
 	{
 		Name: "updated",
 		SearchOptions: &internal.SearchOptions{
@@ -707,6 +726,13 @@ func generateDefaultIndexerData() []*internal.IndexerData {
 				subscriberIDs[i] = int64(i) + 1 // SubscriberID should not be 0
 			}
 
+			customFieldValues := []string{
+				internal.CustomFieldValueToken(1, []string{"low", "high", `a "quoted" \ value`}[id%3]),
+			}
+			if id%4 != 0 {
+				customFieldValues = append(customFieldValues, internal.CustomFieldValueToken(2, fmt.Sprint(id%4)))
+			}
+
 			data = append(data, &internal.IndexerData{
 				ID:                 id,
 				RepoID:             repoID,
@@ -727,6 +753,7 @@ func generateDefaultIndexerData() []*internal.IndexerData {
 				ReviewedIDs:        reviewedIDs,
 				ReviewRequestedIDs: reviewRequestedIDs,
 				SubscriberIDs:      subscriberIDs,
+				CustomFieldValues:  customFieldValues,
 				UpdatedUnix:        timeutil.TimeStamp(id + issueIndex),
 				CreatedUnix:        timeutil.TimeStamp(id),
 				DeadlineUnix:       timeutil.TimeStamp(id + issueIndex + repoID),
@@ -0,0 +1,273 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"strings"
+	"time"
+	"unicode"
+
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// QueryUserMe is the value of a user qualifier which designates the user doing the search
+const QueryUserMe = "@me"
+
+const queryDateLayout = "2006-01-02"
+
+// Query is a structured issue search query, such as
+//
+//	is:open label:bug -label:wontfix assignee:@me updated:>2024-01-01 project:"Q3 plan" sort:updated-desc crash
+//
+// The names it contains (labels, milestones, projects and users) still have to be resolved
+// to build the SearchOptions of the query.
+type Query struct {
+	Keyword string
+
+	IsClosed optional.Option[bool]
+	IsPull   optional.Option[bool]
+
+	Labels         []string
+	ExcludedLabels []string
+	NoLabel        bool
+
+	Milestones  []string
+	NoMilestone bool
+
+	Project   string
+	NoProject bool
+
+	Assignee   string
+	NoAssignee bool
+
+	Author          string
+	Mention         string
+	ReviewRequested string
+	ReviewedBy      string
+
+	UpdatedAfterUnix  optional.Option[int64]
+	UpdatedBeforeUnix optional.Option[int64]
+
+	SortBy SortBy
+}
+
+// querySortTypes are the values of the sort qualifier
+var querySortTypes = map[string]SortBy{
+	"created":       SortByCreatedDesc,
+	"created-desc":  SortByCreatedDesc,
+	"created-asc":   SortByCreatedAsc,
+	"updated":       SortByUpdatedDesc,
+	"updated-desc":  SortByUpdatedDesc,
+	"updated-asc":   SortByUpdatedAsc,
+	"comments":      SortByCommentsDesc,
+	"comments-desc": SortByCommentsDesc,
+	"comments-asc":  SortByCommentsAsc,
+	"deadline":      SortByDeadlineAsc,
+	"deadline-asc":  SortByDeadlineAsc,
+	"deadline-desc": SortByDeadlineDesc,
+}
+
+// ParseQuery parses a structured issue search query. The words which are not qualifiers,
+// including the words with an unknown qualifier, make the keyword of the query.
+func ParseQuery(s string) (*Query, error) {
+	q := &Query{}
+	var keywords []string
+	for _, token := range splitQuery(s) {
+		if token.text == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(token.text, ":")
+		negated := strings.HasPrefix(key, "-")
+		key = strings.ToLower(strings.TrimPrefix(key, "-"))
+		if !ok || token.quotedKey || !isQueryQualifier(key) {
+			keywords = append(keywords, token.text)
+			continue
+		}
+		if value == "" {
+			return nil, util.NewInvalidArgumentErrorf("missing value of the qualifier %q", key)
+		}
+		if negated && key != "label" {
+			return nil, util.NewInvalidArgumentErrorf("the qualifier %q cannot be negated", key)
+		}
+		if err := q.apply(key, value, negated); err != nil {
+			return nil, err
+		}
+	}
+	q.Keyword = strings.Join(keywords, " ")
+	return q, nil
+}
+
+// HasFilters returns true if the query contains qualifiers
+func (q *Query) HasFilters() bool {
+	return q.IsClosed.Has() || q.IsPull.Has() ||
+		len(q.Labels) > 0 || len(q.ExcludedLabels) > 0 || q.NoLabel ||
+		len(q.Milestones) > 0 || q.NoMilestone ||
+		q.Project != "" || q.NoProject ||
+		q.Assignee != "" || q.NoAssignee ||
+		q.Author != "" || q.Mention != "" || q.ReviewRequested != "" || q.ReviewedBy != "" ||
+		q.UpdatedAfterUnix.Has() || q.UpdatedBeforeUnix.Has() ||
+		q.SortBy != ""
+}
+
+func isQueryQualifier(key string) bool {
+	switch key {
+	case "is", "no", "label", "milestone", "project", "assignee", "author", "mentions", "review-requested", "reviewed-by", "updated", "sort":
+		return true
+	}
+	return false
+}
+
+func (q *Query) apply(key, value string, negated bool) error {
+	switch key {
+	case "is":
+		switch strings.ToLower(value) {
+		case "open":
+			q.IsClosed = optional.Some(false)
+		case "closed":
+			q.IsClosed = optional.Some(true)
+		case "issue":
+			q.IsPull = optional.Some(false)
+		case "pr", "pull":
+			q.IsPull = optional.Some(true)
+		default:
+			return util.NewInvalidArgumentErrorf("invalid value %q of the qualifier is", value)
+		}
+	case "no":
+		switch strings.ToLower(value) {
+		case "label":
+			q.NoLabel = true
+		case "milestone":
+			q.NoMilestone = true
+		case "project":
+			q.NoProject = true
+		case "assignee":
+			q.NoAssignee = true
+		default:
+			return util.NewInvalidArgumentErrorf("invalid value %q of the qualifier no", value)
+		}
+	case "label":
+		if negated {
+			q.ExcludedLabels = append(q.ExcludedLabels, value)
+		} else {
+			q.Labels = append(q.Labels, value)
+		}
+	case "milestone":
+		q.Milestones = append(q.Milestones, value)
+	case "project":
+		q.Project = value
+	case "assignee":
+		q.Assignee = value
+	case "author":
+		q.Author = value
+	case "mentions":
+		q.Mention = value
+	case "review-requested":
+		q.ReviewRequested = value
+	case "reviewed-by":
+		q.ReviewedBy = value
+	case "updated":
+		return q.applyUpdated(value)
+	case "sort":
+		sortBy, ok := querySortTypes[strings.ToLower(value)]
+		if !ok {
+			return util.NewInvalidArgumentErrorf("invalid value %q of the qualifier sort", value)
+		}
+		q.SortBy = sortBy
+	}
+	return nil
+}
+
+// applyUpdated parses the value of the updated qualifier: a date, a comparison with a date
+// (">", ">=", "<", "<=") or a range of dates ("2024-01-01..2024-01-31"), the bounds being inclusive
+func (q *Query) applyUpdated(value string) error {
+	parse := func(s string) (time.Time, error) {
+		date, err := time.ParseInLocation(queryDateLayout, s, setting.DefaultUILocation)
+		if err != nil {
+			return date, util.NewInvalidArgumentErrorf("invalid date %q of the qualifier updated, the format is YYYY-MM-DD", s)
+		}
+		return date, nil
+	}
+	startOf := func(date time.Time) optional.Option[int64] {
+		return optional.Some(date.Unix())
+	}
+	endOf := func(date time.Time) optional.Option[int64] {
+		return optional.Some(date.AddDate(0, 0, 1).Unix() - 1)
+	}
+
+	var op string
+	for _, prefix := range []string{">=", "<=", ">", "<"} {
+		if strings.HasPrefix(value, prefix) {
+			op = prefix
+			value = value[len(prefix):]
+			break
+		}
+	}
+
+	if from, to, ok := strings.Cut(value, ".."); ok && op == "" {
+		fromDate, err := parse(from)
+		if err != nil {
+			return err
+		}
+		toDate, err := parse(to)
+		if err != nil {
+			return err
+		}
+		q.UpdatedAfterUnix, q.UpdatedBeforeUnix = startOf(fromDate), endOf(toDate)
+		return nil
+	}
+
+	date, err := parse(value)
+	if err != nil {
+		return err
+	}
+	switch op {
+	case ">":
+		q.UpdatedAfterUnix = startOf(date.AddDate(0, 0, 1))
+	case ">=":
+		q.UpdatedAfterUnix = startOf(date)
+	case "<":
+		q.UpdatedBeforeUnix = optional.Some(date.Unix() - 1)
+	case "<=":
+		q.UpdatedBeforeUnix = endOf(date)
+	default:
+		q.UpdatedAfterUnix, q.UpdatedBeforeUnix = startOf(date), endOf(date)
+	}
+	return nil
+}
+
+type queryToken struct {
+	text      string
+	quotedKey bool // the token starts with a quote, it cannot be a qualifier
+}
+
+// splitQuery splits a query into words separated by spaces, the double quotes grouping words and being removed
+func splitQuery(s string) []queryToken {
+	var tokens []queryToken
+	var current strings.Builder
+	inQuotes, inToken, quotedKey := false, false, false
+	for _, r := range s {
+		switch {
+		case r == '"':
+			if !inToken {
+				quotedKey = true
+			}
+			inQuotes, inToken = !inQuotes, true
+		case unicode.IsSpace(r) && !inQuotes:
+			if inToken {
+				tokens = append(tokens, queryToken{text: current.String(), quotedKey: quotedKey})
+				current.Reset()
+			}
+			inToken, quotedKey = false, false
+		default:
+			current.WriteRune(r)
+			inToken = true
+		}
+	}
+	if inToken {
+		tokens = append(tokens, queryToken{text: current.String(), quotedKey: quotedKey})
+	}
+	return tokens
+}
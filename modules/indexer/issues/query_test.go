@@ -0,0 +1,106 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQuery(t *testing.T) {
+	defer test.MockVariableValue(&setting.DefaultUILocation, time.UTC)()
+
+	t.Run("Qualifiers", func(t *testing.T) {
+		q, err := ParseQuery(`crash is:open label:bug -label:wontfix label:"good first issue" milestone:v1.0 project:"Q3 plan" assignee:@me author:user2 mentions:user4 review-requested:user5 reviewed-by:user1 sort:updated-asc on  start`)
+		require.NoError(t, err)
+		assert.Equal(t, &Query{
+			Keyword:         "crash on start",
+			IsClosed:        optional.Some(false),
+			Labels:          []string{"bug", "good first issue"},
+			ExcludedLabels:  []string{"wontfix"},
+			Milestones:      []string{"v1.0"},
+			Project:         "Q3 plan",
+			Assignee:        QueryUserMe,
+			Author:          "user2",
+			Mention:         "user4",
+			ReviewRequested: "user5",
+			ReviewedBy:      "user1",
+			SortBy:          SortByUpdatedAsc,
+		}, q)
+		assert.True(t, q.HasFilters())
+	})
+
+	t.Run("Keyword", func(t *testing.T) {
+		for query, keyword := range map[string]string{
+			"":                       "",
+			"crash":                  "crash",
+			"http://example.com":     "http://example.com",
+			"foo:bar baz":            "foo:bar baz",
+			`"label:bug" is a label`: "label:bug is a label",
+			`"exact phrase"`:         "exact phrase",
+		} {
+			q, err := ParseQuery(query)
+			require.NoError(t, err, query)
+			assert.Equal(t, keyword, q.Keyword, query)
+			assert.False(t, q.HasFilters(), query)
+		}
+	})
+
+	t.Run("Is and no", func(t *testing.T) {
+		q, err := ParseQuery("is:closed is:pr no:label no:milestone no:project no:assignee")
+		require.NoError(t, err)
+		assert.Equal(t, optional.Some(true), q.IsClosed)
+		assert.Equal(t, optional.Some(true), q.IsPull)
+		assert.True(t, q.NoLabel)
+		assert.True(t, q.NoMilestone)
+		assert.True(t, q.NoProject)
+		assert.True(t, q.NoAssignee)
+
+		q, err = ParseQuery("IS:Issue")
+		require.NoError(t, err)
+		assert.Equal(t, optional.Some(false), q.IsPull)
+	})
+
+	t.Run("Updated", func(t *testing.T) {
+		day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC).Unix()
+		const oneDay = 24 * 60 * 60
+		for value, expected := range map[string][2]optional.Option[int64]{
+			"2024-03-01":             {optional.Some(day), optional.Some(day + oneDay - 1)},
+			">2024-03-01":            {optional.Some(day + oneDay), optional.None[int64]()},
+			">=2024-03-01":           {optional.Some(day), optional.None[int64]()},
+			"<2024-03-01":            {optional.None[int64](), optional.Some(day - 1)},
+			"<=2024-03-01":           {optional.None[int64](), optional.Some(day + oneDay - 1)},
+			"2024-03-01..2024-03-02": {optional.Some(day), optional.Some(day + 2*oneDay - 1)},
+		} {
+			q, err := ParseQuery("updated:" + value)
+			require.NoError(t, err, value)
+			assert.Equal(t, expected[0], q.UpdatedAfterUnix, value)
+			assert.Equal(t, expected[1], q.UpdatedBeforeUnix, value)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, query := range []string{
+			"is:draft",
+			"no:reviewer",
+			"label:",
+			"-author:user2",
+			"sort:stars",
+			"updated:yesterday",
+			"updated:>2024-03-01..2024-03-02",
+			"updated:2024-03-01..",
+		} {
+			_, err := ParseQuery(query)
+			assert.ErrorIs(t, err, util.ErrInvalidArgument, query)
+		}
+	})
+}
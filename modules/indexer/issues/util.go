@@ -87,6 +87,15 @@ func getIssueIndexerData(ctx context.Context, issueID int64) (*internal.IndexerD
 		return nil, false, err
 	}
 
+	customFieldValues, err := issue_model.GetIssueCustomFieldValues(ctx, issue.ID)
+	if err != nil {
+		return nil, false, err
+	}
+	customFieldTokens := make([]string, 0, len(customFieldValues))
+	for _, v := range customFieldValues {
+		customFieldTokens = append(customFieldTokens, internal.CustomFieldValueToken(v.FieldID, v.Value))
+	}
+
 	var projectID int64
 	if issue.Project != nil {
 		projectID = issue.Project.ID
@@ -112,6 +121,7 @@ func getIssueIndexerData(ctx context.Context, issueID int64) (*internal.IndexerD
 		ReviewedIDs:        reviewedIDs,
 		ReviewRequestedIDs: reviewRequestedIDs,
 		SubscriberIDs:      subscriberIDs,
+		CustomFieldValues:  customFieldTokens,
 		UpdatedUnix:        issue.UpdatedUnix,
 		CreatedUnix:        issue.CreatedUnix,
 		DeadlineUnix:       issue.DeadlineUnix,
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/url"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -309,6 +310,88 @@ func RenderToMarkdown(template *api.IssueTemplate, values url.Values) string {
 	return builder.String()
 }
 
+// ToAnswers returns the answers to the fields of template with specified values, except the markdown fields
+func ToAnswers(template *api.IssueTemplate, values url.Values) []*api.IssueFormAnswer {
+	answers := make([]*api.IssueFormAnswer, 0, len(template.Fields))
+
+	for _, field := range template.Fields {
+		f := &valuedField{
+			IssueFormField: field,
+			Values:         values,
+		}
+		if f.ID == "" || f.Type == api.IssueFormFieldTypeMarkdown {
+			continue
+		}
+
+		answer := &api.IssueFormAnswer{
+			ID:    f.ID,
+			Type:  f.Type,
+			Label: f.Label(),
+		}
+		switch f.Type {
+		case api.IssueFormFieldTypeInput, api.IssueFormFieldTypeTextarea:
+			answer.Value = f.Value()
+		case api.IssueFormFieldTypeDropdown, api.IssueFormFieldTypeCheckboxes:
+			for _, option := range f.Options() {
+				if option.IsChecked() {
+					answer.Options = append(answer.Options, option.Label())
+				}
+			}
+		}
+		answers = append(answers, answer)
+	}
+
+	return answers
+}
+
+// ToValues returns the values of the fields of template as posted by the issue form, given the answers to them
+// by field ID. The options of the answers are the labels of the selected options.
+func ToValues(template *api.IssueTemplate, answers []*api.IssueFormAnswer) (url.Values, error) {
+	answerByID := make(map[string]*api.IssueFormAnswer, len(answers))
+	for _, answer := range answers {
+		answerByID[answer.ID] = answer
+	}
+
+	values := url.Values{}
+	for _, field := range template.Fields {
+		answer, ok := answerByID[field.ID]
+		if field.ID == "" || !ok {
+			continue
+		}
+		delete(answerByID, field.ID)
+
+		f := &valuedField{IssueFormField: field}
+		switch field.Type {
+		case api.IssueFormFieldTypeInput, api.IssueFormFieldTypeTextarea:
+			values.Set("form-field-"+field.ID, answer.Value)
+		case api.IssueFormFieldTypeDropdown, api.IssueFormFieldTypeCheckboxes:
+			options := f.Options()
+			indexes := make([]string, 0, len(answer.Options))
+			for _, label := range answer.Options {
+				index := slices.IndexFunc(options, func(option *valuedOption) bool { return option.Label() == label })
+				if index < 0 {
+					return nil, fmt.Errorf("field %q has no option %q", field.ID, label)
+				}
+				if field.Type == api.IssueFormFieldTypeCheckboxes {
+					values.Set(fmt.Sprintf("form-field-%s-%d", field.ID, index), "on")
+				} else {
+					indexes = append(indexes, strconv.Itoa(index))
+				}
+			}
+			if field.Type == api.IssueFormFieldTypeDropdown {
+				values.Set("form-field-"+field.ID, strings.Join(indexes, ","))
+			}
+		default:
+			return nil, fmt.Errorf("field %q cannot be answered", field.ID)
+		}
+	}
+
+	for id := range answerByID {
+		return nil, fmt.Errorf("unknown field %q", id)
+	}
+	return values, nil
+}
+
 type valuedField struct {
 	*api.IssueFormField
 	url.Values
@@ -961,3 +961,66 @@ func Test_minQuotes(t *testing.T) {
 		})
 	}
 }
+
+func TestAnswers(t *testing.T) {
+	template, err := Unmarshal("test.yaml", []byte(`
+name: Name
+about: About
+body:
+  - type: markdown
+    attributes:
+      value: Value of the markdown
+  - type: input
+    id: version
+    attributes:
+      label: Version
+  - type: textarea
+    id: description
+    attributes:
+      label: Description
+  - type: dropdown
+    id: os
+    attributes:
+      label: OS
+      multiple: true
+      options:
+        - Linux
+        - Windows
+        - macOS
+  - type: checkboxes
+    id: terms
+    attributes:
+      label: Terms
+      options:
+        - label: Code of conduct
+        - label: Searched
+`))
+	require.NoError(t, err)
+
+	values := url.Values{
+		"form-field-version":     {" 1.2 "},
+		"form-field-description": {"It breaks"},
+		"form-field-os":          {"0,2"},
+		"form-field-terms-1":     {"on"},
+	}
+	answers := ToAnswers(template, values)
+	assert.Equal(t, []*api.IssueFormAnswer{
+		{ID: "version", Type: api.IssueFormFieldTypeInput, Label: "Version", Value: "1.2"},
+		{ID: "description", Type: api.IssueFormFieldTypeTextarea, Label: "Description", Value: "It breaks"},
+		{ID: "os", Type: api.IssueFormFieldTypeDropdown, Label: "OS", Options: []string{"Linux", "macOS"}},
+		{ID: "terms", Type: api.IssueFormFieldTypeCheckboxes, Label: "Terms", Options: []string{"Searched"}},
+	}, answers)
+
+	// the values posted by the web form are found back from the answers
+	got, err := ToValues(template, answers)
+	require.NoError(t, err)
+	assert.Equal(t, RenderToMarkdown(template, values), RenderToMarkdown(template, got))
+	assert.Equal(t, "0,2", got.Get("form-field-os"))
+	assert.Equal(t, "on", got.Get("form-field-terms-1"))
+	assert.Empty(t, got.Get("form-field-terms-0"))
+
+	_, err = ToValues(template, []*api.IssueFormAnswer{{ID: "unknown", Value: "value"}})
+	require.ErrorContains(t, err, `unknown field "unknown"`)
+	_, err = ToValues(template, []*api.IssueFormAnswer{{ID: "os", Options: []string{"BSD"}}})
+	require.ErrorContains(t, err, `field "os" has no option "BSD"`)
+}
@@ -0,0 +1,68 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import "strings"
+
+// ClientOther is the client family of unknown user agents
+const ClientOther = "other"
+
+// clientFamilies maps user agent prefixes to client families. The order matters because
+// some clients include the name of other tools in their user agent.
+var clientFamilies = []struct {
+	prefix string
+	family string
+}{
+	{"npm/", "npm"},
+	{"pnpm/", "pnpm"},
+	{"yarn/", "yarn"},
+	{"bun/", "bun"},
+	{"pip/", "pip"},
+	{"uv/", "uv"},
+	{"poetry/", "poetry"},
+	{"twine/", "twine"},
+	{"apache-maven/", "maven"},
+	{"gradle/", "gradle"},
+	{"cargo/", "cargo"},
+	{"composer/", "composer"},
+	{"nuget", "nuget"},
+	{"dotnet", "nuget"},
+	{"docker/", "docker"},
+	{"containerd/", "containerd"},
+	{"podman/", "podman"},
+	{"skopeo/", "skopeo"},
+	{"buildkit/", "buildkit"},
+	{"helm/", "helm"},
+	{"go-http-client/", "go"},
+	{"go/", "go"},
+	{"conan/", "conan"},
+	{"conda/", "conda"},
+	{"bundler/", "bundler"},
+	{"rubygems/", "rubygems"},
+	{"ruby", "rubygems"},
+	{"dart pub", "pub"},
+	{"swiftpackagemanager", "swift"},
+	{"vagrant/", "vagrant"},
+	{"apk-tools/", "apk"},
+	{"debian apt-http/", "apt"},
+	{"libdnf", "dnf"},
+	{"urlgrabber/", "yum"},
+	{"pacman/", "pacman"},
+	{"r (", "r"},
+	{"chef ", "chef"},
+	{"curl/", "curl"},
+	{"wget/", "wget"},
+	{"mozilla/", "browser"},
+}
+
+// ClientFamily returns the client family of a user agent like "npm" or "docker"
+func ClientFamily(userAgent string) string {
+	ua := strings.ToLower(strings.TrimSpace(userAgent))
+	for _, cf := range clientFamilies {
+		if strings.HasPrefix(ua, cf.prefix) {
+			return cf.family
+		}
+	}
+	return ClientOther
+}
@@ -0,0 +1,30 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientFamily(t *testing.T) {
+	cases := map[string]string{
+		"npm/10.2.4 node/v20.11.0 linux x64 workspaces/false":                 "npm",
+		"pip/24.0 {\"ci\":null,\"cpu\":\"x86_64\"}":                           "pip",
+		"Apache-Maven/3.9.6 (Java 21.0.2; Linux 6.6.15)":                      "maven",
+		"cargo/1.76.0 (c84b36747 2024-01-18)":                                 "cargo",
+		"Composer/2.7.1 (Linux; 6.6.15; PHP 8.3.2; cURL 8.6.0)":               "composer",
+		"docker/25.0.3 go/go1.21.6 git-commit/f417435 kernel/6.6.15 os/linux": "docker",
+		"Helm/3.14.2":                 "helm",
+		"Debian APT-HTTP/1.3 (2.6.1)": "apt",
+		"curl/8.6.0":                  "curl",
+		"Mozilla/5.0 (X11; Linux x86_64; rv:123.0) Gecko/20100101 Firefox/123.0": "browser",
+		"":                     ClientOther,
+		"SomethingUnknown/1.0": ClientOther,
+	}
+	for ua, expected := range cases {
+		assert.Equal(t, expected, ClientFamily(ua), ua)
+	}
+}
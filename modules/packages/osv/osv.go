@@ -0,0 +1,225 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package osv
+
+import (
+	"archive/zip"
+	"io"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/hashicorp/go-version"
+)
+
+var ErrInvalidDatabase = util.SilentWrap{Message: "OSV database is invalid", Err: util.ErrInvalidArgument}
+
+// Range event and range types of the OSV schema
+// https://ossf.github.io/osv-schema/
+const (
+	RangeTypeSemver    = "SEMVER"
+	RangeTypeEcosystem = "ECOSYSTEM"
+	RangeTypeGit       = "GIT"
+)
+
+// Vulnerability is an entry of an OSV database
+type Vulnerability struct {
+	ID               string      `json:"id"`
+	Modified         string      `json:"modified"`
+	Published        string      `json:"published"`
+	Withdrawn        string      `json:"withdrawn"`
+	Aliases          []string    `json:"aliases"`
+	Summary          string      `json:"summary"`
+	Details          string      `json:"details"`
+	Severity         []*Severity `json:"severity"`
+	Affected         []*Affected `json:"affected"`
+	DatabaseSpecific struct {
+		Severity string `json:"severity"`
+	} `json:"database_specific"`
+}
+
+// Severity is a severity score of a vulnerability
+type Severity struct {
+	Type  string `json:"type"`
+	Score string `json:"score"`
+}
+
+// Affected describes the versions of a package affected by a vulnerability
+type Affected struct {
+	Package struct {
+		Ecosystem string `json:"ecosystem"`
+		Name      string `json:"name"`
+		Purl      string `json:"purl"`
+	} `json:"package"`
+	Ranges   []*Range `json:"ranges"`
+	Versions []string `json:"versions"`
+}
+
+// Range is a range of affected versions described by events
+type Range struct {
+	Type   string   `json:"type"`
+	Events []*Event `json:"events"`
+}
+
+// Event introduces or ends a range of affected versions
+type Event struct {
+	Introduced   string `json:"introduced,omitempty"`
+	Fixed        string `json:"fixed,omitempty"`
+	LastAffected string `json:"last_affected,omitempty"`
+	Limit        string `json:"limit,omitempty"`
+}
+
+// SeverityName returns the qualitative severity if provided by the database or else the first severity score
+func (v *Vulnerability) SeverityName() string {
+	if v.DatabaseSpecific.Severity != "" {
+		return strings.ToUpper(v.DatabaseSpecific.Severity)
+	}
+	if len(v.Severity) > 0 {
+		return v.Severity[0].Score
+	}
+	return ""
+}
+
+// AffectedPackages returns the affected packages of the entry. An entry may describe several blocks of versions
+// of the same package, for example one block per major version, which are merged in a single one.
+func (v *Vulnerability) AffectedPackages() []*Affected {
+	merged := make([]*Affected, 0, len(v.Affected))
+	byPackage := make(map[string]*Affected, len(v.Affected))
+	for _, affected := range v.Affected {
+		if affected.Package.Ecosystem == "" || affected.Package.Name == "" {
+			continue
+		}
+		key := affected.Package.Ecosystem + "/" + strings.ToLower(affected.Package.Name)
+		if m, ok := byPackage[key]; ok {
+			m.Ranges = append(m.Ranges, affected.Ranges...)
+			m.Versions = append(m.Versions, affected.Versions...)
+			continue
+		}
+		m := &Affected{
+			Package:  affected.Package,
+			Ranges:   slices.Clone(affected.Ranges),
+			Versions: slices.Clone(affected.Versions),
+		}
+		byPackage[key] = m
+		merged = append(merged, m)
+	}
+	return merged
+}
+
+// ParseVulnerability parses a single OSV entry
+func ParseVulnerability(r io.Reader) (*Vulnerability, error) {
+	var v *Vulnerability
+	if err := json.NewDecoder(r).Decode(&v); err != nil {
+		return nil, ErrInvalidDatabase
+	}
+	if v == nil || v.ID == "" {
+		return nil, ErrInvalidDatabase
+	}
+	return v, nil
+}
+
+// ReadDatabase reads a zip archive of OSV entries as published by osv.dev and calls fn for every entry.
+// Withdrawn entries are skipped.
+func ReadDatabase(r io.ReaderAt, size int64, fn func(*Vulnerability) error) error {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return ErrInvalidDatabase
+	}
+
+	for _, file := range zr.File {
+		if file.FileInfo().IsDir() || !strings.EqualFold(path.Ext(file.Name), ".json") {
+			continue
+		}
+
+		f, err := file.Open()
+		if err != nil {
+			return err
+		}
+		v, err := ParseVulnerability(f)
+		f.Close()
+		if err != nil {
+			return err
+		}
+
+		if v.Withdrawn != "" {
+			continue
+		}
+
+		if err := fn(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IsAffected checks if the version is affected. Git ranges are ignored because they describe commits.
+func (a *Affected) IsAffected(v string) bool {
+	for _, affected := range a.Versions {
+		if affected == v {
+			return true
+		}
+	}
+
+	parsed, err := version.NewVersion(v)
+	if err != nil {
+		return false
+	}
+
+	for _, r := range a.Ranges {
+		if r.Type == RangeTypeGit {
+			continue
+		}
+		if r.isAffected(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+type parsedEvent struct {
+	Event   *Event
+	Version *version.Version
+}
+
+func (r *Range) isAffected(v *version.Version) bool {
+	events := make([]*parsedEvent, 0, len(r.Events))
+	for _, e := range r.Events {
+		raw := e.Introduced + e.Fixed + e.LastAffected + e.Limit
+		if e.Introduced == "0" {
+			raw = "0.0.0"
+		}
+		ev, err := version.NewVersion(raw)
+		if err != nil {
+			continue
+		}
+		events = append(events, &parsedEvent{e, ev})
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Version.LessThan(events[j].Version)
+	})
+
+	affected := false
+	for _, e := range events {
+		switch {
+		case e.Event.Introduced != "":
+			if v.GreaterThanOrEqual(e.Version) {
+				affected = true
+			}
+		case e.Event.Fixed != "", e.Event.Limit != "":
+			if v.GreaterThanOrEqual(e.Version) {
+				affected = false
+			}
+		case e.Event.LastAffected != "":
+			if v.GreaterThan(e.Version) {
+				affected = false
+			}
+		}
+	}
+	return affected
+}
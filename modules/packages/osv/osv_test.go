@@ -0,0 +1,128 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package osv
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const entry = `{
+  "id": "GHSA-test-0001",
+  "modified": "2024-01-01T00:00:00Z",
+  "aliases": ["CVE-2024-0001"],
+  "summary": "Prototype pollution",
+  "affected": [{
+    "package": {"ecosystem": "npm", "name": "lodash"},
+    "ranges": [{"type": "SEMVER", "events": [{"introduced": "0"}, {"fixed": "4.17.12"}]}]
+  }, {
+    "package": {"ecosystem": "npm", "name": "lodash-es"},
+    "ranges": [{"type": "SEMVER", "events": [{"introduced": "2.0.0"}, {"last_affected": "2.1.0"}]}],
+    "versions": ["1.0.0-beta"]
+  }],
+  "database_specific": {"severity": "high"}
+}`
+
+func TestParseVulnerability(t *testing.T) {
+	v, err := ParseVulnerability(strings.NewReader(entry))
+	require.NoError(t, err)
+	assert.Equal(t, "GHSA-test-0001", v.ID)
+	assert.Equal(t, []string{"CVE-2024-0001"}, v.Aliases)
+	assert.Equal(t, "HIGH", v.SeverityName())
+	assert.Len(t, v.Affected, 2)
+
+	_, err = ParseVulnerability(strings.NewReader(`{}`))
+	assert.ErrorIs(t, err, ErrInvalidDatabase)
+}
+
+func TestIsAffected(t *testing.T) {
+	v, err := ParseVulnerability(strings.NewReader(entry))
+	require.NoError(t, err)
+
+	cases := []struct {
+		Affected *Affected
+		Version  string
+		Expected bool
+	}{
+		{v.Affected[0], "1.0.0", true},
+		{v.Affected[0], "4.17.11", true},
+		{v.Affected[0], "4.17.12", false},
+		{v.Affected[0], "5.0.0", false},
+		{v.Affected[1], "1.9.0", false},
+		{v.Affected[1], "2.0.0", true},
+		{v.Affected[1], "2.1.0", true},
+		{v.Affected[1], "2.1.1", false},
+		{v.Affected[1], "1.0.0-beta", true},
+		{v.Affected[1], "invalid", false},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.Expected, c.Affected.IsAffected(c.Version), "%s@%s", c.Affected.Package.Name, c.Version)
+	}
+}
+
+func TestAffectedPackages(t *testing.T) {
+	v, err := ParseVulnerability(strings.NewReader(`{
+  "id": "GHSA-test-0003",
+  "affected": [{
+    "package": {"ecosystem": "npm", "name": "lodash"},
+    "ranges": [{"type": "SEMVER", "events": [{"introduced": "1.0.0"}, {"fixed": "1.2.0"}]}]
+  }, {
+    "package": {"ecosystem": "npm", "name": "lodash-es"},
+    "versions": ["1.0.0"]
+  }, {
+    "package": {"ecosystem": "npm", "name": "Lodash"},
+    "ranges": [{"type": "SEMVER", "events": [{"introduced": "2.0.0"}, {"fixed": "2.3.0"}]}],
+    "versions": ["3.0.0-beta"]
+  }, {
+    "package": {"ecosystem": "npm", "name": ""}
+  }]
+}`))
+	require.NoError(t, err)
+
+	affected := v.AffectedPackages()
+	require.Len(t, affected, 2)
+	assert.Equal(t, "lodash", affected[0].Package.Name)
+	assert.Len(t, affected[0].Ranges, 2)
+	assert.Equal(t, []string{"3.0.0-beta"}, affected[0].Versions)
+	assert.Equal(t, "lodash-es", affected[1].Package.Name)
+
+	// the versions of every block are affected
+	for version, expected := range map[string]bool{"1.1.0": true, "1.5.0": false, "2.2.0": true, "2.3.0": false, "3.0.0-beta": true} {
+		assert.Equal(t, expected, affected[0].IsAffected(version), version)
+	}
+	// the blocks of the entry are not modified
+	assert.Len(t, v.Affected[0].Ranges, 1)
+}
+
+func TestReadDatabase(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range map[string]string{
+		"GHSA-test-0001.json": entry,
+		"GHSA-test-0002.json": `{"id": "GHSA-test-0002", "withdrawn": "2024-01-02T00:00:00Z"}`,
+		"README.md":           "not an entry",
+	} {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+
+	ids := make([]string, 0, 1)
+	err := ReadDatabase(bytes.NewReader(buf.Bytes()), int64(buf.Len()), func(v *Vulnerability) error {
+		ids = append(ids, v.ID)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"GHSA-test-0001"}, ids)
+
+	err = ReadDatabase(strings.NewReader("invalid"), 7, func(*Vulnerability) error { return nil })
+	assert.ErrorIs(t, err, ErrInvalidDatabase)
+}
@@ -0,0 +1,196 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package sbom
+
+import (
+	"io"
+	"net/url"
+	"strings"
+
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/util"
+)
+
+var (
+	ErrInvalidSBOM       = util.SilentWrap{Message: "SBOM is invalid", Err: util.ErrInvalidArgument}
+	ErrUnsupportedFormat = util.SilentWrap{Message: "only CycloneDX and SPDX JSON documents are supported", Err: util.ErrInvalidArgument}
+	ErrInvalidPackageURL = util.SilentWrap{Message: "package URL is invalid", Err: util.ErrInvalidArgument}
+)
+
+// Format is the format of a SBOM document
+type Format string
+
+const (
+	FormatCycloneDX Format = "cyclonedx"
+	FormatSPDX      Format = "spdx"
+)
+
+// SBOM is a software bill of materials
+type SBOM struct {
+	Format     Format
+	Components []*Component
+}
+
+// Component is a software component listed in a SBOM
+type Component struct {
+	Name     string
+	Version  string
+	PURL     string
+	Licenses []string
+}
+
+type document struct {
+	// CycloneDX
+	BomFormat  string                `json:"bomFormat"`
+	Components []*cycloneDXComponent `json:"components"`
+	// SPDX
+	SPDXVersion string         `json:"spdxVersion"`
+	Packages    []*spdxPackage `json:"packages"`
+}
+
+type cycloneDXComponent struct {
+	Name     string `json:"name"`
+	Group    string `json:"group"`
+	Version  string `json:"version"`
+	PURL     string `json:"purl"`
+	Licenses []struct {
+		License struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"license"`
+		Expression string `json:"expression"`
+	} `json:"licenses"`
+	Components []*cycloneDXComponent `json:"components"`
+}
+
+type spdxPackage struct {
+	Name             string `json:"name"`
+	VersionInfo      string `json:"versionInfo"`
+	LicenseConcluded string `json:"licenseConcluded"`
+	LicenseDeclared  string `json:"licenseDeclared"`
+	ExternalRefs     []struct {
+		ReferenceType    string `json:"referenceType"`
+		ReferenceLocator string `json:"referenceLocator"`
+	} `json:"externalRefs"`
+}
+
+// ParseSBOM parses a CycloneDX or SPDX document in JSON format
+func ParseSBOM(r io.Reader) (*SBOM, error) {
+	var doc document
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, ErrInvalidSBOM
+	}
+
+	switch {
+	case doc.BomFormat == "CycloneDX":
+		s := &SBOM{Format: FormatCycloneDX}
+		addCycloneDXComponents(s, doc.Components)
+		return s, nil
+	case strings.HasPrefix(doc.SPDXVersion, "SPDX-"):
+		s := &SBOM{Format: FormatSPDX}
+		for _, p := range doc.Packages {
+			c := &Component{
+				Name:    p.Name,
+				Version: p.VersionInfo,
+			}
+			for _, ref := range p.ExternalRefs {
+				if ref.ReferenceType == "purl" {
+					c.PURL = ref.ReferenceLocator
+					break
+				}
+			}
+			if license := spdxLicense(p.LicenseConcluded); license != "" {
+				c.Licenses = []string{license}
+			} else if license := spdxLicense(p.LicenseDeclared); license != "" {
+				c.Licenses = []string{license}
+			}
+			s.Components = append(s.Components, c)
+		}
+		return s, nil
+	}
+	return nil, ErrUnsupportedFormat
+}
+
+func addCycloneDXComponents(s *SBOM, components []*cycloneDXComponent) {
+	for _, cc := range components {
+		c := &Component{
+			Name:    cc.Name,
+			Version: cc.Version,
+			PURL:    cc.PURL,
+		}
+		if cc.Group != "" {
+			c.Name = cc.Group + "/" + cc.Name
+		}
+		for _, l := range cc.Licenses {
+			switch {
+			case l.Expression != "":
+				c.Licenses = append(c.Licenses, l.Expression)
+			case l.License.ID != "":
+				c.Licenses = append(c.Licenses, l.License.ID)
+			case l.License.Name != "":
+				c.Licenses = append(c.Licenses, l.License.Name)
+			}
+		}
+		s.Components = append(s.Components, c)
+
+		addCycloneDXComponents(s, cc.Components)
+	}
+}
+
+func spdxLicense(license string) string {
+	switch license {
+	case "", "NOASSERTION", "NONE":
+		return ""
+	}
+	return license
+}
+
+// PackageURL is a parsed package URL
+// https://github.com/package-url/purl-spec
+type PackageURL struct {
+	Type      string
+	Namespace string
+	Name      string
+	Version   string
+}
+
+// ParsePackageURL parses a package URL. Qualifiers and subpath are ignored.
+func ParsePackageURL(s string) (*PackageURL, error) {
+	rest, found := strings.CutPrefix(s, "pkg:")
+	if !found {
+		return nil, ErrInvalidPackageURL
+	}
+
+	if i := strings.IndexAny(rest, "?#"); i != -1 {
+		rest = rest[:i]
+	}
+	rest = strings.Trim(rest, "/")
+
+	purl := &PackageURL{}
+	if i := strings.LastIndex(rest, "@"); i != -1 && i > strings.LastIndex(rest, "/") {
+		version, err := url.PathUnescape(rest[i+1:])
+		if err != nil {
+			return nil, ErrInvalidPackageURL
+		}
+		purl.Version = version
+		rest = rest[:i]
+	}
+
+	parts := strings.Split(rest, "/")
+	if len(parts) < 2 {
+		return nil, ErrInvalidPackageURL
+	}
+	for i, part := range parts {
+		unescaped, err := url.PathUnescape(part)
+		if err != nil || unescaped == "" {
+			return nil, ErrInvalidPackageURL
+		}
+		parts[i] = unescaped
+	}
+
+	purl.Type = strings.ToLower(parts[0])
+	purl.Name = parts[len(parts)-1]
+	purl.Namespace = strings.Join(parts[1:len(parts)-1], "/")
+	return purl, nil
+}
@@ -0,0 +1,78 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package sbom
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSBOM(t *testing.T) {
+	t.Run("CycloneDX", func(t *testing.T) {
+		s, err := ParseSBOM(strings.NewReader(`{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "components": [{
+    "name": "lodash",
+    "version": "4.17.11",
+    "purl": "pkg:npm/lodash@4.17.11",
+    "licenses": [{"license": {"id": "MIT"}}],
+    "components": [{"name": "nested", "version": "1.0.0", "licenses": [{"expression": "MIT OR Apache-2.0"}]}]
+  }]
+}`))
+		require.NoError(t, err)
+		assert.Equal(t, FormatCycloneDX, s.Format)
+		require.Len(t, s.Components, 2)
+		assert.Equal(t, &Component{Name: "lodash", Version: "4.17.11", PURL: "pkg:npm/lodash@4.17.11", Licenses: []string{"MIT"}}, s.Components[0])
+		assert.Equal(t, []string{"MIT OR Apache-2.0"}, s.Components[1].Licenses)
+	})
+
+	t.Run("SPDX", func(t *testing.T) {
+		s, err := ParseSBOM(strings.NewReader(`{
+  "spdxVersion": "SPDX-2.3",
+  "packages": [{
+    "name": "requests",
+    "versionInfo": "2.31.0",
+    "licenseConcluded": "NOASSERTION",
+    "licenseDeclared": "Apache-2.0",
+    "externalRefs": [{"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:pypi/requests@2.31.0"}]
+  }]
+}`))
+		require.NoError(t, err)
+		assert.Equal(t, FormatSPDX, s.Format)
+		require.Len(t, s.Components, 1)
+		assert.Equal(t, &Component{Name: "requests", Version: "2.31.0", PURL: "pkg:pypi/requests@2.31.0", Licenses: []string{"Apache-2.0"}}, s.Components[0])
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := ParseSBOM(strings.NewReader(`{"name": "unknown"}`))
+		assert.ErrorIs(t, err, ErrUnsupportedFormat)
+
+		_, err = ParseSBOM(strings.NewReader(`invalid`))
+		assert.ErrorIs(t, err, ErrInvalidSBOM)
+	})
+}
+
+func TestParsePackageURL(t *testing.T) {
+	cases := map[string]*PackageURL{
+		"pkg:npm/lodash@4.17.11":                                 {Type: "npm", Name: "lodash", Version: "4.17.11"},
+		"pkg:npm/%40angular/core@17.0.0":                         {Type: "npm", Namespace: "@angular", Name: "core", Version: "17.0.0"},
+		"pkg:maven/org.apache.commons/commons-text@1.9?type=jar": {Type: "maven", Namespace: "org.apache.commons", Name: "commons-text", Version: "1.9"},
+		"pkg:golang/github.com/gorilla/mux@v1.8.0":               {Type: "golang", Namespace: "github.com/gorilla", Name: "mux", Version: "v1.8.0"},
+		"pkg:pypi/requests":                                      {Type: "pypi", Name: "requests"},
+	}
+	for s, expected := range cases {
+		purl, err := ParsePackageURL(s)
+		require.NoError(t, err, s)
+		assert.Equal(t, expected, purl, s)
+	}
+
+	for _, s := range []string{"npm/lodash", "pkg:npm", "pkg:npm/"} {
+		_, err := ParsePackageURL(s)
+		assert.ErrorIs(t, err, ErrInvalidPackageURL, s)
+	}
+}
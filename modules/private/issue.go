@@ -0,0 +1,41 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package private
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/structs"
+)
+
+// BulkEditIssuesOptions represents the edition of the issues of a repository matching a search query,
+// naming the labels, the milestone and the users
+type BulkEditIssuesOptions struct {
+	Doer            string
+	Query           string
+	AddLabels       []string
+	RemoveLabels    []string
+	Milestone       string
+	RemoveMilestone bool
+	AddAssignees    []string
+	RemoveAssignees []string
+	State           string
+}
+
+// BulkEditIssues calls the internal BulkEditIssues function
+func BulkEditIssues(ctx context.Context, ownerName, repoName string, opts BulkEditIssuesOptions) (*structs.BulkEditIssuesTask, ResponseExtra) {
+	reqURL := setting.LocalURL + fmt.Sprintf("api/internal/repos/%s/%s/issues/bulk_edit", url.PathEscape(ownerName), url.PathEscape(repoName))
+	req := newInternalRequest(ctx, reqURL, "POST", opts)
+	return requestJSONResp(req, &structs.BulkEditIssuesTask{})
+}
+
+// GetBulkEditIssuesTask calls the internal GetBulkEditIssuesTask function
+func GetBulkEditIssuesTask(ctx context.Context, ownerName, repoName string, id int64) (*structs.BulkEditIssuesTask, ResponseExtra) {
+	reqURL := setting.LocalURL + fmt.Sprintf("api/internal/repos/%s/%s/issues/bulk_edit/%d", url.PathEscape(ownerName), url.PathEscape(repoName), id)
+	req := newInternalRequest(ctx, reqURL, "GET")
+	return requestJSONResp(req, &structs.BulkEditIssuesTask{})
+}
@@ -329,6 +329,37 @@ func FindAllIssueReferences(content string) []IssueReference {
 	return rawToIssueReferenceList(findAllIssueReferencesBytes(contentBytes, []string{}))
 }
 
+// QualifyIssueReferences rewrites the references to issues of the same repository found in a string,
+// such as #123, into references to the issues of the given repository, such as owner/name#123.
+func QualifyIssueReferences(content, owner, name string) string {
+	contentBytes := []byte(content)
+	var sb strings.Builder
+	last, pos, found := 0, 0, false
+	for {
+		match := issueNumericPattern.FindSubmatchIndex(contentBytes[pos:])
+		if match == nil {
+			break
+		}
+		if ref := getCrossReference(contentBytes, match[2]+pos, match[3]+pos, false, false); ref != nil {
+			sb.Write(contentBytes[last:ref.refLocation.Start])
+			sb.WriteString(owner + "/" + name)
+			last = ref.refLocation.Start
+			found = true
+		}
+		notrail := spaceTrimmedPattern.FindSubmatchIndex(contentBytes[match[2]+pos : match[3]+pos])
+		if notrail == nil {
+			pos = match[3] + pos
+		} else {
+			pos = match[3] + pos + notrail[1] - notrail[3]
+		}
+	}
+	if !found {
+		return content
+	}
+	sb.Write(contentBytes[last:])
+	return sb.String()
+}
+
 // FindRenderizableReferenceNumeric returns the first unvalidated reference found in a string.
 func FindRenderizableReferenceNumeric(content string, prOnly, crossLinkOnly bool) (bool, *RenderizableReference) {
 	var match []int
@@ -49,6 +49,16 @@ owner/repo!123456789
 	assert.EqualValues(t, expect, result)
 }
 
+func TestQualifyIssueReferences(t *testing.T) {
+	test := `#1 fixes #12, see !3 and owner/repo#4
+(#5) and #6: done, but not #ABC`
+	expect := `user/name#1 fixes user/name#12, see user/name!3 and owner/repo#4
+(user/name#5) and user/name#6: done, but not #ABC`
+
+	assert.Equal(t, expect, QualifyIssueReferences(test, "user", "name"))
+	assert.Equal(t, "no reference", QualifyIssueReferences("no reference", "user", "name"))
+}
+
 func TestFindAllIssueReferences(t *testing.T) {
 	fixtures := []testFixture{
 		{
@@ -118,6 +118,7 @@ var (
 	_ Payloader = &RepositoryPayload{}
 	_ Payloader = &ReleasePayload{}
 	_ Payloader = &PackagePayload{}
+	_ Payloader = &CommitCommentPayload{}
 )
 
 // _________                        __
@@ -235,6 +236,20 @@ func (p *IssueCommentPayload) JSONPayload() ([]byte, error) {
 	return json.MarshalIndent(p, "", "  ")
 }
 
+// CommitCommentPayload represents a payload information of commit comment event.
+type CommitCommentPayload struct {
+	Action     HookIssueCommentAction `json:"action"`
+	Comment    *CommitComment         `json:"comment"`
+	Changes    *ChangesPayload        `json:"changes,omitempty"`
+	Repository *Repository            `json:"repository"`
+	Sender     *User                  `json:"sender"`
+}
+
+// JSONPayload implements Payload
+func (p *CommitCommentPayload) JSONPayload() ([]byte, error) {
+	return json.MarshalIndent(p, "", "  ")
+}
+
 // __________       .__
 // \______   \ ____ |  |   ____ _____    ______ ____
 //  |       _// __ \|  | _/ __ \\__  \  /  ___// __ \
@@ -351,6 +366,8 @@ const (
 	HookIssueReviewRequested HookIssueAction = "review_requested"
 	// HookIssueReviewRequestRemoved is an issue action for removing a review request to someone on a pull request.
 	HookIssueReviewRequestRemoved HookIssueAction = "review_request_removed"
+	// HookIssueSLABreached is an issue action for when an issue misses a target of an SLA policy of its repository.
+	HookIssueSLABreached HookIssueAction = "sla_breached"
 )
 
 // IssuePayload represents the payload information that is sent along with an issue event.
@@ -362,6 +379,16 @@ type IssuePayload struct {
 	Repository *Repository     `json:"repository"`
 	Sender     *User           `json:"sender"`
 	CommitID   string          `json:"commit_id"`
+	// SLABreach is only set for the sla_breached action
+	SLABreach *SLABreachPayload `json:"sla_breach,omitempty"`
+}
+
+// SLABreachPayload represents the target of an SLA policy missed by an issue
+type SLABreachPayload struct {
+	Policy string `json:"policy"`
+	// Kind is first_response or resolution
+	Kind     string    `json:"kind"`
+	Deadline time.Time `json:"deadline"`
 }
 
 // JSONPayload encodes the IssuePayload to JSON, with an indentation of two spaces.
@@ -501,6 +528,8 @@ const (
 	HookPackageCreated HookPackageAction = "created"
 	// HookPackageDeleted deleted
 	HookPackageDeleted HookPackageAction = "deleted"
+	// HookPackagePromoted promoted from another owner
+	HookPackagePromoted HookPackageAction = "promoted"
 )
 
 // PackagePayload represents a package payload
@@ -508,6 +537,7 @@ type PackagePayload struct {
 	Action       HookPackageAction `json:"action"`
 	Repository   *Repository       `json:"repository"`
 	Package      *Package          `json:"package"`
+	PromotedFrom *Package          `json:"promoted_from,omitempty"`
 	Organization *User             `json:"organization"`
 	Sender       *User             `json:"sender"`
 }
@@ -80,6 +80,11 @@ type Issue struct {
 	Repo        *RepositoryMeta  `json:"repository"`
 
 	PinOrder int `json:"pin_order"`
+
+	// FormTemplate is the path of the issue form the issue was created with
+	FormTemplate string `json:"form_template,omitempty"`
+	// FormAnswers are the answers to the fields of the issue form the issue was created with
+	FormAnswers []*IssueFormAnswer `json:"form_answers,omitempty"`
 }
 
 // CreateIssueOption options to create one issue
@@ -98,6 +103,11 @@ type CreateIssueOption struct {
 	// list of label ids
 	Labels []int64 `json:"labels"`
 	Closed bool    `json:"closed"`
+	// path of an issue form of the default branch, e.g. .forgejo/issue_template/bug.yaml.
+	// The body is then rendered from form_answers, which are validated against the form.
+	Template string `json:"template"`
+	// answers to the fields of the issue form given as template, by field id
+	FormAnswers []*IssueFormAnswer `json:"form_answers"`
 }
 
 // EditIssueOption options for editing an issue
@@ -167,6 +177,20 @@ func (iff IssueFormField) VisibleInContent() bool {
 	return slices.Contains(iff.Visible, IssueFormFieldVisibleContent)
 }
 
+// IssueFormAnswer represents the answer to a field of an issue form
+type IssueFormAnswer struct {
+	// ID of the field
+	ID string `json:"id"`
+	// Type of the field, ignored when creating an issue
+	Type IssueFormFieldType `json:"type,omitempty"`
+	// Label of the field, ignored when creating an issue
+	Label string `json:"label,omitempty"`
+	// Value is the text of an input or a textarea
+	Value string `json:"value,omitempty"`
+	// Options are the labels of the selected options of a dropdown or of the checked ones of checkboxes
+	Options []string `json:"options,omitempty"`
+}
+
 // IssueFormFieldVisible defines issue form field visible
 // swagger:model
 type IssueFormFieldVisible string
@@ -267,3 +291,66 @@ type IssueMeta struct {
 	Owner string `json:"owner"`
 	Name  string `json:"repo"`
 }
+
+// SubIssueProgress is the rollup of the sub-issues of an issue, at any depth
+// swagger:model
+type SubIssueProgress struct {
+	// number of closed sub-issues
+	Closed int `json:"closed"`
+	// number of sub-issues
+	Total int `json:"total"`
+	// percentage of closed sub-issues
+	Percent int `json:"percent"`
+	// time tracked on the sub-issues, in seconds
+	TrackedTime int64 `json:"tracked_time"`
+}
+
+// BulkEditIssuesOption options for editing the issues and pull requests matching a search query
+type BulkEditIssuesOption struct {
+	// structured search query of the issues to edit, such as "is:open label:bug"
+	// required: true
+	Query string `json:"query" binding:"Required"`
+	// IDs of the labels to add
+	AddLabels []int64 `json:"add_labels"`
+	// IDs of the labels to remove
+	RemoveLabels []int64 `json:"remove_labels"`
+	// ID of the new milestone, 0 to remove the milestone
+	Milestone *int64 `json:"milestone"`
+	// usernames of the users to assign
+	AddAssignees []string `json:"add_assignees"`
+	// usernames of the users to unassign
+	RemoveAssignees []string `json:"remove_assignees"`
+	// enum: open,closed
+	State *string `json:"state"`
+}
+
+// BulkEditIssuesTask represents the progress of the edition of issues
+// swagger:model
+type BulkEditIssuesTask struct {
+	ID int64 `json:"id"`
+	// enum: queued,running,failed,finished
+	Status string `json:"status"`
+	// the error of a failed edition
+	Message string `json:"message"`
+	// number of issues to edit
+	Total int64 `json:"total"`
+	// number of issues edited and notified
+	Done int64 `json:"done"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Started *time.Time `json:"started_at"`
+	// swagger:strfmt date-time
+	Ended *time.Time `json:"ended_at"`
+}
+
+// TransferIssueOption options for transferring an issue to another repository
+// swagger:model
+type TransferIssueOption struct {
+	// owner of the new repository
+	// required: true
+	NewOwner string `json:"new_owner" binding:"Required"`
+	// name of the new repository
+	// required: true
+	NewRepo string `json:"new_repo" binding:"Required"`
+}
@@ -0,0 +1,64 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import (
+	"time"
+)
+
+// CustomField a typed field of the issues of a repository, or of all the repositories of an owner
+type CustomField struct {
+	ID int64 `json:"id"`
+	// the repository of the field, 0 for a field of all the repositories of the owner
+	RepoID      int64  `json:"repo_id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// enum: select,number,date,user
+	Type string `json:"type"`
+	// the options of a select field
+	Options     []string `json:"options"`
+	ShowOnCards bool     `json:"show_on_cards"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// CreateCustomFieldOption options for creating a custom field
+type CreateCustomFieldOption struct {
+	// required: true
+	Name        string `json:"name" binding:"Required;MaxSize(255)"`
+	Description string `json:"description"`
+	// required: true
+	// enum: select,number,date,user
+	Type string `json:"type" binding:"Required;In(select,number,date,user)"`
+	// the options of a select field
+	Options     []string `json:"options"`
+	ShowOnCards bool     `json:"show_on_cards"`
+}
+
+// EditCustomFieldOption options for editing a custom field, its type cannot be changed
+type EditCustomFieldOption struct {
+	Name        *string `json:"name" binding:"OmitEmpty;MaxSize(255)"`
+	Description *string `json:"description"`
+	// the options of a select field, the values of the removed options are removed from the issues
+	Options     []string `json:"options"`
+	ShowOnCards *bool    `json:"show_on_cards"`
+}
+
+// IssueCustomFieldValue the value of a custom field on an issue
+type IssueCustomFieldValue struct {
+	Field *CustomField `json:"field"`
+	// the value of the field, the username for a user field
+	Value string `json:"value"`
+	// the user of a user field
+	User *User `json:"user,omitempty"`
+}
+
+// SetIssueCustomFieldValueOption options for setting the value of a custom field on an issue
+type SetIssueCustomFieldValueOption struct {
+	// the option of a select field, a number, a date as YYYY-MM-DD or a username
+	// required: true
+	Value string `json:"value" binding:"Required"`
+}
@@ -0,0 +1,30 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import (
+	"time"
+)
+
+// SavedIssueSearch a structured issue search query saved by a user
+type SavedIssueSearch struct {
+	ID int64 `json:"id"`
+	// the repository the search is listed in, 0 for a search listed in all the repositories
+	RepoID int64  `json:"repo_id"`
+	Name   string `json:"name"`
+	// the query, such as "is:open label:bug assignee:@me"
+	Query string `json:"query"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+}
+
+// CreateSavedIssueSearchOption options for saving an issue search
+type CreateSavedIssueSearchOption struct {
+	// required: true
+	Name string `json:"name" binding:"Required;MaxSize(255)"`
+	// required: true
+	Query string `json:"query" binding:"Required"`
+	// the repository to list the search in, 0 to list it in all the repositories
+	RepoID int64 `json:"repo_id"`
+}
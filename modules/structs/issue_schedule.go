@@ -0,0 +1,70 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import (
+	"time"
+)
+
+// IssueSchedule creates issues in a repository at a given date, once or at a regular interval
+type IssueSchedule struct {
+	ID    int64  `json:"id"`
+	Title string `json:"title"`
+	Body  string `json:"body"`
+	// path of an issue template of the default branch providing the title, the body and the labels
+	// of the issues when they are not set
+	Template string `json:"template"`
+	// enum: once,daily,weekly,monthly,quarterly,yearly
+	Interval string `json:"interval"`
+	// swagger:strfmt date-time
+	Start time.Time `json:"start_at"`
+	// the time of the next issue, null if the schedule is over
+	// swagger:strfmt date-time
+	NextRun *time.Time `json:"next_run_at"`
+	// swagger:strfmt date-time
+	LastRun *time.Time `json:"last_run_at"`
+	// the index of the last issue created by the schedule, 0 if there is none
+	LastIssueIndex int64 `json:"last_issue_index"`
+	// the team whose members are assigned to the issues in turn
+	AssigneeTeam *Team `json:"assignee_team"`
+	Active       bool  `json:"active"`
+	// the poster of the issues
+	Creator *User `json:"creator"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// CreateIssueScheduleOption options for creating an issue schedule
+type CreateIssueScheduleOption struct {
+	// required if there is no template
+	Title string `json:"title" binding:"MaxSize(255)"`
+	Body  string `json:"body"`
+	// path of an issue template of the default branch, e.g. .forgejo/issue_template/rotate.md
+	Template string `json:"template"`
+	// required: true
+	// enum: once,daily,weekly,monthly,quarterly,yearly
+	Interval string `json:"interval" binding:"Required;In(once,daily,weekly,monthly,quarterly,yearly)"`
+	// the time of the first issue
+	// required: true
+	// swagger:strfmt date-time
+	Start time.Time `json:"start_at" binding:"Required"`
+	// name of a team of the organization owning the repository, whose members are assigned to the issues in turn
+	AssigneeTeam string `json:"assignee_team"`
+}
+
+// EditIssueScheduleOption options for editing an issue schedule
+type EditIssueScheduleOption struct {
+	Title    *string `json:"title" binding:"OmitEmpty;MaxSize(255)"`
+	Body     *string `json:"body"`
+	Template *string `json:"template"`
+	// enum: once,daily,weekly,monthly,quarterly,yearly
+	Interval *string `json:"interval" binding:"OmitEmpty;In(once,daily,weekly,monthly,quarterly,yearly)"`
+	// swagger:strfmt date-time
+	Start *time.Time `json:"start_at"`
+	// name of a team of the organization owning the repository, empty to not assign the issues
+	AssigneeTeam *string `json:"assignee_team"`
+	Active       *bool   `json:"active"`
+}
@@ -17,6 +17,10 @@ type Package struct {
 	Name       string      `json:"name"`
 	Version    string      `json:"version"`
 	HTMLURL    string      `json:"html_url"`
+	// Verified is true if all files of the package carry a verified signature
+	Verified bool `json:"verified"`
+	// licenses declared in the package metadata
+	Licenses []string `json:"licenses"`
 	// swagger:strfmt date-time
 	CreatedAt time.Time `json:"created_at"`
 }
@@ -30,4 +34,166 @@ type PackageFile struct {
 	HashSHA1   string `json:"sha1"`
 	HashSHA256 string `json:"sha256"`
 	HashSHA512 string `json:"sha512"`
+	Verified   bool   `json:"verified"`
+}
+
+// PackageSignature represents a verified detached signature of a package file
+type PackageSignature struct {
+	ID     int64 `json:"id"`
+	FileID int64 `json:"file_id"`
+	// enum: pgp,ssh
+	Type string `json:"type"`
+	// the owner of the key which verified the signature
+	Signer *User `json:"signer"`
+	// the creator of the package version the file belongs to
+	Uploader *User `json:"uploader"`
+	// GPG key id or SSH key fingerprint of the signing key
+	KeyID     string `json:"key_id"`
+	Signature string `json:"signature"`
+	// swagger:strfmt date-time
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CreatePackageSignatureOption options to add a detached signature to a package file
+type CreatePackageSignatureOption struct {
+	// required: true
+	// enum: pgp,ssh
+	Type string `json:"type" binding:"Required;In(pgp,ssh)"`
+	// armored detached signature of the file content, SSH signatures must use the "file" namespace
+	// required: true
+	Signature string `json:"signature" binding:"Required"`
+}
+
+// PackageCleanupRule represents a rule which describes when to clean up package versions
+type PackageCleanupRule struct {
+	ID      int64  `json:"id"`
+	Enabled bool   `json:"enabled"`
+	Type    string `json:"type"`
+	// number of most recent versions per package which are kept
+	KeepCount   int    `json:"keep_count"`
+	KeepPattern string `json:"keep_pattern"`
+	// versions with an npm dist-tag or container tag matching the pattern are kept
+	KeepTagPattern string `json:"keep_tag_pattern"`
+	// number of highest semver versions per major version which are kept
+	KeepPerMajorCount int `json:"keep_per_major_count"`
+	// versions downloaded within the number of days are kept
+	KeepDownloadedDays int    `json:"keep_downloaded_days"`
+	RemoveDays         int    `json:"remove_days"`
+	RemovePattern      string `json:"remove_pattern"`
+	// untagged container manifests older than remove_days and at least one day old are removed
+	RemoveUntagged bool `json:"remove_untagged"`
+	MatchFullName  bool `json:"match_full_name"`
+	// swagger:strfmt date-time
+	CreatedAt time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// PackageCleanupCandidate represents a package version which would be removed by a cleanup rule
+type PackageCleanupCandidate struct {
+	ID      int64  `json:"id"`
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	// enum: rule,untagged
+	Reason string `json:"reason"`
+	// swagger:strfmt date-time
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// PackageCleanupRecord represents a package version removed by a cleanup rule
+type PackageCleanupRecord struct {
+	ID      int64  `json:"id"`
+	RuleID  int64  `json:"rule_id"`
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	// enum: rule,untagged
+	Reason string `json:"reason"`
+	// swagger:strfmt date-time
+	RemovedAt time.Time `json:"removed_at"`
+}
+
+// PackageRegistry represents the settings of the registry of a package type of an owner
+type PackageRegistry struct {
+	Type string `json:"type"`
+	// published versions can not be overwritten
+	Immutable bool `json:"immutable"`
+}
+
+// EditPackageRegistryOption options to edit the settings of a package registry
+type EditPackageRegistryOption struct {
+	Immutable *bool `json:"immutable"`
+}
+
+// PromotePackageOption options to promote a package version to another owner
+type PromotePackageOption struct {
+	// name of the user or organization receiving the package version
+	// required: true
+	Owner string `json:"owner" binding:"Required"`
+}
+
+// PackagePromotion represents a package version promoted to another owner
+type PackagePromotion struct {
+	ID          int64  `json:"id"`
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	SourceOwner *User  `json:"source_owner"`
+	TargetOwner *User  `json:"target_owner"`
+	Promoter    *User  `json:"promoter"`
+	// swagger:strfmt date-time
+	PromotedAt time.Time `json:"promoted_at"`
+}
+
+// PackageDependency represents a dependency of a package version
+type PackageDependency struct {
+	// OSV ecosystem of the dependency, empty if unknown
+	Ecosystem string `json:"ecosystem"`
+	Name      string `json:"name"`
+	// exact version listed in the SBOM or lower bound of the declared requirement
+	Version     string `json:"version"`
+	Requirement string `json:"requirement"`
+	// enum: runtime,development,optional,peer,build
+	Scope string `json:"scope"`
+	// enum: metadata,sbom
+	Source   string   `json:"source"`
+	Licenses []string `json:"licenses"`
+}
+
+// PackageVulnerability represents a known vulnerability affecting a package version or one of its dependencies
+type PackageVulnerability struct {
+	// id of the OSV entry
+	ID       string   `json:"id"`
+	Aliases  []string `json:"aliases"`
+	Summary  string   `json:"summary"`
+	Severity string   `json:"severity"`
+	// OSV ecosystem of the affected package
+	Ecosystem string `json:"ecosystem"`
+	// name of the affected package
+	Package string `json:"package"`
+	Version string `json:"version"`
+	// true if the package version itself is affected and not one of its dependencies
+	Direct bool `json:"direct"`
+	// source of the affected dependency
+	// enum: metadata,sbom
+	Source string `json:"source"`
+}
+
+// PackageVulnerabilityImport represents the result of an OSV database import
+type PackageVulnerabilityImport struct {
+	// number of imported OSV entries
+	Imported int `json:"imported"`
+	// number of stored vulnerabilities after the import
+	Total int64 `json:"total"`
+}
+
+// PackageDownloadStat represents the downloads of a package version on a day by a client
+type PackageDownloadStat struct {
+	// start of the UTC day
+	Date time.Time `json:"date"`
+	// client family derived from the user agent like npm, docker or browser
+	Client        string `json:"client"`
+	Authenticated bool   `json:"authenticated"`
+	Count         int64  `json:"count"`
 }
@@ -0,0 +1,60 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import (
+	"time"
+)
+
+// Project a project of an owner, or of a repository, gathering issues and pull requests
+type Project struct {
+	ID          int64  `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	// the owner of the project, 0 for a project of a repository
+	OwnerID int64 `json:"owner_id"`
+	// the repository of the project, 0 for a project of an owner
+	RepoID   int64  `json:"repo_id"`
+	IsClosed bool   `json:"is_closed"`
+	HTMLURL  string `json:"html_url"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+	// swagger:strfmt date-time
+	Closed *time.Time `json:"closed_at"`
+}
+
+// ProjectView a named way to show the issues of a project
+type ProjectView struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	// enum: board,table
+	Type string `json:"type"`
+	// a structured issue search query, such as "is:open label:bug"
+	Filter string `json:"filter"`
+	// one of status, repository, column, assignee, label, milestone or custom_field, empty for a view without grouping
+	GroupBy string `json:"group_by"`
+	// the custom field of a view grouped by custom field
+	GroupFieldID int64 `json:"group_field_id"`
+	// one of created, updated, comments, deadline, title or custom_field, empty for a view keeping the order of the project
+	SortBy string `json:"sort_by"`
+	// the custom field of a view sorted by custom field
+	SortFieldID int64 `json:"sort_field_id"`
+	SortDesc    bool  `json:"sort_desc"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// ProjectViewGroup a group of the issues of a project view
+type ProjectViewGroup struct {
+	// the ID of the repository, column, user, label or milestone, the value of the custom field,
+	// or the status ("open" or "closed"), empty for the issues without value
+	Key    string   `json:"key"`
+	Title  string   `json:"title"`
+	Color  string   `json:"color"`
+	Issues []*Issue `json:"issues"`
+}
@@ -45,6 +45,8 @@ type PullRequest struct {
 	Base      *PRBranchInfo `json:"base"`
 	Head      *PRBranchInfo `json:"head"`
 	MergeBase string        `json:"merge_base"`
+	// index of the pull request this one is stacked on
+	StackedOn int64 `json:"stacked_on,omitempty"`
 
 	// swagger:strfmt date-time
 	Deadline *time.Time `json:"due_date"`
@@ -102,6 +104,8 @@ type EditPullRequestOption struct {
 	Deadline            *time.Time `json:"due_date"`
 	RemoveDeadline      *bool      `json:"unset_due_date"`
 	AllowMaintainerEdit *bool      `json:"allow_maintainer_edit"`
+	// index of the pull request to stack this one on, its head branch has to be the base branch. 0 removes the pull request from its stack
+	StackedOn *int64 `json:"stacked_on"`
 }
 
 // ChangedFile store information about files affected by the pull request
@@ -116,3 +120,57 @@ type ChangedFile struct {
 	ContentsURL      string `json:"contents_url,omitempty"`
 	RawURL           string `json:"raw_url,omitempty"`
 }
+
+// MergeQueueEntry represents a pull request waiting in the merge queue of its base branch
+type MergeQueueEntry struct {
+	// index of the pull request
+	Number     int64  `json:"number"`
+	BaseBranch string `json:"base_branch"`
+	// 1-based position in the merge queue
+	Position int64 `json:"position"`
+	// either "queued" or "testing"
+	Status     string `json:"status"`
+	MergeStyle string `json:"merge_style"`
+	// branch holding the speculative merge of the pull request and the ones ahead of it
+	SpeculativeBranch string `json:"speculative_branch"`
+	// commit of the speculative merge, empty while queued
+	SpeculativeSHA string `json:"speculative_sha"`
+	EnqueuedBy     *User  `json:"enqueued_by"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+}
+
+// MergeMessageTemplate represents the template of the commit message used when merging pull requests into matching branches
+type MergeMessageTemplate struct {
+	ID            int64  `json:"id"`
+	BranchPattern string `json:"branch_pattern"`
+	// merge style the template is restricted to, empty for all the merge styles
+	MergeStyle string `json:"merge_style"`
+	// first line of the message followed by its body, with ${Variable} placeholders
+	Template    string `json:"template"`
+	AddTrailers bool   `json:"add_trailers"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// CreateMergeMessageTemplateOption options for creating a merge message template
+type CreateMergeMessageTemplateOption struct {
+	// required: true
+	BranchPattern string `json:"branch_pattern" binding:"Required;GlobPattern"`
+	// merge style the template is restricted to (merge, rebase, rebase-merge or squash), empty for all the merge styles
+	MergeStyle string `json:"merge_style" binding:"In(merge,rebase,rebase-merge,squash)"`
+	// required: true
+	Template    string `json:"template" binding:"Required"`
+	AddTrailers bool   `json:"add_trailers"`
+}
+
+// EditMergeMessageTemplateOption options for editing a merge message template
+type EditMergeMessageTemplateOption struct {
+	BranchPattern *string `json:"branch_pattern" binding:"GlobPattern"`
+	// merge style the template is restricted to (merge, rebase, rebase-merge or squash), empty for all the merge styles
+	MergeStyle  *string `json:"merge_style" binding:"In(merge,rebase,rebase-merge,squash)"`
+	Template    *string `json:"template"`
+	AddTrailers *bool   `json:"add_trailers"`
+}
@@ -87,11 +87,19 @@ type CreatePullReviewComment struct {
 	OldLineNum int64 `json:"old_position"`
 	// if comment to new file line or 0
 	NewLineNum int64 `json:"new_position"`
+	// id of the review comment to reply to, its path and position are used
+	ReplyTo int64 `json:"reply_to"`
 }
 
 // CreatePullReviewCommentOptions are options to create a pull review comment
 type CreatePullReviewCommentOptions CreatePullReviewComment
 
+// EditPullReviewCommentOptions are options to edit a pull review comment
+type EditPullReviewCommentOptions struct {
+	// required: true
+	Body string `json:"body" binding:"Required"`
+}
+
 // SubmitPullReviewOptions are options to submit a pending pull review
 type SubmitPullReviewOptions struct {
 	Event ReviewStateType `json:"event"`
@@ -71,3 +71,36 @@ type CommitAffectedFiles struct {
 	Filename string `json:"filename"`
 	Status   string `json:"status"`
 }
+
+// CommitComment represents a comment on a commit, outside of any pull request
+type CommitComment struct {
+	ID       int64  `json:"id"`
+	HTMLURL  string `json:"html_url"`
+	CommitID string `json:"commit_id"`
+	// the file the comment is attached to, empty for the whole commit
+	Path string `json:"path"`
+	// the line of the file, negative for a line of the parent version, 0 for the whole file
+	Line   int64  `json:"line"`
+	Poster *User  `json:"user"`
+	Body   string `json:"body"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// CreateCommitCommentOption options for creating a comment on a commit
+type CreateCommitCommentOption struct {
+	// required: true
+	Body string `json:"body" binding:"Required"`
+	// the file to comment on, empty to comment on the whole commit
+	Path string `json:"path"`
+	// the line to comment on, negative for a line of the parent version, 0 for the whole file
+	Line int64 `json:"line"`
+}
+
+// EditCommitCommentOption options for editing a comment on a commit
+type EditCommitCommentOption struct {
+	// required: true
+	Body string `json:"body" binding:"Required"`
+}
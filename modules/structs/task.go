@@ -6,13 +6,18 @@ package structs
 // TaskType defines task type
 type TaskType int
 
-const TaskTypeMigrateRepo TaskType = iota // migrate repository from external or local disk
+const (
+	TaskTypeMigrateRepo    TaskType = iota // migrate repository from external or local disk
+	TaskTypeBulkEditIssues                 // edit the issues of a repository matching a search query
+)
 
 // Name returns the task type name
 func (taskType TaskType) Name() string {
 	switch taskType {
 	case TaskTypeMigrateRepo:
 		return "Migrate Repository"
+	case TaskTypeBulkEditIssues:
+		return "Bulk Edit Issues"
 	}
 	return ""
 }
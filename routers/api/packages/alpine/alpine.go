@@ -123,6 +123,7 @@ func GetRepositoryFile(ctx *context.Context) {
 			Filename:     alpine_service.IndexArchiveFilename,
 			CompositeKey: fmt.Sprintf("%s|%s|%s", ctx.Params("branch"), ctx.Params("repository"), ctx.Params("architecture")),
 		},
+		helper.DownloadClient(ctx),
 	)
 	if err != nil {
 		if errors.Is(err, util.ErrNotExist) {
@@ -238,7 +239,7 @@ func DownloadPackageFile(ctx *context.Context) {
 		return
 	}
 
-	s, u, pf, err := packages_service.GetPackageFileStream(ctx, pfs[0])
+	s, u, pf, err := packages_service.GetPackageFileStream(ctx, pfs[0], helper.DownloadClient(ctx))
 	if err != nil {
 		if errors.Is(err, util.ErrNotExist) {
 			apiError(ctx, http.StatusNotFound, err)
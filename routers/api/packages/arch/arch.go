@@ -126,7 +126,7 @@ func PushPackage(ctx *context.Context) {
 	)
 	if err != nil {
 		switch {
-		case errors.Is(err, packages_model.ErrDuplicatePackageVersion), errors.Is(err, packages_model.ErrDuplicatePackageFile):
+		case errors.Is(err, packages_model.ErrDuplicatePackageVersion), errors.Is(err, packages_model.ErrDuplicatePackageFile), errors.Is(err, packages_service.ErrPackageVersionImmutable):
 			apiError(ctx, http.StatusConflict, err)
 		case errors.Is(err, packages_service.ErrQuotaTotalCount), errors.Is(err, packages_service.ErrQuotaTypeSize), errors.Is(err, packages_service.ErrQuotaTotalSize):
 			apiError(ctx, http.StatusForbidden, err)
@@ -343,7 +343,7 @@ func DownloadPackage(ctx *context.Context) {
 
 	pf := pd.Files[0].File
 
-	s, u, _, err := packages_service.GetPackageFileStream(ctx, pf)
+	s, u, _, err := packages_service.GetPackageFileStream(ctx, pf, helper.DownloadClient(ctx))
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
@@ -563,6 +563,17 @@ func UploadManifest(ctx *context.Context) {
 		return
 	}
 
+	if mci.IsTagged {
+		if err := checkTagOverwriteAllowed(ctx, mci, digestFromHashSummer(buf)); err != nil {
+			if errors.Is(err, packages_service.ErrPackageVersionImmutable) {
+				apiErrorDefined(ctx, errDenied.WithMessage(err.Error()))
+			} else {
+				apiError(ctx, http.StatusInternalServerError, err)
+			}
+			return
+		}
+	}
+
 	digest, err := processManifest(ctx, mci, buf)
 	if err != nil {
 		var namedError *namedError
@@ -685,7 +696,7 @@ func DeleteManifest(ctx *context.Context) {
 }
 
 func serveBlob(ctx *context.Context, pfd *packages_model.PackageFileDescriptor) {
-	s, u, _, err := packages_service.GetPackageBlobStream(ctx, pfd.File, pfd.Blob)
+	s, u, _, err := packages_service.GetPackageBlobStream(ctx, pfd.File, pfd.Blob, helper.DownloadClient(ctx))
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
@@ -300,6 +300,32 @@ func processImageManifestIndex(ctx context.Context, mci *manifestCreationInfo, b
 	return manifestDigest, nil
 }
 
+// checkTagOverwriteAllowed returns ErrPackageVersionImmutable if the tag points to another manifest and the registry is immutable
+func checkTagOverwriteAllowed(ctx context.Context, mci *manifestCreationInfo, manifestDigest string) error {
+	immutable, err := packages_model.IsRegistryImmutable(ctx, mci.Owner.ID, packages_model.TypeContainer)
+	if err != nil || !immutable {
+		return err
+	}
+
+	pfd, err := container_model.GetContainerBlob(ctx, &container_model.BlobSearchOptions{
+		OwnerID:    mci.Owner.ID,
+		Image:      mci.Image,
+		Tag:        mci.Reference,
+		IsManifest: true,
+	})
+	if err != nil {
+		if errors.Is(err, container_model.ErrContainerBlobNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	if pfd.Properties.GetByName(container_module.PropertyDigest) != manifestDigest {
+		return packages_service.ErrPackageVersionImmutable
+	}
+	return nil
+}
+
 func notifyPackageCreate(ctx context.Context, doer *user_model.User, pv *packages_model.PackageVersion) error {
 	pd, err := packages_model.GetPackageDescriptor(ctx, pv)
 	if err != nil {
@@ -66,6 +66,7 @@ func GetRepositoryFile(ctx *context.Context) {
 			Filename:     ctx.Params("filename"),
 			CompositeKey: key,
 		},
+		helper.DownloadClient(ctx),
 	)
 	if err != nil {
 		if err == packages_model.ErrPackageNotExist || err == packages_model.ErrPackageFileNotExist {
@@ -106,7 +107,7 @@ func GetRepositoryFileByHash(ctx *context.Context) {
 		return
 	}
 
-	s, u, pf, err := packages_service.GetPackageFileStream(ctx, pfs[0])
+	s, u, pf, err := packages_service.GetPackageFileStream(ctx, pfs[0], helper.DownloadClient(ctx))
 	if err != nil {
 		if errors.Is(err, util.ErrNotExist) {
 			apiError(ctx, http.StatusNotFound, err)
@@ -222,6 +223,7 @@ func DownloadPackageFile(ctx *context.Context) {
 			Filename:     fmt.Sprintf("%s_%s_%s.deb", name, version, ctx.Params("architecture")),
 			CompositeKey: fmt.Sprintf("%s|%s", ctx.Params("distribution"), ctx.Params("component")),
 		},
+		helper.DownloadClient(ctx),
 	)
 	if err != nil {
 		if errors.Is(err, util.ErrNotExist) {
@@ -42,6 +42,7 @@ func DownloadPackageFile(ctx *context.Context) {
 		&packages_service.PackageFileInfo{
 			Filename: ctx.Params("filename"),
 		},
+		helper.DownloadClient(ctx),
 	)
 	if err != nil {
 		if err == packages_model.ErrPackageNotExist || err == packages_model.ErrPackageFileNotExist {
@@ -202,6 +203,17 @@ func DeletePackageFile(ctx *context.Context) {
 			return
 		}
 	} else {
+		// removing a single file would allow to upload it again with a different content
+		immutable, err := packages_model.IsRegistryImmutable(ctx, ctx.Package.Owner.ID, packages_model.TypeGeneric)
+		if err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+		if immutable {
+			apiError(ctx, http.StatusConflict, packages_service.ErrPackageVersionImmutable)
+			return
+		}
+
 		if err := packages_service.DeletePackageFile(ctx, pf); err != nil {
 			apiError(ctx, http.StatusInternalServerError, err)
 			return
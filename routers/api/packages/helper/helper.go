@@ -11,8 +11,10 @@ import (
 
 	packages_model "code.gitea.io/gitea/models/packages"
 	"code.gitea.io/gitea/modules/log"
+	packages_module "code.gitea.io/gitea/modules/packages"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/services/context"
+	packages_service "code.gitea.io/gitea/services/packages"
 )
 
 // LogAndProcessError logs an error and calls a custom callback with the processed error message.
@@ -39,6 +41,14 @@ func LogAndProcessError(ctx *context.Context, status int, obj any, cb func(strin
 	}
 }
 
+// DownloadClient returns the client family and the authentication state of the request for the download statistics
+func DownloadClient(ctx *context.Context) *packages_service.DownloadClient {
+	return &packages_service.DownloadClient{
+		Family:        packages_module.ClientFamily(ctx.Req.UserAgent()),
+		Authenticated: ctx.Doer != nil,
+	}
+}
+
 // Serves the content of the package file
 // If the url is set it will redirect the request, otherwise the content is copied to the response.
 func ServePackageFile(ctx *context.Context, s io.ReadSeekCloser, u *url.URL, pf *packages_model.PackageFile, forceOpts ...*context.ServeHeaderOptions) {
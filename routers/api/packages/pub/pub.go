@@ -274,7 +274,7 @@ func DownloadPackageFile(ctx *context.Context) {
 
 	pf := pd.Files[0].File
 
-	s, u, _, err := packages_service.GetPackageFileStream(ctx, pf)
+	s, u, _, err := packages_service.GetPackageFileStream(ctx, pf, helper.DownloadClient(ctx))
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
@@ -103,6 +103,7 @@ func GetRepositoryFile(ctx *context.Context) {
 			Filename:     ctx.Params("filename"),
 			CompositeKey: ctx.Params("group"),
 		},
+		helper.DownloadClient(ctx),
 	)
 	if err != nil {
 		if errors.Is(err, util.ErrNotExist) {
@@ -231,6 +232,7 @@ func DownloadPackageFile(ctx *context.Context) {
 			Filename:     fmt.Sprintf("%s-%s.%s.rpm", name, version, ctx.Params("architecture")),
 			CompositeKey: ctx.Params("group"),
 		},
+		helper.DownloadClient(ctx),
 	)
 	if err != nil {
 		if errors.Is(err, util.ErrNotExist) {
@@ -0,0 +1,68 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"errors"
+	"net/http"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/packages/vulnerability"
+)
+
+// ImportPackageVulnerabilities imports an OSV database
+func ImportPackageVulnerabilities(ctx *context.APIContext) {
+	// swagger:operation POST /admin/packages/vulnerabilities admin adminImportPackageVulnerabilities
+	// ---
+	// summary: Import an OSV database used to find known vulnerabilities of packages
+	// description: The file must be a zip archive of OSV entries in JSON format like the ecosystem exports of osv.dev. Existing entries are replaced.
+	// consumes:
+	// - multipart/form-data
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: file
+	//   in: formData
+	//   description: zip archive of OSV entries
+	//   type: file
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PackageVulnerabilityImport"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	file, header, err := ctx.Req.FormFile("file")
+	if err != nil {
+		ctx.Error(http.StatusUnprocessableEntity, "", err)
+		return
+	}
+	defer file.Close()
+
+	imported, err := vulnerability.ImportOSVDatabase(ctx, file, header.Size)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusUnprocessableEntity, "", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "ImportOSVDatabase", err)
+		}
+		return
+	}
+
+	total, err := packages_model.CountVulnerabilities(ctx)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "CountVulnerabilities", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &api.PackageVulnerabilityImport{
+		Imported: imported,
+		Total:    total,
+	})
+}
@@ -559,6 +559,16 @@ func reqOrgMembership() func(ctx *context.APIContext) {
 	}
 }
 
+// reqOrgUnitReader user should be able to read the unit of the organization
+func reqOrgUnitReader(unitType unit.Type) func(ctx *context.APIContext) {
+	return func(ctx *context.APIContext) {
+		if ctx.Org.Organization.UnitPermission(ctx, ctx.Doer, unitType) < perm.AccessModeRead {
+			ctx.NotFound()
+			return
+		}
+	}
+}
+
 func reqGitHook() func(ctx *context.APIContext) {
 	return func(ctx *context.APIContext) {
 		if !ctx.Doer.CanEditGitHook() {
@@ -993,6 +1003,11 @@ func Routes() *web.Route {
 			}
 			m.Get("/times", repo.ListMyTrackedTimes)
 			m.Get("/stopwatches", repo.GetStopwatches)
+			m.Group("/saved_searches", func() {
+				m.Combo("").Get(user.ListSavedIssueSearches).
+					Post(bind(api.CreateSavedIssueSearchOption{}), user.CreateSavedIssueSearch)
+				m.Delete("/{id}", user.DeleteSavedIssueSearch)
+			})
 			m.Get("/subscriptions", user.GetMyWatchedRepos)
 			m.Get("/teams", org.ListUserTeams)
 			m.Group("/hooks", func() {
@@ -1126,6 +1141,13 @@ func Routes() *web.Route {
 					m.Post("", reqToken(), reqRepoWriter(unit.TypeCode), mustNotBeArchived, bind(api.CreateTagOption{}), context.EnforceQuotaAPI(quota_model.LimitSubjectSizeReposAll, context.QuotaTargetRepo), repo.CreateTag)
 					m.Delete("/*", reqToken(), reqRepoWriter(unit.TypeCode), mustNotBeArchived, repo.DeleteTag)
 				}, reqRepoReader(unit.TypeCode), context.ReferencesGitRepo(true))
+				m.Group("/merge_message_templates", func() {
+					m.Combo("").Get(repo.ListMergeMessageTemplates).
+						Post(bind(api.CreateMergeMessageTemplateOption{}), mustNotBeArchived, repo.CreateMergeMessageTemplate)
+					m.Combo("/{id}").Get(repo.GetMergeMessageTemplate).
+						Patch(bind(api.EditMergeMessageTemplateOption{}), mustNotBeArchived, repo.EditMergeMessageTemplate).
+						Delete(repo.DeleteMergeMessageTemplate)
+				}, reqToken(), reqAdmin(), reqRepoReader(unit.TypePullRequests))
 				m.Group("/tag_protections", func() {
 					m.Combo("").Get(repo.ListTagProtection).
 						Post(bind(api.CreateTagProtectionOption{}), mustNotBeArchived, repo.CreateTagProtection)
@@ -1222,10 +1244,13 @@ func Routes() *web.Route {
 						m.Combo("/merge").Get(repo.IsPullRequestMerged).
 							Post(reqToken(), mustNotBeArchived, bind(forms.MergePullRequestForm{}), context.EnforceQuotaAPI(quota_model.LimitSubjectSizeGitAll, context.QuotaTargetRepo), repo.MergePullRequest).
 							Delete(reqToken(), mustNotBeArchived, repo.CancelScheduledAutoMerge)
+						m.Combo("/merge-queue").Get(repo.GetPullRequestMergeQueueEntry).
+							Delete(reqToken(), mustNotBeArchived, repo.RemovePullRequestFromMergeQueue)
 						m.Group("/reviews", func() {
 							m.Combo("").
 								Get(repo.ListPullReviews).
 								Post(reqToken(), bind(api.CreatePullReviewOptions{}), repo.CreatePullReview)
+							m.Get("/pending", reqToken(), repo.GetPendingPullReview)
 							m.Group("/{id}", func() {
 								m.Combo("").
 									Get(repo.GetPullReview).
@@ -1238,6 +1263,7 @@ func Routes() *web.Route {
 									m.Group("/{comment}", func() {
 										m.Combo("").
 											Get(repo.GetPullReviewComment).
+											Patch(reqToken(), bind(api.EditPullReviewCommentOptions{}), repo.EditPullReviewComment).
 											Delete(reqToken(), repo.DeletePullReviewComment)
 									}, commentAssignment("comment"))
 								})
@@ -1251,6 +1277,7 @@ func Routes() *web.Route {
 					})
 					m.Get("/{base}/*", repo.GetPullRequestByBaseHead)
 				}, mustAllowPulls, reqRepoReader(unit.TypeCode), context.ReferencesGitRepo())
+				m.Get("/merge-queue", mustAllowPulls, reqRepoReader(unit.TypeCode), repo.ListMergeQueue)
 				m.Group("/statuses", func() {
 					m.Combo("/{sha}").Get(repo.GetCommitStatuses).
 						Post(reqToken(), reqRepoWriter(unit.TypeCode), bind(api.CreateStatusOption{}), repo.NewCommitStatus)
@@ -1267,6 +1294,16 @@ func Routes() *web.Route {
 					m.Group("/commits", func() {
 						m.Get("/{sha}", repo.GetSingleCommit)
 						m.Get("/{sha}.{diffType:diff|patch}", repo.DownloadCommitDiffOrPatch)
+						m.Combo("/{sha}/comments").
+							Get(repo.ListCommitComments).
+							Post(reqToken(), mustNotBeArchived, bind(api.CreateCommitCommentOption{}), repo.CreateCommitComment)
+						m.Group("/comments", func() {
+							m.Get("", repo.ListRepoCommitComments)
+							m.Combo("/{id}").
+								Get(repo.GetCommitComment).
+								Patch(reqToken(), mustNotBeArchived, bind(api.EditCommitCommentOption{}), repo.EditCommitComment).
+								Delete(reqToken(), mustNotBeArchived, repo.DeleteCommitComment)
+						})
 					})
 					m.Get("/refs", repo.GetGitAllRefs)
 					m.Get("/refs/*", repo.GetGitRefs)
@@ -1326,6 +1363,10 @@ func Routes() *web.Route {
 					m.Combo("").Get(repo.ListIssues).
 						Post(reqToken(), mustNotBeArchived, bind(api.CreateIssueOption{}), reqRepoReader(unit.TypeIssues), repo.CreateIssue)
 					m.Get("/pinned", reqRepoReader(unit.TypeIssues), repo.ListPinnedIssues)
+					m.Group("/bulk_edit", func() {
+						m.Post("", mustNotBeArchived, bind(api.BulkEditIssuesOption{}), repo.BulkEditIssues)
+						m.Get("/{id}", repo.GetBulkEditIssuesTask)
+					}, reqToken())
 					m.Group("/comments", func() {
 						m.Get("", repo.ListRepoIssueComments)
 						m.Group("/{id}", func() {
@@ -1374,6 +1415,12 @@ func Routes() *web.Route {
 							m.Delete("/{id}", repo.DeleteTime)
 						}, reqToken())
 						m.Combo("/deadline").Post(reqToken(), bind(api.EditDeadlineOption{}), repo.UpdateIssueDeadline)
+						m.Group("/custom_fields", func() {
+							m.Get("", repo.ListIssueCustomFieldValues)
+							m.Combo("/{id}").
+								Put(reqToken(), mustNotBeArchived, bind(api.SetIssueCustomFieldValueOption{}), repo.SetIssueCustomFieldValue).
+								Delete(reqToken(), mustNotBeArchived, repo.DeleteIssueCustomFieldValue)
+						})
 						m.Group("/stopwatch", func() {
 							m.Post("/start", repo.StartIssueStopwatch)
 							m.Post("/stop", repo.StopIssueStopwatch)
@@ -1406,6 +1453,15 @@ func Routes() *web.Route {
 							Get(repo.GetIssueBlocks).
 							Post(reqToken(), bind(api.IssueMeta{}), repo.CreateIssueBlocking).
 							Delete(reqToken(), bind(api.IssueMeta{}), repo.RemoveIssueBlocking)
+						m.Group("/sub_issues", func() {
+							m.Combo("").
+								Get(repo.ListSubIssues).
+								Post(reqToken(), mustNotBeArchived, bind(api.IssueMeta{}), repo.AddSubIssue).
+								Delete(reqToken(), mustNotBeArchived, bind(api.IssueMeta{}), repo.RemoveSubIssue)
+							m.Get("/progress", repo.GetSubIssueProgress)
+						})
+						m.Get("/parent", repo.GetParentIssue)
+						m.Post("/transfer", reqToken(), mustNotBeArchived, reqRepoWriter(unit.TypeIssues), bind(api.TransferIssueOption{}), repo.TransferIssue)
 						m.Group("/pin", func() {
 							m.Combo("").
 								Post(reqToken(), reqAdmin(), repo.PinIssue).
@@ -1414,6 +1470,20 @@ func Routes() *web.Route {
 						})
 					})
 				}, mustEnableIssuesOrPulls)
+				m.Group("/custom_fields", func() {
+					m.Combo("").Get(repo.ListCustomFields).
+						Post(reqToken(), reqAdmin(), mustNotBeArchived, bind(api.CreateCustomFieldOption{}), repo.CreateCustomField)
+					m.Combo("/{id}").Get(repo.GetCustomField).
+						Patch(reqToken(), reqAdmin(), mustNotBeArchived, bind(api.EditCustomFieldOption{}), repo.EditCustomField).
+						Delete(reqToken(), reqAdmin(), mustNotBeArchived, repo.DeleteCustomField)
+				}, mustEnableIssuesOrPulls)
+				m.Group("/issue_schedules", func() {
+					m.Combo("").Get(repo.ListIssueSchedules).
+						Post(reqToken(), reqRepoWriter(unit.TypeIssues), mustNotBeArchived, bind(api.CreateIssueScheduleOption{}), repo.CreateIssueSchedule)
+					m.Combo("/{id}").Get(repo.GetIssueSchedule).
+						Patch(reqToken(), reqRepoWriter(unit.TypeIssues), mustNotBeArchived, bind(api.EditIssueScheduleOption{}), repo.EditIssueSchedule).
+						Delete(reqToken(), reqRepoWriter(unit.TypeIssues), mustNotBeArchived, repo.DeleteIssueSchedule)
+				}, reqRepoReader(unit.TypeIssues))
 				m.Group("/labels", func() {
 					m.Combo("").Get(repo.ListLabels).
 						Post(reqToken(), reqRepoWriter(unit.TypeIssues, unit.TypePullRequests), bind(api.CreateLabelOption{}), repo.CreateLabel)
@@ -1437,7 +1507,26 @@ func Routes() *web.Route {
 				m.Get("", reqToken(), packages.GetPackage)
 				m.Delete("", reqToken(), reqPackageAccess(perm.AccessModeWrite), packages.DeletePackage)
 				m.Get("/files", reqToken(), packages.ListPackageFiles)
+				m.Post("/files/{id}/signatures", reqToken(), reqPackageAccess(perm.AccessModeWrite), bind(api.CreatePackageSignatureOption{}), packages.AddPackageFileSignature)
+				m.Get("/signatures", reqToken(), packages.ListPackageSignatures)
+				m.Get("/downloads", reqToken(), packages.ListPackageDownloadStats)
+				m.Post("/promote", reqToken(), reqPackageAccess(perm.AccessModeWrite), bind(api.PromotePackageOption{}), packages.PromotePackage)
+				m.Get("/dependencies", reqToken(), packages.ListPackageDependencies)
+				m.Get("/vulnerabilities", reqToken(), packages.ListPackageVulnerabilities)
+				m.Combo("/sbom").Get(reqToken(), packages.GetPackageSBOM).
+					Put(reqToken(), reqPackageAccess(perm.AccessModeWrite), packages.UploadPackageSBOM).
+					Delete(reqToken(), reqPackageAccess(perm.AccessModeWrite), packages.DeletePackageSBOM)
 			})
+			m.Group("/cleanup-rules", func() {
+				m.Get("", packages.ListPackageCleanupRules)
+				m.Get("/{id}/preview", packages.PreviewPackageCleanupRule)
+			}, reqToken(), reqPackageAccess(perm.AccessModeWrite))
+			m.Get("/cleanup-records", reqToken(), reqPackageAccess(perm.AccessModeWrite), packages.ListPackageCleanupRecords)
+			m.Group("/registries", func() {
+				m.Get("", packages.ListPackageRegistries)
+				m.Patch("/{type}", bind(api.EditPackageRegistryOption{}), packages.EditPackageRegistry)
+			}, reqToken(), reqPackageAccess(perm.AccessModeWrite))
+			m.Get("/promotions", reqToken(), reqPackageAccess(perm.AccessModeWrite), packages.ListPackagePromotions)
 			m.Get("/", reqToken(), packages.ListPackages)
 		}, tokenRequiresScopes(auth_model.AccessTokenScopeCategoryPackage), context.UserAssignmentAPI(), context.PackageAssignmentAPI(), reqPackageAccess(perm.AccessModeRead))
 
@@ -1476,6 +1565,21 @@ func Routes() *web.Route {
 				m.Post("", reqOrgOwnership(), bind(api.CreateTeamOption{}), org.CreateTeam)
 				m.Get("/search", org.SearchTeam)
 			}, reqToken(), reqOrgMembership())
+			m.Group("/custom_fields", func() {
+				m.Get("", org.ListCustomFields)
+				m.Post("", reqToken(), reqOrgOwnership(), bind(api.CreateCustomFieldOption{}), org.CreateCustomField)
+				m.Combo("/{id}").Get(reqToken(), org.GetCustomField).
+					Patch(reqToken(), reqOrgOwnership(), bind(api.EditCustomFieldOption{}), org.EditCustomField).
+					Delete(reqToken(), reqOrgOwnership(), org.DeleteCustomField)
+			})
+			m.Group("/projects", func() {
+				m.Get("", org.ListProjects)
+				m.Group("/{id}", func() {
+					m.Get("", org.GetProject)
+					m.Get("/views", org.ListProjectViews)
+					m.Get("/views/{view_id}/issues", org.ListProjectViewIssues)
+				})
+			}, reqOrgUnitReader(unit.TypeProjects))
 			m.Group("/labels", func() {
 				m.Get("", org.ListLabels)
 				m.Post("", reqToken(), reqOrgOwnership(), bind(api.CreateLabelOption{}), org.CreateLabel)
@@ -1563,6 +1667,7 @@ func Routes() *web.Route {
 					}
 				}, context.UserAssignmentAPI())
 			})
+			m.Post("/packages/vulnerabilities", admin.ImportPackageVulnerabilities)
 			m.Group("/emails", func() {
 				m.Get("", admin.GetAllEmails)
 				m.Get("/search", admin.SearchEmail)
@@ -0,0 +1,200 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	"net/http"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+// ListCustomFields list the custom fields of the issues of all the repositories of an organization
+func ListCustomFields(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/custom_fields organization orgListCustomFields
+	// ---
+	// summary: List the custom fields of the issues of all the repositories of an organization
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/CustomFieldList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	fields, err := issues_model.GetOwnerCustomFields(ctx, ctx.Org.Organization.ID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetOwnerCustomFields", err)
+		return
+	}
+
+	ctx.SetTotalCountHeader(int64(len(fields)))
+	ctx.JSON(http.StatusOK, convert.ToCustomFieldList(fields))
+}
+
+// GetCustomField get a custom field of an organization
+func GetCustomField(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/custom_fields/{id} organization orgGetCustomField
+	// ---
+	// summary: Get a custom field of an organization
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the custom field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/CustomField"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	field := getOrgCustomField(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToCustomField(field))
+}
+
+// CreateCustomField create a custom field for the issues of all the repositories of an organization
+func CreateCustomField(ctx *context.APIContext) {
+	// swagger:operation POST /orgs/{org}/custom_fields organization orgCreateCustomField
+	// ---
+	// summary: Create a custom field for the issues of all the repositories of an organization
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateCustomFieldOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/CustomField"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	utils.CreateCustomField(ctx, ctx.Org.Organization.ID, 0)
+}
+
+// EditCustomField modify a custom field of an organization
+func EditCustomField(ctx *context.APIContext) {
+	// swagger:operation PATCH /orgs/{org}/custom_fields/{id} organization orgEditCustomField
+	// ---
+	// summary: Edit a custom field of an organization
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the custom field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditCustomFieldOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/CustomField"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	field := getOrgCustomField(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	utils.EditCustomField(ctx, field)
+}
+
+// DeleteCustomField delete a custom field of an organization
+func DeleteCustomField(ctx *context.APIContext) {
+	// swagger:operation DELETE /orgs/{org}/custom_fields/{id} organization orgDeleteCustomField
+	// ---
+	// summary: Delete a custom field of an organization, with its values
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the custom field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	field := getOrgCustomField(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	utils.DeleteCustomField(ctx, field)
+}
+
+// getOrgCustomField returns the custom field of the request defined by the organization for all of its repositories
+func getOrgCustomField(ctx *context.APIContext) *issues_model.CustomField {
+	field, err := issues_model.GetCustomFieldByID(ctx, ctx.Org.Organization.ID, ctx.ParamsInt64(":id"))
+	if err == nil && !field.BelongsToOwner() {
+		err = issues_model.ErrCustomFieldNotExist{ID: field.ID}
+	}
+	if err != nil {
+		if issues_model.IsErrCustomFieldNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetCustomFieldByID", err)
+		}
+		return nil
+	}
+	return field
+}
@@ -0,0 +1,226 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	"errors"
+	"net/http"
+
+	"code.gitea.io/gitea/models/db"
+	project_model "code.gitea.io/gitea/models/project"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+	project_service "code.gitea.io/gitea/services/projects"
+)
+
+// ListProjects list the projects of an organization
+func ListProjects(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/projects organization orgListProjects
+	// ---
+	// summary: List an organization's projects
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: state
+	//   in: query
+	//   description: whether to list the open, the closed or all the projects
+	//   type: string
+	//   enum: [open, closed, all]
+	//   default: open
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	var isClosed optional.Option[bool]
+	switch ctx.FormString("state") {
+	case "closed":
+		isClosed = optional.Some(true)
+	case "all":
+	default:
+		isClosed = optional.Some(false)
+	}
+
+	projects, count, err := db.FindAndCount[project_model.Project](ctx, project_model.SearchOptions{
+		ListOptions: utils.GetListOptions(ctx),
+		OwnerID:     ctx.Org.Organization.ID,
+		IsClosed:    isClosed,
+		Type:        project_model.TypeOrganization,
+	})
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "FindProjects", err)
+		return
+	}
+
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, convert.ToProjectList(ctx, projects))
+}
+
+// getOrgProject returns the project of the request, which must belong to the organization of the request
+func getOrgProject(ctx *context.APIContext) *project_model.Project {
+	project, err := project_model.GetProjectByID(ctx, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if project_model.IsErrProjectNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetProjectByID", err)
+		}
+		return nil
+	}
+	if project.OwnerID != ctx.Org.Organization.ID {
+		ctx.NotFound()
+		return nil
+	}
+	return project
+}
+
+// GetProject get a project of an organization
+func GetProject(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/projects/{id} organization orgGetProject
+	// ---
+	// summary: Get a project of an organization
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project to get
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/Project"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	project := getOrgProject(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToProject(ctx, project))
+}
+
+// ListProjectViews list the views of a project of an organization
+func ListProjectViews(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/projects/{id}/views organization orgListProjectViews
+	// ---
+	// summary: List the views of a project of an organization
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectViewList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	project := getOrgProject(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	views, err := project_model.GetViews(ctx, project.ID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetViews", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToProjectViewList(views))
+}
+
+// ListProjectViewIssues list the issues of a view of a project of an organization
+func ListProjectViewIssues(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/projects/{id}/views/{view_id}/issues organization orgListProjectViewIssues
+	// ---
+	// summary: List the issues of a view of a project of an organization, filtered, sorted and grouped as defined by the view
+	// description: The issues of a board view are grouped by column. Only the issues the user can read are listed.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: view_id
+	//   in: path
+	//   description: id of the view
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectViewGroupList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	project := getOrgProject(ctx)
+	if ctx.Written() {
+		return
+	}
+	view, err := project_model.GetViewByID(ctx, project.ID, ctx.ParamsInt64(":view_id"))
+	if err != nil {
+		if project_model.IsErrProjectViewNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetViewByID", err)
+		}
+		return
+	}
+
+	groups, err := project_service.GetViewIssueGroups(ctx, project, view, ctx.Doer)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusUnprocessableEntity, "GetViewIssueGroups", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetViewIssueGroups", err)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToProjectViewGroupList(ctx, ctx.Doer, groups))
+}
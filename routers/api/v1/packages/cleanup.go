@@ -0,0 +1,171 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"errors"
+	"net/http"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/packages"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+	packages_cleanup_service "code.gitea.io/gitea/services/packages/cleanup"
+)
+
+// ListPackageCleanupRules gets all cleanup rules of an owner
+func ListPackageCleanupRules(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/cleanup-rules package listPackageCleanupRules
+	// ---
+	// summary: Gets all package cleanup rules of an owner
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the packages
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PackageCleanupRuleList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	pcrs, err := packages.GetCleanupRulesByOwner(ctx, ctx.Package.Owner.ID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetCleanupRulesByOwner", err)
+		return
+	}
+
+	apiRules := make([]*api.PackageCleanupRule, 0, len(pcrs))
+	for _, pcr := range pcrs {
+		apiRules = append(apiRules, convert.ToPackageCleanupRule(pcr))
+	}
+
+	ctx.JSON(http.StatusOK, apiRules)
+}
+
+// PreviewPackageCleanupRule lists the package versions a cleanup rule would remove
+func PreviewPackageCleanupRule(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/cleanup-rules/{id}/preview package previewPackageCleanupRule
+	// ---
+	// summary: Lists the package versions a cleanup rule would remove, without removing them
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the packages
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the cleanup rule
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PackageCleanupCandidateList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	pcr, err := packages.GetCleanupRuleByID(ctx, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetCleanupRuleByID", err)
+		}
+		return
+	}
+	if pcr.OwnerID != ctx.Package.Owner.ID {
+		ctx.NotFound()
+		return
+	}
+
+	candidates, err := packages_cleanup_service.GetCleanupCandidates(ctx, pcr)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetCleanupCandidates", err)
+		return
+	}
+
+	apiCandidates := make([]*api.PackageCleanupCandidate, 0, len(candidates))
+	for _, c := range candidates {
+		apiCandidates = append(apiCandidates, &api.PackageCleanupCandidate{
+			ID:        c.Version.ID,
+			Type:      string(c.Package.Type),
+			Name:      c.Package.Name,
+			Version:   c.Version.Version,
+			Reason:    c.Reason,
+			CreatedAt: c.Version.CreatedUnix.AsTime(),
+		})
+	}
+
+	ctx.JSON(http.StatusOK, apiCandidates)
+}
+
+// ListPackageCleanupRecords gets the package versions removed by cleanup rules of an owner
+func ListPackageCleanupRecords(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/cleanup-records package listPackageCleanupRecords
+	// ---
+	// summary: Gets the package versions removed by the cleanup rules of an owner
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the packages
+	//   type: string
+	//   required: true
+	// - name: rule_id
+	//   in: query
+	//   description: cleanup rule filter
+	//   type: integer
+	//   format: int64
+	// - name: type
+	//   in: query
+	//   description: package type filter
+	//   type: string
+	//   enum: [alpine, cargo, chef, composer, conan, conda, container, cran, debian, generic, go, helm, maven, npm, nuget, pub, pypi, rpm, rubygems, swift, vagrant]
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PackageCleanupRecordList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	listOptions := utils.GetListOptions(ctx)
+
+	records, count, err := db.FindAndCount[packages.PackageCleanupRecord](ctx, packages.FindCleanupRecordsOptions{
+		ListOptions: listOptions,
+		OwnerID:     ctx.Package.Owner.ID,
+		RuleID:      ctx.FormInt64("rule_id"),
+		Type:        packages.Type(ctx.FormTrim("type")),
+	})
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "FindCleanupRecords", err)
+		return
+	}
+
+	apiRecords := make([]*api.PackageCleanupRecord, 0, len(records))
+	for _, record := range records {
+		apiRecords = append(apiRecords, convert.ToPackageCleanupRecord(record))
+	}
+
+	ctx.SetLinkHeader(int(count), listOptions.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiRecords)
+}
@@ -0,0 +1,96 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"net/http"
+	"time"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+// defaultDownloadStatDays is the number of days returned if no time range is given
+const defaultDownloadStatDays = 30
+
+// ListPackageDownloadStats gets the daily download statistics of a package
+func ListPackageDownloadStats(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/{type}/{name}/{version}/downloads package listPackageDownloadStats
+	// ---
+	// summary: Gets the daily downloads of a package grouped by client and authentication
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: version of the package
+	//   type: string
+	//   required: true
+	// - name: since
+	//   in: query
+	//   description: Only show downloads on or after the given time, defaults to the last 30 days. This is a timestamp in RFC 3339 format
+	//   type: string
+	//   format: date-time
+	// - name: before
+	//   in: query
+	//   description: Only show downloads before the given time. This is a timestamp in RFC 3339 format
+	//   type: string
+	//   format: date-time
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PackageDownloadStatList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	before, since, err := context.GetQueryBeforeSince(ctx.Base)
+	if err != nil {
+		ctx.Error(http.StatusUnprocessableEntity, "GetQueryBeforeSince", err)
+		return
+	}
+
+	opts := &packages_model.FindDownloadStatsOptions{
+		VersionID: ctx.Package.Descriptor.Version.ID,
+	}
+	if since != 0 {
+		opts.Since = packages_model.DownloadStatDay(time.Unix(since, 0))
+	} else {
+		opts.Since = packages_model.DownloadStatDay(time.Now().AddDate(0, 0, -defaultDownloadStatDays+1))
+	}
+	if before != 0 {
+		opts.Before = timeutil.TimeStamp(before)
+	}
+
+	stats, err := packages_model.FindDownloadStats(ctx, opts)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "FindDownloadStats", err)
+		return
+	}
+
+	apiStats := make([]*api.PackageDownloadStat, 0, len(stats))
+	for _, s := range stats {
+		apiStats = append(apiStats, convert.ToPackageDownloadStat(s))
+	}
+
+	ctx.JSON(http.StatusOK, apiStats)
+}
@@ -4,11 +4,16 @@
 package packages
 
 import (
+	"errors"
 	"net/http"
 
 	"code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/perm"
+	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/optional"
 	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
 	"code.gitea.io/gitea/routers/api/v1/utils"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/convert"
@@ -213,3 +218,237 @@ func ListPackageFiles(ctx *context.APIContext) {
 
 	ctx.JSON(http.StatusOK, apiPackageFiles)
 }
+
+// ListPackageSignatures gets all verified signatures of the files of a package
+func ListPackageSignatures(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/{type}/{name}/{version}/signatures package listPackageSignatures
+	// ---
+	// summary: Gets all verified signatures of the files of a package
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: version of the package
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PackageSignatureList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	apiSignatures := make([]*api.PackageSignature, 0, len(ctx.Package.Descriptor.Files))
+	for _, pfd := range ctx.Package.Descriptor.Files {
+		for _, ps := range pfd.Signatures {
+			apiSignature, err := convert.ToPackageSignature(ctx, ps, ctx.Package.Descriptor.Creator, ctx.Doer)
+			if err != nil {
+				ctx.Error(http.StatusInternalServerError, "ToPackageSignature", err)
+				return
+			}
+			apiSignatures = append(apiSignatures, apiSignature)
+		}
+	}
+
+	ctx.JSON(http.StatusOK, apiSignatures)
+}
+
+// AddPackageFileSignature adds a detached signature to a package file
+func AddPackageFileSignature(ctx *context.APIContext) {
+	// swagger:operation POST /packages/{owner}/{type}/{name}/{version}/files/{id}/signatures package addPackageFileSignature
+	// ---
+	// summary: Add a detached signature to a package file
+	// description: Only the uploader of the package version can sign its files. The signature is verified against
+	//   the GPG or SSH keys of the uploader and rejected if no key verifies it.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: version of the package
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the package file
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreatePackageSignatureOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/PackageSignature"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.CreatePackageSignatureOption)
+
+	var pf *packages.PackageFile
+	for _, pfd := range ctx.Package.Descriptor.Files {
+		if pfd.File.ID == ctx.ParamsInt64(":id") {
+			pf = pfd.File
+			break
+		}
+	}
+	if pf == nil {
+		ctx.NotFound()
+		return
+	}
+
+	ps, err := packages_service.AddFileSignature(ctx, ctx.Doer, ctx.Package.Descriptor.Creator, pf, packages.SignatureType(form.Type), form.Signature)
+	if err != nil {
+		switch {
+		case errors.Is(err, util.ErrPermissionDenied):
+			ctx.Error(http.StatusForbidden, "", err)
+		case errors.Is(err, packages.ErrDuplicatePackageSignature):
+			ctx.Error(http.StatusConflict, "", err)
+		case errors.Is(err, util.ErrInvalidArgument):
+			ctx.Error(http.StatusUnprocessableEntity, "", err)
+		default:
+			ctx.Error(http.StatusInternalServerError, "AddFileSignature", err)
+		}
+		return
+	}
+
+	apiSignature, err := convert.ToPackageSignature(ctx, ps, ctx.Package.Descriptor.Creator, ctx.Doer)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "ToPackageSignature", err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, apiSignature)
+}
+
+// PromotePackage copies a package version to another owner
+func PromotePackage(ctx *context.APIContext) {
+	// swagger:operation POST /packages/{owner}/{type}/{name}/{version}/promote package promotePackage
+	// ---
+	// summary: Promote a package version to another owner
+	// description: The package version is copied without uploading its files again. The authenticated user needs write access to the packages of both the owner of the package and the target owner.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: version of the package
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/PromotePackageOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/Package"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.PromotePackageOption)
+
+	target, err := user_model.GetUserByName(ctx, form.Owner)
+	if err != nil {
+		if user_model.IsErrUserNotExist(err) {
+			ctx.Error(http.StatusUnprocessableEntity, "", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetUserByName", err)
+		}
+		return
+	}
+
+	accessMode, err := context.PackageOwnerAccessMode(ctx.Base, target, ctx.Doer)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "PackageOwnerAccessMode", err)
+		return
+	}
+	if accessMode < perm.AccessModeWrite && !ctx.IsUserSiteAdmin() {
+		ctx.Error(http.StatusForbidden, "", "user should have write permission to the packages of the target owner")
+		return
+	}
+
+	pd, err := packages_service.PromotePackageVersion(ctx, ctx.Doer, target, ctx.Package.Descriptor)
+	if err != nil {
+		switch {
+		case errors.Is(err, packages.ErrDuplicatePackageVersion):
+			ctx.Error(http.StatusConflict, "", err)
+		case errors.Is(err, util.ErrInvalidArgument):
+			ctx.Error(http.StatusUnprocessableEntity, "", err)
+		case errors.Is(err, packages_service.ErrQuotaTotalCount), errors.Is(err, packages_service.ErrQuotaTypeSize), errors.Is(err, packages_service.ErrQuotaTotalSize):
+			ctx.Error(http.StatusForbidden, "", err)
+		default:
+			ctx.Error(http.StatusInternalServerError, "PromotePackageVersion", err)
+		}
+		return
+	}
+
+	apiPackage, err := convert.ToPackage(ctx, pd, ctx.Doer)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "Error converting package for api", err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, apiPackage)
+}
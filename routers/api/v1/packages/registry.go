@@ -0,0 +1,176 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"net/http"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/packages"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+// ListPackageRegistries gets the registry settings of all package types of an owner
+func ListPackageRegistries(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/registries package listPackageRegistries
+	// ---
+	// summary: Gets the registry settings of all package types of an owner
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the packages
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PackageRegistryList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	settings, err := packages.GetRegistrySettingsByOwner(ctx, ctx.Package.Owner.ID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetRegistrySettingsByOwner", err)
+		return
+	}
+
+	byType := make(map[packages.Type]*packages.PackageRegistrySetting, len(settings))
+	for _, prs := range settings {
+		byType[prs.Type] = prs
+	}
+
+	apiRegistries := make([]*api.PackageRegistry, 0, len(packages.TypeList))
+	for _, t := range packages.TypeList {
+		prs, ok := byType[t]
+		if !ok {
+			prs = &packages.PackageRegistrySetting{OwnerID: ctx.Package.Owner.ID, Type: t}
+		}
+		apiRegistries = append(apiRegistries, convert.ToPackageRegistry(prs))
+	}
+
+	ctx.JSON(http.StatusOK, apiRegistries)
+}
+
+// EditPackageRegistry edits the registry settings of a package type of an owner
+func EditPackageRegistry(ctx *context.APIContext) {
+	// swagger:operation PATCH /packages/{owner}/registries/{type} package editPackageRegistry
+	// ---
+	// summary: Edit the registry settings of a package type of an owner
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the packages
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the packages
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditPackageRegistryOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PackageRegistry"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	form := web.GetForm(ctx).(*api.EditPackageRegistryOption)
+
+	packageType := packages.Type(ctx.Params(":type"))
+	if !isKnownType(packageType) {
+		ctx.NotFound()
+		return
+	}
+
+	prs, err := packages.GetRegistrySetting(ctx, ctx.Package.Owner.ID, packageType)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetRegistrySetting", err)
+		return
+	}
+
+	if form.Immutable != nil {
+		prs.Immutable = *form.Immutable
+	}
+
+	if err := packages.UpsertRegistrySetting(ctx, prs); err != nil {
+		ctx.Error(http.StatusInternalServerError, "UpsertRegistrySetting", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToPackageRegistry(prs))
+}
+
+func isKnownType(packageType packages.Type) bool {
+	for _, t := range packages.TypeList {
+		if t == packageType {
+			return true
+		}
+	}
+	return false
+}
+
+// ListPackagePromotions gets the package versions promoted from or to an owner
+func ListPackagePromotions(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/promotions package listPackagePromotions
+	// ---
+	// summary: Gets the package versions promoted from or to an owner
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the packages
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PackagePromotionList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	listOptions := utils.GetListOptions(ctx)
+
+	promotions, count, err := db.FindAndCount[packages.PackagePromotion](ctx, packages.FindPromotionsOptions{
+		ListOptions: listOptions,
+		OwnerID:     ctx.Package.Owner.ID,
+	})
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "FindPromotions", err)
+		return
+	}
+
+	apiPromotions := make([]*api.PackagePromotion, 0, len(promotions))
+	for _, pp := range promotions {
+		apiPromotion, err := convert.ToPackagePromotion(ctx, pp, ctx.Doer)
+		if err != nil {
+			ctx.Error(http.StatusInternalServerError, "ToPackagePromotion", err)
+			return
+		}
+		apiPromotions = append(apiPromotions, apiPromotion)
+	}
+
+	ctx.SetLinkHeader(int(count), listOptions.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiPromotions)
+}
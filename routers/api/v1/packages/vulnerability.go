@@ -0,0 +1,271 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"code.gitea.io/gitea/models/packages"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+	"code.gitea.io/gitea/services/packages/vulnerability"
+)
+
+const maxSBOMSize = 32 << 20 // 32 MiB
+
+// ListPackageDependencies gets the dependencies of a package
+func ListPackageDependencies(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/{type}/{name}/{version}/dependencies package listPackageDependencies
+	// ---
+	// summary: Gets the dependencies declared in the package metadata and listed in the SBOM of a package
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: version of the package
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PackageDependencyList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	dependencies, err := vulnerability.GetDependencies(ctx, ctx.Package.Descriptor)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetDependencies", err)
+		return
+	}
+
+	apiDependencies := make([]*api.PackageDependency, 0, len(dependencies))
+	for _, d := range dependencies {
+		apiDependencies = append(apiDependencies, convert.ToPackageDependency(d))
+	}
+
+	ctx.JSON(http.StatusOK, apiDependencies)
+}
+
+// ListPackageVulnerabilities gets the known vulnerabilities of a package
+func ListPackageVulnerabilities(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/{type}/{name}/{version}/vulnerabilities package listPackageVulnerabilities
+	// ---
+	// summary: Gets the known vulnerabilities of a package and its dependencies
+	// description: Vulnerabilities are matched against the OSV database imported by the administrator.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: version of the package
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PackageVulnerabilityList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	findings, err := vulnerability.GetVulnerabilities(ctx, ctx.Package.Descriptor)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetVulnerabilities", err)
+		return
+	}
+
+	apiVulnerabilities := make([]*api.PackageVulnerability, 0, len(findings))
+	for _, f := range findings {
+		apiVulnerabilities = append(apiVulnerabilities, convert.ToPackageVulnerability(f))
+	}
+
+	ctx.JSON(http.StatusOK, apiVulnerabilities)
+}
+
+// GetPackageSBOM gets the SBOM of a package
+func GetPackageSBOM(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/{type}/{name}/{version}/sbom package getPackageSBOM
+	// ---
+	// summary: Gets the SBOM of a package
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: version of the package
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     description: CycloneDX or SPDX document
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	ps, err := packages.GetSBOMByVersionID(ctx, ctx.Package.Descriptor.Version.ID)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetSBOMByVersionID", err)
+		}
+		return
+	}
+
+	ctx.Resp.Header().Set("Content-Type", "application/json")
+	ctx.Resp.WriteHeader(http.StatusOK)
+	_, _ = ctx.Resp.Write([]byte(ps.Content))
+}
+
+// UploadPackageSBOM stores the SBOM of a package
+func UploadPackageSBOM(ctx *context.APIContext) {
+	// swagger:operation PUT /packages/{owner}/{type}/{name}/{version}/sbom package uploadPackageSBOM
+	// ---
+	// summary: Upload the SBOM of a package, an existing SBOM is replaced
+	// consumes:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: version of the package
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   description: CycloneDX or SPDX document in JSON format
+	//   required: true
+	//   schema:
+	//     type: object
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "413":
+	//     "$ref": "#/responses/error"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	content, err := io.ReadAll(io.LimitReader(ctx.Req.Body, maxSBOMSize+1))
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "ReadAll", err)
+		return
+	}
+	if len(content) > maxSBOMSize {
+		ctx.Error(http.StatusRequestEntityTooLarge, "", "SBOM exceeds maximum size")
+		return
+	}
+
+	if err := vulnerability.SetSBOM(ctx, ctx.Doer, ctx.Package.Descriptor.Version, content); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusUnprocessableEntity, "", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "SetSBOM", err)
+		}
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// DeletePackageSBOM deletes the SBOM of a package
+func DeletePackageSBOM(ctx *context.APIContext) {
+	// swagger:operation DELETE /packages/{owner}/{type}/{name}/{version}/sbom package deletePackageSBOM
+	// ---
+	// summary: Delete the SBOM of a package
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: version of the package
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	if err := packages.DeleteSBOMByVersionID(ctx, ctx.Package.Descriptor.Version.ID); err != nil {
+		ctx.Error(http.StatusInternalServerError, "DeleteSBOMByVersionID", err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
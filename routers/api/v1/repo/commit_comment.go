@@ -0,0 +1,372 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+
+	"code.gitea.io/gitea/models/db"
+	git_model "code.gitea.io/gitea/models/git"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+	repo_service "code.gitea.io/gitea/services/repository"
+)
+
+func listCommitComments(ctx *context.APIContext, opts git_model.FindCommitCommentsOptions) {
+	comments, count, err := db.FindAndCount[git_model.CommitComment](ctx, opts)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "FindCommitComments", err)
+		return
+	}
+	if err := git_model.CommitCommentList(comments).LoadPosters(ctx); err != nil {
+		ctx.Error(http.StatusInternalServerError, "LoadPosters", err)
+		return
+	}
+
+	apiComments := make([]*api.CommitComment, len(comments))
+	for i := range comments {
+		apiComments[i] = convert.ToCommitComment(ctx, ctx.Repo.Repository, comments[i])
+	}
+
+	ctx.SetLinkHeader(int(count), opts.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiComments)
+}
+
+// ListRepoCommitComments list the comments on the commits of a repository
+func ListRepoCommitComments(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/git/commits/comments repository repoListCommitComments
+	// ---
+	// summary: List the comments on the commits of a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/CommitCommentList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	listCommitComments(ctx, git_model.FindCommitCommentsOptions{
+		ListOptions: utils.GetListOptions(ctx),
+		RepoID:      ctx.Repo.Repository.ID,
+	})
+}
+
+// ListCommitComments list the comments on a commit
+func ListCommitComments(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/git/commits/{sha}/comments repository repoListCommitCommentsBySHA
+	// ---
+	// summary: List the comments on a commit
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: sha
+	//   in: path
+	//   description: sha of the commit
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/CommitCommentList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	commit, err := ctx.Repo.GitRepo.GetCommit(ctx.Params(":sha"))
+	if err != nil {
+		if git.IsErrNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetCommit", err)
+		}
+		return
+	}
+
+	listCommitComments(ctx, git_model.FindCommitCommentsOptions{
+		ListOptions: utils.GetListOptions(ctx),
+		RepoID:      ctx.Repo.Repository.ID,
+		CommitSHA:   commit.ID.String(),
+	})
+}
+
+// CreateCommitComment comment on a commit
+func CreateCommitComment(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/git/commits/{sha}/comments repository repoCreateCommitComment
+	// ---
+	// summary: Comment on a commit, on one of its files or on a line of one of its files
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: sha
+	//   in: path
+	//   description: sha of the commit
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateCommitCommentOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/CommitComment"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	form := web.GetForm(ctx).(*api.CreateCommitCommentOption)
+
+	comment, err := repo_service.CreateCommitComment(ctx, ctx.Doer, ctx.Repo.Repository, ctx.Repo.GitRepo, repo_service.CreateCommitCommentOptions{
+		CommitSHA: ctx.Params(":sha"),
+		TreePath:  form.Path,
+		Line:      form.Line,
+		Content:   form.Body,
+	})
+	if err != nil {
+		switch {
+		case git.IsErrNotExist(err):
+			ctx.NotFound()
+		case errors.Is(err, user_model.ErrBlockedByUser):
+			ctx.Error(http.StatusForbidden, "CreateCommitComment", err)
+		case errors.Is(err, util.ErrInvalidArgument):
+			ctx.Error(http.StatusUnprocessableEntity, "CreateCommitComment", err)
+		default:
+			ctx.Error(http.StatusInternalServerError, "CreateCommitComment", err)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, convert.ToCommitComment(ctx, ctx.Repo.Repository, comment))
+}
+
+func getCommitComment(ctx *context.APIContext) *git_model.CommitComment {
+	comment, err := git_model.GetCommitCommentByID(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if git_model.IsErrCommitCommentNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetCommitCommentByID", err)
+		}
+		return nil
+	}
+	if err := comment.LoadPoster(ctx); err != nil {
+		ctx.Error(http.StatusInternalServerError, "LoadPoster", err)
+		return nil
+	}
+	return comment
+}
+
+// GetCommitComment get a comment on a commit
+func GetCommitComment(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/git/commits/comments/{id} repository repoGetCommitComment
+	// ---
+	// summary: Get a comment on a commit
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the comment
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/CommitComment"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	comment := getCommitComment(ctx)
+	if comment == nil {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToCommitComment(ctx, ctx.Repo.Repository, comment))
+}
+
+// EditCommitComment edit a comment on a commit
+func EditCommitComment(ctx *context.APIContext) {
+	// swagger:operation PATCH /repos/{owner}/{repo}/git/commits/comments/{id} repository repoEditCommitComment
+	// ---
+	// summary: Edit a comment on a commit
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the comment
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditCommitCommentOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/CommitComment"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	form := web.GetForm(ctx).(*api.EditCommitCommentOption)
+
+	comment := getCommitComment(ctx)
+	if comment == nil {
+		return
+	}
+
+	if comment.PosterID != ctx.Doer.ID {
+		ctx.Status(http.StatusForbidden)
+		return
+	}
+
+	oldContent := comment.Content
+	comment.Content = form.Body
+	if err := repo_service.UpdateCommitComment(ctx, ctx.Doer, ctx.Repo.Repository, comment, oldContent); err != nil {
+		ctx.Error(http.StatusInternalServerError, "UpdateCommitComment", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToCommitComment(ctx, ctx.Repo.Repository, comment))
+}
+
+// DeleteCommitComment delete a comment on a commit
+func DeleteCommitComment(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/git/commits/comments/{id} repository repoDeleteCommitComment
+	// ---
+	// summary: Delete a comment on a commit
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the comment
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	comment := getCommitComment(ctx)
+	if comment == nil {
+		return
+	}
+
+	if comment.PosterID != ctx.Doer.ID && !ctx.Repo.CanWrite(unit.TypeCode) {
+		ctx.Status(http.StatusForbidden)
+		return
+	}
+
+	if err := repo_service.DeleteCommitComment(ctx, ctx.Doer, ctx.Repo.Repository, comment); err != nil {
+		ctx.Error(http.StatusInternalServerError, "DeleteCommitComment", err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -12,6 +12,7 @@ import (
 	"strings"
 	"time"
 
+	admin_model "code.gitea.io/gitea/models/admin"
 	"code.gitea.io/gitea/models/db"
 	issues_model "code.gitea.io/gitea/models/issues"
 	"code.gitea.io/gitea/models/organization"
@@ -19,16 +20,20 @@ import (
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unit"
 	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/gitrepo"
 	issue_indexer "code.gitea.io/gitea/modules/indexer/issues"
+	issue_template "code.gitea.io/gitea/modules/issue/template"
 	"code.gitea.io/gitea/modules/optional"
 	"code.gitea.io/gitea/modules/setting"
 	api "code.gitea.io/gitea/modules/structs"
 	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/modules/web"
 	"code.gitea.io/gitea/routers/api/v1/utils"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/convert"
 	issue_service "code.gitea.io/gitea/services/issue"
+	task_service "code.gitea.io/gitea/services/task"
 )
 
 // SearchIssues searches for issues across the repositories that the user has access to
@@ -53,7 +58,7 @@ func SearchIssues(ctx *context.APIContext) {
 	//   type: string
 	// - name: q
 	//   in: query
-	//   description: search string
+	//   description: search string, which can contain qualifiers such as `is:open label:bug author:@me`
 	//   type: string
 	// - name: priority_repo_id
 	//   in: query
@@ -115,6 +120,8 @@ func SearchIssues(ctx *context.APIContext) {
 	// responses:
 	//   "200":
 	//     "$ref": "#/responses/IssueList"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
 
 	before, since, err := context.GetQueryBeforeSince(ctx.Base)
 	if err != nil {
@@ -203,6 +210,11 @@ func SearchIssues(ctx *context.APIContext) {
 	if strings.IndexByte(keyword, 0) >= 0 {
 		keyword = ""
 	}
+	query, err := issue_indexer.ParseQuery(keyword)
+	if err != nil {
+		ctx.Error(http.StatusUnprocessableEntity, "ParseQuery", err)
+		return
+	}
 
 	var isPull optional.Option[bool]
 	switch ctx.FormString("type") {
@@ -292,6 +304,17 @@ func SearchIssues(ctx *context.APIContext) {
 		}
 	}
 
+	applyQuery, err := issue_service.ResolveSearchQuery(ctx, query, nil, ctx.Doer)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusUnprocessableEntity, "ResolveSearchQuery", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "ResolveSearchQuery", err)
+		}
+		return
+	}
+	searchOpt = searchOpt.Copy(applyQuery)
+
 	// FIXME: It's unsupported to sort by priority repo when searching by indexer,
 	//        it's indeed an regression, but I think it is worth to support filtering by indexer first.
 	_ = ctx.FormInt64("priority_repo_id")
@@ -306,6 +329,10 @@ func SearchIssues(ctx *context.APIContext) {
 		ctx.Error(http.StatusInternalServerError, "FindIssuesByIDs", err)
 		return
 	}
+	if err := issues.LoadForms(ctx); err != nil {
+		ctx.Error(http.StatusInternalServerError, "LoadForms", err)
+		return
+	}
 
 	ctx.SetLinkHeader(int(total), limit)
 	ctx.SetTotalCountHeader(total)
@@ -341,7 +368,7 @@ func ListIssues(ctx *context.APIContext) {
 	//   type: string
 	// - name: q
 	//   in: query
-	//   description: search string
+	//   description: search string, which may contain qualifiers taking precedence over the other parameters, such as is:open label:bug -label:wontfix assignee:@me updated:>2024-01-01 sort:updated-desc
 	//   type: string
 	// - name: type
 	//   in: query
@@ -376,6 +403,23 @@ func ListIssues(ctx *context.APIContext) {
 	//   in: query
 	//   description: Only show items in which the given user was mentioned
 	//   type: string
+	// - name: field
+	//   in: query
+	//   description: Only show items with the given value of a custom field, as "<field id>:<value>"
+	//   type: array
+	//   items:
+	//     type: string
+	//   collectionFormat: multi
+	// - name: sort_field
+	//   in: query
+	//   description: id of a custom field to sort the items by, the items without value come last
+	//   type: integer
+	//   format: int64
+	// - name: order
+	//   in: query
+	//   description: sort order when sorting by a custom field
+	//   type: string
+	//   enum: [asc, desc]
 	// - name: page
 	//   in: query
 	//   description: page number of results to return (1-based)
@@ -389,6 +433,8 @@ func ListIssues(ctx *context.APIContext) {
 	//     "$ref": "#/responses/IssueList"
 	//   "404":
 	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
 	before, since, err := context.GetQueryBeforeSince(ctx.Base)
 	if err != nil {
 		ctx.Error(http.StatusUnprocessableEntity, "GetQueryBeforeSince", err)
@@ -409,6 +455,14 @@ func ListIssues(ctx *context.APIContext) {
 	if strings.IndexByte(keyword, 0) >= 0 {
 		keyword = ""
 	}
+	query, err := issue_indexer.ParseQuery(keyword)
+	if err != nil {
+		ctx.Error(http.StatusUnprocessableEntity, "ParseQuery", err)
+		return
+	}
+	if query.IsClosed.Has() {
+		isClosed = query.IsClosed
+	}
 
 	var labelIDs []int64
 	if split := strings.Split(ctx.FormString("labels"), ","); len(split) > 0 {
@@ -458,6 +512,9 @@ func ListIssues(ctx *context.APIContext) {
 	case "issues":
 		isPull = optional.Some(false)
 	}
+	if query.IsPull.Has() {
+		isPull = query.IsPull
+	}
 
 	if isPull.Has() && !ctx.Repo.CanReadIssuesOrPulls(isPull.Value()) {
 		ctx.NotFound()
@@ -491,13 +548,20 @@ func ListIssues(ctx *context.APIContext) {
 		return
 	}
 
+	customFieldValues, sortBy := getCustomFieldFilterAndSort(ctx)
+	if ctx.Written() {
+		return
+	}
+
 	searchOpt := &issue_indexer.SearchOptions{
 		Paginator: &listOptions,
 		Keyword:   keyword,
 		RepoIDs:   []int64{ctx.Repo.Repository.ID},
 		IsPull:    isPull,
 		IsClosed:  isClosed,
-		SortBy:    issue_indexer.SortByCreatedDesc,
+		SortBy:    sortBy,
+
+		CustomFieldValues: customFieldValues,
 	}
 	if since != 0 {
 		searchOpt.UpdatedAfterUnix = optional.Some(since)
@@ -533,6 +597,21 @@ func ListIssues(ctx *context.APIContext) {
 		searchOpt.MentionID = optional.Some(mentionedByID)
 	}
 
+	applyQuery, err := issue_service.ResolveSearchQuery(ctx, query, ctx.Repo.Repository, ctx.Doer)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusUnprocessableEntity, "ResolveSearchQuery", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "ResolveSearchQuery", err)
+		}
+		return
+	}
+	// the query is resolved with the permissions of the request, the type it contains has been checked above
+	searchOpt = searchOpt.Copy(func(o *issue_indexer.SearchOptions) {
+		applyQuery(o)
+		o.IsPull = isPull
+	})
+
 	ids, total, err := issue_indexer.SearchIssues(ctx, searchOpt)
 	if err != nil {
 		ctx.Error(http.StatusInternalServerError, "SearchIssues", err)
@@ -543,12 +622,68 @@ func ListIssues(ctx *context.APIContext) {
 		ctx.Error(http.StatusInternalServerError, "FindIssuesByIDs", err)
 		return
 	}
+	if err := issues.LoadForms(ctx); err != nil {
+		ctx.Error(http.StatusInternalServerError, "LoadForms", err)
+		return
+	}
 
 	ctx.SetLinkHeader(int(total), listOptions.PageSize)
 	ctx.SetTotalCountHeader(total)
 	ctx.JSON(http.StatusOK, convert.ToAPIIssueList(ctx, ctx.Doer, issues))
 }
 
+// getCustomFieldFilterAndSort returns the normalized values of the custom field filters of the request, by field ID,
+// and the sort of the issues
+func getCustomFieldFilterAndSort(ctx *context.APIContext) (map[int64]string, issue_indexer.SortBy) {
+	filters := ctx.FormStrings("field")
+	sortFieldID := ctx.FormInt64("sort_field")
+	if len(filters) == 0 && sortFieldID == 0 {
+		return nil, issue_indexer.SortByCreatedDesc
+	}
+
+	getField := func(id int64) *issues_model.CustomField {
+		field, err := issues_model.GetCustomFieldInRepoByID(ctx, ctx.Repo.Repository.OwnerID, ctx.Repo.Repository.ID, id)
+		if err != nil {
+			if issues_model.IsErrCustomFieldNotExist(err) {
+				ctx.Error(http.StatusUnprocessableEntity, "GetCustomFieldInRepoByID", err)
+			} else {
+				ctx.Error(http.StatusInternalServerError, "GetCustomFieldInRepoByID", err)
+			}
+			return nil
+		}
+		return field
+	}
+
+	values := make(map[int64]string, len(filters))
+	for _, filter := range filters {
+		idStr, value, _ := strings.Cut(filter, ":")
+		id, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil {
+			ctx.Error(http.StatusUnprocessableEntity, "field", fmt.Errorf("invalid custom field filter %q", filter))
+			return nil, ""
+		}
+		field := getField(id)
+		if field == nil {
+			return nil, ""
+		}
+		normalized, _, err := field.NormalizeValue(ctx, value)
+		if err != nil {
+			ctx.Error(http.StatusUnprocessableEntity, "NormalizeValue", err)
+			return nil, ""
+		}
+		values[id] = normalized
+	}
+
+	sortBy := issue_indexer.SortByCreatedDesc
+	if sortFieldID != 0 {
+		if getField(sortFieldID) == nil {
+			return nil, ""
+		}
+		sortBy = issue_indexer.SortByCustomField(sortFieldID, ctx.FormString("order") == "desc")
+	}
+	return values, sortBy
+}
+
 func getUserIDForFilter(ctx *context.APIContext, queryName string) int64 {
 	userName := ctx.FormString(queryName)
 	if len(userName) == 0 {
@@ -596,13 +731,15 @@ func GetIssue(ctx *context.APIContext) {
 	// responses:
 	//   "200":
 	//     "$ref": "#/responses/Issue"
+	//   "301":
+	//     description: the issue was transferred to another repository
 	//   "404":
 	//     "$ref": "#/responses/notFound"
 
 	issue, err := issues_model.GetIssueWithAttrsByIndex(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":index"))
 	if err != nil {
 		if issues_model.IsErrIssueNotExist(err) {
-			ctx.NotFound()
+			redirectTransferredIssue(ctx)
 		} else {
 			ctx.Error(http.StatusInternalServerError, "GetIssueByIndex", err)
 		}
@@ -669,6 +806,13 @@ func CreateIssue(ctx *context.APIContext) {
 		DeadlineUnix: deadlineUnix,
 	}
 
+	if form.Template != "" {
+		issue.Form, issue.Content = newIssueFormFromTemplate(ctx, form.Template, form.FormAnswers)
+		if ctx.Written() {
+			return
+		}
+	}
+
 	assigneeIDs := make([]int64, 0)
 	var err error
 	if ctx.Repo.CanWrite(unit.TypeIssues) {
@@ -738,6 +882,44 @@ func CreateIssue(ctx *context.APIContext) {
 	ctx.JSON(http.StatusCreated, convert.ToAPIIssue(ctx, ctx.Doer, issue))
 }
 
+// newIssueFormFromTemplate validates the answers to the issue form of the default branch at the given path,
+// and returns them along with the content of the issue rendered from them
+func newIssueFormFromTemplate(ctx *context.APIContext, filename string, answers []*api.IssueFormAnswer) (*issues_model.IssueForm, string) {
+	if ctx.Repo.Repository.IsEmpty {
+		ctx.Error(http.StatusUnprocessableEntity, "", "the repository has no issue forms")
+		return nil, ""
+	}
+
+	gitRepo, closer, err := gitrepo.RepositoryFromContextOrOpen(ctx, ctx.Repo.Repository)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "RepositoryFromContextOrOpen", err)
+		return nil, ""
+	}
+	defer closer.Close()
+
+	template, err := issue_template.UnmarshalFromRepo(gitRepo, ctx.Repo.Repository.DefaultBranch, filename)
+	if err != nil {
+		ctx.Error(http.StatusUnprocessableEntity, "", fmt.Sprintf("invalid issue form %s: %v", filename, err))
+		return nil, ""
+	}
+	values, err := issue_template.ToValues(template, answers)
+	if err != nil {
+		ctx.Error(http.StatusUnprocessableEntity, "", fmt.Sprintf("invalid answers to the issue form: %v", err))
+		return nil, ""
+	}
+
+	issueForm, content, err := issue_service.NewIssueForm(template, values)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusUnprocessableEntity, "", err.Error())
+		} else {
+			ctx.Error(http.StatusInternalServerError, "NewIssueForm", err)
+		}
+		return nil, ""
+	}
+	return issueForm, content
+}
+
 // EditIssue modify an issue of a repository
 func EditIssue(ctx *context.APIContext) {
 	// swagger:operation PATCH /repos/{owner}/{repo}/issues/{index} issue issueEditIssue
@@ -919,6 +1101,160 @@ func EditIssue(ctx *context.APIContext) {
 	ctx.JSON(http.StatusCreated, convert.ToAPIIssue(ctx, ctx.Doer, issue))
 }
 
+// BulkEditIssues edits the issues and pull requests matching a search query
+func BulkEditIssues(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/issues/bulk_edit issue issueBulkEdit
+	// ---
+	// summary: Edit the issues and pull requests matching a search query
+	// description: The issues are edited in a single transaction by a background task, whose progress is returned.
+	//   Only the issues, or the pull requests, the user can write are edited.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/BulkEditIssuesOption"
+	// responses:
+	//   "202":
+	//     "$ref": "#/responses/BulkEditIssuesTask"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	form := web.GetForm(ctx).(*api.BulkEditIssuesOption)
+
+	isPull := optional.None[bool]()
+	canWriteIssues, canWritePulls := ctx.Repo.CanWriteIssuesOrPulls(false), ctx.Repo.CanWriteIssuesOrPulls(true)
+	switch {
+	case !canWriteIssues && !canWritePulls:
+		ctx.Error(http.StatusForbidden, "CanWriteIssuesOrPulls", "user cannot edit the issues or the pull requests")
+		return
+	case !canWritePulls:
+		isPull = optional.Some(false)
+	case !canWriteIssues:
+		isPull = optional.Some(true)
+	}
+
+	opts := issue_service.BulkEditOptions{
+		AddLabelIDs:    form.AddLabels,
+		RemoveLabelIDs: form.RemoveLabels,
+		MilestoneID:    form.Milestone,
+	}
+	var err error
+	if opts.AddAssigneeIDs, err = user_model.GetUserIDsByNames(ctx, form.AddAssignees, false); err != nil {
+		if user_model.IsErrUserNotExist(err) {
+			ctx.Error(http.StatusUnprocessableEntity, "GetUserIDsByNames", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetUserIDsByNames", err)
+		}
+		return
+	}
+	if opts.RemoveAssigneeIDs, err = user_model.GetUserIDsByNames(ctx, form.RemoveAssignees, false); err != nil {
+		if user_model.IsErrUserNotExist(err) {
+			ctx.Error(http.StatusUnprocessableEntity, "GetUserIDsByNames", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetUserIDsByNames", err)
+		}
+		return
+	}
+	if form.State != nil {
+		switch api.StateType(*form.State) {
+		case api.StateOpen, api.StateClosed:
+			isClosed := api.StateType(*form.State) == api.StateClosed
+			opts.IsClosed = &isClosed
+		default:
+			ctx.Error(http.StatusUnprocessableEntity, "State", fmt.Errorf("invalid state %q", *form.State))
+			return
+		}
+	}
+	if opts.IsEmpty() {
+		ctx.Error(http.StatusUnprocessableEntity, "BulkEditOptions", "no change to apply to the issues")
+		return
+	}
+
+	issueIDs, err := issue_service.FindBulkEditIssueIDs(ctx, ctx.Repo.Repository, ctx.Doer, form.Query, isPull)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusUnprocessableEntity, "FindBulkEditIssueIDs", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "FindBulkEditIssueIDs", err)
+		}
+		return
+	}
+
+	task, err := task_service.BulkEditIssues(ctx, ctx.Doer, ctx.Repo.Repository, issueIDs, opts)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "BulkEditIssues", err)
+		return
+	}
+	ctx.JSON(http.StatusAccepted, convert.ToAPIBulkEditIssuesTask(task))
+}
+
+// GetBulkEditIssuesTask returns the progress of the edition of issues
+func GetBulkEditIssuesTask(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issues/bulk_edit/{id} issue issueGetBulkEditTask
+	// ---
+	// summary: Get the progress of the edition of issues matching a search query
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the bulk edit task
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/BulkEditIssuesTask"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	task, err := admin_model.GetRepoTaskByID(ctx, ctx.Repo.Repository.ID, api.TaskTypeBulkEditIssues, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if admin_model.IsErrTaskDoesNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetRepoTaskByID", err)
+		}
+		return
+	}
+	// only the user who started the edition and the administrators of the repository can follow it
+	if task.DoerID != ctx.Doer.ID && !ctx.Repo.IsAdmin() {
+		ctx.NotFound()
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToAPIBulkEditIssuesTask(task))
+}
+
 func DeleteIssue(ctx *context.APIContext) {
 	// swagger:operation DELETE /repos/{owner}/{repo}/issues/{index} issue issueDelete
 	// ---
@@ -0,0 +1,40 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"net/http"
+	"testing"
+
+	"code.gitea.io/gitea/models/perm"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	"code.gitea.io/gitea/models/unit"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/services/contexttest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListIssueCustomFieldValuesPermission(t *testing.T) {
+	unittest.PrepareTestEnv(t)
+
+	list := func(t *testing.T, index string, unitType unit.Type) int {
+		ctx, _ := contexttest.MockAPIContext(t, "user2/repo1/issues/"+index+"/custom_fields")
+		contexttest.LoadRepo(t, ctx, 1)
+		contexttest.LoadUser(t, ctx, 4)
+		ctx.Repo.Permission = access_model.Permission{
+			Units:     ctx.Repo.Repository.Units,
+			UnitsMode: map[unit.Type]perm.AccessMode{unitType: perm.AccessModeRead},
+		}
+		ctx.SetParams(":index", index)
+		ListIssueCustomFieldValues(ctx)
+		return ctx.Resp.Status()
+	}
+
+	// the issue 1 of the repository 1 is an issue, the issue 2 is a pull request
+	assert.Equal(t, http.StatusOK, list(t, "1", unit.TypeIssues))
+	assert.Equal(t, http.StatusNotFound, list(t, "1", unit.TypePullRequests))
+	assert.Equal(t, http.StatusOK, list(t, "2", unit.TypePullRequests))
+	assert.Equal(t, http.StatusNotFound, list(t, "2", unit.TypeIssues))
+}
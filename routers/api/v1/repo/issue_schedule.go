@@ -0,0 +1,360 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/organization"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+	issue_service "code.gitea.io/gitea/services/issue"
+)
+
+// ListIssueSchedules list the issue schedules of a repository
+func ListIssueSchedules(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issue_schedules issue issueListSchedules
+	// ---
+	// summary: List the schedules creating issues in a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueScheduleList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	schedules, err := issues_model.GetIssueSchedules(ctx, ctx.Repo.Repository.ID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetIssueSchedules", err)
+		return
+	}
+	apiSchedules, err := convert.ToIssueScheduleList(ctx, schedules)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "ToIssueScheduleList", err)
+		return
+	}
+
+	ctx.SetTotalCountHeader(int64(len(schedules)))
+	ctx.JSON(http.StatusOK, apiSchedules)
+}
+
+// GetIssueSchedule get an issue schedule of a repository
+func GetIssueSchedule(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issue_schedules/{id} issue issueGetSchedule
+	// ---
+	// summary: Get a schedule creating issues in a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the issue schedule
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueSchedule"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	schedule := getIssueSchedule(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	respondIssueSchedule(ctx, http.StatusOK, schedule)
+}
+
+// CreateIssueSchedule create an issue schedule for a repository
+func CreateIssueSchedule(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/issue_schedules issue issueCreateSchedule
+	// ---
+	// summary: Create a schedule creating issues in a repository, once at a given date or at a regular interval
+	// description: The issues are posted by the creator of the schedule. When the schedule has no title or body,
+	//   they are taken from its template, whose labels are also applied.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateIssueScheduleOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/IssueSchedule"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	form := web.GetForm(ctx).(*api.CreateIssueScheduleOption)
+	schedule := &issues_model.IssueSchedule{
+		RepoID:       ctx.Repo.Repository.ID,
+		CreatorID:    ctx.Doer.ID,
+		Title:        form.Title,
+		Content:      form.Body,
+		TemplateFile: form.Template,
+		Interval:     issues_model.IssueScheduleInterval(form.Interval),
+		StartUnix:    timeutil.TimeStamp(form.Start.Unix()),
+	}
+	if form.AssigneeTeam != "" {
+		if schedule.AssigneeTeamID = getIssueScheduleTeamID(ctx, form.AssigneeTeam); ctx.Written() {
+			return
+		}
+	}
+	if !checkIssueScheduleTemplate(ctx, schedule.TemplateFile) {
+		return
+	}
+
+	if err := issues_model.CreateIssueSchedule(ctx, schedule); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusUnprocessableEntity, "CreateIssueSchedule", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "CreateIssueSchedule", err)
+		}
+		return
+	}
+
+	respondIssueSchedule(ctx, http.StatusCreated, schedule)
+}
+
+// EditIssueSchedule modify an issue schedule of a repository
+func EditIssueSchedule(ctx *context.APIContext) {
+	// swagger:operation PATCH /repos/{owner}/{repo}/issue_schedules/{id} issue issueEditSchedule
+	// ---
+	// summary: Edit a schedule creating issues in a repository
+	// description: Changing the start, the interval or the activation moves the next issue to the first time of the schedule from now. The issues of the schedule are then posted by the user editing it.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the issue schedule
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditIssueScheduleOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueSchedule"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	schedule := getIssueSchedule(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	form := web.GetForm(ctx).(*api.EditIssueScheduleOption)
+	if form.Title != nil {
+		schedule.Title = *form.Title
+	}
+	if form.Body != nil {
+		schedule.Content = *form.Body
+	}
+	if form.Template != nil && *form.Template != schedule.TemplateFile {
+		if !checkIssueScheduleTemplate(ctx, *form.Template) {
+			return
+		}
+		schedule.TemplateFile = *form.Template
+	}
+	if form.Interval != nil {
+		schedule.Interval = issues_model.IssueScheduleInterval(*form.Interval)
+	}
+	if form.Start != nil {
+		schedule.StartUnix = timeutil.TimeStamp(form.Start.Unix())
+	}
+	if form.AssigneeTeam != nil {
+		schedule.AssigneeTeamID = 0
+		if *form.AssigneeTeam != "" {
+			if schedule.AssigneeTeamID = getIssueScheduleTeamID(ctx, *form.AssigneeTeam); ctx.Written() {
+				return
+			}
+		}
+	}
+	if form.Active != nil {
+		schedule.IsActive = *form.Active
+	}
+
+	if err := issues_model.UpdateIssueSchedule(ctx, ctx.Doer, schedule); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusUnprocessableEntity, "UpdateIssueSchedule", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "UpdateIssueSchedule", err)
+		}
+		return
+	}
+
+	respondIssueSchedule(ctx, http.StatusOK, schedule)
+}
+
+// DeleteIssueSchedule delete an issue schedule of a repository
+func DeleteIssueSchedule(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/issue_schedules/{id} issue issueDeleteSchedule
+	// ---
+	// summary: Delete a schedule creating issues in a repository, the issues it created are kept
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the issue schedule
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	schedule := getIssueSchedule(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := issues_model.DeleteIssueSchedule(ctx, ctx.Repo.Repository.ID, schedule.ID); err != nil {
+		ctx.Error(http.StatusInternalServerError, "DeleteIssueSchedule", err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// getIssueSchedule returns the issue schedule of the request
+func getIssueSchedule(ctx *context.APIContext) *issues_model.IssueSchedule {
+	schedule, err := issues_model.GetIssueScheduleByID(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if issues_model.IsErrIssueScheduleNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetIssueScheduleByID", err)
+		}
+		return nil
+	}
+	return schedule
+}
+
+// getIssueScheduleTeamID returns the ID of the team of the organization owning the repository with the given name
+func getIssueScheduleTeamID(ctx *context.APIContext, name string) int64 {
+	if !ctx.Repo.Owner.IsOrganization() {
+		ctx.Error(http.StatusUnprocessableEntity, "", "only the issues of a repository owned by an organization can be assigned to a team")
+		return 0
+	}
+
+	team, err := organization.GetTeam(ctx, ctx.Repo.Owner.ID, name)
+	if err != nil {
+		if organization.IsErrTeamNotExist(err) {
+			ctx.Error(http.StatusUnprocessableEntity, "", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetTeam", err)
+		}
+		return 0
+	}
+	return team.ID
+}
+
+// checkIssueScheduleTemplate checks that the template of a schedule is an issue template of the default branch
+func checkIssueScheduleTemplate(ctx *context.APIContext, filename string) bool {
+	if filename == "" {
+		return true
+	}
+
+	if _, err := issue_service.LoadIssueScheduleTemplate(ctx, ctx.Repo.Repository, filename); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusUnprocessableEntity, "", err.Error())
+		} else {
+			ctx.Error(http.StatusInternalServerError, "LoadIssueScheduleTemplate", err)
+		}
+		return false
+	}
+	return true
+}
+
+func respondIssueSchedule(ctx *context.APIContext, status int, schedule *issues_model.IssueSchedule) {
+	apiSchedule, err := convert.ToIssueSchedule(ctx, schedule)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "ToIssueSchedule", err)
+		return
+	}
+	ctx.JSON(status, apiSchedule)
+}
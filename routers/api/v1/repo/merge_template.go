@@ -0,0 +1,276 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"net/http"
+	"strings"
+
+	git_model "code.gitea.io/gitea/models/git"
+	repo_model "code.gitea.io/gitea/models/repo"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+// ListMergeMessageTemplates list the merge message templates of a repository
+func ListMergeMessageTemplates(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/merge_message_templates repository repoListMergeMessageTemplates
+	// ---
+	// summary: List the merge message templates of a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/MergeMessageTemplateList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	tmpls, err := git_model.GetMergeMessageTemplates(ctx, ctx.Repo.Repository.ID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetMergeMessageTemplates", err)
+		return
+	}
+
+	apiTmpls := make([]*api.MergeMessageTemplate, len(tmpls))
+	for i := range tmpls {
+		apiTmpls[i] = convert.ToMergeMessageTemplate(tmpls[i])
+	}
+
+	ctx.JSON(http.StatusOK, apiTmpls)
+}
+
+func getMergeMessageTemplate(ctx *context.APIContext) *git_model.MergeMessageTemplate {
+	tmpl, err := git_model.GetMergeMessageTemplateByID(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if git_model.IsErrMergeMessageTemplateNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetMergeMessageTemplateByID", err)
+		}
+		return nil
+	}
+	return tmpl
+}
+
+// GetMergeMessageTemplate get a merge message template of a repository
+func GetMergeMessageTemplate(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/merge_message_templates/{id} repository repoGetMergeMessageTemplate
+	// ---
+	// summary: Get a merge message template of a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the merge message template
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/MergeMessageTemplate"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	tmpl := getMergeMessageTemplate(ctx)
+	if tmpl == nil {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToMergeMessageTemplate(tmpl))
+}
+
+// CreateMergeMessageTemplate create a merge message template for a repository
+func CreateMergeMessageTemplate(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/merge_message_templates repository repoCreateMergeMessageTemplate
+	// ---
+	// summary: Create a merge message template for a repository
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateMergeMessageTemplateOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/MergeMessageTemplate"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	form := web.GetForm(ctx).(*api.CreateMergeMessageTemplateOption)
+
+	tmpl := &git_model.MergeMessageTemplate{
+		RepoID:        ctx.Repo.Repository.ID,
+		BranchPattern: strings.TrimSpace(form.BranchPattern),
+		MergeStyle:    repo_model.MergeStyle(form.MergeStyle),
+		Template:      strings.TrimSpace(form.Template),
+		AddTrailers:   form.AddTrailers,
+	}
+	if tmpl.BranchPattern == "" || tmpl.Template == "" {
+		ctx.Error(http.StatusUnprocessableEntity, "", "branch_pattern and template are required")
+		return
+	}
+	if err := git_model.CreateMergeMessageTemplate(ctx, tmpl); err != nil {
+		ctx.Error(http.StatusInternalServerError, "CreateMergeMessageTemplate", err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, convert.ToMergeMessageTemplate(tmpl))
+}
+
+// EditMergeMessageTemplate edit a merge message template of a repository
+func EditMergeMessageTemplate(ctx *context.APIContext) {
+	// swagger:operation PATCH /repos/{owner}/{repo}/merge_message_templates/{id} repository repoEditMergeMessageTemplate
+	// ---
+	// summary: Edit a merge message template of a repository. Only fields that are set will be changed
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the merge message template
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditMergeMessageTemplateOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/MergeMessageTemplate"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	form := web.GetForm(ctx).(*api.EditMergeMessageTemplateOption)
+
+	tmpl := getMergeMessageTemplate(ctx)
+	if tmpl == nil {
+		return
+	}
+
+	if form.BranchPattern != nil {
+		tmpl.BranchPattern = strings.TrimSpace(*form.BranchPattern)
+	}
+	if form.MergeStyle != nil {
+		tmpl.MergeStyle = repo_model.MergeStyle(*form.MergeStyle)
+	}
+	if form.Template != nil {
+		tmpl.Template = strings.TrimSpace(*form.Template)
+	}
+	if form.AddTrailers != nil {
+		tmpl.AddTrailers = *form.AddTrailers
+	}
+	if tmpl.BranchPattern == "" || tmpl.Template == "" {
+		ctx.Error(http.StatusUnprocessableEntity, "", "branch_pattern and template cannot be empty")
+		return
+	}
+
+	if err := git_model.UpdateMergeMessageTemplate(ctx, tmpl); err != nil {
+		ctx.Error(http.StatusInternalServerError, "UpdateMergeMessageTemplate", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToMergeMessageTemplate(tmpl))
+}
+
+// DeleteMergeMessageTemplate delete a merge message template of a repository
+func DeleteMergeMessageTemplate(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/merge_message_templates/{id} repository repoDeleteMergeMessageTemplate
+	// ---
+	// summary: Delete a merge message template of a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the merge message template
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	if err := git_model.DeleteMergeMessageTemplate(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":id")); err != nil {
+		if git_model.IsErrMergeMessageTemplateNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "DeleteMergeMessageTemplate", err)
+		}
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
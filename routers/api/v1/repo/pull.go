@@ -37,6 +37,7 @@ import (
 	"code.gitea.io/gitea/services/forms"
 	"code.gitea.io/gitea/services/gitdiff"
 	issue_service "code.gitea.io/gitea/services/issue"
+	"code.gitea.io/gitea/services/mergequeue"
 	notify_service "code.gitea.io/gitea/services/notify"
 	pull_service "code.gitea.io/gitea/services/pull"
 	repo_service "code.gitea.io/gitea/services/repository"
@@ -749,6 +750,30 @@ func EditPullRequest(ctx *context.APIContext) {
 		notify_service.PullRequestChangeTargetBranch(ctx, ctx.Doer, pr, form.Base)
 	}
 
+	// change the pull request this one is stacked on
+	if !pr.HasMerged && form.StackedOn != nil {
+		var parent *issues_model.PullRequest
+		if *form.StackedOn != 0 {
+			parent, err = issues_model.GetPullRequestByIndex(ctx, ctx.Repo.Repository.ID, *form.StackedOn)
+			if err != nil {
+				if issues_model.IsErrPullRequestNotExist(err) {
+					ctx.Error(http.StatusUnprocessableEntity, "GetPullRequestByIndex", fmt.Errorf("pull request #%d to stack on does not exist", *form.StackedOn))
+				} else {
+					ctx.Error(http.StatusInternalServerError, "GetPullRequestByIndex", err)
+				}
+				return
+			}
+		}
+		if err := issues_model.SetStackParent(ctx, pr, parent); err != nil {
+			if issues_model.IsErrInvalidStackParent(err) {
+				ctx.Error(http.StatusUnprocessableEntity, "SetStackParent", err)
+				return
+			}
+			ctx.Error(http.StatusInternalServerError, "SetStackParent", err)
+			return
+		}
+	}
+
 	// update allow edits
 	if form.AllowMaintainerEdit != nil {
 		if err := pull_service.SetAllowEdits(ctx, ctx.Doer, pr, *form.AllowMaintainerEdit); err != nil {
@@ -853,6 +878,8 @@ func MergePullRequest(ctx *context.APIContext) {
 	// responses:
 	//   "200":
 	//     "$ref": "#/responses/empty"
+	//   "202":
+	//     "$ref": "#/responses/empty"
 	//   "404":
 	//     "$ref": "#/responses/notFound"
 	//   "405":
@@ -979,6 +1006,26 @@ func MergePullRequest(ctx *context.APIContext) {
 		}
 	}
 
+	if !form.ForceMerge {
+		enabled, err := mergequeue.IsEnabled(ctx, pr)
+		if err != nil {
+			ctx.Error(http.StatusInternalServerError, "IsEnabled", err)
+			return
+		} else if enabled {
+			if err := mergequeue.Add(ctx, ctx.Doer, pr, repo_model.MergeStyle(form.Do), message); err != nil {
+				if pull_model.IsErrAlreadyInMergeQueue(err) {
+					ctx.Error(http.StatusConflict, "AddToMergeQueue", err)
+					return
+				}
+				ctx.Error(http.StatusInternalServerError, "AddToMergeQueue", err)
+				return
+			}
+			// the pull request is merged by the merge queue
+			ctx.Status(http.StatusAccepted)
+			return
+		}
+	}
+
 	if err := pull_service.Merge(ctx, pr, ctx.Doer, ctx.Repo.GitRepo, repo_model.MergeStyle(form.Do), form.HeadCommitID, message, false); err != nil {
 		if models.IsErrInvalidMergeStyle(err) {
 			ctx.Error(http.StatusMethodNotAllowed, "Invalid merge style", fmt.Errorf("%s is not allowed an allowed merge style for this repository", repo_model.MergeStyle(form.Do)))
@@ -0,0 +1,195 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"net/http"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	pull_model "code.gitea.io/gitea/models/pull"
+	"code.gitea.io/gitea/models/unit"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+	"code.gitea.io/gitea/services/mergequeue"
+)
+
+// getMergeQueueEntry returns the pull request of the context and its merge queue entry
+func getMergeQueueEntry(ctx *context.APIContext) (*issues_model.PullRequest, *pull_model.MergeQueueEntry) {
+	pr, err := issues_model.GetPullRequestByIndex(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":index"))
+	if err != nil {
+		if issues_model.IsErrPullRequestNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.InternalServerError(err)
+		}
+		return nil, nil
+	}
+
+	exists, e, err := pull_model.GetMergeQueueEntryByPullID(ctx, pr.ID)
+	if err != nil {
+		ctx.InternalServerError(err)
+		return nil, nil
+	}
+	if !exists {
+		ctx.NotFound()
+		return nil, nil
+	}
+	return pr, e
+}
+
+// GetPullRequestMergeQueueEntry gets the merge queue state of a pull request
+func GetPullRequestMergeQueueEntry(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/pulls/{index}/merge-queue repository repoGetPullRequestMergeQueueEntry
+	// ---
+	// summary: Get the merge queue state of a pull request
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the pull request
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/MergeQueueEntry"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	pr, e := getMergeQueueEntry(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := e.LoadDoer(ctx); err != nil {
+		ctx.InternalServerError(err)
+		return
+	}
+	position, err := pull_model.GetMergeQueuePosition(ctx, e)
+	if err != nil {
+		ctx.InternalServerError(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToMergeQueueEntry(ctx, e, pr, position, ctx.Doer))
+}
+
+// RemovePullRequestFromMergeQueue removes a pull request from the merge queue
+func RemovePullRequestFromMergeQueue(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/pulls/{index}/merge-queue repository repoRemovePullRequestFromMergeQueue
+	// ---
+	// summary: Remove a pull request from the merge queue
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the pull request
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	pr, e := getMergeQueueEntry(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if ctx.Doer.ID != e.DoerID && !ctx.Repo.CanWrite(unit.TypeCode) {
+		ctx.Error(http.StatusForbidden, "No permission to remove", "user has no permission to remove the pull request from the merge queue")
+		return
+	}
+
+	if err := mergequeue.Remove(ctx, ctx.Doer, pr); err != nil {
+		ctx.InternalServerError(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListMergeQueue lists the pull requests in the merge queue of a branch
+func ListMergeQueue(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/merge-queue repository repoListMergeQueue
+	// ---
+	// summary: List the pull requests in the merge queue of a branch in queue order
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: branch
+	//   in: query
+	//   description: base branch of the merge queue, defaults to the default branch
+	//   type: string
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/MergeQueueEntryList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	branch := ctx.FormTrim("branch")
+	if branch == "" {
+		branch = ctx.Repo.Repository.DefaultBranch
+	}
+
+	entries, err := pull_model.GetMergeQueue(ctx, ctx.Repo.Repository.ID, branch)
+	if err != nil {
+		ctx.InternalServerError(err)
+		return
+	}
+
+	apiEntries := make([]*api.MergeQueueEntry, 0, len(entries))
+	for i, e := range entries {
+		pr, err := issues_model.GetPullRequestByID(ctx, e.PullID)
+		if err != nil {
+			ctx.InternalServerError(err)
+			return
+		}
+		if err := e.LoadDoer(ctx); err != nil {
+			ctx.InternalServerError(err)
+			return
+		}
+		apiEntries = append(apiEntries, convert.ToMergeQueueEntry(ctx, e, pr, int64(i+1), ctx.Doer))
+	}
+
+	ctx.JSON(http.StatusOK, apiEntries)
+}
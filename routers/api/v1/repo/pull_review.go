@@ -310,6 +310,8 @@ func CreatePullReviewComment(ctx *context.APIContext) {
 	// responses:
 	//   "200":
 	//     "$ref": "#/responses/PullReviewComment"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
 	//   "404":
 	//     "$ref": "#/responses/notFound"
 	//   "422":
@@ -322,14 +324,19 @@ func CreatePullReviewComment(ctx *context.APIContext) {
 		return
 	}
 
+	if review.ReviewerID != ctx.Doer.ID {
+		ctx.Error(http.StatusForbidden, "", "only the reviewer can add comments to a review")
+		return
+	}
+
 	if err := pr.Issue.LoadRepo(ctx); err != nil {
 		ctx.InternalServerError(err)
 		return
 	}
 
-	line := opts.NewLineNum
-	if opts.OldLineNum > 0 {
-		line = opts.OldLineNum * -1
+	path, line, ok := reviewCommentPosition(ctx, pr, api.CreatePullReviewComment(*opts))
+	if !ok {
+		return
 	}
 
 	comment, err := pull_service.CreateCodeCommentKnownReviewID(ctx,
@@ -337,7 +344,7 @@ func CreatePullReviewComment(ctx *context.APIContext) {
 		pr.Issue.Repo,
 		pr.Issue,
 		opts.Body,
-		opts.Path,
+		path,
 		line,
 		review.ID,
 		nil,
@@ -356,6 +363,204 @@ func CreatePullReviewComment(ctx *context.APIContext) {
 	ctx.JSON(http.StatusOK, apiComment)
 }
 
+// EditPullReviewComment edit the body of a pull review comment
+func EditPullReviewComment(ctx *context.APIContext) {
+	// swagger:operation PATCH /repos/{owner}/{repo}/pulls/{index}/reviews/{id}/comments/{comment} repository repoEditPullReviewComment
+	// ---
+	// summary: Edit a pull review comment
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the pull request
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the review
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: comment
+	//   in: path
+	//   description: id of the comment
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/EditPullReviewCommentOptions"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PullReviewComment"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	opts := web.GetForm(ctx).(*api.EditPullReviewCommentOptions)
+
+	review, _, statusSet := prepareSingleReview(ctx)
+	if statusSet {
+		return
+	}
+
+	comment := ctx.Comment
+	if comment.ReviewID != review.ID || comment.Type != issues_model.CommentTypeCode {
+		ctx.NotFound()
+		return
+	}
+	if comment.PosterID != ctx.Doer.ID && !ctx.Repo.CanWriteIssuesOrPulls(true) {
+		ctx.Status(http.StatusForbidden)
+		return
+	}
+
+	if err := comment.LoadIssue(ctx); err != nil {
+		ctx.InternalServerError(err)
+		return
+	}
+
+	oldContent := comment.Content
+	comment.Content = opts.Body
+	if err := issue_service.UpdateComment(ctx, comment, comment.ContentVersion, ctx.Doer, oldContent); err != nil {
+		ctx.Error(http.StatusInternalServerError, "UpdateComment", err)
+		return
+	}
+
+	if err := comment.LoadPoster(ctx); err != nil {
+		ctx.InternalServerError(err)
+		return
+	}
+
+	apiComment, err := convert.ToPullReviewComment(ctx, review, comment, ctx.Doer)
+	if err != nil {
+		ctx.InternalServerError(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, apiComment)
+}
+
+// reviewCommentPosition returns the path and the line of a new review comment, taken from the comment
+// it replies to if any, it writes the error response and returns false if the comment cannot be replied to.
+// The line is negative for a line of the old file.
+func reviewCommentPosition(ctx *context.APIContext, pr *issues_model.PullRequest, c api.CreatePullReviewComment) (string, int64, bool) {
+	if c.ReplyTo == 0 {
+		line := c.NewLineNum
+		if c.OldLineNum > 0 {
+			line = c.OldLineNum * -1
+		}
+		return c.Path, line, true
+	}
+
+	comment, err := issues_model.GetCommentByID(ctx, c.ReplyTo)
+	if err != nil {
+		if issues_model.IsErrCommentNotExist(err) {
+			ctx.Error(http.StatusUnprocessableEntity, "", fmt.Errorf("the comment %d to reply to does not exist", c.ReplyTo))
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetCommentByID", err)
+		}
+		return "", 0, false
+	}
+	if err := comment.LoadReview(ctx); err != nil && !issues_model.IsErrReviewNotExist(err) {
+		ctx.Error(http.StatusInternalServerError, "LoadReview", err)
+		return "", 0, false
+	}
+	// the comments of the pending reviews of other users are not visible
+	if comment.IssueID != pr.IssueID || comment.Type != issues_model.CommentTypeCode ||
+		(comment.Review != nil && comment.Review.Type == issues_model.ReviewTypePending && comment.Review.ReviewerID != ctx.Doer.ID) {
+		ctx.Error(http.StatusUnprocessableEntity, "", fmt.Errorf("the comment %d to reply to is not a review comment of the pull request", c.ReplyTo))
+		return "", 0, false
+	}
+	return comment.TreePath, comment.Line, true
+}
+
+// GetPendingPullReview gets the pending review of the authenticated user on a pull request
+func GetPendingPullReview(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/pulls/{index}/reviews/pending repository repoGetPendingPullReview
+	// ---
+	// summary: Get the pending review of the authenticated user on a pull request
+	// description: The pending review is shared with the web interface, the comments added to it on either side are submitted together.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the pull request
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PullReview"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	pr, err := issues_model.GetPullRequestByIndex(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":index"))
+	if err != nil {
+		if issues_model.IsErrPullRequestNotExist(err) {
+			ctx.NotFound("GetPullRequestByIndex", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetPullRequestByIndex", err)
+		}
+		return
+	}
+	if err := pr.LoadIssue(ctx); err != nil {
+		ctx.Error(http.StatusInternalServerError, "LoadIssue", err)
+		return
+	}
+
+	review, err := issues_model.GetCurrentReview(ctx, ctx.Doer, pr.Issue)
+	if err != nil {
+		if issues_model.IsErrReviewNotExist(err) {
+			ctx.NotFound("GetCurrentReview", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetCurrentReview", err)
+		}
+		return
+	}
+
+	if err := review.LoadAttributes(ctx); err != nil && !user_model.IsErrUserNotExist(err) {
+		ctx.Error(http.StatusInternalServerError, "ReviewLoadAttributes", err)
+		return
+	}
+
+	apiReview, err := convert.ToPullReview(ctx, review, ctx.Doer)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "convertToPullReview", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, apiReview)
+}
+
 // DeletePullReview delete a specific review from a pull request
 func DeletePullReview(ctx *context.APIContext) {
 	// swagger:operation DELETE /repos/{owner}/{repo}/pulls/{index}/reviews/{id} repository repoDeletePullReview
@@ -495,9 +700,9 @@ func CreatePullReview(ctx *context.APIContext) {
 
 	// create review comments
 	for _, c := range opts.Comments {
-		line := c.NewLineNum
-		if c.OldLineNum > 0 {
-			line = c.OldLineNum * -1
+		path, line, ok := reviewCommentPosition(ctx, pr, c)
+		if !ok {
+			return
 		}
 
 		if _, err := pull_service.CreateCodeComment(ctx,
@@ -506,7 +711,7 @@ func CreatePullReview(ctx *context.APIContext) {
 			pr.Issue,
 			line,
 			c.Body,
-			c.Path,
+			path,
 			true, // pending review
 			0,    // no reply
 			opts.CommitID,
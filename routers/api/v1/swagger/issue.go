@@ -56,6 +56,27 @@ type swaggerResponseLabelList struct {
 	Body []api.Label `json:"body"`
 }
 
+// CustomField
+// swagger:response CustomField
+type swaggerResponseCustomField struct {
+	// in:body
+	Body api.CustomField `json:"body"`
+}
+
+// CustomFieldList
+// swagger:response CustomFieldList
+type swaggerResponseCustomFieldList struct {
+	// in:body
+	Body []api.CustomField `json:"body"`
+}
+
+// IssueCustomFieldValueList
+// swagger:response IssueCustomFieldValueList
+type swaggerResponseIssueCustomFieldValueList struct {
+	// in:body
+	Body []api.IssueCustomFieldValue `json:"body"`
+}
+
 // Milestone
 // swagger:response Milestone
 type swaggerResponseMilestone struct {
@@ -125,3 +146,73 @@ type swaggerReactionList struct {
 	// in:body
 	Body []api.Reaction `json:"body"`
 }
+
+// SavedIssueSearch
+// swagger:response SavedIssueSearch
+type swaggerResponseSavedIssueSearch struct {
+	// in:body
+	Body api.SavedIssueSearch `json:"body"`
+}
+
+// SavedIssueSearchList
+// swagger:response SavedIssueSearchList
+type swaggerResponseSavedIssueSearchList struct {
+	// in:body
+	Body []api.SavedIssueSearch `json:"body"`
+}
+
+// Project
+// swagger:response Project
+type swaggerResponseProject struct {
+	// in:body
+	Body api.Project `json:"body"`
+}
+
+// ProjectList
+// swagger:response ProjectList
+type swaggerResponseProjectList struct {
+	// in:body
+	Body []api.Project `json:"body"`
+}
+
+// ProjectViewList
+// swagger:response ProjectViewList
+type swaggerResponseProjectViewList struct {
+	// in:body
+	Body []api.ProjectView `json:"body"`
+}
+
+// ProjectViewGroupList
+// swagger:response ProjectViewGroupList
+type swaggerResponseProjectViewGroupList struct {
+	// in:body
+	Body []api.ProjectViewGroup `json:"body"`
+}
+
+// SubIssueProgress
+// swagger:response SubIssueProgress
+type swaggerResponseSubIssueProgress struct {
+	// in:body
+	Body api.SubIssueProgress `json:"body"`
+}
+
+// BulkEditIssuesTask
+// swagger:response BulkEditIssuesTask
+type swaggerResponseBulkEditIssuesTask struct {
+	// in:body
+	Body api.BulkEditIssuesTask `json:"body"`
+}
+
+// IssueSchedule
+// swagger:response IssueSchedule
+type swaggerResponseIssueSchedule struct {
+	// in:body
+	Body api.IssueSchedule `json:"body"`
+}
+
+// IssueScheduleList
+// swagger:response IssueScheduleList
+type swaggerResponseIssueScheduleList struct {
+	// in:body
+	Body []api.IssueSchedule `json:"body"`
+}
@@ -169,6 +169,9 @@ type swaggerParameterBodies struct {
 	// in:body
 	CreatePullReviewCommentOptions api.CreatePullReviewCommentOptions
 
+	// in:body
+	EditPullReviewCommentOptions api.EditPullReviewCommentOptions
+
 	// in:body
 	SubmitPullReviewOptions api.SubmitPullReviewOptions
 
@@ -184,6 +187,42 @@ type swaggerParameterBodies struct {
 	// in:body
 	CreateTagOption api.CreateTagOption
 
+	// in:body
+	CreateMergeMessageTemplateOption api.CreateMergeMessageTemplateOption
+
+	// in:body
+	EditMergeMessageTemplateOption api.EditMergeMessageTemplateOption
+
+	// in:body
+	CreateCommitCommentOption api.CreateCommitCommentOption
+
+	// in:body
+	EditCommitCommentOption api.EditCommitCommentOption
+
+	// in:body
+	CreateCustomFieldOption api.CreateCustomFieldOption
+
+	// in:body
+	EditCustomFieldOption api.EditCustomFieldOption
+
+	// in:body
+	SetIssueCustomFieldValueOption api.SetIssueCustomFieldValueOption
+
+	// in:body
+	CreateSavedIssueSearchOption api.CreateSavedIssueSearchOption
+
+	// in:body
+	BulkEditIssuesOption api.BulkEditIssuesOption
+
+	// in:body
+	TransferIssueOption api.TransferIssueOption
+
+	// in:body
+	CreateIssueScheduleOption api.CreateIssueScheduleOption
+
+	// in:body
+	EditIssueScheduleOption api.EditIssueScheduleOption
+
 	// in:body
 	CreateTagProtectionOption api.CreateTagProtectionOption
 
@@ -231,4 +270,13 @@ type swaggerParameterBodies struct {
 
 	// in:body
 	SetUserQuotaGroupsOptions api.SetUserQuotaGroupsOptions
+
+	// in:body
+	CreatePackageSignatureOption api.CreatePackageSignatureOption
+
+	// in:body
+	EditPackageRegistryOption api.EditPackageRegistryOption
+
+	// in:body
+	PromotePackageOption api.PromotePackageOption
 }
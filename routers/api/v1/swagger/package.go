@@ -27,3 +27,87 @@ type swaggerResponsePackageFileList struct {
 	// in:body
 	Body []api.PackageFile `json:"body"`
 }
+
+// PackageSignature
+// swagger:response PackageSignature
+type swaggerResponsePackageSignature struct {
+	// in:body
+	Body api.PackageSignature `json:"body"`
+}
+
+// PackageSignatureList
+// swagger:response PackageSignatureList
+type swaggerResponsePackageSignatureList struct {
+	// in:body
+	Body []api.PackageSignature `json:"body"`
+}
+
+// PackageCleanupRuleList
+// swagger:response PackageCleanupRuleList
+type swaggerResponsePackageCleanupRuleList struct {
+	// in:body
+	Body []api.PackageCleanupRule `json:"body"`
+}
+
+// PackageCleanupCandidateList
+// swagger:response PackageCleanupCandidateList
+type swaggerResponsePackageCleanupCandidateList struct {
+	// in:body
+	Body []api.PackageCleanupCandidate `json:"body"`
+}
+
+// PackageCleanupRecordList
+// swagger:response PackageCleanupRecordList
+type swaggerResponsePackageCleanupRecordList struct {
+	// in:body
+	Body []api.PackageCleanupRecord `json:"body"`
+}
+
+// PackageRegistry
+// swagger:response PackageRegistry
+type swaggerResponsePackageRegistry struct {
+	// in:body
+	Body api.PackageRegistry `json:"body"`
+}
+
+// PackageRegistryList
+// swagger:response PackageRegistryList
+type swaggerResponsePackageRegistryList struct {
+	// in:body
+	Body []api.PackageRegistry `json:"body"`
+}
+
+// PackagePromotionList
+// swagger:response PackagePromotionList
+type swaggerResponsePackagePromotionList struct {
+	// in:body
+	Body []api.PackagePromotion `json:"body"`
+}
+
+// PackageDependencyList
+// swagger:response PackageDependencyList
+type swaggerResponsePackageDependencyList struct {
+	// in:body
+	Body []api.PackageDependency `json:"body"`
+}
+
+// PackageVulnerabilityList
+// swagger:response PackageVulnerabilityList
+type swaggerResponsePackageVulnerabilityList struct {
+	// in:body
+	Body []api.PackageVulnerability `json:"body"`
+}
+
+// PackageVulnerabilityImport
+// swagger:response PackageVulnerabilityImport
+type swaggerResponsePackageVulnerabilityImport struct {
+	// in:body
+	Body api.PackageVulnerabilityImport `json:"body"`
+}
+
+// PackageDownloadStatList
+// swagger:response PackageDownloadStatList
+type swaggerResponsePackageDownloadStatList struct {
+	// in:body
+	Body []api.PackageDownloadStat `json:"body"`
+}
@@ -70,6 +70,34 @@ type swaggerResponseAnnotatedTag struct {
 	Body api.AnnotatedTag `json:"body"`
 }
 
+// MergeMessageTemplateList
+// swagger:response MergeMessageTemplateList
+type swaggerResponseMergeMessageTemplateList struct {
+	// in:body
+	Body []api.MergeMessageTemplate `json:"body"`
+}
+
+// MergeMessageTemplate
+// swagger:response MergeMessageTemplate
+type swaggerResponseMergeMessageTemplate struct {
+	// in:body
+	Body api.MergeMessageTemplate `json:"body"`
+}
+
+// CommitCommentList
+// swagger:response CommitCommentList
+type swaggerResponseCommitCommentList struct {
+	// in:body
+	Body []api.CommitComment `json:"body"`
+}
+
+// CommitComment
+// swagger:response CommitComment
+type swaggerResponseCommitComment struct {
+	// in:body
+	Body api.CommitComment `json:"body"`
+}
+
 // TagProtectionList
 // swagger:response TagProtectionList
 type swaggerResponseTagProtectionList struct {
@@ -154,6 +182,20 @@ type swaggerResponsePullRequestList struct {
 	Body []api.PullRequest `json:"body"`
 }
 
+// MergeQueueEntry
+// swagger:response MergeQueueEntry
+type swaggerResponseMergeQueueEntry struct {
+	// in:body
+	Body api.MergeQueueEntry `json:"body"`
+}
+
+// MergeQueueEntryList
+// swagger:response MergeQueueEntryList
+type swaggerResponseMergeQueueEntryList struct {
+	// in:body
+	Body []api.MergeQueueEntry `json:"body"`
+}
+
 // PullReview
 // swagger:response PullReview
 type swaggerResponsePullReview struct {
@@ -0,0 +1,148 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package user
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	issue_indexer "code.gitea.io/gitea/modules/indexer/issues"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+// ListSavedIssueSearches list the saved issue searches of the authenticated user
+func ListSavedIssueSearches(ctx *context.APIContext) {
+	// swagger:operation GET /user/saved_searches user userListSavedIssueSearches
+	// ---
+	// summary: List the authenticated user's saved issue searches
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: repo_id
+	//   in: query
+	//   description: only list the searches listed in this repository, including the searches listed in all the repositories
+	//   type: integer
+	//   format: int64
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SavedIssueSearchList"
+
+	repoID := ctx.FormInt64("repo_id")
+	if repoID == 0 {
+		repoID = -1
+	}
+	searches, err := issues_model.GetSavedIssueSearches(ctx, ctx.Doer.ID, repoID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetSavedIssueSearches", err)
+		return
+	}
+
+	ctx.SetTotalCountHeader(int64(len(searches)))
+	ctx.JSON(http.StatusOK, convert.ToSavedIssueSearchList(searches))
+}
+
+// CreateSavedIssueSearch save an issue search for the authenticated user
+func CreateSavedIssueSearch(ctx *context.APIContext) {
+	// swagger:operation POST /user/saved_searches user userCreateSavedIssueSearch
+	// ---
+	// summary: Save an issue search for the authenticated user
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateSavedIssueSearchOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/SavedIssueSearch"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.CreateSavedIssueSearchOption)
+
+	if _, err := issue_indexer.ParseQuery(form.Query); err != nil {
+		ctx.Error(http.StatusUnprocessableEntity, "ParseQuery", err)
+		return
+	}
+
+	if form.RepoID != 0 {
+		// a repository which cannot be read is reported as missing, to not disclose its existence
+		canRead := false
+		repo, err := repo_model.GetRepositoryByID(ctx, form.RepoID)
+		if err == nil {
+			perm, err := access_model.GetUserRepoPermission(ctx, repo, ctx.Doer)
+			if err != nil {
+				ctx.Error(http.StatusInternalServerError, "GetUserRepoPermission", err)
+				return
+			}
+			canRead = perm.CanReadAny(unit.TypeIssues, unit.TypePullRequests)
+		} else if !repo_model.IsErrRepoNotExist(err) {
+			ctx.Error(http.StatusInternalServerError, "GetRepositoryByID", err)
+			return
+		}
+		if !canRead {
+			ctx.Error(http.StatusUnprocessableEntity, "", fmt.Sprintf("repository does not exist [id: %d]", form.RepoID))
+			return
+		}
+	}
+
+	search := &issues_model.SavedIssueSearch{
+		UserID: ctx.Doer.ID,
+		RepoID: form.RepoID,
+		Name:   form.Name,
+		Query:  form.Query,
+	}
+	if err := issues_model.CreateSavedIssueSearch(ctx, search); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusUnprocessableEntity, "CreateSavedIssueSearch", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "CreateSavedIssueSearch", err)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, convert.ToSavedIssueSearch(search))
+}
+
+// DeleteSavedIssueSearch delete a saved issue search of the authenticated user
+func DeleteSavedIssueSearch(ctx *context.APIContext) {
+	// swagger:operation DELETE /user/saved_searches/{id} user userDeleteSavedIssueSearch
+	// ---
+	// summary: Delete a saved issue search of the authenticated user
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the saved search
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	if err := issues_model.DeleteSavedIssueSearch(ctx, ctx.Doer.ID, ctx.ParamsInt64(":id")); err != nil {
+		if issues_model.IsErrSavedIssueSearchNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "DeleteSavedIssueSearch", err)
+		}
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,79 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"errors"
+	"net/http"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+	issue_service "code.gitea.io/gitea/services/issue"
+)
+
+// CreateCustomField creates a custom field of a repository, or of all the repositories of the owner if repoID is 0
+func CreateCustomField(ctx *context.APIContext, ownerID, repoID int64) {
+	form := web.GetForm(ctx).(*api.CreateCustomFieldOption)
+	field := &issues_model.CustomField{
+		OwnerID:     ownerID,
+		RepoID:      repoID,
+		Name:        form.Name,
+		Description: form.Description,
+		Type:        issues_model.CustomFieldType(form.Type),
+		Options:     form.Options,
+		ShowOnCards: form.ShowOnCards,
+	}
+	if err := issues_model.NewCustomField(ctx, field); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusUnprocessableEntity, "NewCustomField", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "NewCustomField", err)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, convert.ToCustomField(field))
+}
+
+// EditCustomField applies the changes of the request to a custom field
+func EditCustomField(ctx *context.APIContext, field *issues_model.CustomField) {
+	form := web.GetForm(ctx).(*api.EditCustomFieldOption)
+	if form.Name != nil {
+		field.Name = *form.Name
+	}
+	if form.Description != nil {
+		field.Description = *form.Description
+	}
+	if form.Options != nil {
+		field.Options = form.Options
+	}
+	if form.ShowOnCards != nil {
+		field.ShowOnCards = *form.ShowOnCards
+	}
+
+	if err := issue_service.UpdateCustomField(ctx, field); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusUnprocessableEntity, "UpdateCustomField", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "UpdateCustomField", err)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToCustomField(field))
+}
+
+// DeleteCustomField deletes a custom field and its values
+func DeleteCustomField(ctx *context.APIContext, field *issues_model.CustomField) {
+	if err := issue_service.DeleteCustomField(ctx, field); err != nil {
+		ctx.Error(http.StatusInternalServerError, "DeleteCustomField", err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
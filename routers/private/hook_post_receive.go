@@ -356,6 +356,10 @@ func handlePullRequestMerging(ctx *gitea_context.PrivateContext, opts *private.H
 		if err := pull_model.DeleteScheduledAutoMerge(ctx, pr.ID); err != nil && !db.IsErrNotExist(err) {
 			return fmt.Errorf("DeleteScheduledAutoMerge[%d]: %v", opts.PullRequestID, err)
 		}
+		// The pull request may have been merged by its merge queue
+		if err := pull_model.DeleteMergeQueueEntry(ctx, pr.ID); err != nil && !db.IsErrNotExist(err) {
+			return fmt.Errorf("DeleteMergeQueueEntry[%d]: %v", opts.PullRequestID, err)
+		}
 		if _, err := pr.SetMerged(ctx); err != nil {
 			return fmt.Errorf("SetMerged failed: %s/%s Error: %v", ownerName, repoName, err)
 		}
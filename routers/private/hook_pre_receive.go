@@ -25,6 +25,7 @@ import (
 	"code.gitea.io/gitea/modules/web"
 	gitea_context "code.gitea.io/gitea/services/context"
 	pull_service "code.gitea.io/gitea/services/pull"
+	pushrule_service "code.gitea.io/gitea/services/pushrule"
 )
 
 type preReceiveContext struct {
@@ -45,6 +46,9 @@ type preReceiveContext struct {
 	protectedTags    []*git_model.ProtectedTag
 	gotProtectedTags bool
 
+	pushRules    []*git_model.PushRule
+	gotPushRules bool
+
 	env []string
 
 	opts *private.HookOptions
@@ -174,6 +178,43 @@ func (ctx *preReceiveContext) quotaExceeded() {
 	})
 }
 
+// assertPushRules checks the received commits against the push rules of the repository and its owner.
+// The commits created when merging a pull request are not checked, the commits of the pull request were.
+func (ctx *preReceiveContext) assertPushRules(newCommitID string) bool {
+	if ctx.opts.IsWiki || ctx.opts.PullRequestID != 0 || newCommitID == ctx.Repo.GetObjectFormat().EmptyObjectID().String() {
+		return true
+	}
+
+	if !ctx.gotPushRules {
+		var err error
+		ctx.pushRules, err = git_model.GetRepoPushRules(ctx, ctx.Repo.Repository)
+		if err != nil {
+			log.Error("Unable to get push rules for %-v Error: %v", ctx.Repo.Repository, err)
+			ctx.JSON(http.StatusInternalServerError, private.Response{
+				Err: err.Error(),
+			})
+			return false
+		}
+		ctx.gotPushRules = true
+	}
+
+	if err := pushrule_service.CheckPush(ctx, ctx.Repo.Repository, ctx.pushRules, newCommitID, ctx.env); err != nil {
+		if pushrule_service.IsErrViolation(err) {
+			log.Warn("Forbidden: push to %-v rejected by push rules: %v", ctx.Repo.Repository, err)
+			ctx.JSON(http.StatusForbidden, private.Response{
+				UserMsg: fmt.Sprintf("Rejected by push rules: %v", err),
+			})
+			return false
+		}
+		log.Error("Unable to check push rules for %-v Error: %v", ctx.Repo.Repository, err)
+		ctx.JSON(http.StatusInternalServerError, private.Response{
+			Err: fmt.Sprintf("Unable to check push rules: %v", err),
+		})
+		return false
+	}
+	return true
+}
+
 // HookPreReceive checks whether a individual commit is acceptable
 func HookPreReceive(ctx *gitea_context.PrivateContext) {
 	opts := web.GetForm(ctx).(*private.HookOptions)
@@ -242,7 +283,14 @@ func preReceiveBranch(ctx *preReceiveContext, oldCommitID, newCommitID string, r
 		return
 	}
 
-	protectBranch, err := git_model.GetFirstMatchProtectedBranchRule(ctx, repo.ID, branchName)
+	if !ctx.assertPushRules(newCommitID) {
+		return
+	}
+
+	// The rulesets in evaluate mode only report what they would have rejected
+	evaluateOrgRulesets(ctx, oldCommitID, newCommitID, branchName)
+
+	protectBranch, err := git_model.GetFirstMatchProtectedBranchRule(ctx, repo, branchName)
 	if err != nil {
 		log.Error("Unable to get protected branch: %s in %-v Error: %v", branchName, repo, err)
 		ctx.JSON(http.StatusInternalServerError, private.Response{
@@ -500,6 +548,10 @@ func preReceiveTag(ctx *preReceiveContext, oldCommitID, newCommitID string, refF
 		return
 	}
 
+	if !ctx.assertPushRules(newCommitID) {
+		return
+	}
+
 	// If the user is over quota, and the push isn't a tag deletion, deny it
 	if ctx.isOverQuota {
 		objectFormat := ctx.Repo.GetObjectFormat()
@@ -551,6 +603,8 @@ func preReceiveFor(ctx *preReceiveContext, oldCommitID, newCommitID string, refF
 		})
 		return
 	}
+
+	ctx.assertPushRules(newCommitID)
 }
 
 func generateGitEnv(opts *private.HookOptions) (env []string) {
@@ -0,0 +1,128 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package private
+
+import (
+	"fmt"
+
+	"code.gitea.io/gitea/models"
+	git_model "code.gitea.io/gitea/models/git"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/log"
+	pull_service "code.gitea.io/gitea/services/pull"
+)
+
+// evaluateOrgRulesets records the violations of the organization rulesets in evaluate mode
+// matching the pushed branch. The push is never rejected by these rulesets.
+func evaluateOrgRulesets(ctx *preReceiveContext, oldCommitID, newCommitID, branchName string) {
+	repo := ctx.Repo.Repository
+	rulesets, err := git_model.FindMatchingOrgRulesets(ctx, repo, branchName, git_model.OrgRulesetEvaluate)
+	if err != nil {
+		log.Error("Unable to get the rulesets in evaluate mode for %s in %-v: %v", branchName, repo, err)
+		return
+	}
+	if len(rulesets) == 0 {
+		return
+	}
+
+	for _, rs := range rulesets {
+		reason, err := checkOrgRuleset(ctx, rs, oldCommitID, newCommitID, branchName)
+		if err != nil {
+			log.Error("Unable to evaluate the ruleset %d for %s in %-v: %v", rs.ID, branchName, repo, err)
+			continue
+		}
+		if reason == "" {
+			continue
+		}
+
+		log.Info("Ruleset %d in evaluate mode would have rejected the push of %s to %s in %-v: %s", rs.ID, newCommitID, branchName, repo, reason)
+		if err := git_model.AddOrgRulesetViolation(ctx, &git_model.OrgRulesetViolation{
+			RulesetID:  rs.ID,
+			RepoID:     repo.ID,
+			BranchName: branchName,
+			PusherID:   ctx.opts.UserID,
+			CommitID:   newCommitID,
+			Reason:     reason,
+		}); err != nil {
+			log.Error("Unable to record the violation of the ruleset %d: %v", rs.ID, err)
+		}
+	}
+}
+
+// checkOrgRuleset returns the reason why the ruleset would reject the push, empty if it would not.
+// The ruleset is checked as if it were active: it is merged with the protected branch rule of the
+// branch and the users allowed to push or merge and the admins bypass it the same way.
+func checkOrgRuleset(ctx *preReceiveContext, rs *git_model.OrgRuleset, oldCommitID, newCommitID, branchName string) (string, error) {
+	repo := ctx.Repo.Repository
+	emptyCommitID := ctx.Repo.GetObjectFormat().EmptyObjectID().String()
+
+	pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, repo, branchName)
+	if err != nil {
+		return "", fmt.Errorf("GetFirstMatchProtectedBranchRule: %w", err)
+	}
+	if pb == nil {
+		pb = rs.ProtectedBranch(repo)
+	} else {
+		pb.ApplyOrgRuleset(rs)
+	}
+	pb.Repo = repo
+
+	if newCommitID == emptyCommitID {
+		return "branch deletion", nil
+	}
+
+	if oldCommitID != emptyCommitID {
+		output, _, err := git.NewCommand(ctx, "rev-list", "--max-count=1").AddDynamicArguments(oldCommitID, "^"+newCommitID).RunStdString(&git.RunOpts{Dir: repo.RepoPath(), Env: ctx.env})
+		if err != nil {
+			return "", fmt.Errorf("detect force push: %w", err)
+		} else if len(output) > 0 {
+			return "force push", nil
+		}
+	}
+
+	if pb.RequireSignedCommits {
+		if err := verifyCommits(oldCommitID, newCommitID, ctx.Repo.GitRepo, ctx.env); err != nil {
+			if !isErrUnverifiedCommit(err) {
+				return "", fmt.Errorf("verify commits: %w", err)
+			}
+			return fmt.Sprintf("unverified commit %s", err.(*errUnverifiedCommit).sha), nil
+		}
+	}
+
+	var canPush bool
+	if ctx.opts.DeployKeyID != 0 {
+		canPush = pb.CanPush && (!pb.EnableWhitelist || pb.WhitelistDeployKeys)
+	} else {
+		canPush = pb.CanUserPush(ctx, ctx.user)
+	}
+	if canPush {
+		return "", nil
+	}
+
+	if ctx.opts.PullRequestID == 0 {
+		return "push without pull request", nil
+	}
+
+	pr, err := issues_model.GetPullRequestByID(ctx, ctx.opts.PullRequestID)
+	if err != nil {
+		return "", fmt.Errorf("GetPullRequestByID: %w", err)
+	}
+	if !git_model.IsUserMergeWhitelisted(ctx, pb, ctx.user.ID, ctx.userPerm) {
+		return "not allowed to merge", nil
+	}
+	if ctx.user.IsAdmin {
+		return "", nil
+	}
+	if _, err := pull_service.CheckPullBranchProtectionRule(ctx, pr, pb, true); err != nil {
+		if models.IsErrDisallowedToMerge(err) {
+			if ctx.userPerm.IsAdmin() && !pb.ApplyToAdmins {
+				return "", nil
+			}
+			return err.(models.ErrDisallowedToMerge).Reason, nil
+		}
+		return "", err
+	}
+	return "", nil
+}
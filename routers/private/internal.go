@@ -78,6 +78,8 @@ func Routes() *web.Route {
 	r.Get("/manager/processes", Processes)
 	r.Post("/mail/send", SendEmail)
 	r.Post("/restore_repo", RestoreRepo)
+	r.Post("/repos/{owner}/{repo}/issues/bulk_edit", RepoAssignment, bind(private.BulkEditIssuesOptions{}), BulkEditIssues)
+	r.Get("/repos/{owner}/{repo}/issues/bulk_edit/{id}", RepoAssignment, GetBulkEditIssuesTask)
 	r.Post("/actions/generate_actions_runner_token", GenerateActionsRunnerToken)
 
 	return r
@@ -0,0 +1,112 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package private
+
+import (
+	"errors"
+	"net/http"
+
+	admin_model "code.gitea.io/gitea/models/admin"
+	issues_model "code.gitea.io/gitea/models/issues"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/private"
+	"code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	gitea_context "code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+	issue_service "code.gitea.io/gitea/services/issue"
+	task_service "code.gitea.io/gitea/services/task"
+)
+
+// BulkEditIssues queues the edition of the issues of a repository matching a search query
+func BulkEditIssues(ctx *gitea_context.PrivateContext) {
+	opts := web.GetForm(ctx).(*private.BulkEditIssuesOptions)
+	repo := ctx.Repo.Repository
+
+	handleError := func(name string, err error) {
+		if errors.Is(err, util.ErrInvalidArgument) || errors.Is(err, util.ErrNotExist) {
+			ctx.JSON(http.StatusBadRequest, private.Response{UserMsg: err.Error()})
+			return
+		}
+		log.Error("%s: %v", name, err)
+		ctx.JSON(http.StatusInternalServerError, private.Response{Err: err.Error()})
+	}
+
+	doer, err := user_model.GetUserByName(ctx, opts.Doer)
+	if err != nil {
+		handleError("GetUserByName", err)
+		return
+	}
+
+	editOpts := issue_service.BulkEditOptions{}
+	if editOpts.AddLabelIDs, err = issue_service.GetLabelIDsByNames(ctx, repo, opts.AddLabels); err != nil {
+		handleError("GetLabelIDsByNames", err)
+		return
+	}
+	if editOpts.RemoveLabelIDs, err = issue_service.GetLabelIDsByNames(ctx, repo, opts.RemoveLabels); err != nil {
+		handleError("GetLabelIDsByNames", err)
+		return
+	}
+	if opts.RemoveMilestone {
+		editOpts.MilestoneID = new(int64)
+	} else if opts.Milestone != "" {
+		milestone, err := issues_model.GetMilestoneByRepoIDANDName(ctx, repo.ID, opts.Milestone)
+		if err != nil {
+			handleError("GetMilestoneByRepoIDANDName", err)
+			return
+		}
+		editOpts.MilestoneID = &milestone.ID
+	}
+	if editOpts.AddAssigneeIDs, err = user_model.GetUserIDsByNames(ctx, opts.AddAssignees, false); err != nil {
+		handleError("GetUserIDsByNames", err)
+		return
+	}
+	if editOpts.RemoveAssigneeIDs, err = user_model.GetUserIDsByNames(ctx, opts.RemoveAssignees, false); err != nil {
+		handleError("GetUserIDsByNames", err)
+		return
+	}
+	switch structs.StateType(opts.State) {
+	case "":
+	case structs.StateOpen, structs.StateClosed:
+		isClosed := structs.StateType(opts.State) == structs.StateClosed
+		editOpts.IsClosed = &isClosed
+	default:
+		handleError("State", util.NewInvalidArgumentErrorf("invalid state %q", opts.State))
+		return
+	}
+	if editOpts.IsEmpty() {
+		handleError("BulkEditOptions", util.NewInvalidArgumentErrorf("no change to apply to the issues"))
+		return
+	}
+
+	issueIDs, err := issue_service.FindBulkEditIssueIDs(ctx, repo, doer, opts.Query, optional.None[bool]())
+	if err != nil {
+		handleError("FindBulkEditIssueIDs", err)
+		return
+	}
+	task, err := task_service.BulkEditIssues(ctx, doer, repo, issueIDs, editOpts)
+	if err != nil {
+		handleError("BulkEditIssues", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToAPIBulkEditIssuesTask(task))
+}
+
+// GetBulkEditIssuesTask returns the progress of the edition of issues of a repository
+func GetBulkEditIssuesTask(ctx *gitea_context.PrivateContext) {
+	task, err := admin_model.GetRepoTaskByID(ctx, ctx.Repo.Repository.ID, structs.TaskTypeBulkEditIssues, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if admin_model.IsErrTaskDoesNotExist(err) {
+			ctx.JSON(http.StatusNotFound, private.Response{UserMsg: err.Error()})
+			return
+		}
+		log.Error("GetRepoTaskByID: %v", err)
+		ctx.JSON(http.StatusInternalServerError, private.Response{Err: err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToAPIBulkEditIssuesTask(task))
+}
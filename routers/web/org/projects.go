@@ -20,6 +20,7 @@ import (
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/templates"
 	"code.gitea.io/gitea/modules/web"
+	shared_project "code.gitea.io/gitea/routers/web/shared/project"
 	shared_user "code.gitea.io/gitea/routers/web/shared/user"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/forms"
@@ -338,6 +339,15 @@ func ViewProject(ctx *context.Context) {
 		return
 	}
 
+	shared_project.PrepareProjectViews(ctx, project, issuesMap)
+	if ctx.Written() {
+		return
+	}
+	shared_project.PrepareProjectAutomations(ctx, project)
+	if ctx.Written() {
+		return
+	}
+
 	if project.CardType != project_model.CardTypeTextOnly {
 		issuesAttachmentMap := make(map[int64][]*attachment_model.Attachment)
 		for _, issuesList := range issuesMap {
@@ -350,6 +360,19 @@ func ViewProject(ctx *context.Context) {
 		ctx.Data["issuesAttachmentMap"] = issuesAttachmentMap
 	}
 
+	var issueIDs []int64
+	for _, issuesList := range issuesMap {
+		for _, issue := range issuesList {
+			issueIDs = append(issueIDs, issue.ID)
+		}
+	}
+	cardCustomFieldValues, err := issues_model.GetCardCustomFieldValues(ctx, issueIDs)
+	if err != nil {
+		ctx.ServerError("GetCardCustomFieldValues", err)
+		return
+	}
+	ctx.Data["CardCustomFieldValues"] = cardCustomFieldValues
+
 	linkedPrsMap := make(map[int64][]*issues_model.Issue)
 	for _, issuesList := range issuesMap {
 		for _, issue := range issuesList {
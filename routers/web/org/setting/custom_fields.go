@@ -0,0 +1,70 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"net/http"
+
+	"code.gitea.io/gitea/modules/base"
+	shared "code.gitea.io/gitea/routers/web/shared/customfield"
+	shared_user "code.gitea.io/gitea/routers/web/shared/user"
+	"code.gitea.io/gitea/services/context"
+)
+
+const tplCustomFields base.TplName = "org/settings/custom_fields"
+
+func prepareCustomFieldsContext(ctx *context.Context) bool {
+	ctx.Data["Title"] = ctx.Tr("repo.settings.custom_fields")
+	ctx.Data["PageIsOrgSettings"] = true
+	ctx.Data["PageIsSettingsCustomFields"] = true
+	ctx.Data["CustomFieldsLink"] = customFieldsLink(ctx)
+
+	if err := shared_user.LoadHeaderCount(ctx); err != nil {
+		ctx.ServerError("LoadHeaderCount", err)
+		return false
+	}
+	shared.SetCustomFieldsContext(ctx, ctx.Org.Organization.ID, 0)
+	return !ctx.Written()
+}
+
+func customFieldsLink(ctx *context.Context) string {
+	return ctx.Org.OrgLink + "/settings/custom_fields"
+}
+
+// CustomFields renders the custom fields of the issues of all the repositories of the organization
+func CustomFields(ctx *context.Context) {
+	if !prepareCustomFieldsContext(ctx) {
+		return
+	}
+	ctx.HTML(http.StatusOK, tplCustomFields)
+}
+
+// NewCustomFieldPost creates a custom field for all the repositories of the organization
+func NewCustomFieldPost(ctx *context.Context) {
+	if !prepareCustomFieldsContext(ctx) {
+		return
+	}
+	shared.NewCustomFieldPost(ctx, ctx.Org.Organization.ID, 0, tplCustomFields, customFieldsLink(ctx))
+}
+
+// EditCustomField renders the form to edit a custom field of the organization
+func EditCustomField(ctx *context.Context) {
+	if !prepareCustomFieldsContext(ctx) {
+		return
+	}
+	shared.EditCustomField(ctx, ctx.Org.Organization.ID, 0, tplCustomFields)
+}
+
+// EditCustomFieldPost updates a custom field of the organization
+func EditCustomFieldPost(ctx *context.Context) {
+	if !prepareCustomFieldsContext(ctx) {
+		return
+	}
+	shared.EditCustomFieldPost(ctx, ctx.Org.Organization.ID, 0, tplCustomFields, customFieldsLink(ctx))
+}
+
+// DeleteCustomFieldPost deletes a custom field of the organization
+func DeleteCustomFieldPost(ctx *context.Context) {
+	shared.DeleteCustomFieldPost(ctx, ctx.Org.Organization.ID, 0, customFieldsLink(ctx))
+}
@@ -0,0 +1,48 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"net/http"
+
+	"code.gitea.io/gitea/modules/base"
+	shared "code.gitea.io/gitea/routers/web/shared/pushrule"
+	shared_user "code.gitea.io/gitea/routers/web/shared/user"
+	"code.gitea.io/gitea/services/context"
+)
+
+const tplPushRules base.TplName = "org/settings/push_rules"
+
+func preparePushRulesContext(ctx *context.Context) bool {
+	ctx.Data["Title"] = ctx.Tr("repo.settings.push_rules")
+	ctx.Data["PageIsOrgSettings"] = true
+	ctx.Data["PageIsSettingsPushRules"] = true
+
+	if err := shared_user.LoadHeaderCount(ctx); err != nil {
+		ctx.ServerError("LoadHeaderCount", err)
+		return false
+	}
+	return true
+}
+
+// PushRules renders the push rules applying to all the repositories of the organization
+func PushRules(ctx *context.Context) {
+	if !preparePushRulesContext(ctx) {
+		return
+	}
+	shared.SetPushRuleContext(ctx, ctx.Org.Organization.ID, 0)
+	if ctx.Written() {
+		return
+	}
+
+	ctx.HTML(http.StatusOK, tplPushRules)
+}
+
+// PushRulesPost updates the push rules of the organization
+func PushRulesPost(ctx *context.Context) {
+	if !preparePushRulesContext(ctx) {
+		return
+	}
+	shared.PerformPushRulePost(ctx, ctx.Org.Organization.ID, 0, tplPushRules, ctx.Org.OrgLink+"/settings/push_rules")
+}
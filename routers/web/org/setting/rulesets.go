@@ -0,0 +1,192 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"net/http"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	git_model "code.gitea.io/gitea/models/git"
+	"code.gitea.io/gitea/modules/base"
+	"code.gitea.io/gitea/modules/web"
+	shared_user "code.gitea.io/gitea/routers/web/shared/user"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/forms"
+)
+
+const (
+	tplRulesets    base.TplName = "org/settings/rulesets"
+	tplRulesetEdit base.TplName = "org/settings/rulesets_edit"
+)
+
+var rulesetEnforcements = map[string]git_model.OrgRulesetEnforcement{
+	"disabled": git_model.OrgRulesetDisabled,
+	"active":   git_model.OrgRulesetActive,
+	"evaluate": git_model.OrgRulesetEvaluate,
+}
+
+func prepareRulesetsContext(ctx *context.Context) bool {
+	ctx.Data["Title"] = ctx.Tr("org.settings.rulesets")
+	ctx.Data["PageIsOrgSettings"] = true
+	ctx.Data["PageIsSettingsRulesets"] = true
+
+	if err := shared_user.LoadHeaderCount(ctx); err != nil {
+		ctx.ServerError("LoadHeaderCount", err)
+		return false
+	}
+	return true
+}
+
+// Rulesets renders the list of the branch protection rulesets of the organization
+func Rulesets(ctx *context.Context) {
+	if !prepareRulesetsContext(ctx) {
+		return
+	}
+
+	rulesets, err := git_model.GetOrgRulesets(ctx, ctx.Org.Organization.ID)
+	if err != nil {
+		ctx.ServerError("GetOrgRulesets", err)
+		return
+	}
+	ctx.Data["Rulesets"] = rulesets
+
+	ctx.HTML(http.StatusOK, tplRulesets)
+}
+
+// RulesetNew renders the form to create a ruleset
+func RulesetNew(ctx *context.Context) {
+	if !prepareRulesetsContext(ctx) {
+		return
+	}
+	ctx.Data["Ruleset"] = &git_model.OrgRuleset{Enforcement: git_model.OrgRulesetEvaluate}
+	ctx.HTML(http.StatusOK, tplRulesetEdit)
+}
+
+// RulesetNewPost creates a ruleset
+func RulesetNewPost(ctx *context.Context) {
+	rs := &git_model.OrgRuleset{OrgID: ctx.Org.Organization.ID}
+	if !applyRulesetForm(ctx, rs) {
+		return
+	}
+	if err := git_model.CreateOrgRuleset(ctx, rs); err != nil {
+		ctx.ServerError("CreateOrgRuleset", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("org.settings.rulesets.created", rs.Name))
+	ctx.Redirect(ctx.Org.OrgLink + "/settings/rulesets")
+}
+
+func getRuleset(ctx *context.Context) *git_model.OrgRuleset {
+	rs, err := git_model.GetOrgRulesetByID(ctx, ctx.Org.Organization.ID, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if db.IsErrNotExist(err) {
+			ctx.NotFound("GetOrgRulesetByID", err)
+		} else {
+			ctx.ServerError("GetOrgRulesetByID", err)
+		}
+		return nil
+	}
+	return rs
+}
+
+// RulesetEdit renders the form to edit a ruleset and its latest violations
+func RulesetEdit(ctx *context.Context) {
+	if !prepareRulesetsContext(ctx) {
+		return
+	}
+	rs := getRuleset(ctx)
+	if rs == nil {
+		return
+	}
+	ctx.Data["Ruleset"] = rs
+
+	violations, err := git_model.GetOrgRulesetViolations(ctx, rs.ID, db.ListOptions{PageSize: 50, Page: 1})
+	if err != nil {
+		ctx.ServerError("GetOrgRulesetViolations", err)
+		return
+	}
+	for _, v := range violations {
+		if err := v.LoadAttributes(ctx); err != nil {
+			ctx.ServerError("LoadAttributes", err)
+			return
+		}
+	}
+	ctx.Data["Violations"] = violations
+
+	ctx.HTML(http.StatusOK, tplRulesetEdit)
+}
+
+// RulesetEditPost updates a ruleset
+func RulesetEditPost(ctx *context.Context) {
+	rs := getRuleset(ctx)
+	if rs == nil {
+		return
+	}
+	if !applyRulesetForm(ctx, rs) {
+		return
+	}
+	if err := git_model.UpdateOrgRuleset(ctx, rs); err != nil {
+		ctx.ServerError("UpdateOrgRuleset", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("org.settings.rulesets.updated", rs.Name))
+	ctx.Redirect(ctx.Org.OrgLink + "/settings/rulesets")
+}
+
+// RulesetDelete deletes a ruleset
+func RulesetDelete(ctx *context.Context) {
+	if err := git_model.DeleteOrgRuleset(ctx, ctx.Org.Organization.ID, ctx.ParamsInt64(":id")); err != nil {
+		if db.IsErrNotExist(err) {
+			ctx.NotFound("DeleteOrgRuleset", err)
+		} else {
+			ctx.ServerError("DeleteOrgRuleset", err)
+		}
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("org.settings.rulesets.deleted"))
+	ctx.JSONRedirect(ctx.Org.OrgLink + "/settings/rulesets")
+}
+
+// applyRulesetForm copies the submitted form into the ruleset, it renders the form again if it is invalid
+func applyRulesetForm(ctx *context.Context, rs *git_model.OrgRuleset) bool {
+	form := web.GetForm(ctx).(*forms.OrgRulesetForm)
+
+	rs.Name = form.Name
+	rs.Enforcement = rulesetEnforcements[form.Enforcement]
+	rs.RepoNamePattern = strings.TrimSpace(form.RepoNamePattern)
+	rs.RepoTopics = splitRulesetList(strings.ToLower(form.RepoTopics))
+	rs.RefPattern = strings.TrimSpace(form.RefPattern)
+	rs.RequirePullRequest = form.RequirePullRequest
+	rs.RequiredApprovals = max(form.RequiredApprovals, 0)
+	rs.BlockOnRejectedReviews = form.BlockOnRejectedReviews
+	rs.DismissStaleApprovals = form.DismissStaleApprovals
+	rs.RequireSignedCommits = form.RequireSignedCommits
+	rs.EnableStatusCheck = form.EnableStatusCheck
+	rs.StatusCheckContexts = splitRulesetList(form.StatusCheckContexts)
+	rs.ApplyToAdmins = form.ApplyToAdmins
+
+	if ctx.HasError() {
+		if prepareRulesetsContext(ctx) {
+			ctx.Data["Ruleset"] = rs
+			ctx.HTML(http.StatusOK, tplRulesetEdit)
+		}
+		return false
+	}
+	return true
+}
+
+// splitRulesetList splits a list separated by commas or new lines
+func splitRulesetList(s string) []string {
+	items := make([]string, 0, 2)
+	for _, item := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == '\n' }) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -8,8 +8,10 @@ import (
 	"time"
 
 	activities_model "code.gitea.io/gitea/models/activities"
+	issues_model "code.gitea.io/gitea/models/issues"
 	"code.gitea.io/gitea/models/unit"
 	"code.gitea.io/gitea/modules/base"
+	"code.gitea.io/gitea/modules/timeutil"
 	"code.gitea.io/gitea/services/context"
 )
 
@@ -62,6 +64,13 @@ func Activity(ctx *context.Context) {
 		return
 	}
 
+	if ctx.Repo.CanRead(unit.TypeIssues) {
+		if ctx.Data["SLAMetrics"], err = issues_model.GetSLAMetrics(ctx, ctx.Repo.Repository.ID, timeutil.TimeStamp(timeFrom.Unix())); err != nil {
+			ctx.ServerError("GetSLAMetrics", err)
+			return
+		}
+	}
+
 	if ctx.PageData["repoActivityTopAuthors"], err = activities_model.GetActivityStatsTopAuthors(ctx, ctx.Repo.Repository, timeFrom, 10); err != nil {
 		ctx.ServerError("GetActivityStatsTopAuthors", err)
 		return
@@ -416,6 +416,13 @@ func Diff(ctx *context.Context) {
 		}
 	}
 
+	if ctx.Data["PageIsWiki"] == nil {
+		if err := loadCommitComments(ctx, commitID); err != nil {
+			ctx.ServerError("loadCommitComments", err)
+			return
+		}
+	}
+
 	ctx.Data["BranchName"], err = commit.GetBranchName()
 	if err != nil {
 		ctx.ServerError("commit.GetBranchName", err)
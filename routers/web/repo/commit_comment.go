@@ -0,0 +1,123 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+
+	"code.gitea.io/gitea/models/db"
+	git_model "code.gitea.io/gitea/models/git"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/markup"
+	"code.gitea.io/gitea/modules/markup/markdown"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/forms"
+	repo_service "code.gitea.io/gitea/services/repository"
+)
+
+// loadCommitComments loads and renders the comments of a commit for the commit page
+func loadCommitComments(ctx *context.Context, commitID string) error {
+	comments, err := db.Find[git_model.CommitComment](ctx, git_model.FindCommitCommentsOptions{
+		RepoID:    ctx.Repo.Repository.ID,
+		CommitSHA: commitID,
+	})
+	if err != nil {
+		return err
+	}
+	if err := git_model.CommitCommentList(comments).LoadPosters(ctx); err != nil {
+		return err
+	}
+
+	for _, comment := range comments {
+		comment.RenderedContent, err = markdown.RenderString(&markup.RenderContext{
+			Links: markup.Links{
+				Base: ctx.Repo.RepoLink,
+			},
+			Metas:   ctx.Repo.Repository.ComposeMetas(ctx),
+			GitRepo: ctx.Repo.GitRepo,
+			Ctx:     ctx,
+		}, comment.Content)
+		if err != nil {
+			return err
+		}
+	}
+
+	ctx.Data["CommitComments"] = comments
+	ctx.Data["CanDeleteCommitComments"] = ctx.Repo.CanWrite(unit.TypeCode)
+	return nil
+}
+
+// NewCommitCommentPost comments on a commit
+func NewCommitCommentPost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.CommitCommentForm)
+	commitLink := ctx.Repo.RepoLink + "/commit/" + util.PathEscapeSegments(ctx.Params(":sha"))
+
+	if ctx.HasError() {
+		ctx.Flash.Error(ctx.GetErrMsg())
+		ctx.Redirect(commitLink)
+		return
+	}
+
+	line := form.Line
+	if line < 0 {
+		line = -line
+	}
+	if form.Side == "previous" {
+		line = -line
+	}
+
+	comment, err := repo_service.CreateCommitComment(ctx, ctx.Doer, ctx.Repo.Repository, ctx.Repo.GitRepo, repo_service.CreateCommitCommentOptions{
+		CommitSHA: ctx.Params(":sha"),
+		TreePath:  form.TreePath,
+		Line:      line,
+		Content:   form.Content,
+	})
+	if err != nil {
+		switch {
+		case git.IsErrNotExist(err):
+			ctx.NotFound("CreateCommitComment", err)
+		case errors.Is(err, user_model.ErrBlockedByUser):
+			ctx.Flash.Error(ctx.Tr("repo.commits.comment.blocked_by_user"))
+			ctx.Redirect(commitLink)
+		case errors.Is(err, util.ErrInvalidArgument):
+			ctx.Flash.Error(ctx.Tr("repo.commits.comment.invalid_position"))
+			ctx.Redirect(commitLink)
+		default:
+			ctx.ServerError("CreateCommitComment", err)
+		}
+		return
+	}
+
+	ctx.Redirect(ctx.Repo.RepoLink + "/commit/" + comment.CommitSHA + "#" + comment.HashTag())
+}
+
+// DeleteCommitCommentPost deletes a comment on a commit
+func DeleteCommitCommentPost(ctx *context.Context) {
+	comment, err := git_model.GetCommitCommentByID(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if git_model.IsErrCommitCommentNotExist(err) {
+			ctx.NotFound("GetCommitCommentByID", err)
+		} else {
+			ctx.ServerError("GetCommitCommentByID", err)
+		}
+		return
+	}
+
+	if comment.PosterID != ctx.Doer.ID && !ctx.Repo.CanWrite(unit.TypeCode) {
+		ctx.Error(http.StatusForbidden)
+		return
+	}
+
+	if err := repo_service.DeleteCommitComment(ctx, ctx.Doer, ctx.Repo.Repository, comment); err != nil {
+		ctx.ServerError("DeleteCommitComment", err)
+		return
+	}
+
+	ctx.Redirect(ctx.Repo.RepoLink + "/commit/" + comment.CommitSHA)
+}
@@ -203,6 +203,23 @@ func issues(ctx *context.Context, milestoneID, projectID int64, isPullOption opt
 
 	isFuzzy := ctx.FormBool("fuzzy")
 
+	query, applyQuery := parseIssueSearchQuery(ctx, keyword)
+	if ctx.Written() {
+		return
+	}
+
+	customFields, err := issues_model.GetCustomFieldsForRepo(ctx, repo.OwnerID, repo.ID)
+	if err != nil {
+		ctx.ServerError("GetCustomFieldsForRepo", err)
+		return
+	}
+	customFieldValues, customFieldFilters := parseCustomFieldFilters(ctx, customFields)
+	var customFieldQuery strings.Builder
+	for _, filter := range customFieldFilters {
+		customFieldQuery.WriteString("&field=")
+		customFieldQuery.WriteString(url.QueryEscape(filter))
+	}
+
 	var mileIDs []int64
 	if milestoneID > 0 || milestoneID == db.NoConditionID { // -1 to get those issues which have no any milestone assigned
 		mileIDs = []int64{milestoneID}
@@ -221,9 +238,13 @@ func issues(ctx *context.Context, milestoneID, projectID int64, isPullOption opt
 		ReviewedID:        reviewedID,
 		IsPull:            isPullOption,
 		IssueIDs:          nil,
+		CustomFieldValues: customFieldValues,
 	}
 	if keyword != "" {
-		allIssueIDs, err := issueIDsFromSearch(ctx, keyword, isFuzzy, statsOpts)
+		// the stats count both the open and the closed issues, whatever the state in the query
+		allIssueIDs, err := issueIDsFromSearch(ctx, keyword, isFuzzy, statsOpts, applyQuery, func(o *issue_indexer.SearchOptions) {
+			o.IsClosed = optional.None[bool]()
+		})
 		if err != nil {
 			if issue_indexer.IsAvailable(ctx) {
 				ctx.ServerError("issueIDsFromSearch", err)
@@ -261,6 +282,10 @@ func issues(ctx *context.Context, milestoneID, projectID int64, isPullOption opt
 	if len(ctx.FormString("state")) == 0 && issueStats.OpenCount == 0 && issueStats.ClosedCount != 0 {
 		isShowClosed = optional.None[bool]()
 	}
+	// the state in the query takes precedence
+	if query.IsClosed.Has() {
+		isShowClosed = query.IsClosed
+	}
 
 	if repo.IsTimetrackerEnabled(ctx) {
 		totalTrackedTime, err := issues_model.GetIssueTotalTrackedTime(ctx, statsOpts, isShowClosed)
@@ -307,8 +332,9 @@ func issues(ctx *context.Context, milestoneID, projectID int64, isPullOption opt
 			IsClosed:          isShowClosed,
 			IsPull:            isPullOption,
 			LabelIDs:          labelIDs,
+			CustomFieldValues: customFieldValues,
 			SortType:          sortType,
-		})
+		}, applyQuery)
 		if err != nil {
 			if issue_indexer.IsAvailable(ctx) {
 				ctx.ServerError("issueIDsFromSearch", err)
@@ -357,7 +383,18 @@ func issues(ctx *context.Context, milestoneID, projectID int64, isPullOption opt
 		return
 	}
 
+	// the SLA policies only cover the issues
+	var slaStatuses map[int64]issues_model.IssueSLAStatuses
+	if !isPullOption.Value() {
+		slaStatuses, err = issues_model.GetIssuesSLAStatuses(ctx, repo.ID, issues)
+		if err != nil {
+			ctx.ServerError("GetIssuesSLAStatuses", err)
+			return
+		}
+	}
+
 	ctx.Data["Issues"] = issues
+	ctx.Data["IssueSLAStatuses"] = slaStatuses
 	ctx.Data["CommitLastStatus"] = lastStatus
 	ctx.Data["CommitStatuses"] = commitStatuses
 
@@ -457,13 +494,13 @@ func issues(ctx *context.Context, milestoneID, projectID int64, isPullOption opt
 	linkStr := "%s?q=%s&type=%s&sort=%s&state=%s&labels=%s&milestone=%d&project=%d&assignee=%d&poster=%d&archived=%t"
 	ctx.Data["AllStatesLink"] = fmt.Sprintf(linkStr, ctx.Link,
 		url.QueryEscape(keyword), url.QueryEscape(viewType), url.QueryEscape(sortType), "all", url.QueryEscape(selectLabels),
-		milestoneID, projectID, assigneeID, posterID, archived)
+		milestoneID, projectID, assigneeID, posterID, archived) + customFieldQuery.String()
 	ctx.Data["OpenLink"] = fmt.Sprintf(linkStr, ctx.Link,
 		url.QueryEscape(keyword), url.QueryEscape(viewType), url.QueryEscape(sortType), "open", url.QueryEscape(selectLabels),
-		milestoneID, projectID, assigneeID, posterID, archived)
+		milestoneID, projectID, assigneeID, posterID, archived) + customFieldQuery.String()
 	ctx.Data["ClosedLink"] = fmt.Sprintf(linkStr, ctx.Link,
 		url.QueryEscape(keyword), url.QueryEscape(viewType), url.QueryEscape(sortType), "closed", url.QueryEscape(selectLabels),
-		milestoneID, projectID, assigneeID, posterID, archived)
+		milestoneID, projectID, assigneeID, posterID, archived) + customFieldQuery.String()
 	ctx.Data["SelLabelIDs"] = labelIDs
 	ctx.Data["SelectLabels"] = selectLabels
 	ctx.Data["ViewType"] = viewType
@@ -483,6 +520,20 @@ func issues(ctx *context.Context, milestoneID, projectID int64, isPullOption opt
 		ctx.Data["State"] = "open"
 	}
 	ctx.Data["ShowArchivedLabels"] = archived
+	ctx.Data["CustomFields"] = customFields
+	ctx.Data["CustomFieldValues"] = customFieldValues
+	ctx.Data["CustomFieldFilters"] = customFieldFilters
+	ctx.Data["CustomFieldQuery"] = template.URL(customFieldQuery.String())
+	ctx.Data["SearchQuery"] = query
+
+	if ctx.IsSigned {
+		savedSearches, err := issues_model.GetSavedIssueSearches(ctx, ctx.Doer.ID, repo.ID)
+		if err != nil {
+			ctx.ServerError("GetSavedIssueSearches", err)
+			return
+		}
+		ctx.Data["SavedIssueSearches"] = savedSearches
+	}
 
 	pager.AddParam(ctx, "q", "Keyword")
 	pager.AddParam(ctx, "type", "ViewType")
@@ -495,15 +546,20 @@ func issues(ctx *context.Context, milestoneID, projectID int64, isPullOption opt
 	pager.AddParam(ctx, "poster", "PosterID")
 	pager.AddParam(ctx, "archived", "ShowArchivedLabels")
 	pager.AddParam(ctx, "fuzzy", "IsFuzzy")
+	for _, filter := range customFieldFilters {
+		pager.AddParamString("field", filter)
+	}
 
 	ctx.Data["Page"] = pager
 }
 
-func issueIDsFromSearch(ctx *context.Context, keyword string, fuzzy bool, opts *issues_model.IssuesOptions) ([]int64, error) {
+func issueIDsFromSearch(ctx *context.Context, keyword string, fuzzy bool, opts *issues_model.IssuesOptions, edits ...func(*issue_indexer.SearchOptions)) ([]int64, error) {
 	ids, _, err := issue_indexer.SearchIssues(ctx, issue_indexer.ToSearchOptions(keyword, opts).Copy(
-		func(o *issue_indexer.SearchOptions) {
-			o.IsFuzzyKeyword = fuzzy
-		},
+		append([]func(*issue_indexer.SearchOptions){
+			func(o *issue_indexer.SearchOptions) {
+				o.IsFuzzyKeyword = fuzzy
+			},
+		}, edits...)...,
 	))
 	if err != nil {
 		return nil, fmt.Errorf("SearchIssues: %w", err)
@@ -1244,9 +1300,18 @@ func NewIssuePost(ctx *context.Context) {
 	}
 
 	content := form.Content
+	var issueForm *issues_model.IssueForm
 	if filename := ctx.Req.Form.Get("template-file"); filename != "" {
 		if template, err := issue_template.UnmarshalFromRepo(ctx.Repo.GitRepo, ctx.Repo.Repository.DefaultBranch, filename); err == nil {
-			content = issue_template.RenderToMarkdown(template, ctx.Req.Form)
+			issueForm, content, err = issue_service.NewIssueForm(template, ctx.Req.Form)
+			if err != nil {
+				if errors.Is(err, util.ErrInvalidArgument) {
+					ctx.JSONError(ctx.Tr("repo.issues.new.form_invalid", err.Error()))
+					return
+				}
+				ctx.ServerError("NewIssueForm", err)
+				return
+			}
 		}
 	}
 
@@ -1259,6 +1324,7 @@ func NewIssuePost(ctx *context.Context) {
 		MilestoneID: milestoneID,
 		Content:     content,
 		Ref:         form.Ref,
+		Form:        issueForm,
 	}
 
 	if err := issue_service.NewIssue(ctx, repo, issue, labelIDs, attachments, assigneeIDs); err != nil {
@@ -1279,8 +1345,8 @@ func NewIssuePost(ctx *context.Context) {
 			ctx.Error(http.StatusBadRequest, "user hasn't permissions to read projects")
 			return
 		}
-		if err := issues_model.IssueAssignOrRemoveProject(ctx, issue, ctx.Doer, projectID, 0); err != nil {
-			ctx.ServerError("IssueAssignOrRemoveProject", err)
+		if err := issue_service.AssignOrRemoveProject(ctx, issue, ctx.Doer, projectID, 0); err != nil {
+			ctx.ServerError("AssignOrRemoveProject", err)
 			return
 		}
 	}
@@ -1418,7 +1484,9 @@ func ViewIssue(ctx *context.Context) {
 	issue, err := issues_model.GetIssueByIndex(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":index"))
 	if err != nil {
 		if issues_model.IsErrIssueNotExist(err) {
-			ctx.NotFound("GetIssueByIndex", err)
+			if !redirectTransferredIssue(ctx, ctx.ParamsInt64(":index")) {
+				ctx.NotFound("GetIssueByIndex", err)
+			}
 		} else {
 			ctx.ServerError("GetIssueByIndex", err)
 		}
@@ -1541,6 +1609,16 @@ func ViewIssue(ctx *context.Context) {
 		labels = append(labels, orgLabels...)
 	}
 
+	loadIssueCustomFields(ctx, issue)
+	if ctx.Written() {
+		return
+	}
+
+	loadIssueSubIssues(ctx, issue)
+	if ctx.Written() {
+		return
+	}
+
 	hasSelected := false
 	for i := range labels {
 		if labelIDMark.Contains(labels[i].ID) {
@@ -1713,7 +1791,8 @@ func ViewIssue(ctx *context.Context) {
 				ctx.ServerError("LoadAssigneeUserAndTeam", err)
 				return
 			}
-		} else if comment.Type == issues_model.CommentTypeRemoveDependency || comment.Type == issues_model.CommentTypeAddDependency {
+		} else if comment.Type == issues_model.CommentTypeRemoveDependency || comment.Type == issues_model.CommentTypeAddDependency ||
+			comment.Type == issues_model.CommentTypeAddSubIssue || comment.Type == issues_model.CommentTypeRemoveSubIssue {
 			if err = comment.LoadDepIssueDetails(ctx); err != nil {
 				if !issues_model.IsErrIssueNotExist(err) {
 					ctx.ServerError("LoadDepIssueDetails", err)
@@ -1826,6 +1905,10 @@ func ViewIssue(ctx *context.Context) {
 	if issue.IsPull {
 		pull := issue.PullRequest
 		pull.Issue = issue
+		preparePullRevisions(ctx, pull)
+		if ctx.Written() {
+			return
+		}
 		canDelete := false
 		allowMerge := false
 
@@ -1841,7 +1924,7 @@ func ViewIssue(ctx *context.Context) {
 				if perm.CanWrite(unit.TypeCode) {
 					// Check if branch is not protected
 					if pull.HeadBranch != pull.HeadRepo.DefaultBranch {
-						if protected, err := git_model.IsBranchProtected(ctx, pull.HeadRepo.ID, pull.HeadBranch); err != nil {
+						if protected, err := git_model.IsBranchProtected(ctx, pull.HeadRepo, pull.HeadBranch); err != nil {
 							log.Error("IsProtectedBranch: %v", err)
 						} else if !protected {
 							canDelete = true
@@ -1874,6 +1957,18 @@ func ViewIssue(ctx *context.Context) {
 
 		ctx.Data["AllowMerge"] = allowMerge
 
+		stack, err := issues_model.GetPullRequestStack(ctx, pull)
+		if err != nil {
+			ctx.ServerError("GetPullRequestStack", err)
+			return
+		}
+		if len(stack) > 1 {
+			for _, pr := range stack {
+				pr.Issue.Repo = repo
+			}
+			ctx.Data["PullRequestStack"] = stack
+		}
+
 		prUnit, err := repo.GetUnit(ctx, unit.TypePullRequests)
 		if err != nil {
 			ctx.ServerError("GetUnit", err)
@@ -1921,7 +2016,7 @@ func ViewIssue(ctx *context.Context) {
 		ctx.Data["DefaultSquashMergeMessage"] = defaultSquashMergeMessage
 		ctx.Data["DefaultSquashMergeBody"] = defaultSquashMergeBody
 
-		pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, pull.BaseRepoID, pull.BaseBranch)
+		pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, ctx.Repo.Repository, pull.BaseBranch)
 		if err != nil {
 			ctx.ServerError("LoadProtectedBranch", err)
 			return
@@ -1938,6 +2033,22 @@ func ViewIssue(ctx *context.Context) {
 			ctx.Data["IsBlockedByRejection"] = issues_model.MergeBlockedByRejectedReview(ctx, pb, pull)
 			ctx.Data["IsBlockedByOfficialReviewRequests"] = issues_model.MergeBlockedByOfficialReviewRequests(ctx, pb, pull)
 			ctx.Data["IsBlockedByOutdatedBranch"] = issues_model.MergeBlockedByOutdatedBranch(pb, pull)
+			if pb.RequireCodeOwnerApproval {
+				codeOwnerGroups, err := pull_service.GetCodeOwnerGroups(ctx, pb, pull)
+				if err != nil {
+					ctx.ServerError("GetCodeOwnerGroups", err)
+					return
+				}
+				missingCodeOwnerGroups := make([]*pull_service.CodeOwnerGroup, 0, len(codeOwnerGroups))
+				for _, group := range codeOwnerGroups {
+					if !group.Approved {
+						missingCodeOwnerGroups = append(missingCodeOwnerGroups, group)
+					}
+				}
+				ctx.Data["CodeOwnerGroups"] = codeOwnerGroups
+				ctx.Data["MissingCodeOwnerGroups"] = missingCodeOwnerGroups
+				ctx.Data["IsBlockedByCodeOwners"] = len(missingCodeOwnerGroups) != 0
+			}
 			ctx.Data["GrantedApprovals"] = issues_model.GetGrantedApprovalsCount(ctx, pb, pull)
 			ctx.Data["RequireSigned"] = pb.RequireSignedCommits
 			ctx.Data["ChangedProtectedFiles"] = pull.ChangedProtectedFiles
@@ -2000,6 +2111,28 @@ func ViewIssue(ctx *context.Context) {
 			ctx.ServerError("GetScheduledMergeByPullID", err)
 			return
 		}
+
+		// Check if the pull request is waiting in the merge queue of its base branch
+		isInMergeQueue, mergeQueueEntry, err := pull_model.GetMergeQueueEntryByPullID(ctx, pull.ID)
+		if err != nil {
+			ctx.ServerError("GetMergeQueueEntryByPullID", err)
+			return
+		}
+		if isInMergeQueue {
+			if err := mergeQueueEntry.LoadDoer(ctx); err != nil {
+				ctx.ServerError("LoadDoer", err)
+				return
+			}
+			position, err := pull_model.GetMergeQueuePosition(ctx, mergeQueueEntry)
+			if err != nil {
+				ctx.ServerError("GetMergeQueuePosition", err)
+				return
+			}
+			ctx.Data["MergeQueueEntry"] = mergeQueueEntry
+			ctx.Data["MergeQueuePosition"] = position
+			ctx.Data["CanRemoveFromMergeQueue"] = ctx.IsSigned && (mergeQueueEntry.DoerID == ctx.Doer.ID || ctx.Repo.CanWrite(unit.TypeCode))
+		}
+		ctx.Data["IsInMergeQueue"] = isInMergeQueue
 	}
 
 	// Get Dependencies
@@ -2673,6 +2806,11 @@ func SearchIssues(ctx *context.Context) {
 	if strings.IndexByte(keyword, 0) >= 0 {
 		keyword = ""
 	}
+	query, err := issue_indexer.ParseQuery(keyword)
+	if err != nil {
+		ctx.Error(http.StatusUnprocessableEntity, err.Error())
+		return
+	}
 
 	isPull := optional.None[bool]()
 	switch ctx.FormString("type") {
@@ -2768,6 +2906,18 @@ func SearchIssues(ctx *context.Context) {
 		}
 	}
 
+	applyQuery, err := issue_service.ResolveSearchQuery(ctx, query, nil, ctx.Doer)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusUnprocessableEntity, err.Error())
+		} else {
+			log.Error("ResolveSearchQuery: %v", err)
+			ctx.Error(http.StatusInternalServerError)
+		}
+		return
+	}
+	searchOpt = searchOpt.Copy(applyQuery)
+
 	// FIXME: It's unsupported to sort by priority repo when searching by indexer,
 	//        it's indeed an regression, but I think it is worth to support filtering by indexer first.
 	_ = ctx.FormInt64("priority_repo_id")
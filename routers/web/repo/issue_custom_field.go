@@ -0,0 +1,108 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/context"
+	issue_service "code.gitea.io/gitea/services/issue"
+)
+
+// loadIssueCustomFields loads the custom fields of the repository and their values on the issue for the sidebar
+func loadIssueCustomFields(ctx *context.Context, issue *issues_model.Issue) {
+	fields, err := issues_model.GetCustomFieldsForRepo(ctx, ctx.Repo.Repository.OwnerID, ctx.Repo.Repository.ID)
+	if err != nil {
+		ctx.ServerError("GetCustomFieldsForRepo", err)
+		return
+	}
+	values, err := issues_model.GetIssueCustomFieldValues(ctx, issue.ID)
+	if err != nil {
+		ctx.ServerError("GetIssueCustomFieldValues", err)
+		return
+	}
+
+	valuesByField := make(map[int64]*issues_model.IssueCustomFieldValue, len(values))
+	for _, v := range values {
+		valuesByField[v.FieldID] = v
+	}
+	ctx.Data["CustomFields"] = fields
+	ctx.Data["IssueCustomFieldValues"] = valuesByField
+}
+
+// UpdateIssueCustomFields sets the values of the custom fields of an issue from the sidebar form
+func UpdateIssueCustomFields(ctx *context.Context) {
+	issue := GetActionIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+	if !ctx.Repo.CanWriteIssuesOrPulls(issue.IsPull) {
+		ctx.NotFound("CanWriteIssuesOrPulls", nil)
+		return
+	}
+
+	fields, err := issues_model.GetCustomFieldsForRepo(ctx, ctx.Repo.Repository.OwnerID, ctx.Repo.Repository.ID)
+	if err != nil {
+		ctx.ServerError("GetCustomFieldsForRepo", err)
+		return
+	}
+
+	values := make(map[int64]string, len(fields))
+	for _, field := range fields {
+		key := fmt.Sprintf("field_%d", field.ID)
+		if _, ok := ctx.Req.PostForm[key]; ok {
+			values[field.ID] = ctx.Req.PostFormValue(key)
+		}
+	}
+
+	if err := issue_service.SetCustomFieldValues(ctx, issue, ctx.Doer, values); err != nil {
+		if !errors.Is(err, util.ErrInvalidArgument) {
+			ctx.ServerError("SetCustomFieldValues", err)
+			return
+		}
+		ctx.Flash.Error(ctx.Tr("repo.issues.custom_fields.invalid", err.Error()))
+	}
+
+	ctx.Redirect(issue.Link())
+}
+
+// parseCustomFieldFilters reads the custom field filters of an issue list, given as "<field id>:<value>" in the
+// "field" query parameters, and returns the normalized values by field ID and the query string of the valid filters
+func parseCustomFieldFilters(ctx *context.Context, fields []*issues_model.CustomField) (map[int64]string, []string) {
+	filters := ctx.FormStrings("field")
+	if len(filters) == 0 {
+		return nil, nil
+	}
+
+	values := make(map[int64]string, len(filters))
+	valid := make([]string, 0, len(filters))
+	for _, filter := range filters {
+		idStr, value, ok := strings.Cut(filter, ":")
+		if !ok {
+			continue
+		}
+		id, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil {
+			continue
+		}
+		for _, field := range fields {
+			if field.ID != id {
+				continue
+			}
+			normalized, _, err := field.NormalizeValue(ctx, value)
+			if err != nil {
+				ctx.Flash.Error(ctx.Tr("repo.issues.custom_fields.invalid", err.Error()), true)
+				break
+			}
+			values[id] = normalized
+			valid = append(valid, filter)
+		}
+	}
+	return values, valid
+}
@@ -0,0 +1,91 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"net/url"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	issue_indexer "code.gitea.io/gitea/modules/indexer/issues"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/forms"
+	issue_service "code.gitea.io/gitea/services/issue"
+)
+
+// parseIssueSearchQuery parses the structured search query of an issue list and returns it with the function
+// applying it to the search options. An invalid query is flashed and searched as a plain keyword.
+func parseIssueSearchQuery(ctx *context.Context, keyword string) (*issue_indexer.Query, func(*issue_indexer.SearchOptions)) {
+	plain := func() (*issue_indexer.Query, func(*issue_indexer.SearchOptions)) {
+		return &issue_indexer.Query{Keyword: keyword}, func(o *issue_indexer.SearchOptions) {}
+	}
+
+	query, err := issue_indexer.ParseQuery(keyword)
+	if err == nil {
+		// the list decides whether it shows the issues or the pull requests
+		query.IsPull = optional.None[bool]()
+		var apply func(*issue_indexer.SearchOptions)
+		if apply, err = issue_service.ResolveSearchQuery(ctx, query, ctx.Repo.Repository, ctx.Doer); err == nil {
+			return query, apply
+		}
+	}
+	if !errors.Is(err, util.ErrInvalidArgument) {
+		ctx.ServerError("ResolveSearchQuery", err)
+		return plain()
+	}
+	ctx.Flash.Error(ctx.Tr("repo.issues.search_query_invalid", err.Error()), true)
+	return plain()
+}
+
+// SaveIssueSearch saves the search query of an issue list for the doer
+func SaveIssueSearch(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.SavedIssueSearchForm)
+	link := ctx.Repo.RepoLink + "/" + ctx.Params(":type")
+	if ctx.HasError() {
+		ctx.Flash.Error(ctx.GetErrMsg())
+		ctx.Redirect(link)
+		return
+	}
+
+	if _, err := issue_indexer.ParseQuery(form.Query); err != nil {
+		ctx.Flash.Error(ctx.Tr("repo.issues.search_query_invalid", err.Error()))
+		ctx.Redirect(link)
+		return
+	}
+
+	search := &issues_model.SavedIssueSearch{
+		UserID: ctx.Doer.ID,
+		RepoID: ctx.Repo.Repository.ID,
+		Name:   form.Name,
+		Query:  form.Query,
+	}
+	if form.AllRepos {
+		search.RepoID = 0
+	}
+	if err := issues_model.CreateSavedIssueSearch(ctx, search); err != nil {
+		ctx.ServerError("CreateSavedIssueSearch", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.issues.saved_searches.saved", search.Name))
+	ctx.Redirect(link + "?q=" + url.QueryEscape(search.Query))
+}
+
+// DeleteSavedIssueSearch deletes a saved search of the doer
+func DeleteSavedIssueSearch(ctx *context.Context) {
+	if err := issues_model.DeleteSavedIssueSearch(ctx, ctx.Doer.ID, ctx.ParamsInt64(":id")); err != nil {
+		if issues_model.IsErrSavedIssueSearchNotExist(err) {
+			ctx.NotFound("DeleteSavedIssueSearch", err)
+		} else {
+			ctx.ServerError("DeleteSavedIssueSearch", err)
+		}
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.issues.saved_searches.deleted"))
+	ctx.JSONRedirect(ctx.Repo.RepoLink + "/" + ctx.Params(":type"))
+}
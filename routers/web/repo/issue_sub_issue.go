@@ -0,0 +1,198 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/context"
+)
+
+// loadIssueSubIssues loads the parent of an issue and the tree of its sub-issues for the sidebar,
+// without the issues the doer cannot read
+func loadIssueSubIssues(ctx *context.Context, issue *issues_model.Issue) {
+	if issue.IsPull {
+		return
+	}
+	repoPerms := map[int64]access_model.Permission{ctx.Repo.Repository.ID: ctx.Repo.Permission}
+	canRead := func(issue *issues_model.Issue) (bool, error) {
+		perm, ok := repoPerms[issue.RepoID]
+		if !ok {
+			var err error
+			if perm, err = access_model.GetUserRepoPermission(ctx, issue.Repo, ctx.Doer); err != nil {
+				return false, err
+			}
+			repoPerms[issue.RepoID] = perm
+		}
+		return perm.CanReadIssuesOrPulls(issue.IsPull), nil
+	}
+
+	parent, err := issues_model.GetParentIssue(ctx, issue.ID)
+	if err != nil {
+		ctx.ServerError("GetParentIssue", err)
+		return
+	}
+	if parent != nil {
+		if err := parent.LoadRepo(ctx); err != nil {
+			ctx.ServerError("LoadRepo", err)
+			return
+		}
+		if ok, err := canRead(parent); err != nil {
+			ctx.ServerError("GetUserRepoPermission", err)
+			return
+		} else if ok {
+			ctx.Data["ParentIssue"] = parent
+		}
+	}
+
+	tree, err := issues_model.GetSubIssueTree(ctx, issue.ID)
+	if err != nil {
+		ctx.ServerError("GetSubIssueTree", err)
+		return
+	}
+	progress, err := issues_model.GetSubIssueProgress(ctx, tree)
+	if err != nil {
+		ctx.ServerError("GetSubIssueProgress", err)
+		return
+	}
+	if _, err := tree.Issues().LoadRepositories(ctx); err != nil {
+		ctx.ServerError("LoadRepositories", err)
+		return
+	}
+
+	// the progress counts all the sub-issues, the tree only shows the readable ones
+	var filter func(nodes []*issues_model.SubIssueNode) ([]*issues_model.SubIssueNode, error)
+	filter = func(nodes []*issues_model.SubIssueNode) ([]*issues_model.SubIssueNode, error) {
+		readable := make([]*issues_model.SubIssueNode, 0, len(nodes))
+		for _, node := range nodes {
+			ok, err := canRead(node.Issue)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+			if node.Children, err = filter(node.Children); err != nil {
+				return nil, err
+			}
+			readable = append(readable, node)
+		}
+		return readable, nil
+	}
+	readable, err := filter(tree)
+	if err != nil {
+		ctx.ServerError("GetUserRepoPermission", err)
+		return
+	}
+	_, numReadable := issues_model.SubIssueTree(readable).Count()
+
+	ctx.Data["SubIssues"] = readable
+	ctx.Data["SubIssueProgress"] = progress
+	ctx.Data["SubIssuesNotPermitted"] = progress.Total - numReadable
+}
+
+// findSubIssueByRef returns the issue referenced as "#index", "repo#index" or "owner/repo#index",
+// which must belong to a repository of the owner of the current repository
+func findSubIssueByRef(ctx *context.Context, ref string) (*issues_model.Issue, error) {
+	repoName, indexStr, ok := strings.Cut(strings.TrimSpace(ref), "#")
+	index, err := strconv.ParseInt(indexStr, 10, 64)
+	if !ok || err != nil {
+		return nil, util.NewInvalidArgumentErrorf("invalid issue reference %q", ref)
+	}
+	if ownerName, name, ok := strings.Cut(repoName, "/"); ok {
+		if !strings.EqualFold(ownerName, ctx.Repo.Owner.Name) {
+			return nil, util.NewInvalidArgumentErrorf("a sub-issue must belong to a repository of %s", ctx.Repo.Owner.Name)
+		}
+		repoName = name
+	}
+
+	repo := ctx.Repo.Repository
+	if repoName != "" && !strings.EqualFold(repoName, repo.Name) {
+		if repo, err = repo_model.GetRepositoryByName(ctx, ctx.Repo.Owner.ID, repoName); err != nil {
+			return nil, err
+		}
+	}
+	issue, err := issues_model.GetIssueByIndex(ctx, repo.ID, index)
+	if err != nil {
+		return nil, err
+	}
+	issue.Repo = repo
+
+	perm, err := access_model.GetUserRepoPermission(ctx, repo, ctx.Doer)
+	if err != nil {
+		return nil, err
+	}
+	if !perm.CanReadIssuesOrPulls(issue.IsPull) {
+		return nil, issues_model.ErrIssueNotExist{RepoID: repo.ID, Index: index}
+	}
+	return issue, nil
+}
+
+// AddSubIssue adds a sub-issue to an issue
+func AddSubIssue(ctx *context.Context) {
+	issue := GetActionIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+	if issue.IsPull || !ctx.Repo.CanWriteIssuesOrPulls(false) {
+		ctx.Error(http.StatusForbidden, "CanWriteIssuesOrPulls")
+		return
+	}
+
+	subIssue, err := findSubIssueByRef(ctx, ctx.FormString("issue"))
+	if err == nil {
+		err = issues_model.AddSubIssue(ctx, ctx.Doer, issue, subIssue)
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, util.ErrNotExist):
+			ctx.Flash.Error(ctx.Tr("repo.issues.sub_issues.error_issue_not_exist"))
+		case issues_model.IsErrSubIssueHasParent(err):
+			ctx.Flash.Error(ctx.Tr("repo.issues.sub_issues.error_has_parent"))
+		case issues_model.IsErrCircularSubIssue(err):
+			ctx.Flash.Error(ctx.Tr("repo.issues.sub_issues.error_circular"))
+		case errors.Is(err, util.ErrInvalidArgument):
+			ctx.Flash.Error(ctx.Tr("repo.issues.sub_issues.error_invalid", err.Error()))
+		default:
+			ctx.ServerError("AddSubIssue", err)
+			return
+		}
+	}
+
+	ctx.Redirect(issue.Link())
+}
+
+// RemoveSubIssue removes a sub-issue from an issue
+func RemoveSubIssue(ctx *context.Context) {
+	issue := GetActionIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+	if issue.IsPull || !ctx.Repo.CanWriteIssuesOrPulls(false) {
+		ctx.Error(http.StatusForbidden, "CanWriteIssuesOrPulls")
+		return
+	}
+
+	subIssue, err := issues_model.GetIssueByID(ctx, ctx.ParamsInt64(":subIssueID"))
+	if err != nil {
+		ctx.NotFoundOrServerError("GetIssueByID", issues_model.IsErrIssueNotExist, err)
+		return
+	}
+	if err := issues_model.RemoveSubIssue(ctx, ctx.Doer, issue, subIssue); err != nil {
+		if !issues_model.IsErrSubIssueNotExist(err) {
+			ctx.ServerError("RemoveSubIssue", err)
+			return
+		}
+		ctx.Flash.Error(ctx.Tr("repo.issues.sub_issues.error_not_exist"))
+	}
+
+	ctx.JSONRedirect(issue.Link())
+}
@@ -0,0 +1,56 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"net/http"
+	"testing"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/services/contexttest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubIssues(t *testing.T) {
+	unittest.PrepareTestEnv(t)
+
+	addSubIssue := func(ref string) {
+		ctx, _ := contexttest.MockContext(t, "user2/repo1/issues/1/sub_issues/add")
+		contexttest.LoadUser(t, ctx, 2)
+		contexttest.LoadRepo(t, ctx, 1)
+		ctx.SetParams(":index", "1")
+		ctx.Req.Form.Set("issue", ref)
+		AddSubIssue(ctx)
+		assert.EqualValues(t, http.StatusSeeOther, ctx.Resp.Status())
+		assert.Equal(t, "/user2/repo1/issues/1", test.RedirectURL(ctx.Resp))
+	}
+
+	// issue 4 is the first issue of repo2 of user2, issue 6 the first issue of repo3 of user3
+	addSubIssue("repo2#1")
+	unittest.AssertExistsAndLoadBean(t, &issues_model.SubIssue{ParentID: 1, IssueID: 4})
+	addSubIssue("user3/repo3#1")
+	unittest.AssertNotExistsBean(t, &issues_model.SubIssue{IssueID: 6})
+
+	ctx, _ := contexttest.MockContext(t, "user2/repo1/issues/1")
+	contexttest.LoadUser(t, ctx, 2)
+	contexttest.LoadRepo(t, ctx, 1)
+	loadIssueSubIssues(ctx, unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1}))
+	assert.False(t, ctx.Written())
+	if progress, ok := ctx.Data["SubIssueProgress"].(*issues_model.SubIssueProgress); assert.True(t, ok) {
+		assert.Equal(t, 1, progress.Total)
+	}
+	assert.Len(t, ctx.Data["SubIssues"], 1)
+
+	ctx, _ = contexttest.MockContext(t, "user2/repo1/issues/1/sub_issues/4/delete")
+	contexttest.LoadUser(t, ctx, 2)
+	contexttest.LoadRepo(t, ctx, 1)
+	ctx.SetParams(":index", "1")
+	ctx.SetParams(":subIssueID", "4")
+	RemoveSubIssue(ctx)
+	assert.EqualValues(t, http.StatusOK, ctx.Resp.Status())
+	unittest.AssertNotExistsBean(t, &issues_model.SubIssue{IssueID: 4})
+}
@@ -0,0 +1,94 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"strings"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/forms"
+	issue_service "code.gitea.io/gitea/services/issue"
+)
+
+// TransferIssue moves an issue to another repository given as owner/repository
+func TransferIssue(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.IssueTransferForm)
+	issue := GetActionIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	repoName := strings.TrimSpace(form.Repo)
+	ownerName, name, _ := strings.Cut(repoName, "/")
+	newRepo, err := repo_model.GetRepositoryByOwnerAndName(ctx, ownerName, name)
+	if err != nil {
+		if repo_model.IsErrRepoNotExist(err) {
+			ctx.JSONError(ctx.Tr("repo.issues.transfer.repo_not_exist", repoName))
+		} else {
+			ctx.ServerError("GetRepositoryByOwnerAndName", err)
+		}
+		return
+	}
+	perm, err := access_model.GetUserRepoPermission(ctx, newRepo, ctx.Doer)
+	if err != nil {
+		ctx.ServerError("GetUserRepoPermission", err)
+		return
+	}
+	if !perm.CanRead(unit.TypeIssues) {
+		ctx.JSONError(ctx.Tr("repo.issues.transfer.repo_not_exist", repoName))
+		return
+	}
+
+	if err := issue_service.TransferIssue(ctx, ctx.Doer, issue, newRepo); err != nil {
+		if errors.Is(err, util.ErrPermissionDenied) {
+			ctx.JSONError(ctx.Tr("repo.issues.transfer.no_permission", newRepo.FullName()))
+		} else if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.JSONError(ctx.Tr("repo.issues.transfer.invalid", err.Error()))
+		} else {
+			ctx.ServerError("TransferIssue", err)
+		}
+		return
+	}
+
+	ctx.JSONRedirect(issue.Link())
+}
+
+// redirectTransferredIssue redirects to the new location of an issue transferred from the given index
+// of the current repository, if the doer can read it. It returns false if there is no such issue.
+func redirectTransferredIssue(ctx *context.Context, index int64) bool {
+	issueID, err := issues_model.LookupIssueRedirect(ctx, ctx.Repo.Repository.ID, index)
+	if err != nil {
+		ctx.ServerError("LookupIssueRedirect", err)
+		return true
+	}
+	if issueID == 0 {
+		return false
+	}
+	issue, err := issues_model.GetIssueByID(ctx, issueID)
+	if err != nil {
+		ctx.ServerError("GetIssueByID", err)
+		return true
+	}
+	if err := issue.LoadRepo(ctx); err != nil {
+		ctx.ServerError("LoadRepo", err)
+		return true
+	}
+	perm, err := access_model.GetUserRepoPermission(ctx, issue.Repo, ctx.Doer)
+	if err != nil {
+		ctx.ServerError("GetUserRepoPermission", err)
+		return true
+	}
+	if !perm.CanReadIssuesOrPulls(issue.IsPull) {
+		return false
+	}
+	ctx.Redirect(issue.Link())
+	return true
+}
@@ -23,8 +23,10 @@ import (
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/modules/web"
+	shared_project "code.gitea.io/gitea/routers/web/shared/project"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/forms"
+	issue_service "code.gitea.io/gitea/services/issue"
 )
 
 const (
@@ -316,6 +318,15 @@ func ViewProject(ctx *context.Context) {
 		return
 	}
 
+	shared_project.PrepareProjectViews(ctx, project, issuesMap)
+	if ctx.Written() {
+		return
+	}
+	shared_project.PrepareProjectAutomations(ctx, project)
+	if ctx.Written() {
+		return
+	}
+
 	if project.CardType != project_model.CardTypeTextOnly {
 		issuesAttachmentMap := make(map[int64][]*attachment_model.Attachment)
 		for _, issuesList := range issuesMap {
@@ -328,6 +339,19 @@ func ViewProject(ctx *context.Context) {
 		ctx.Data["issuesAttachmentMap"] = issuesAttachmentMap
 	}
 
+	var issueIDs []int64
+	for _, issuesList := range issuesMap {
+		for _, issue := range issuesList {
+			issueIDs = append(issueIDs, issue.ID)
+		}
+	}
+	cardCustomFieldValues, err := issues_model.GetCardCustomFieldValues(ctx, issueIDs)
+	if err != nil {
+		ctx.ServerError("GetCardCustomFieldValues", err)
+		return
+	}
+	ctx.Data["CardCustomFieldValues"] = cardCustomFieldValues
+
 	linkedPrsMap := make(map[int64][]*issues_model.Issue)
 	for _, issuesList := range issuesMap {
 		for _, issue := range issuesList {
@@ -393,11 +417,11 @@ func UpdateIssueProject(ctx *context.Context) {
 		if issue.Project != nil && issue.Project.ID == projectID {
 			continue
 		}
-		if err := issues_model.IssueAssignOrRemoveProject(ctx, issue, ctx.Doer, projectID, 0); err != nil {
+		if err := issue_service.AssignOrRemoveProject(ctx, issue, ctx.Doer, projectID, 0); err != nil {
 			if errors.Is(err, util.ErrPermissionDenied) {
 				continue
 			}
-			ctx.ServerError("IssueAssignOrRemoveProject", err)
+			ctx.ServerError("AssignOrRemoveProject", err)
 			return
 		}
 	}
@@ -46,6 +46,8 @@ import (
 	"code.gitea.io/gitea/services/context/upload"
 	"code.gitea.io/gitea/services/forms"
 	"code.gitea.io/gitea/services/gitdiff"
+	issue_service "code.gitea.io/gitea/services/issue"
+	"code.gitea.io/gitea/services/mergequeue"
 	notify_service "code.gitea.io/gitea/services/notify"
 	pull_service "code.gitea.io/gitea/services/pull"
 	repo_service "code.gitea.io/gitea/services/repository"
@@ -547,7 +549,7 @@ func PrepareViewPullInfo(ctx *context.Context, issue *issues_model.Issue) *git.C
 
 	setMergeTarget(ctx, pull)
 
-	pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, repo.ID, pull.BaseBranch)
+	pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, pull.BaseRepo, pull.BaseBranch)
 	if err != nil {
 		ctx.ServerError("LoadProtectedBranch", err)
 		return nil
@@ -859,6 +861,10 @@ func ViewPullCommits(ctx *context.Context) {
 	if ctx.Written() {
 		return
 	}
+	preparePullRevisions(ctx, pull)
+	if ctx.Written() {
+		return
+	}
 	getBranchData(ctx, issue)
 	ctx.HTML(http.StatusOK, tplPullCommits)
 }
@@ -1001,6 +1007,7 @@ func viewPullFiles(ctx *context.Context, specifiedStartCommit, specifiedEndCommi
 		return
 	}
 
+	numSuggestions := 0
 	for _, file := range diff.Files {
 		for _, section := range file.Sections {
 			for _, line := range section.Lines {
@@ -1010,13 +1017,18 @@ func viewPullFiles(ctx *context.Context, specifiedStartCommit, specifiedEndCommi
 							ctx.ServerError("LoadAttachments", err)
 							return
 						}
+						if !comment.Invalidated && comment.ResolveDoerID == 0 && comment.Review != nil &&
+							comment.Review.Type != issues_model.ReviewTypePending && comment.Suggestion() != nil {
+							numSuggestions++
+						}
 					}
 				}
 			}
 		}
 	}
+	ctx.Data["NumSuggestions"] = numSuggestions
 
-	pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, pull.BaseRepoID, pull.BaseBranch)
+	pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, ctx.Repo.Repository, pull.BaseBranch)
 	if err != nil {
 		ctx.ServerError("LoadProtectedBranch", err)
 		return
@@ -1047,12 +1059,10 @@ func viewPullFiles(ctx *context.Context, specifiedStartCommit, specifiedEndCommi
 
 	// determine if the user viewing the pull request can edit the head branch
 	if ctx.Doer != nil && pull.HeadRepo != nil && !pull.HasMerged {
-		headRepoPerm, err := access_model.GetUserRepoPermission(ctx, pull.HeadRepo, ctx.Doer)
-		if err != nil {
-			ctx.ServerError("GetUserRepoPermission", err)
+		if ctx.Data["HeadBranchIsEditable"], err = isHeadBranchEditable(ctx, pull); err != nil {
+			ctx.ServerError("isHeadBranchEditable", err)
 			return
 		}
-		ctx.Data["HeadBranchIsEditable"] = pull.HeadRepo.CanEnableEditor() && issues_model.CanMaintainerWriteToBranch(ctx, headRepoPerm, pull.HeadBranch, ctx.Doer)
 		ctx.Data["SourceRepoLink"] = pull.HeadRepo.Link()
 		ctx.Data["HeadBranch"] = pull.HeadBranch
 	}
@@ -1098,6 +1108,10 @@ func viewPullFiles(ctx *context.Context, specifiedStartCommit, specifiedEndCommi
 	ctx.Data["CurrentReview"] = currentReview
 	ctx.Data["PendingCodeCommentNumber"] = numPendingCodeComments
 
+	preparePullRevisions(ctx, pull)
+	if ctx.Written() {
+		return
+	}
 	getBranchData(ctx, issue)
 	ctx.Data["IsIssuePoster"] = ctx.IsSigned && issue.IsPoster(ctx.Doer.ID)
 	ctx.Data["HasIssuesOrPullsWritePermission"] = ctx.Repo.CanWriteIssuesOrPulls(issue.IsPull)
@@ -1314,6 +1328,26 @@ func MergePullRequest(ctx *context.Context) {
 		}
 	}
 
+	if !form.ForceMerge {
+		enabled, err := mergequeue.IsEnabled(ctx, pr)
+		if err != nil {
+			ctx.ServerError("IsEnabled", err)
+			return
+		} else if enabled {
+			if err := mergequeue.Add(ctx, ctx.Doer, pr, repo_model.MergeStyle(form.Do), message); err != nil {
+				if pull_model.IsErrAlreadyInMergeQueue(err) {
+					ctx.JSONError(ctx.Tr("repo.pulls.merge_queue.already_added"))
+					return
+				}
+				ctx.ServerError("AddToMergeQueue", err)
+				return
+			}
+			ctx.Flash.Success(ctx.Tr("repo.pulls.merge_queue.added"))
+			ctx.JSONRedirect(fmt.Sprintf("%s/pulls/%d", ctx.Repo.RepoLink, pr.Index))
+			return
+		}
+	}
+
 	if err := pull_service.Merge(ctx, pr, ctx.Doer, ctx.Repo.GitRepo, repo_model.MergeStyle(form.Do), form.HeadCommitID, message, false); err != nil {
 		if models.IsErrInvalidMergeStyle(err) {
 			ctx.JSONError(ctx.Tr("repo.pulls.invalid_merge_option"))
@@ -1437,6 +1471,36 @@ func CancelAutoMergePullRequest(ctx *context.Context) {
 	ctx.Redirect(fmt.Sprintf("%s/pulls/%d", ctx.Repo.RepoLink, issue.Index))
 }
 
+// RemoveFromMergeQueue removes a pull request from the merge queue of its base branch
+func RemoveFromMergeQueue(ctx *context.Context) {
+	issue, ok := getPullInfo(ctx)
+	if !ok {
+		return
+	}
+
+	exists, entry, err := pull_model.GetMergeQueueEntryByPullID(ctx, issue.PullRequest.ID)
+	if err != nil {
+		ctx.ServerError("GetMergeQueueEntryByPullID", err)
+		return
+	}
+	if exists && entry.DoerID != ctx.Doer.ID && !ctx.Repo.CanWrite(unit.TypeCode) {
+		ctx.NotFound("RemoveFromMergeQueue", nil)
+		return
+	}
+
+	if err := mergequeue.Remove(ctx, ctx.Doer, issue.PullRequest); err != nil {
+		if db.IsErrNotExist(err) {
+			ctx.Flash.Error(ctx.Tr("repo.pulls.merge_queue.not_queued"))
+			ctx.Redirect(fmt.Sprintf("%s/pulls/%d", ctx.Repo.RepoLink, issue.Index))
+			return
+		}
+		ctx.ServerError("RemoveFromMergeQueue", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("repo.pulls.merge_queue.removed"))
+	ctx.Redirect(fmt.Sprintf("%s/pulls/%d", ctx.Repo.RepoLink, issue.Index))
+}
+
 func stopTimerIfAvailable(ctx *context.Context, user *user_model.User, issue *issues_model.Issue) error {
 	if issues_model.StopwatchExists(ctx, user.ID, issue.ID) {
 		if err := issues_model.CreateOrStopIssueStopwatch(ctx, user, issue); err != nil {
@@ -1555,9 +1619,9 @@ func CompareAndPullRequestPost(ctx *context.Context) {
 	}
 
 	if projectID > 0 && ctx.Repo.CanWrite(unit.TypeProjects) {
-		if err := issues_model.IssueAssignOrRemoveProject(ctx, pullIssue, ctx.Doer, projectID, 0); err != nil {
+		if err := issue_service.AssignOrRemoveProject(ctx, pullIssue, ctx.Doer, projectID, 0); err != nil {
 			if !errors.Is(err, util.ErrPermissionDenied) {
-				ctx.ServerError("IssueAssignOrRemoveProject", err)
+				ctx.ServerError("AssignOrRemoveProject", err)
 				return
 			}
 		}
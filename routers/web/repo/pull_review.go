@@ -7,8 +7,11 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 
+	"code.gitea.io/gitea/models"
 	issues_model "code.gitea.io/gitea/models/issues"
+	access_model "code.gitea.io/gitea/models/perm/access"
 	pull_model "code.gitea.io/gitea/models/pull"
 	"code.gitea.io/gitea/modules/base"
 	"code.gitea.io/gitea/modules/json"
@@ -19,6 +22,7 @@ import (
 	"code.gitea.io/gitea/services/context/upload"
 	"code.gitea.io/gitea/services/forms"
 	pull_service "code.gitea.io/gitea/services/pull"
+	files_service "code.gitea.io/gitea/services/repository/files"
 )
 
 const (
@@ -193,6 +197,16 @@ func renderConversation(ctx *context.Context, comment *issues_model.Comment, ori
 		return
 	}
 	ctx.Data["AfterCommitID"] = pullHeadCommitID
+	if err = comment.Issue.PullRequest.LoadHeadRepo(ctx); err != nil {
+		ctx.ServerError("LoadHeadRepo", err)
+		return
+	}
+	if ctx.Doer != nil && comment.Issue.PullRequest.HeadRepo != nil && !comment.Issue.PullRequest.HasMerged {
+		if ctx.Data["HeadBranchIsEditable"], err = isHeadBranchEditable(ctx, comment.Issue.PullRequest); err != nil {
+			ctx.ServerError("isHeadBranchEditable", err)
+			return
+		}
+	}
 	if origin == "diff" {
 		ctx.HTML(http.StatusOK, tplDiffConversation)
 	} else if origin == "timeline" {
@@ -316,3 +330,89 @@ func UpdateViewedFiles(ctx *context.Context) {
 		ctx.ServerError("UpdateReview", err)
 	}
 }
+
+// isHeadBranchEditable returns true if the doer can commit to the head branch of the pull request
+func isHeadBranchEditable(ctx *context.Context, pr *issues_model.PullRequest) (bool, error) {
+	headRepoPerm, err := access_model.GetUserRepoPermission(ctx, pr.HeadRepo, ctx.Doer)
+	if err != nil {
+		return false, err
+	}
+	return pr.HeadRepo.CanEnableEditor() && issues_model.CanMaintainerWriteToBranch(ctx, headRepoPerm, pr.HeadBranch, ctx.Doer), nil
+}
+
+// ApplySuggestions commits the suggestions of the selected code comments to the head branch with a single commit
+func ApplySuggestions(ctx *context.Context) {
+	issue, ok := getPullInfo(ctx)
+	if !ok {
+		return
+	}
+	pr := issue.PullRequest
+	redirectURL := issue.Link() + "/files"
+
+	if err := pr.LoadHeadRepo(ctx); err != nil {
+		ctx.ServerError("LoadHeadRepo", err)
+		return
+	}
+	if pr.HeadRepo == nil || pr.HasMerged || issue.IsClosed {
+		ctx.NotFound("ApplySuggestions", nil)
+		return
+	}
+	if editable, err := isHeadBranchEditable(ctx, pr); err != nil {
+		ctx.ServerError("isHeadBranchEditable", err)
+		return
+	} else if !editable {
+		ctx.Error(http.StatusForbidden)
+		return
+	}
+
+	commentIDs := ctx.FormStrings("comment_id")
+	comments := make([]*issues_model.Comment, 0, len(commentIDs))
+	for _, commentID := range commentIDs {
+		id, err := strconv.ParseInt(commentID, 10, 64)
+		if err != nil {
+			ctx.Error(http.StatusBadRequest, "invalid comment id")
+			return
+		}
+		comment, err := issues_model.GetCommentByID(ctx, id)
+		if err != nil {
+			if issues_model.IsErrCommentNotExist(err) {
+				ctx.NotFound("GetCommentByID", err)
+			} else {
+				ctx.ServerError("GetCommentByID", err)
+			}
+			return
+		}
+		if comment.IssueID != issue.ID {
+			ctx.NotFound("ApplySuggestions", nil)
+			return
+		}
+		comments = append(comments, comment)
+	}
+	if len(comments) == 0 {
+		ctx.Flash.Error(ctx.Tr("repo.pulls.suggestions.none_selected"))
+		ctx.Redirect(redirectURL)
+		return
+	}
+
+	if _, err := files_service.ApplySuggestions(ctx, ctx.Doer, pr, comments, ctx.FormString("commit_message")); err != nil {
+		switch {
+		case files_service.IsErrSuggestionNotApplicable(err):
+			ctx.Flash.Error(ctx.Tr("repo.pulls.suggestions.not_applicable", err.(files_service.ErrSuggestionNotApplicable).Reason))
+		case models.IsErrSHADoesNotMatch(err), models.IsErrCommitIDDoesNotMatch(err):
+			ctx.Flash.Error(ctx.Tr("repo.pulls.suggestions.head_changed"))
+		case models.IsErrUserCannotCommit(err), models.IsErrFilePathProtected(err):
+			ctx.Flash.Error(ctx.Tr("repo.editor.cannot_commit_to_protected_branch", pr.HeadBranch))
+		case files_service.IsErrSuggestionConversationsNotResolved(err):
+			log.Error("ApplySuggestions: %v", err)
+			ctx.Flash.Warning(ctx.TrN(len(comments), "repo.pulls.suggestions.applied_not_resolved_1", "repo.pulls.suggestions.applied_not_resolved_n", len(comments)))
+		default:
+			ctx.ServerError("ApplySuggestions", err)
+			return
+		}
+		ctx.Redirect(redirectURL)
+		return
+	}
+
+	ctx.Flash.Success(ctx.TrN(len(comments), "repo.pulls.suggestions.applied_1", "repo.pulls.suggestions.applied_n", len(comments)))
+	ctx.Redirect(redirectURL)
+}
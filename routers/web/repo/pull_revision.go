@@ -0,0 +1,151 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"net/http"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	pull_model "code.gitea.io/gitea/models/pull"
+	"code.gitea.io/gitea/modules/base"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/gitdiff"
+)
+
+const (
+	tplPullRevisions base.TplName = "repo/pulls/revisions"
+	tplPullRangeDiff base.TplName = "repo/pulls/range_diff"
+)
+
+// preparePullRevisions sets the number of revisions of the pull request for the tab menu and, if the doer
+// reviewed an older revision, the revision they reviewed last
+func preparePullRevisions(ctx *context.Context, pull *issues_model.PullRequest) {
+	latest, err := pull_model.GetLatestRevision(ctx, pull.ID)
+	if err != nil {
+		ctx.ServerError("GetLatestRevision", err)
+		return
+	}
+	if latest == nil {
+		return
+	}
+	ctx.Data["NumRevisions"] = latest.Index
+	ctx.Data["LatestRevision"] = latest
+
+	if ctx.Doer == nil {
+		return
+	}
+	reviewed, err := pull_model.GetReviewedRevision(ctx, ctx.Doer.ID, pull.ID)
+	if err != nil {
+		ctx.ServerError("GetReviewedRevision", err)
+		return
+	}
+	if reviewed != nil {
+		ctx.Data["ReviewedRevisionIndex"] = reviewed.RevisionIndex
+	}
+}
+
+// preparePullTabs sets the data of the tab menu of the pull request pages
+func preparePullTabs(ctx *context.Context, issue *issues_model.Issue) bool {
+	var prInfo *git.CompareInfo
+	if issue.PullRequest.HasMerged {
+		prInfo = PrepareMergedViewPullInfo(ctx, issue)
+	} else {
+		prInfo = PrepareViewPullInfo(ctx, issue)
+	}
+	if ctx.Written() {
+		return false
+	}
+	if prInfo == nil {
+		ctx.NotFound("PrepareViewPullInfo", nil)
+		return false
+	}
+	preparePullRevisions(ctx, issue.PullRequest)
+	if ctx.Written() {
+		return false
+	}
+	getBranchData(ctx, issue)
+	ctx.Data["HasIssuesOrPullsWritePermission"] = ctx.Repo.CanWriteIssuesOrPulls(issue.IsPull)
+	ctx.Data["IsIssuePoster"] = ctx.IsSigned && issue.IsPoster(ctx.Doer.ID)
+	PrepareBranchList(ctx)
+	return !ctx.Written()
+}
+
+// ViewPullRevisions render the list of the revisions pushed to a pull request
+func ViewPullRevisions(ctx *context.Context) {
+	ctx.Data["PageIsPullList"] = true
+	ctx.Data["PageIsPullRevisions"] = true
+
+	issue, ok := getPullInfo(ctx)
+	if !ok {
+		return
+	}
+	if !preparePullTabs(ctx, issue) {
+		return
+	}
+
+	revisions, err := pull_model.GetRevisions(ctx, issue.PullRequest.ID)
+	if err != nil {
+		ctx.ServerError("GetRevisions", err)
+		return
+	}
+	for _, revision := range revisions {
+		if err := revision.LoadPusher(ctx); err != nil {
+			ctx.ServerError("LoadPusher", err)
+			return
+		}
+	}
+	// the latest revision first
+	for i, j := 0, len(revisions)-1; i < j; i, j = i+1, j-1 {
+		revisions[i], revisions[j] = revisions[j], revisions[i]
+	}
+	ctx.Data["Revisions"] = revisions
+
+	ctx.HTML(http.StatusOK, tplPullRevisions)
+}
+
+// ViewPullRangeDiff render the range-diff between two revisions of a pull request
+func ViewPullRangeDiff(ctx *context.Context) {
+	ctx.Data["PageIsPullList"] = true
+	ctx.Data["PageIsPullRevisions"] = true
+
+	issue, ok := getPullInfo(ctx)
+	if !ok {
+		return
+	}
+	if !preparePullTabs(ctx, issue) {
+		return
+	}
+
+	from, err := pull_model.GetRevisionByIndex(ctx, issue.PullRequest.ID, ctx.FormInt64("from"))
+	if err != nil {
+		if db.IsErrNotExist(err) {
+			ctx.NotFound("GetRevisionByIndex", err)
+		} else {
+			ctx.ServerError("GetRevisionByIndex", err)
+		}
+		return
+	}
+	to, err := pull_model.GetRevisionByIndex(ctx, issue.PullRequest.ID, ctx.FormInt64("to"))
+	if err != nil {
+		if db.IsErrNotExist(err) {
+			ctx.NotFound("GetRevisionByIndex", err)
+		} else {
+			ctx.ServerError("GetRevisionByIndex", err)
+		}
+		return
+	}
+
+	entries, err := gitdiff.GetRangeDiff(ctx, ctx.Repo.Repository.RepoPath(), from.MergeBase, from.CommitID, to.MergeBase, to.CommitID)
+	if err != nil {
+		ctx.ServerError("GetRangeDiff", err)
+		return
+	}
+
+	ctx.Data["FromRevision"] = from
+	ctx.Data["ToRevision"] = to
+	ctx.Data["RangeDiff"] = entries
+	ctx.HTML(http.StatusOK, tplPullRangeDiff)
+}
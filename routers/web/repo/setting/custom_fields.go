@@ -0,0 +1,72 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"net/http"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/modules/base"
+	shared "code.gitea.io/gitea/routers/web/shared/customfield"
+	"code.gitea.io/gitea/services/context"
+)
+
+const tplCustomFields base.TplName = "repo/settings/custom_fields"
+
+func prepareCustomFieldsContext(ctx *context.Context) bool {
+	ctx.Data["Title"] = ctx.Tr("repo.settings.custom_fields")
+	ctx.Data["PageIsSettingsCustomFields"] = true
+	ctx.Data["CustomFieldsLink"] = customFieldsLink(ctx)
+
+	ownerFields, err := issues_model.GetOwnerCustomFields(ctx, ctx.Repo.Repository.OwnerID)
+	if err != nil {
+		ctx.ServerError("GetOwnerCustomFields", err)
+		return false
+	}
+	ctx.Data["OwnerCustomFields"] = ownerFields
+
+	shared.SetCustomFieldsContext(ctx, ctx.Repo.Repository.OwnerID, ctx.Repo.Repository.ID)
+	return !ctx.Written()
+}
+
+func customFieldsLink(ctx *context.Context) string {
+	return ctx.Repo.RepoLink + "/settings/custom_fields"
+}
+
+// CustomFields renders the custom fields of the issues of the repository
+func CustomFields(ctx *context.Context) {
+	if !prepareCustomFieldsContext(ctx) {
+		return
+	}
+	ctx.HTML(http.StatusOK, tplCustomFields)
+}
+
+// NewCustomFieldPost creates a custom field for the issues of the repository
+func NewCustomFieldPost(ctx *context.Context) {
+	if !prepareCustomFieldsContext(ctx) {
+		return
+	}
+	shared.NewCustomFieldPost(ctx, ctx.Repo.Repository.OwnerID, ctx.Repo.Repository.ID, tplCustomFields, customFieldsLink(ctx))
+}
+
+// EditCustomField renders the form to edit a custom field of the repository
+func EditCustomField(ctx *context.Context) {
+	if !prepareCustomFieldsContext(ctx) {
+		return
+	}
+	shared.EditCustomField(ctx, ctx.Repo.Repository.OwnerID, ctx.Repo.Repository.ID, tplCustomFields)
+}
+
+// EditCustomFieldPost updates a custom field of the repository
+func EditCustomFieldPost(ctx *context.Context) {
+	if !prepareCustomFieldsContext(ctx) {
+		return
+	}
+	shared.EditCustomFieldPost(ctx, ctx.Repo.Repository.OwnerID, ctx.Repo.Repository.ID, tplCustomFields, customFieldsLink(ctx))
+}
+
+// DeleteCustomFieldPost deletes a custom field of the repository
+func DeleteCustomFieldPost(ctx *context.Context) {
+	shared.DeleteCustomFieldPost(ctx, ctx.Repo.Repository.OwnerID, ctx.Repo.Repository.ID, customFieldsLink(ctx))
+}
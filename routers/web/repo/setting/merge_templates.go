@@ -0,0 +1,157 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"net/http"
+	"strings"
+
+	git_model "code.gitea.io/gitea/models/git"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/base"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/forms"
+)
+
+const (
+	tplMergeTemplates base.TplName = "repo/settings/merge_templates"
+)
+
+func setMergeTemplatesContext(ctx *context.Context) error {
+	ctx.Data["Title"] = ctx.Tr("repo.settings.merge_templates")
+	ctx.Data["PageIsSettingsMergeTemplates"] = true
+
+	tmpls, err := git_model.GetMergeMessageTemplates(ctx, ctx.Repo.Repository.ID)
+	if err != nil {
+		ctx.ServerError("GetMergeMessageTemplates", err)
+		return err
+	}
+	ctx.Data["MergeTemplates"] = tmpls
+	return nil
+}
+
+func selectMergeTemplateByContext(ctx *context.Context) *git_model.MergeMessageTemplate {
+	id := ctx.FormInt64("id")
+	if id == 0 {
+		id = ctx.ParamsInt64(":id")
+	}
+
+	tmpl, err := git_model.GetMergeMessageTemplateByID(ctx, ctx.Repo.Repository.ID, id)
+	if err != nil {
+		if git_model.IsErrMergeMessageTemplateNotExist(err) {
+			ctx.NotFound("GetMergeMessageTemplateByID", err)
+		} else {
+			ctx.ServerError("GetMergeMessageTemplateByID", err)
+		}
+		return nil
+	}
+	return tmpl
+}
+
+// MergeTemplates renders the page to manage the merge message templates
+func MergeTemplates(ctx *context.Context) {
+	if setMergeTemplatesContext(ctx) != nil {
+		return
+	}
+	ctx.Data["add_trailers"] = true
+
+	ctx.HTML(http.StatusOK, tplMergeTemplates)
+}
+
+// NewMergeTemplatePost creates a merge message template
+func NewMergeTemplatePost(ctx *context.Context) {
+	if setMergeTemplatesContext(ctx) != nil {
+		return
+	}
+
+	if ctx.HasError() {
+		ctx.HTML(http.StatusOK, tplMergeTemplates)
+		return
+	}
+
+	tmpl := &git_model.MergeMessageTemplate{RepoID: ctx.Repo.Repository.ID}
+	applyMergeTemplateForm(ctx, tmpl)
+	if err := git_model.CreateMergeMessageTemplate(ctx, tmpl); err != nil {
+		ctx.ServerError("CreateMergeMessageTemplate", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.update_settings_success"))
+	ctx.Redirect(ctx.Repo.RepoLink + "/settings/merge_templates")
+}
+
+// EditMergeTemplate renders the page to edit a merge message template
+func EditMergeTemplate(ctx *context.Context) {
+	if setMergeTemplatesContext(ctx) != nil {
+		return
+	}
+
+	ctx.Data["PageIsEditMergeTemplate"] = true
+
+	tmpl := selectMergeTemplateByContext(ctx)
+	if tmpl == nil {
+		return
+	}
+
+	ctx.Data["branch_pattern"] = tmpl.BranchPattern
+	ctx.Data["merge_style"] = string(tmpl.MergeStyle)
+	ctx.Data["template"] = tmpl.Template
+	ctx.Data["add_trailers"] = tmpl.AddTrailers
+
+	ctx.HTML(http.StatusOK, tplMergeTemplates)
+}
+
+// EditMergeTemplatePost updates a merge message template
+func EditMergeTemplatePost(ctx *context.Context) {
+	if setMergeTemplatesContext(ctx) != nil {
+		return
+	}
+
+	ctx.Data["PageIsEditMergeTemplate"] = true
+
+	if ctx.HasError() {
+		ctx.HTML(http.StatusOK, tplMergeTemplates)
+		return
+	}
+
+	tmpl := selectMergeTemplateByContext(ctx)
+	if tmpl == nil {
+		return
+	}
+
+	applyMergeTemplateForm(ctx, tmpl)
+	if err := git_model.UpdateMergeMessageTemplate(ctx, tmpl); err != nil {
+		ctx.ServerError("UpdateMergeMessageTemplate", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.update_settings_success"))
+	ctx.Redirect(ctx.Repo.RepoLink + "/settings/merge_templates")
+}
+
+// DeleteMergeTemplatePost deletes a merge message template
+func DeleteMergeTemplatePost(ctx *context.Context) {
+	tmpl := selectMergeTemplateByContext(ctx)
+	if tmpl == nil {
+		return
+	}
+
+	if err := git_model.DeleteMergeMessageTemplate(ctx, ctx.Repo.Repository.ID, tmpl.ID); err != nil {
+		ctx.ServerError("DeleteMergeMessageTemplate", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.update_settings_success"))
+	ctx.Redirect(ctx.Repo.RepoLink + "/settings/merge_templates")
+}
+
+func applyMergeTemplateForm(ctx *context.Context, tmpl *git_model.MergeMessageTemplate) {
+	form := web.GetForm(ctx).(*forms.MergeMessageTemplateForm)
+
+	tmpl.BranchPattern = strings.TrimSpace(form.BranchPattern)
+	tmpl.MergeStyle = repo_model.MergeStyle(form.MergeStyle)
+	tmpl.Template = strings.TrimSpace(form.Template)
+	tmpl.AddTrailers = form.AddTrailers
+}
@@ -42,6 +42,13 @@ func ProtectedBranchRules(ctx *context.Context) {
 	}
 	ctx.Data["ProtectedBranches"] = rules
 
+	orgRulesets, err := git_model.FindRepoOrgRulesets(ctx, ctx.Repo.Repository)
+	if err != nil {
+		ctx.ServerError("FindRepoOrgRulesets", err)
+		return
+	}
+	ctx.Data["OrgRulesets"] = orgRulesets
+
 	repo.PrepareBranchList(ctx)
 	if ctx.Written() {
 		return
@@ -238,6 +245,8 @@ func SettingsProtectedBranchPost(ctx *context.Context) {
 	protectBranch.UnprotectedFilePatterns = f.UnprotectedFilePatterns
 	protectBranch.BlockOnOutdatedBranch = f.BlockOnOutdatedBranch
 	protectBranch.ApplyToAdmins = f.ApplyToAdmins
+	protectBranch.EnableMergeQueue = f.EnableMergeQueue
+	protectBranch.RequireCodeOwnerApproval = f.RequireCodeOwnerApproval
 
 	err = git_model.UpdateProtectBranch(ctx, ctx.Repo.Repository, protectBranch, git_model.WhitelistOptions{
 		UserIDs:          whitelistUsers,
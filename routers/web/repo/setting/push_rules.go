@@ -0,0 +1,52 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"net/http"
+
+	git_model "code.gitea.io/gitea/models/git"
+	"code.gitea.io/gitea/modules/base"
+	shared "code.gitea.io/gitea/routers/web/shared/pushrule"
+	"code.gitea.io/gitea/services/context"
+)
+
+const tplPushRules base.TplName = "repo/settings/push_rules"
+
+func preparePushRulesContext(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("repo.settings.push_rules")
+	ctx.Data["PageIsSettingsPushRules"] = true
+
+	ownerRule, err := git_model.GetPushRule(ctx, ctx.Repo.Repository.OwnerID, 0)
+	if err != nil {
+		ctx.ServerError("GetPushRule", err)
+		return
+	}
+	if !ownerRule.IsEmpty() {
+		ctx.Data["OwnerPushRule"] = ownerRule
+	}
+}
+
+// PushRules renders the push rules of the repository
+func PushRules(ctx *context.Context) {
+	preparePushRulesContext(ctx)
+	if ctx.Written() {
+		return
+	}
+	shared.SetPushRuleContext(ctx, ctx.Repo.Repository.OwnerID, ctx.Repo.Repository.ID)
+	if ctx.Written() {
+		return
+	}
+
+	ctx.HTML(http.StatusOK, tplPushRules)
+}
+
+// PushRulesPost updates the push rules of the repository
+func PushRulesPost(ctx *context.Context) {
+	preparePushRulesContext(ctx)
+	if ctx.Written() {
+		return
+	}
+	shared.PerformPushRulePost(ctx, ctx.Repo.Repository.OwnerID, ctx.Repo.Repository.ID, tplPushRules, ctx.Repo.RepoLink+"/settings/push_rules")
+}
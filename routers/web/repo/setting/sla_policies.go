@@ -0,0 +1,199 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"net/http"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/modules/base"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/forms"
+)
+
+const (
+	tplSLAPolicies base.TplName = "repo/settings/sla_policies"
+)
+
+func setSLAPoliciesContext(ctx *context.Context) error {
+	ctx.Data["Title"] = ctx.Tr("repo.settings.sla_policies")
+	ctx.Data["PageIsSettingsSLAPolicies"] = true
+
+	policies, err := issues_model.GetSLAPolicies(ctx, ctx.Repo.Repository.ID)
+	if err != nil {
+		ctx.ServerError("GetSLAPolicies", err)
+		return err
+	}
+	if err := issues_model.LoadSLAPoliciesLabels(ctx, policies); err != nil {
+		ctx.ServerError("LoadSLAPoliciesLabels", err)
+		return err
+	}
+	ctx.Data["SLAPolicies"] = policies
+
+	labels, err := issues_model.GetLabelsByRepoID(ctx, ctx.Repo.Repository.ID, "", db.ListOptions{})
+	if err != nil {
+		ctx.ServerError("GetLabelsByRepoID", err)
+		return err
+	}
+	if ctx.Repo.Owner.IsOrganization() {
+		orgLabels, err := issues_model.GetLabelsByOrgID(ctx, ctx.Repo.Owner.ID, "", db.ListOptions{})
+		if err != nil {
+			ctx.ServerError("GetLabelsByOrgID", err)
+			return err
+		}
+		labels = append(labels, orgLabels...)
+	}
+	ctx.Data["Labels"] = labels
+	return nil
+}
+
+func selectSLAPolicyByContext(ctx *context.Context) *issues_model.SLAPolicy {
+	id := ctx.FormInt64("id")
+	if id == 0 {
+		id = ctx.ParamsInt64(":id")
+	}
+
+	policy, err := issues_model.GetSLAPolicyByID(ctx, ctx.Repo.Repository.ID, id)
+	if err != nil {
+		if issues_model.IsErrSLAPolicyNotExist(err) {
+			ctx.NotFound("GetSLAPolicyByID", err)
+		} else {
+			ctx.ServerError("GetSLAPolicyByID", err)
+		}
+		return nil
+	}
+	return policy
+}
+
+// SLAPolicies renders the page to manage the SLA policies
+func SLAPolicies(ctx *context.Context) {
+	if setSLAPoliciesContext(ctx) != nil {
+		return
+	}
+
+	ctx.HTML(http.StatusOK, tplSLAPolicies)
+}
+
+// NewSLAPolicyPost creates an SLA policy
+func NewSLAPolicyPost(ctx *context.Context) {
+	if setSLAPoliciesContext(ctx) != nil {
+		return
+	}
+
+	if ctx.HasError() {
+		ctx.HTML(http.StatusOK, tplSLAPolicies)
+		return
+	}
+
+	policy := &issues_model.SLAPolicy{RepoID: ctx.Repo.Repository.ID}
+	if !applySLAPolicyForm(ctx, policy) {
+		return
+	}
+	if err := issues_model.CreateSLAPolicy(ctx, policy); err != nil {
+		ctx.ServerError("CreateSLAPolicy", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.update_settings_success"))
+	ctx.Redirect(ctx.Repo.RepoLink + "/settings/sla_policies")
+}
+
+// EditSLAPolicy renders the page to edit an SLA policy
+func EditSLAPolicy(ctx *context.Context) {
+	if setSLAPoliciesContext(ctx) != nil {
+		return
+	}
+
+	ctx.Data["PageIsEditSLAPolicy"] = true
+
+	policy := selectSLAPolicyByContext(ctx)
+	if policy == nil {
+		return
+	}
+
+	ctx.Data["name"] = policy.Name
+	ctx.Data["label_id"] = policy.LabelID
+	ctx.Data["first_response_hours"] = policy.FirstResponseHours
+	ctx.Data["resolution_hours"] = policy.ResolutionHours
+
+	ctx.HTML(http.StatusOK, tplSLAPolicies)
+}
+
+// EditSLAPolicyPost updates an SLA policy
+func EditSLAPolicyPost(ctx *context.Context) {
+	if setSLAPoliciesContext(ctx) != nil {
+		return
+	}
+
+	ctx.Data["PageIsEditSLAPolicy"] = true
+
+	if ctx.HasError() {
+		ctx.HTML(http.StatusOK, tplSLAPolicies)
+		return
+	}
+
+	policy := selectSLAPolicyByContext(ctx)
+	if policy == nil {
+		return
+	}
+
+	if !applySLAPolicyForm(ctx, policy) {
+		return
+	}
+	if err := issues_model.UpdateSLAPolicy(ctx, policy); err != nil {
+		ctx.ServerError("UpdateSLAPolicy", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.update_settings_success"))
+	ctx.Redirect(ctx.Repo.RepoLink + "/settings/sla_policies")
+}
+
+// DeleteSLAPolicyPost deletes an SLA policy
+func DeleteSLAPolicyPost(ctx *context.Context) {
+	policy := selectSLAPolicyByContext(ctx)
+	if policy == nil {
+		return
+	}
+
+	if err := issues_model.DeleteSLAPolicy(ctx, ctx.Repo.Repository.ID, policy.ID); err != nil {
+		ctx.ServerError("DeleteSLAPolicy", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.update_settings_success"))
+	ctx.Redirect(ctx.Repo.RepoLink + "/settings/sla_policies")
+}
+
+// applySLAPolicyForm fills an SLA policy from the form, it renders the form with an error and returns false if it is invalid
+func applySLAPolicyForm(ctx *context.Context, policy *issues_model.SLAPolicy) bool {
+	form := web.GetForm(ctx).(*forms.SLAPolicyForm)
+
+	if form.FirstResponseHours == 0 && form.ResolutionHours == 0 {
+		ctx.RenderWithErr(ctx.Tr("repo.settings.sla_policies.no_target"), tplSLAPolicies, form)
+		return false
+	}
+	if form.LabelID != 0 {
+		found := false
+		for _, label := range ctx.Data["Labels"].([]*issues_model.Label) {
+			if label.ID == form.LabelID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			ctx.RenderWithErr(ctx.Tr("repo.settings.sla_policies.label_not_exist"), tplSLAPolicies, form)
+			return false
+		}
+	}
+
+	policy.Name = strings.TrimSpace(form.Name)
+	policy.LabelID = form.LabelID
+	policy.FirstResponseHours = form.FirstResponseHours
+	policy.ResolutionHours = form.ResolutionHours
+	return true
+}
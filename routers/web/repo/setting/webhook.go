@@ -175,6 +175,7 @@ func ParseHookEvent(form forms.WebhookCoreForm) *webhook_module.HookEvent {
 			Wiki:                     form.Wiki,
 			Repository:               form.Repository,
 			Package:                  form.Package,
+			CommitComment:            form.CommitComment,
 		},
 		BranchFilter: form.BranchFilter,
 	}
@@ -0,0 +1,156 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package customfield
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/modules/base"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/forms"
+	issue_service "code.gitea.io/gitea/services/issue"
+)
+
+// SetCustomFieldsContext loads the custom fields of a repository, or of the owner if repoID is 0
+func SetCustomFieldsContext(ctx *context.Context, ownerID, repoID int64) {
+	var fields []*issues_model.CustomField
+	var err error
+	if repoID == 0 {
+		fields, err = issues_model.GetOwnerCustomFields(ctx, ownerID)
+	} else {
+		fields, err = issues_model.GetRepoCustomFields(ctx, repoID)
+	}
+	if err != nil {
+		ctx.ServerError("GetCustomFields", err)
+		return
+	}
+	ctx.Data["CustomFields"] = fields
+	ctx.Data["CustomFieldTypes"] = issues_model.CustomFieldTypes
+}
+
+// getCustomField returns the custom field of the request, defined by the repository, or by the owner if repoID is 0
+func getCustomField(ctx *context.Context, ownerID, repoID int64) *issues_model.CustomField {
+	id := ctx.FormInt64("id")
+	if id == 0 {
+		id = ctx.ParamsInt64(":id")
+	}
+
+	field, err := issues_model.GetCustomFieldByID(ctx, ownerID, id)
+	if err == nil && field.RepoID != repoID {
+		err = issues_model.ErrCustomFieldNotExist{ID: id}
+	}
+	if err != nil {
+		if issues_model.IsErrCustomFieldNotExist(err) {
+			ctx.NotFound("GetCustomFieldByID", err)
+		} else {
+			ctx.ServerError("GetCustomFieldByID", err)
+		}
+		return nil
+	}
+	return field
+}
+
+func applyCustomFieldForm(form *forms.CustomFieldForm, field *issues_model.CustomField) {
+	field.Name = form.Name
+	field.Description = strings.TrimSpace(form.Description)
+	field.Options = strings.Split(strings.ReplaceAll(form.Options, "\r\n", "\n"), "\n")
+	field.ShowOnCards = form.ShowOnCards
+}
+
+// NewCustomFieldPost creates a custom field with the submitted form
+func NewCustomFieldPost(ctx *context.Context, ownerID, repoID int64, tpl base.TplName, redirectURL string) {
+	form := web.GetForm(ctx).(*forms.CustomFieldForm)
+
+	if ctx.HasError() {
+		ctx.HTML(http.StatusOK, tpl)
+		return
+	}
+
+	field := &issues_model.CustomField{
+		OwnerID: ownerID,
+		RepoID:  repoID,
+		Type:    issues_model.CustomFieldType(form.Type),
+	}
+	applyCustomFieldForm(form, field)
+	if err := issues_model.NewCustomField(ctx, field); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.RenderWithErr(ctx.Tr("repo.settings.custom_fields.invalid", err.Error()), tpl, form)
+			return
+		}
+		ctx.ServerError("NewCustomField", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.custom_fields.created", field.Name))
+	ctx.Redirect(redirectURL)
+}
+
+// EditCustomField renders the form to edit a custom field
+func EditCustomField(ctx *context.Context, ownerID, repoID int64, tpl base.TplName) {
+	field := getCustomField(ctx, ownerID, repoID)
+	if field == nil {
+		return
+	}
+
+	ctx.Data["PageIsEditCustomField"] = true
+	ctx.Data["name"] = field.Name
+	ctx.Data["description"] = field.Description
+	ctx.Data["type"] = string(field.Type)
+	ctx.Data["options"] = strings.Join(field.Options, "\n")
+	ctx.Data["show_on_cards"] = field.ShowOnCards
+
+	ctx.HTML(http.StatusOK, tpl)
+}
+
+// EditCustomFieldPost updates a custom field with the submitted form
+func EditCustomFieldPost(ctx *context.Context, ownerID, repoID int64, tpl base.TplName, redirectURL string) {
+	form := web.GetForm(ctx).(*forms.CustomFieldForm)
+
+	field := getCustomField(ctx, ownerID, repoID)
+	if field == nil {
+		return
+	}
+
+	ctx.Data["PageIsEditCustomField"] = true
+	ctx.Data["type"] = string(field.Type)
+	if ctx.HasError() {
+		ctx.HTML(http.StatusOK, tpl)
+		return
+	}
+
+	applyCustomFieldForm(form, field)
+	if err := issue_service.UpdateCustomField(ctx, field); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			form.Type = string(field.Type)
+			ctx.RenderWithErr(ctx.Tr("repo.settings.custom_fields.invalid", err.Error()), tpl, form)
+			return
+		}
+		ctx.ServerError("UpdateCustomField", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.custom_fields.updated", field.Name))
+	ctx.Redirect(redirectURL)
+}
+
+// DeleteCustomFieldPost deletes a custom field and its values
+func DeleteCustomFieldPost(ctx *context.Context, ownerID, repoID int64, redirectURL string) {
+	field := getCustomField(ctx, ownerID, repoID)
+	if field == nil {
+		return
+	}
+
+	if err := issue_service.DeleteCustomField(ctx, field); err != nil {
+		ctx.ServerError("DeleteCustomField", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.custom_fields.deleted", field.Name))
+	ctx.Redirect(redirectURL)
+}
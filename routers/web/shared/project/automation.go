@@ -0,0 +1,85 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"errors"
+
+	project_model "code.gitea.io/gitea/models/project"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/forms"
+	project_service "code.gitea.io/gitea/services/projects"
+)
+
+// PrepareProjectAutomations loads the automation rules of a project and the labels which can trigger them
+func PrepareProjectAutomations(ctx *context.Context, project *project_model.Project) {
+	automations, err := project_model.GetAutomations(ctx, project.ID)
+	if err != nil {
+		ctx.ServerError("GetAutomations", err)
+		return
+	}
+	labels, err := project_service.GetAutomationLabels(ctx, project)
+	if err != nil {
+		ctx.ServerError("GetAutomationLabels", err)
+		return
+	}
+	ctx.Data["ProjectAutomations"] = automations
+	ctx.Data["ProjectAutomationLabels"] = labels
+	ctx.Data["ProjectAutomationEvents"] = project_model.AutomationEvents
+}
+
+// NewAutomationPost creates an automation rule of a project
+func NewAutomationPost(ctx *context.Context) {
+	project := getProject(ctx)
+	if ctx.Written() {
+		return
+	}
+	link := project.Link(ctx)
+	if ctx.HasError() {
+		ctx.Flash.Error(ctx.GetErrMsg())
+		ctx.Redirect(link)
+		return
+	}
+
+	form := web.GetForm(ctx).(*forms.ProjectAutomationForm)
+	automation := &project_model.Automation{
+		Event:     project_model.AutomationEvent(form.Event),
+		LabelID:   form.LabelID,
+		ColumnID:  form.ColumnID,
+		CreatorID: ctx.Doer.ID,
+	}
+	if err := project_service.CreateAutomation(ctx, project, automation); err != nil {
+		switch {
+		case errors.Is(err, util.ErrInvalidArgument), project_model.IsErrProjectColumnNotExist(err):
+			ctx.Flash.Error(ctx.Tr("repo.projects.automation.invalid"))
+		case errors.Is(err, util.ErrAlreadyExist):
+			ctx.Flash.Error(ctx.Tr("repo.projects.automation.already_exists"))
+		default:
+			ctx.ServerError("CreateAutomation", err)
+			return
+		}
+		ctx.Redirect(link)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.projects.automation.created"))
+	ctx.Redirect(link)
+}
+
+// DeleteAutomationPost deletes an automation rule of a project
+func DeleteAutomationPost(ctx *context.Context) {
+	project := getProject(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := project_model.DeleteAutomation(ctx, project.ID, ctx.ParamsInt64(":automationID")); err != nil {
+		ctx.NotFoundOrServerError("DeleteAutomation", project_model.IsErrProjectAutomationNotExist, err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.projects.automation.deleted"))
+	ctx.JSONRedirect(project.Link(ctx))
+}
@@ -0,0 +1,191 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	project_model "code.gitea.io/gitea/models/project"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/forms"
+	project_service "code.gitea.io/gitea/services/projects"
+)
+
+// PrepareProjectViews loads the views of a project and applies the view selected by the "view" query parameter:
+// a table view shows the groups of the issues instead of the board, a board view filters the issues of the columns
+func PrepareProjectViews(ctx *context.Context, project *project_model.Project, issuesMap map[int64]issues_model.IssueList) {
+	views, err := project_model.GetViews(ctx, project.ID)
+	if err != nil {
+		ctx.ServerError("GetViews", err)
+		return
+	}
+	fields, err := project_service.GetViewCustomFields(ctx, project)
+	if err != nil {
+		ctx.ServerError("GetViewCustomFields", err)
+		return
+	}
+	ctx.Data["ProjectViews"] = views
+	ctx.Data["ProjectViewCustomFields"] = fields
+	ctx.Data["ProjectViewGroupBys"] = project_model.ViewGroupBys
+	ctx.Data["ProjectViewSortBys"] = project_model.ViewSortBys
+
+	viewID := ctx.FormInt64("view")
+	if viewID == 0 {
+		return
+	}
+	var view *project_model.View
+	for _, v := range views {
+		if v.ID == viewID {
+			view = v
+		}
+	}
+	if view == nil {
+		ctx.NotFound("GetViewByID", project_model.ErrProjectViewNotExist{ID: viewID})
+		return
+	}
+
+	if err := applyProjectView(ctx, project, view, issuesMap); err != nil {
+		if !errors.Is(err, util.ErrInvalidArgument) {
+			ctx.ServerError("applyProjectView", err)
+			return
+		}
+		ctx.Flash.Error(ctx.Tr("repo.projects.view.invalid", err.Error()), true)
+	}
+	ctx.Data["ProjectView"] = view
+}
+
+func applyProjectView(ctx *context.Context, project *project_model.Project, view *project_model.View, issuesMap map[int64]issues_model.IssueList) error {
+	if !view.IsTable() {
+		// the issues of all the columns are filtered at once
+		var issues issues_model.IssueList
+		for _, columnIssues := range issuesMap {
+			issues = append(issues, columnIssues...)
+		}
+		filtered, err := project_service.FilterViewIssues(ctx, project, view, issues, ctx.Doer)
+		if err != nil {
+			return err
+		}
+		matched := make(container.Set[int64], len(filtered))
+		for _, issue := range filtered {
+			matched.Add(issue.ID)
+		}
+		for columnID, columnIssues := range issuesMap {
+			issuesMap[columnID] = slices.DeleteFunc(columnIssues, func(issue *issues_model.Issue) bool {
+				return !matched.Contains(issue.ID)
+			})
+		}
+		return nil
+	}
+
+	groups, err := project_service.GetViewIssueGroups(ctx, project, view, ctx.Doer)
+	if err != nil {
+		return err
+	}
+	ctx.Data["ProjectViewGroups"] = groups
+	return nil
+}
+
+// getProject returns the project of the request, which must belong to the owner or the repository of the request
+func getProject(ctx *context.Context) *project_model.Project {
+	project, err := project_model.GetProjectByID(ctx, ctx.ParamsInt64(":id"))
+	if err != nil {
+		ctx.NotFoundOrServerError("GetProjectByID", project_model.IsErrProjectNotExist, err)
+		return nil
+	}
+	if !project.CanBeAccessedByOwnerRepo(ctx.ContextUser.ID, ctx.Repo.Repository) {
+		ctx.NotFound("CanBeAccessedByOwnerRepo", nil)
+		return nil
+	}
+	return project
+}
+
+func applyProjectViewForm(form *forms.ProjectViewForm, view *project_model.View) {
+	view.Name = form.Name
+	view.Type = project_model.ViewType(form.Type)
+	view.Filter = form.Filter
+	view.GroupBy = project_model.ViewGroupBy(form.GroupBy)
+	view.GroupFieldID = form.GroupFieldID
+	view.SortBy = project_model.ViewSortBy(form.SortBy)
+	view.SortFieldID = form.SortFieldID
+	view.SortDesc = form.SortDesc
+}
+
+// saveProjectView validates and saves a view with the submitted form, then redirects to it
+func saveProjectView(ctx *context.Context, project *project_model.Project, view *project_model.View, save func() error) {
+	link := project.Link(ctx)
+	if ctx.HasError() {
+		ctx.Flash.Error(ctx.GetErrMsg())
+		ctx.Redirect(link)
+		return
+	}
+	applyProjectViewForm(web.GetForm(ctx).(*forms.ProjectViewForm), view)
+
+	if err := project_service.ValidateView(ctx, project, view); err != nil {
+		if !errors.Is(err, util.ErrInvalidArgument) {
+			ctx.ServerError("ValidateView", err)
+			return
+		}
+		ctx.Flash.Error(ctx.Tr("repo.projects.view.invalid", err.Error()))
+		ctx.Redirect(link)
+		return
+	}
+	if err := save(); err != nil {
+		ctx.ServerError("SaveView", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.projects.view.saved", view.Name))
+	ctx.Redirect(fmt.Sprintf("%s?view=%d", link, view.ID))
+}
+
+// NewViewPost creates a view of a project
+func NewViewPost(ctx *context.Context) {
+	project := getProject(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	view := &project_model.View{ProjectID: project.ID, CreatorID: ctx.Doer.ID}
+	saveProjectView(ctx, project, view, func() error {
+		return project_model.NewView(ctx, view)
+	})
+}
+
+// EditViewPost edits a view of a project
+func EditViewPost(ctx *context.Context) {
+	project := getProject(ctx)
+	if ctx.Written() {
+		return
+	}
+	view, err := project_model.GetViewByID(ctx, project.ID, ctx.ParamsInt64(":viewID"))
+	if err != nil {
+		ctx.NotFoundOrServerError("GetViewByID", project_model.IsErrProjectViewNotExist, err)
+		return
+	}
+
+	saveProjectView(ctx, project, view, func() error {
+		return project_model.UpdateView(ctx, view)
+	})
+}
+
+// DeleteViewPost deletes a view of a project
+func DeleteViewPost(ctx *context.Context) {
+	project := getProject(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := project_model.DeleteView(ctx, project.ID, ctx.ParamsInt64(":viewID")); err != nil {
+		ctx.NotFoundOrServerError("DeleteView", project_model.IsErrProjectViewNotExist, err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.projects.view.deleted"))
+	ctx.JSONRedirect(project.Link(ctx))
+}
@@ -0,0 +1,63 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pushrule
+
+import (
+	"net/http"
+	"regexp"
+
+	git_model "code.gitea.io/gitea/models/git"
+	"code.gitea.io/gitea/modules/base"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/forms"
+)
+
+// SetPushRuleContext loads the push rule of a repository, or of all the repositories of the owner if repoID is 0
+func SetPushRuleContext(ctx *context.Context, ownerID, repoID int64) {
+	rule, err := git_model.GetPushRule(ctx, ownerID, repoID)
+	if err != nil {
+		ctx.ServerError("GetPushRule", err)
+		return
+	}
+	ctx.Data["PushRule"] = rule
+}
+
+// PerformPushRulePost updates the push rule with the submitted form
+func PerformPushRulePost(ctx *context.Context, ownerID, repoID int64, tpl base.TplName, redirectURL string) {
+	form := web.GetForm(ctx).(*forms.PushRuleForm)
+
+	rule, err := git_model.GetPushRule(ctx, ownerID, repoID)
+	if err != nil {
+		ctx.ServerError("GetPushRule", err)
+		return
+	}
+	rule.CommitMessagePattern = form.CommitMessagePattern
+	rule.RequireVerifiedEmail = form.RequireVerifiedEmail
+	// the form holds the size in MiB
+	rule.MaxFileSize = max(form.MaxFileSize, 0) * 1024 * 1024
+	rule.ForbiddenPathPatterns = form.ForbiddenPathPatterns
+	rule.DetectSecrets = form.DetectSecrets
+
+	if ctx.HasError() {
+		ctx.Data["PushRule"] = rule
+		ctx.HTML(http.StatusOK, tpl)
+		return
+	}
+
+	if _, err := regexp.Compile(rule.CommitMessagePattern); err != nil {
+		ctx.Data["PushRule"] = rule
+		ctx.Data["Err_CommitMessagePattern"] = true
+		ctx.RenderWithErr(ctx.Tr("repo.settings.push_rules.commit_message_pattern_invalid", err.Error()), tpl, form)
+		return
+	}
+
+	if err := git_model.UpdatePushRule(ctx, rule); err != nil {
+		ctx.ServerError("UpdatePushRule", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.push_rules.updated"))
+	ctx.Redirect(redirectURL)
+}
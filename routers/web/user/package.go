@@ -6,6 +6,8 @@ package user
 import (
 	"fmt"
 	"net/http"
+	"sort"
+	"time"
 
 	"code.gitea.io/gitea/models/db"
 	org_model "code.gitea.io/gitea/models/organization"
@@ -23,6 +25,7 @@ import (
 	debian_module "code.gitea.io/gitea/modules/packages/debian"
 	rpm_module "code.gitea.io/gitea/modules/packages/rpm"
 	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
 	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/modules/web"
 	packages_helper "code.gitea.io/gitea/routers/api/packages/helper"
@@ -30,6 +33,7 @@ import (
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/forms"
 	packages_service "code.gitea.io/gitea/services/packages"
+	vulnerability_service "code.gitea.io/gitea/services/packages/vulnerability"
 )
 
 const (
@@ -282,6 +286,17 @@ func ViewPackageVersion(ctx *context.Context) {
 	ctx.Data["LatestVersions"] = pvs
 	ctx.Data["TotalVersionCount"] = total
 
+	findings, err := vulnerability_service.GetVulnerabilities(ctx, pd)
+	if err != nil {
+		log.Error("GetVulnerabilities: %v", err)
+	}
+	ctx.Data["PackageVulnerabilities"] = findings
+
+	if err := prepareDownloadStats(ctx, pd.Version); err != nil {
+		ctx.ServerError("prepareDownloadStats", err)
+		return
+	}
+
 	ctx.Data["CanWritePackages"] = ctx.Package.AccessMode >= perm.AccessModeWrite || ctx.IsUserSiteAdmin()
 
 	hasRepositoryAccess := false
@@ -304,6 +319,77 @@ func ViewPackageVersion(ctx *context.Context) {
 	ctx.HTML(http.StatusOK, tplPackagesView)
 }
 
+// downloadStatDays is the number of days shown in the download chart
+const downloadStatDays = 30
+
+type downloadStatDay struct {
+	Day   time.Time
+	Count int64
+	// Height is the height of the bar in percent of the busiest day
+	Height int64
+}
+
+type downloadStatClient struct {
+	Client string
+	Count  int64
+}
+
+// prepareDownloadStats aggregates the daily download buckets of the last days for the download chart
+func prepareDownloadStats(ctx *context.Context, pv *packages_model.PackageVersion) error {
+	since := packages_model.DownloadStatDay(time.Now().AddDate(0, 0, -downloadStatDays+1))
+	stats, err := packages_model.FindDownloadStats(ctx, &packages_model.FindDownloadStatsOptions{
+		VersionID: pv.ID,
+		Since:     since,
+	})
+	if err != nil {
+		return err
+	}
+
+	days := make([]*downloadStatDay, 0, downloadStatDays)
+	dayIndex := make(map[timeutil.TimeStamp]*downloadStatDay, downloadStatDays)
+	for i := 0; i < downloadStatDays; i++ {
+		d := &downloadStatDay{Day: since.AddDuration(time.Duration(i) * 24 * time.Hour).AsTime().UTC()}
+		days = append(days, d)
+		dayIndex[timeutil.TimeStamp(d.Day.Unix())] = d
+	}
+
+	var total, authenticated, maxCount int64
+	clientIndex := make(map[string]*downloadStatClient)
+	clients := make([]*downloadStatClient, 0, 5)
+	for _, s := range stats {
+		if d, ok := dayIndex[s.DayUnix]; ok {
+			d.Count += s.Count
+			maxCount = max(maxCount, d.Count)
+		}
+		c, ok := clientIndex[s.Client]
+		if !ok {
+			c = &downloadStatClient{Client: s.Client}
+			clientIndex[s.Client] = c
+			clients = append(clients, c)
+		}
+		c.Count += s.Count
+		total += s.Count
+		if s.Authenticated {
+			authenticated += s.Count
+		}
+	}
+	if maxCount > 0 {
+		for _, d := range days {
+			d.Height = d.Count * 100 / maxCount
+		}
+	}
+	sort.SliceStable(clients, func(i, j int) bool {
+		return clients[i].Count > clients[j].Count
+	})
+
+	ctx.Data["DownloadStatDays"] = days
+	ctx.Data["DownloadStatClients"] = clients
+	ctx.Data["DownloadStatTotal"] = total
+	ctx.Data["DownloadStatAuthenticated"] = authenticated
+	ctx.Data["DownloadStatAnonymous"] = total - authenticated
+	return nil
+}
+
 // ListPackageVersions lists all versions of a package
 func ListPackageVersions(ctx *context.Context) {
 	shared_user.PrepareContextForProfileBigAvatar(ctx)
@@ -504,7 +590,7 @@ func DownloadPackageFile(ctx *context.Context) {
 		return
 	}
 
-	s, u, _, err := packages_service.GetPackageFileStream(ctx, pf)
+	s, u, _, err := packages_service.GetPackageFileStream(ctx, pf, packages_helper.DownloadClient(ctx))
 	if err != nil {
 		ctx.ServerError("GetPackageFileStream", err)
 		return
@@ -783,6 +783,7 @@ func registerRoutes(m *web.Route) {
 	reqRepoReleaseReader := context.RequireRepoReader(unit.TypeReleases)
 	reqRepoWikiWriter := context.RequireRepoWriter(unit.TypeWiki)
 	reqRepoIssueReader := context.RequireRepoReader(unit.TypeIssues)
+	reqRepoIssueWriter := context.RequireRepoWriter(unit.TypeIssues)
 	reqRepoPullsReader := context.RequireRepoReader(unit.TypePullRequests)
 	reqRepoIssuesOrPullsWriter := context.RequireRepoWriterOr(unit.TypeIssues, unit.TypePullRequests)
 	reqRepoIssuesOrPullsReader := context.RequireRepoReaderOr(unit.TypeIssues, unit.TypePullRequests)
@@ -918,6 +919,28 @@ func registerRoutes(m *web.Route) {
 					m.Post("/unblock", org_setting.BlockedUsersUnblock)
 				})
 
+				m.Combo("/push_rules").Get(org_setting.PushRules).
+					Post(web.Bind(forms.PushRuleForm{}), org_setting.PushRulesPost)
+
+				m.Group("/custom_fields", func() {
+					m.Get("", org_setting.CustomFields)
+					m.Post("", web.Bind(forms.CustomFieldForm{}), org_setting.NewCustomFieldPost)
+					m.Post("/delete", org_setting.DeleteCustomFieldPost)
+					m.Get("/{id}", org_setting.EditCustomField)
+					m.Post("/{id}", web.Bind(forms.CustomFieldForm{}), org_setting.EditCustomFieldPost)
+				})
+
+				m.Group("/rulesets", func() {
+					m.Get("", org_setting.Rulesets)
+					m.Combo("/new").Get(org_setting.RulesetNew).
+						Post(web.Bind(forms.OrgRulesetForm{}), org_setting.RulesetNewPost)
+					m.Group("/{id}", func() {
+						m.Combo("").Get(org_setting.RulesetEdit).
+							Post(web.Bind(forms.OrgRulesetForm{}), org_setting.RulesetEditPost)
+						m.Post("/delete", org_setting.RulesetDelete)
+					})
+				})
+
 				m.Group("/packages", func() {
 					m.Get("", org.Packages)
 					m.Group("/rules", func() {
@@ -989,6 +1012,16 @@ func registerRoutes(m *web.Route) {
 					m.Post("/edit", web.Bind(forms.CreateProjectForm{}), org.EditProjectPost)
 					m.Post("/{action:open|close}", org.ChangeProjectStatus)
 
+					m.Group("/views", func() {
+						m.Post("", web.Bind(forms.ProjectViewForm{}), project.NewViewPost)
+						m.Post("/{viewID}", web.Bind(forms.ProjectViewForm{}), project.EditViewPost)
+						m.Post("/{viewID}/delete", project.DeleteViewPost)
+					})
+					m.Group("/automations", func() {
+						m.Post("", web.Bind(forms.ProjectAutomationForm{}), project.NewAutomationPost)
+						m.Post("/{automationID}/delete", project.DeleteAutomationPost)
+					})
+
 					m.Group("/{columnID}", func() {
 						m.Put("", web.Bind(forms.EditProjectColumnForm{}), org.EditProjectColumn)
 						m.Delete("", org.DeleteProjectColumn)
@@ -1044,6 +1077,33 @@ func registerRoutes(m *web.Route) {
 
 			m.Post("/rename_branch", web.Bind(forms.RenameBranchForm{}), context.RepoMustNotBeArchived(), repo_setting.RenameBranchPost)
 
+			m.Combo("/push_rules").Get(repo_setting.PushRules).
+				Post(web.Bind(forms.PushRuleForm{}), context.RepoMustNotBeArchived(), repo_setting.PushRulesPost)
+
+			m.Group("/custom_fields", func() {
+				m.Get("", repo_setting.CustomFields)
+				m.Post("", web.Bind(forms.CustomFieldForm{}), context.RepoMustNotBeArchived(), repo_setting.NewCustomFieldPost)
+				m.Post("/delete", context.RepoMustNotBeArchived(), repo_setting.DeleteCustomFieldPost)
+				m.Get("/{id}", repo_setting.EditCustomField)
+				m.Post("/{id}", web.Bind(forms.CustomFieldForm{}), context.RepoMustNotBeArchived(), repo_setting.EditCustomFieldPost)
+			}, reqRepoIssuesOrPullsReader)
+
+			m.Group("/merge_templates", func() {
+				m.Get("", repo_setting.MergeTemplates)
+				m.Post("", web.Bind(forms.MergeMessageTemplateForm{}), context.RepoMustNotBeArchived(), repo_setting.NewMergeTemplatePost)
+				m.Post("/delete", context.RepoMustNotBeArchived(), repo_setting.DeleteMergeTemplatePost)
+				m.Get("/{id}", repo_setting.EditMergeTemplate)
+				m.Post("/{id}", web.Bind(forms.MergeMessageTemplateForm{}), context.RepoMustNotBeArchived(), repo_setting.EditMergeTemplatePost)
+			}, reqRepoPullsReader)
+
+			m.Group("/sla_policies", func() {
+				m.Get("", repo_setting.SLAPolicies)
+				m.Post("", web.Bind(forms.SLAPolicyForm{}), context.RepoMustNotBeArchived(), repo_setting.NewSLAPolicyPost)
+				m.Post("/delete", context.RepoMustNotBeArchived(), repo_setting.DeleteSLAPolicyPost)
+				m.Get("/{id}", repo_setting.EditSLAPolicy)
+				m.Post("/{id}", web.Bind(forms.SLAPolicyForm{}), context.RepoMustNotBeArchived(), repo_setting.EditSLAPolicyPost)
+			}, reqRepoIssueReader)
+
 			m.Group("/tags", func() {
 				m.Get("", repo_setting.ProtectedTags)
 				m.Post("", web.Bind(forms.ProtectTagForm{}), context.RepoMustNotBeArchived(), repo_setting.NewProtectedTagPost)
@@ -1158,6 +1218,7 @@ func registerRoutes(m *web.Route) {
 				m.Post("/title", repo.UpdateIssueTitle)
 				m.Post("/content", repo.UpdateIssueContent)
 				m.Post("/deadline", web.Bind(structs.EditDeadlineOption{}), repo.UpdateIssueDeadline)
+				m.Post("/custom_fields", repo.UpdateIssueCustomFields)
 				m.Post("/watch", repo.IssueWatch)
 				m.Post("/ref", repo.UpdateIssueRef)
 				m.Post("/pin", reqRepoAdmin, repo.IssuePinOrUnpin)
@@ -1166,6 +1227,10 @@ func registerRoutes(m *web.Route) {
 					m.Post("/add", repo.AddDependency)
 					m.Post("/delete", repo.RemoveDependency)
 				})
+				m.Group("/sub_issues", func() {
+					m.Post("/add", repo.AddSubIssue)
+					m.Post("/{subIssueID}/delete", repo.RemoveSubIssue)
+				})
 				m.Combo("/comments").Post(repo.MustAllowUserComment, web.Bind(forms.CreateCommentForm{}), repo.NewComment)
 				m.Group("/times", func() {
 					m.Post("/add", web.Bind(forms.AddTimeManuallyForm{}), repo.AddTimeManually)
@@ -1178,6 +1243,7 @@ func registerRoutes(m *web.Route) {
 				m.Post("/reactions/{action}", web.Bind(forms.ReactionForm{}), repo.ChangeIssueReaction)
 				m.Post("/lock", reqRepoIssuesOrPullsWriter, web.Bind(forms.IssueLockForm{}), repo.LockIssue)
 				m.Post("/unlock", reqRepoIssuesOrPullsWriter, repo.UnlockIssue)
+				m.Post("/transfer", reqRepoIssueWriter, web.Bind(forms.IssueTransferForm{}), repo.TransferIssue)
 				m.Post("/delete", reqRepoAdmin, repo.DeleteIssue)
 			}, context.RepoMustNotBeArchived())
 			m.Group("/{index}", func() {
@@ -1202,6 +1268,10 @@ func registerRoutes(m *web.Route) {
 			m.Delete("/unpin/{index}", reqRepoAdmin, repo.IssueUnpin)
 			m.Post("/move_pin", reqRepoAdmin, repo.IssuePinMove)
 		}, context.RepoMustNotBeArchived())
+		m.Group("/{type:issues|pulls}/saved_searches", func() {
+			m.Post("", web.Bind(forms.SavedIssueSearchForm{}), repo.SaveIssueSearch)
+			m.Post("/{id}/delete", repo.DeleteSavedIssueSearch)
+		}, reqRepoIssuesOrPullsReader)
 		m.Group("/comments/{id}", func() {
 			m.Post("", repo.UpdateCommentContent)
 			m.Post("/delete", repo.DeleteComment)
@@ -1364,6 +1434,16 @@ func registerRoutes(m *web.Route) {
 					m.Post("/edit", web.Bind(forms.CreateProjectForm{}), repo.EditProjectPost)
 					m.Post("/{action:open|close}", repo.ChangeProjectStatus)
 
+					m.Group("/views", func() {
+						m.Post("", web.Bind(forms.ProjectViewForm{}), project.NewViewPost)
+						m.Post("/{viewID}", web.Bind(forms.ProjectViewForm{}), project.EditViewPost)
+						m.Post("/{viewID}/delete", project.DeleteViewPost)
+					})
+					m.Group("/automations", func() {
+						m.Post("", web.Bind(forms.ProjectAutomationForm{}), project.NewAutomationPost)
+						m.Post("/{automationID}/delete", project.DeleteAutomationPost)
+					})
+
 					m.Group("/{columnID}", func() {
 						m.Put("", web.Bind(forms.EditProjectColumnForm{}), repo.EditProjectColumn)
 						m.Delete("", repo.DeleteProjectColumn)
@@ -1492,8 +1572,13 @@ func registerRoutes(m *web.Route) {
 				m.Get("/list", context.RepoRef(), repo.GetPullCommits)
 				m.Get("/{sha:[a-f0-9]{4,40}}", context.RepoRef(), repo.SetEditorconfigIfExists, repo.SetDiffViewStyle, repo.SetWhitespaceBehavior, repo.SetShowOutdatedComments, repo.ViewPullFilesForSingleCommit)
 			})
+			m.Group("/revisions", func() {
+				m.Get("", repo.ViewPullRevisions)
+				m.Get("/range-diff", repo.ViewPullRangeDiff)
+			}, context.RepoRef(), repo.GetPullDiffStats)
 			m.Post("/merge", context.RepoMustNotBeArchived(), web.Bind(forms.MergePullRequestForm{}), context.EnforceQuotaWeb(quota_model.LimitSubjectSizeGitAll, context.QuotaTargetRepo), repo.MergePullRequest)
 			m.Post("/cancel_auto_merge", context.RepoMustNotBeArchived(), repo.CancelAutoMergePullRequest)
+			m.Post("/merge_queue/remove", context.RepoMustNotBeArchived(), repo.RemoveFromMergeQueue)
 			m.Post("/update", repo.UpdatePullRequest)
 			m.Post("/set_allow_maintainer_edit", web.Bind(forms.UpdateAllowEditsForm{}), repo.SetAllowEdits)
 			m.Post("/cleanup", context.RepoMustNotBeArchived(), context.RepoRef(), repo.CleanUpPullRequest)
@@ -1506,6 +1591,7 @@ func registerRoutes(m *web.Route) {
 					m.Post("/comments", web.Bind(forms.CodeCommentForm{}), repo.SetShowOutdatedComments, repo.CreateCodeComment)
 					m.Post("/submit", web.Bind(forms.SubmitReviewForm{}), repo.SubmitReview)
 				}, context.RepoMustNotBeArchived())
+				m.Post("/suggestions/apply", reqSignIn, context.RepoMustNotBeArchived(), repo.ApplySuggestions)
 			})
 		}, repo.MustAllowPulls)
 
@@ -1552,6 +1638,10 @@ func registerRoutes(m *web.Route) {
 			m.Get("/graph", repo.Graph)
 			m.Get("/commit/{sha:([a-f0-9]{4,64})$}", repo.SetEditorconfigIfExists, repo.SetDiffViewStyle, repo.SetWhitespaceBehavior, repo.Diff)
 			m.Get("/commit/{sha:([a-f0-9]{4,64})$}/load-branches-and-tags", repo.LoadBranchesAndTags)
+			m.Group("/commit/{sha:([a-f0-9]{4,64})}/comments", func() {
+				m.Post("", web.Bind(forms.CommitCommentForm{}), repo.NewCommitCommentPost)
+				m.Post("/{id}/delete", repo.DeleteCommitCommentPost)
+			}, reqSignIn, context.RepoMustNotBeArchived())
 			m.Get("/cherry-pick/{sha:([a-f0-9]{4,64})$}", repo.SetEditorconfigIfExists, repo.CherryPick)
 		}, repo.MustBeNotEmpty, context.RepoRef(), reqRepoCodeReader)
 
@@ -225,6 +225,9 @@ func ProcReceive(ctx context.Context, repo *repo_model.Repository, gitRepo *git.
 		}
 		notify_service.PullRequestSynchronized(ctx, pusher, pr)
 		isForcePush := comment != nil && comment.IsForcePush
+		if err := pull_service.RecordRevision(ctx, pusher, pr, isForcePush); err != nil {
+			log.Error("RecordRevision %-v: %v", pr, err)
+		}
 
 		results = append(results, private.HookProcReceiveRefResult{
 			OldOID:      oldCommitID,
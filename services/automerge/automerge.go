@@ -22,6 +22,7 @@ import (
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/process"
 	"code.gitea.io/gitea/modules/queue"
+	"code.gitea.io/gitea/services/mergequeue"
 	notify_service "code.gitea.io/gitea/services/notify"
 	pull_service "code.gitea.io/gitea/services/pull"
 )
@@ -284,6 +285,21 @@ func handlePullRequestAutoMerge(pullID int64, sha string) {
 		return
 	}
 
+	if enabled, err := mergequeue.IsEnabled(ctx, pr); err != nil {
+		log.Error("%-v mergequeue.IsEnabled: %v", pr, err)
+		return
+	} else if enabled {
+		// the merge queue takes over the scheduled merge
+		if err := pull_model.DeleteScheduledAutoMerge(ctx, pr.ID); err != nil && !db.IsErrNotExist(err) {
+			log.Error("%-v DeleteScheduledAutoMerge: %v", pr, err)
+			return
+		}
+		if err := mergequeue.Add(ctx, doer, pr, scheduledPRM.MergeStyle, scheduledPRM.Message); err != nil && !pull_model.IsErrAlreadyInMergeQueue(err) {
+			log.Error("%-v mergequeue.Add: %v", pr, err)
+		}
+		return
+	}
+
 	if err := pull_service.Merge(ctx, pr, doer, baseGitRepo, scheduledPRM.MergeStyle, "", scheduledPRM.Message, true); err != nil {
 		log.Error("pull_service.Merge: %v", err)
 		// FIXME: if merge failed, we should display some error message to the pull request page.
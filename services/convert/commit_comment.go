@@ -0,0 +1,28 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	"context"
+
+	git_model "code.gitea.io/gitea/models/git"
+	repo_model "code.gitea.io/gitea/models/repo"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// ToCommitComment converts a git_model.CommitComment to an api.CommitComment
+func ToCommitComment(ctx context.Context, repo *repo_model.Repository, c *git_model.CommitComment) *api.CommitComment {
+	return &api.CommitComment{
+		ID:       c.ID,
+		HTMLURL:  repo.HTMLURL() + "/commit/" + util.PathEscapeSegments(c.CommitSHA) + "#" + c.HashTag(),
+		CommitID: c.CommitSHA,
+		Path:     c.TreePath,
+		Line:     c.Line,
+		Poster:   ToUser(ctx, c.Poster, nil),
+		Body:     c.Content,
+		Created:  c.CreatedUnix.AsTime(),
+		Updated:  c.UpdatedUnix.AsTime(),
+	}
+}
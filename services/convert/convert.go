@@ -179,6 +179,8 @@ func ToBranchProtection(ctx context.Context, bp *git_model.ProtectedBranch, repo
 		ProtectedFilePatterns:         bp.ProtectedFilePatterns,
 		UnprotectedFilePatterns:       bp.UnprotectedFilePatterns,
 		ApplyToAdmins:                 bp.ApplyToAdmins,
+		EnableMergeQueue:              bp.EnableMergeQueue,
+		RequireCodeOwnerApproval:      bp.RequireCodeOwnerApproval,
 		Created:                       bp.CreatedUnix.AsTime(),
 		Updated:                       bp.UpdatedUnix.AsTime(),
 	}
@@ -437,6 +439,19 @@ func ToTagProtection(ctx context.Context, pt *git_model.ProtectedTag, repo *repo
 	}
 }
 
+// ToMergeMessageTemplate convert a git.MergeMessageTemplate to an api.MergeMessageTemplate
+func ToMergeMessageTemplate(tmpl *git_model.MergeMessageTemplate) *api.MergeMessageTemplate {
+	return &api.MergeMessageTemplate{
+		ID:            tmpl.ID,
+		BranchPattern: tmpl.BranchPattern,
+		MergeStyle:    string(tmpl.MergeStyle),
+		Template:      tmpl.Template,
+		AddTrailers:   tmpl.AddTrailers,
+		Created:       tmpl.CreatedUnix.AsTime(),
+		Updated:       tmpl.UpdatedUnix.AsTime(),
+	}
+}
+
 // ToTopicResponse convert from models.Topic to api.TopicResponse
 func ToTopicResponse(topic *repo_model.Topic) *api.TopicResponse {
 	return &api.TopicResponse{
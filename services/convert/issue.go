@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"strings"
 
+	admin_model "code.gitea.io/gitea/models/admin"
 	issues_model "code.gitea.io/gitea/models/issues"
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
@@ -95,6 +96,15 @@ func toIssue(ctx context.Context, doer *user_model.User, issue *issues_model.Iss
 		}
 		apiIssue.Assignee = ToUser(ctx, issue.Assignees[0], nil) // For compatibility, we're keeping the first assignee as `apiIssue.Assignee`
 	}
+	if !issue.IsPull {
+		if err := issue.LoadForm(ctx); err != nil {
+			return &api.Issue{}
+		}
+		if issue.Form != nil {
+			apiIssue.FormTemplate = issue.Form.TemplateFile
+			apiIssue.FormAnswers = issue.Form.Answers
+		}
+	}
 	if issue.IsPull {
 		if err := issue.LoadPullRequest(ctx); err != nil {
 			return &api.Issue{}
@@ -129,6 +139,9 @@ func ToIssueList(ctx context.Context, doer *user_model.User, il issues_model.Iss
 
 // ToAPIIssueList converts an IssueList to API format
 func ToAPIIssueList(ctx context.Context, doer *user_model.User, il issues_model.IssueList) []*api.Issue {
+	if err := il.LoadForms(ctx); err != nil {
+		log.Error("LoadForms: %v", err)
+	}
 	result := make([]*api.Issue, len(il))
 	for i := range il {
 		result[i] = ToAPIIssue(ctx, doer, il[i])
@@ -266,6 +279,44 @@ func ToAPIMilestone(m *issues_model.Milestone) *api.Milestone {
 	return apiMilestone
 }
 
+// ToAPISubIssueProgress converts the rollup of the sub-issues of an issue into API format
+func ToAPISubIssueProgress(p *issues_model.SubIssueProgress) *api.SubIssueProgress {
+	return &api.SubIssueProgress{
+		Closed:      p.Closed,
+		Total:       p.Total,
+		Percent:     p.Percent(),
+		TrackedTime: p.TrackedTime,
+	}
+}
+
+// ToAPIBulkEditIssuesTask converts a task editing issues to API format
+func ToAPIBulkEditIssuesTask(t *admin_model.Task) *api.BulkEditIssuesTask {
+	result := &api.BulkEditIssuesTask{
+		ID:      t.ID,
+		Message: t.Message,
+		Total:   t.ProgressTotal,
+		Done:    t.ProgressDone,
+		Created: t.Created.AsTime(),
+	}
+	switch t.Status {
+	case api.TaskStatusQueued:
+		result.Status = "queued"
+	case api.TaskStatusRunning:
+		result.Status = "running"
+	case api.TaskStatusFailed:
+		result.Status = "failed"
+	case api.TaskStatusFinished:
+		result.Status = "finished"
+	}
+	if t.StartTime > 0 {
+		result.Started = t.StartTime.AsTimePtr()
+	}
+	if t.EndTime > 0 {
+		result.Ended = t.EndTime.AsTimePtr()
+	}
+	return result
+}
+
 // ToLabelTemplate converts Label to API format
 func ToLabelTemplate(label *label.Label) *api.LabelTemplate {
 	result := &api.LabelTemplate{
@@ -0,0 +1,54 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	"context"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+// ToCustomField converts an issues_model.CustomField to an api.CustomField
+func ToCustomField(field *issues_model.CustomField) *api.CustomField {
+	options := field.Options
+	if options == nil {
+		options = []string{}
+	}
+	return &api.CustomField{
+		ID:          field.ID,
+		RepoID:      field.RepoID,
+		Name:        field.Name,
+		Description: field.Description,
+		Type:        string(field.Type),
+		Options:     options,
+		ShowOnCards: field.ShowOnCards,
+		Created:     field.CreatedUnix.AsTime(),
+		Updated:     field.UpdatedUnix.AsTime(),
+	}
+}
+
+// ToCustomFieldList converts a list of issues_model.CustomField to a list of api.CustomField
+func ToCustomFieldList(fields []*issues_model.CustomField) []*api.CustomField {
+	result := make([]*api.CustomField, len(fields))
+	for i := range fields {
+		result[i] = ToCustomField(fields[i])
+	}
+	return result
+}
+
+// ToIssueCustomFieldValues converts the values of the custom fields of an issue to a list of api.IssueCustomFieldValue
+func ToIssueCustomFieldValues(ctx context.Context, values []*issues_model.IssueCustomFieldValue) []*api.IssueCustomFieldValue {
+	result := make([]*api.IssueCustomFieldValue, len(values))
+	for i, v := range values {
+		result[i] = &api.IssueCustomFieldValue{
+			Field: ToCustomField(v.Field),
+			Value: v.DisplayValue(),
+		}
+		if v.User != nil {
+			result[i].User = ToUser(ctx, v.User, nil)
+		}
+	}
+	return result
+}
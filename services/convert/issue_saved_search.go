@@ -0,0 +1,29 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	issues_model "code.gitea.io/gitea/models/issues"
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+// ToSavedIssueSearch converts an issues_model.SavedIssueSearch to an api.SavedIssueSearch
+func ToSavedIssueSearch(search *issues_model.SavedIssueSearch) *api.SavedIssueSearch {
+	return &api.SavedIssueSearch{
+		ID:      search.ID,
+		RepoID:  search.RepoID,
+		Name:    search.Name,
+		Query:   search.Query,
+		Created: search.CreatedUnix.AsTime(),
+	}
+}
+
+// ToSavedIssueSearchList converts a list of issues_model.SavedIssueSearch to a list of api.SavedIssueSearch
+func ToSavedIssueSearchList(searches []*issues_model.SavedIssueSearch) []*api.SavedIssueSearch {
+	result := make([]*api.SavedIssueSearch, len(searches))
+	for i := range searches {
+		result[i] = ToSavedIssueSearch(searches[i])
+	}
+	return result
+}
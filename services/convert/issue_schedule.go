@@ -0,0 +1,80 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	"context"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/organization"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+// ToIssueSchedule converts an issues_model.IssueSchedule to an api.IssueSchedule
+func ToIssueSchedule(ctx context.Context, schedule *issues_model.IssueSchedule) (*api.IssueSchedule, error) {
+	apiSchedule := &api.IssueSchedule{
+		ID:       schedule.ID,
+		Title:    schedule.Title,
+		Body:     schedule.Content,
+		Template: schedule.TemplateFile,
+		Interval: string(schedule.Interval),
+		Start:    schedule.StartUnix.AsTime(),
+		Active:   schedule.IsActive,
+		Created:  schedule.CreatedUnix.AsTime(),
+		Updated:  schedule.UpdatedUnix.AsTime(),
+	}
+	if schedule.IsActive {
+		apiSchedule.NextRun = schedule.NextRunUnix.AsTimePtr()
+	}
+	if schedule.LastRunUnix > 0 {
+		apiSchedule.LastRun = schedule.LastRunUnix.AsTimePtr()
+	}
+
+	creator, err := user_model.GetPossibleUserByID(ctx, schedule.CreatorID)
+	if err != nil {
+		if !user_model.IsErrUserNotExist(err) {
+			return nil, err
+		}
+		creator = user_model.NewGhostUser()
+	}
+	apiSchedule.Creator = ToUser(ctx, creator, nil)
+
+	if schedule.LastIssueID > 0 {
+		issue, err := issues_model.GetIssueByID(ctx, schedule.LastIssueID)
+		if err != nil && !issues_model.IsErrIssueNotExist(err) {
+			return nil, err
+		}
+		if issue != nil && issue.RepoID == schedule.RepoID {
+			apiSchedule.LastIssueIndex = issue.Index
+		}
+	}
+
+	if schedule.AssigneeTeamID > 0 {
+		team, err := organization.GetTeamByID(ctx, schedule.AssigneeTeamID)
+		if err != nil && !organization.IsErrTeamNotExist(err) {
+			return nil, err
+		}
+		if team != nil {
+			if apiSchedule.AssigneeTeam, err = ToTeam(ctx, team); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return apiSchedule, nil
+}
+
+// ToIssueScheduleList converts a list of issues_model.IssueSchedule to a list of api.IssueSchedule
+func ToIssueScheduleList(ctx context.Context, schedules []*issues_model.IssueSchedule) ([]*api.IssueSchedule, error) {
+	result := make([]*api.IssueSchedule, len(schedules))
+	for i, schedule := range schedules {
+		apiSchedule, err := ToIssueSchedule(ctx, schedule)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = apiSchedule
+	}
+	return result, nil
+}
@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"code.gitea.io/gitea/models/db"
 	issues_model "code.gitea.io/gitea/models/issues"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unittest"
@@ -56,3 +57,22 @@ func TestMilestone_APIFormat(t *testing.T) {
 		Deadline:     milestone.DeadlineUnix.AsTimePtr(),
 	}, *ToAPIMilestone(milestone))
 }
+
+func TestToAPIIssueList_Forms(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	answers := []*api.IssueFormAnswer{
+		{ID: "version", Type: api.IssueFormFieldTypeInput, Label: "Version", Value: "1.2"},
+	}
+	require.NoError(t, db.Insert(db.DefaultContext, &issues_model.IssueForm{IssueID: 5, TemplateFile: ".forgejo/issue_template/bug.yaml", Answers: answers}))
+
+	issues := issues_model.IssueList{
+		unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1}),
+		unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 5}),
+	}
+	apiIssues := ToAPIIssueList(db.DefaultContext, nil, issues)
+	require.Len(t, apiIssues, 2)
+	assert.Empty(t, apiIssues[0].FormTemplate)
+	assert.Nil(t, apiIssues[0].FormAnswers)
+	assert.Equal(t, ".forgejo/issue_template/bug.yaml", apiIssues[1].FormTemplate)
+	assert.Equal(t, answers, apiIssues[1].FormAnswers)
+}
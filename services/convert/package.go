@@ -10,6 +10,7 @@ import (
 	access_model "code.gitea.io/gitea/models/perm/access"
 	user_model "code.gitea.io/gitea/models/user"
 	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/services/packages/vulnerability"
 )
 
 // ToPackage convert a packages.PackageDescriptor to api.Package
@@ -36,6 +37,8 @@ func ToPackage(ctx context.Context, pd *packages.PackageDescriptor, doer *user_m
 		Version:    pd.Version.Version,
 		CreatedAt:  pd.Version.CreatedUnix.AsTime(),
 		HTMLURL:    pd.VersionHTMLURL(),
+		Verified:   pd.IsVerified(),
+		Licenses:   pd.Licenses(),
 	}, nil
 }
 
@@ -49,5 +52,132 @@ func ToPackageFile(pfd *packages.PackageFileDescriptor) *api.PackageFile {
 		HashSHA1:   pfd.Blob.HashSHA1,
 		HashSHA256: pfd.Blob.HashSHA256,
 		HashSHA512: pfd.Blob.HashSHA512,
+		Verified:   pfd.IsVerified(),
+	}
+}
+
+// ToPackageSignature converts packages.PackageSignature to api.PackageSignature
+func ToPackageSignature(ctx context.Context, ps *packages.PackageSignature, uploader, doer *user_model.User) (*api.PackageSignature, error) {
+	if err := ps.LoadSigner(ctx); err != nil {
+		return nil, err
+	}
+
+	return &api.PackageSignature{
+		ID:        ps.ID,
+		FileID:    ps.FileID,
+		Type:      string(ps.Type),
+		Signer:    ToUser(ctx, ps.Signer, doer),
+		Uploader:  ToUser(ctx, uploader, doer),
+		KeyID:     ps.KeyID,
+		Signature: ps.Content,
+		CreatedAt: ps.CreatedUnix.AsTime(),
+	}, nil
+}
+
+// ToPackageCleanupRule converts packages.PackageCleanupRule to api.PackageCleanupRule
+func ToPackageCleanupRule(pcr *packages.PackageCleanupRule) *api.PackageCleanupRule {
+	return &api.PackageCleanupRule{
+		ID:                 pcr.ID,
+		Enabled:            pcr.Enabled,
+		Type:               string(pcr.Type),
+		KeepCount:          pcr.KeepCount,
+		KeepPattern:        pcr.KeepPattern,
+		KeepTagPattern:     pcr.KeepTagPattern,
+		KeepPerMajorCount:  pcr.KeepPerMajorCount,
+		KeepDownloadedDays: pcr.KeepDownloadedDays,
+		RemoveDays:         pcr.RemoveDays,
+		RemovePattern:      pcr.RemovePattern,
+		RemoveUntagged:     pcr.RemoveUntagged,
+		MatchFullName:      pcr.MatchFullName,
+		CreatedAt:          pcr.CreatedUnix.AsTime(),
+		UpdatedAt:          pcr.UpdatedUnix.AsTime(),
+	}
+}
+
+// ToPackageCleanupRecord converts packages.PackageCleanupRecord to api.PackageCleanupRecord
+func ToPackageCleanupRecord(pcr *packages.PackageCleanupRecord) *api.PackageCleanupRecord {
+	return &api.PackageCleanupRecord{
+		ID:        pcr.ID,
+		RuleID:    pcr.RuleID,
+		Type:      string(pcr.Type),
+		Name:      pcr.Name,
+		Version:   pcr.Version,
+		Reason:    pcr.Reason,
+		RemovedAt: pcr.CreatedUnix.AsTime(),
+	}
+}
+
+// ToPackageRegistry converts packages.PackageRegistrySetting to api.PackageRegistry
+func ToPackageRegistry(prs *packages.PackageRegistrySetting) *api.PackageRegistry {
+	return &api.PackageRegistry{
+		Type:      string(prs.Type),
+		Immutable: prs.Immutable,
+	}
+}
+
+// ToPackagePromotion converts packages.PackagePromotion to api.PackagePromotion
+func ToPackagePromotion(ctx context.Context, pp *packages.PackagePromotion, doer *user_model.User) (*api.PackagePromotion, error) {
+	users := make(map[int64]*api.User, 3)
+	for _, id := range []int64{pp.SourceOwnerID, pp.TargetOwnerID, pp.DoerID} {
+		if _, ok := users[id]; ok {
+			continue
+		}
+		u, err := user_model.GetPossibleUserByID(ctx, id)
+		if err != nil {
+			if !user_model.IsErrUserNotExist(err) {
+				return nil, err
+			}
+			u = user_model.NewGhostUser()
+		}
+		users[id] = ToUser(ctx, u, doer)
+	}
+
+	return &api.PackagePromotion{
+		ID:          pp.ID,
+		Type:        string(pp.Type),
+		Name:        pp.Name,
+		Version:     pp.Version,
+		SourceOwner: users[pp.SourceOwnerID],
+		TargetOwner: users[pp.TargetOwnerID],
+		Promoter:    users[pp.DoerID],
+		PromotedAt:  pp.CreatedUnix.AsTime(),
+	}, nil
+}
+
+// ToPackageDependency converts vulnerability.Dependency to api.PackageDependency
+func ToPackageDependency(d *vulnerability.Dependency) *api.PackageDependency {
+	return &api.PackageDependency{
+		Ecosystem:   d.Ecosystem,
+		Name:        d.Name,
+		Version:     d.Version,
+		Requirement: d.Requirement,
+		Scope:       d.Scope,
+		Source:      d.Source,
+		Licenses:    d.Licenses,
+	}
+}
+
+// ToPackageVulnerability converts vulnerability.Finding to api.PackageVulnerability
+func ToPackageVulnerability(f *vulnerability.Finding) *api.PackageVulnerability {
+	return &api.PackageVulnerability{
+		ID:        f.Vulnerability.OSVID,
+		Aliases:   f.Vulnerability.Aliases,
+		Summary:   f.Vulnerability.Summary,
+		Severity:  f.Vulnerability.Severity,
+		Ecosystem: f.Ecosystem,
+		Package:   f.Name,
+		Version:   f.Version,
+		Direct:    f.Direct,
+		Source:    f.Source,
+	}
+}
+
+// ToPackageDownloadStat converts packages.PackageDownloadStat to api.PackageDownloadStat
+func ToPackageDownloadStat(s *packages.PackageDownloadStat) *api.PackageDownloadStat {
+	return &api.PackageDownloadStat{
+		Date:          s.DayUnix.AsTime().UTC(),
+		Client:        s.Client,
+		Authenticated: s.Authenticated,
+		Count:         s.Count,
 	}
 }
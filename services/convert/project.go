@@ -0,0 +1,81 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	"context"
+
+	project_model "code.gitea.io/gitea/models/project"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+	project_service "code.gitea.io/gitea/services/projects"
+)
+
+// ToProject converts a project_model.Project to an api.Project
+func ToProject(ctx context.Context, project *project_model.Project) *api.Project {
+	apiProject := &api.Project{
+		ID:          project.ID,
+		Title:       project.Title,
+		Description: project.Description,
+		OwnerID:     project.OwnerID,
+		RepoID:      project.RepoID,
+		IsClosed:    project.IsClosed,
+		HTMLURL:     project.HTMLURL(ctx),
+		Created:     project.CreatedUnix.AsTime(),
+		Updated:     project.UpdatedUnix.AsTime(),
+	}
+	if project.IsClosed {
+		apiProject.Closed = project.ClosedDateUnix.AsTimePtr()
+	}
+	return apiProject
+}
+
+// ToProjectList converts a list of project_model.Project to a list of api.Project
+func ToProjectList(ctx context.Context, projects []*project_model.Project) []*api.Project {
+	result := make([]*api.Project, len(projects))
+	for i := range projects {
+		result[i] = ToProject(ctx, projects[i])
+	}
+	return result
+}
+
+// ToProjectView converts a project_model.View to an api.ProjectView
+func ToProjectView(view *project_model.View) *api.ProjectView {
+	return &api.ProjectView{
+		ID:           view.ID,
+		Name:         view.Name,
+		Type:         string(view.Type),
+		Filter:       view.Filter,
+		GroupBy:      string(view.GroupBy),
+		GroupFieldID: view.GroupFieldID,
+		SortBy:       string(view.SortBy),
+		SortFieldID:  view.SortFieldID,
+		SortDesc:     view.SortDesc,
+		Created:      view.CreatedUnix.AsTime(),
+		Updated:      view.UpdatedUnix.AsTime(),
+	}
+}
+
+// ToProjectViewList converts a list of project_model.View to a list of api.ProjectView
+func ToProjectViewList(views []*project_model.View) []*api.ProjectView {
+	result := make([]*api.ProjectView, len(views))
+	for i := range views {
+		result[i] = ToProjectView(views[i])
+	}
+	return result
+}
+
+// ToProjectViewGroupList converts the groups of the issues of a project view to a list of api.ProjectViewGroup
+func ToProjectViewGroupList(ctx context.Context, doer *user_model.User, groups []*project_service.ViewGroup) []*api.ProjectViewGroup {
+	result := make([]*api.ProjectViewGroup, len(groups))
+	for i, group := range groups {
+		result[i] = &api.ProjectViewGroup{
+			Key:    group.Key,
+			Title:  group.Title,
+			Color:  group.Color,
+			Issues: ToAPIIssueList(ctx, doer, group.Issues),
+		}
+	}
+	return result
+}
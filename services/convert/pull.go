@@ -10,6 +10,7 @@ import (
 	issues_model "code.gitea.io/gitea/models/issues"
 	"code.gitea.io/gitea/models/perm"
 	access_model "code.gitea.io/gitea/models/perm/access"
+	pull_model "code.gitea.io/gitea/models/pull"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/cache"
 	"code.gitea.io/gitea/modules/git"
@@ -102,6 +103,12 @@ func ToAPIPullRequest(ctx context.Context, pr *issues_model.PullRequest, doer *u
 		},
 	}
 
+	if err := pr.LoadParentPull(ctx); err != nil {
+		log.Error("LoadParentPull[%d]: %v", pr.ID, err)
+	} else if pr.ParentPull != nil {
+		apiPullRequest.StackedOn = pr.ParentPull.Index
+	}
+
 	if err = pr.LoadRequestedReviewers(ctx); err != nil {
 		log.Error("LoadRequestedReviewers[%d]: %v", pr.ID, err)
 		return nil
@@ -244,3 +251,19 @@ func ToAPIPullRequest(ctx context.Context, pr *issues_model.PullRequest, doer *u
 
 	return apiPullRequest
 }
+
+// ToMergeQueueEntry converts a merge queue entry of the pull request to API format,
+// the doer of the entry has to be loaded
+func ToMergeQueueEntry(ctx context.Context, e *pull_model.MergeQueueEntry, pr *issues_model.PullRequest, position int64, doer *user_model.User) *api.MergeQueueEntry {
+	return &api.MergeQueueEntry{
+		Number:            pr.Index,
+		BaseBranch:        e.BaseBranch,
+		Position:          position,
+		Status:            e.Status.String(),
+		MergeStyle:        string(e.MergeStyle),
+		SpeculativeBranch: e.SpeculativeBranch(pr.Index),
+		SpeculativeSHA:    e.SpeculativeCommitID,
+		EnqueuedBy:        ToUser(ctx, e.Doer, doer),
+		Created:           e.CreatedUnix.AsTime(),
+	}
+}
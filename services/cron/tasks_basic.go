@@ -14,6 +14,7 @@ import (
 	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/services/auth"
+	issue_service "code.gitea.io/gitea/services/issue"
 	"code.gitea.io/gitea/services/migrations"
 	mirror_service "code.gitea.io/gitea/services/mirror"
 	packages_cleanup_service "code.gitea.io/gitea/services/packages/cleanup"
@@ -156,6 +157,26 @@ func registerCleanupPackages() {
 	})
 }
 
+func registerCheckSLABreaches() {
+	RegisterTaskFatal("check_sla_breaches", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@every 10m",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return issue_service.CheckSLABreaches(ctx)
+	})
+}
+
+func registerCreateScheduledIssues() {
+	RegisterTaskFatal("create_scheduled_issues", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@every 10m",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return issue_service.CreateScheduledIssues(ctx)
+	})
+}
+
 func initBasicTasks() {
 	if setting.Mirror.Enabled {
 		registerUpdateMirrorTask()
@@ -169,6 +190,8 @@ func initBasicTasks() {
 		registerUpdateMigrationPosterID()
 	}
 	registerCleanupHookTaskTable()
+	registerCheckSLABreaches()
+	registerCreateScheduledIssues()
 	if setting.Packages.Enabled {
 		registerCleanupPackages()
 	}
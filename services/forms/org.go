@@ -53,6 +53,29 @@ func (f *UpdateOrgSettingForm) Validate(req *http.Request, errs binding.Errors)
 	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
 }
 
+// OrgRulesetForm form for creating or updating an organization ruleset
+type OrgRulesetForm struct {
+	Name                   string `binding:"Required;MaxSize(255)"`
+	Enforcement            string `binding:"Required;In(active,evaluate,disabled)"`
+	RepoNamePattern        string `binding:"GlobPattern"`
+	RepoTopics             string
+	RefPattern             string `binding:"Required;GlobPattern"`
+	RequirePullRequest     bool
+	RequiredApprovals      int64
+	BlockOnRejectedReviews bool
+	DismissStaleApprovals  bool
+	RequireSignedCommits   bool
+	EnableStatusCheck      bool
+	StatusCheckContexts    string
+	ApplyToAdmins          bool
+}
+
+// Validate validates the fields
+func (f *OrgRulesetForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
 // ___________
 // \__    ___/___ _____    _____
 //   |    |_/ __ \\__  \  /     \
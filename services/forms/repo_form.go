@@ -223,6 +223,8 @@ type ProtectBranchForm struct {
 	ProtectedFilePatterns         string
 	UnprotectedFilePatterns       string
 	ApplyToAdmins                 bool
+	EnableMergeQueue              bool
+	RequireCodeOwnerApproval      bool
 }
 
 // Validate validates the fields
@@ -231,6 +233,80 @@ func (f *ProtectBranchForm) Validate(req *http.Request, errs binding.Errors) bin
 	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
 }
 
+// PushRuleForm form for changing the push rules of a repository or an owner
+type PushRuleForm struct {
+	CommitMessagePattern  string `binding:"RegexPattern"`
+	RequireVerifiedEmail  bool
+	MaxFileSize           int64
+	ForbiddenPathPatterns string
+	DetectSecrets         bool
+}
+
+// Validate validates the fields
+func (f *PushRuleForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+// CustomFieldForm form for creating or editing a custom field of the issues
+type CustomFieldForm struct {
+	Name        string `binding:"Required;MaxSize(255)"`
+	Description string
+	// Type cannot be changed once the field is created
+	Type        string `binding:"In(select,number,date,user)"`
+	Options     string // one option of a select field per line
+	ShowOnCards bool
+}
+
+// Validate validates the fields
+func (f *CustomFieldForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+// SavedIssueSearchForm form for saving a search of the issues
+type SavedIssueSearchForm struct {
+	Name  string `binding:"Required;MaxSize(255)"`
+	Query string `binding:"Required"`
+	// AllRepos lists the search in all the repositories instead of only the current one
+	AllRepos bool
+}
+
+// Validate validates the fields
+func (f *SavedIssueSearchForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+// MergeMessageTemplateForm form for creating or editing a merge message template
+type MergeMessageTemplateForm struct {
+	BranchPattern string `binding:"Required;GlobPattern"`
+	MergeStyle    string `binding:"In(merge,rebase,rebase-merge,squash)"`
+	Template      string `binding:"Required"`
+	AddTrailers   bool
+}
+
+// Validate validates the fields
+func (f *MergeMessageTemplateForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+// SLAPolicyForm form for creating or editing an SLA policy of the issues
+type SLAPolicyForm struct {
+	Name    string `binding:"Required;MaxSize(255)"`
+	LabelID int64
+	// FirstResponseHours and ResolutionHours are 0 for no target
+	FirstResponseHours int64 `binding:"Range(0,100000)"`
+	ResolutionHours    int64 `binding:"Range(0,100000)"`
+}
+
+// Validate validates the fields
+func (f *SLAPolicyForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
 //  __      __      ___.   .__                   __
 // /  \    /  \ ____\_ |__ |  |__   ____   ____ |  | __
 // \   \/\/   // __ \| __ \|  |  \ /  _ \ /  _ \|  |/ /
@@ -262,6 +338,7 @@ type WebhookCoreForm struct {
 	Wiki                     bool
 	Repository               bool
 	Package                  bool
+	CommitComment            bool
 	Active                   bool
 	BranchFilter             string `binding:"GlobPattern"`
 	AuthorizationHeader      string
@@ -322,7 +399,7 @@ func (f *CreateIssueForm) Validate(req *http.Request, errs binding.Errors) bindi
 // CreateCommentForm form for creating comment
 type CreateCommentForm struct {
 	Content string
-	Status  string `binding:"OmitEmpty;In(reopen,close)"`
+	Status  string `binding:"In(reopen,close)"`
 	Files   []string
 }
 
@@ -370,6 +447,17 @@ func (i IssueLockForm) HasValidReason() bool {
 	return false
 }
 
+// IssueTransferForm form for transferring an issue to another repository
+type IssueTransferForm struct {
+	Repo string `binding:"Required"`
+}
+
+// Validate validates the fields
+func (i *IssueTransferForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, i, ctx.Locale)
+}
+
 // CreateProjectForm form for creating a project
 type CreateProjectForm struct {
 	Title        string `binding:"Required;MaxSize(100)"`
@@ -385,6 +473,37 @@ type EditProjectColumnForm struct {
 	Color   string `binding:"MaxSize(7)"`
 }
 
+// ProjectViewForm is a form for creating or editing a view of a project
+type ProjectViewForm struct {
+	Name         string `binding:"Required;MaxSize(255)"`
+	Type         string `binding:"In(board,table)"`
+	Filter       string
+	GroupBy      string
+	GroupFieldID int64
+	SortBy       string
+	SortFieldID  int64
+	SortDesc     bool
+}
+
+// Validate validates the fields
+func (f *ProjectViewForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+// ProjectAutomationForm is a form for creating an automation rule of a project
+type ProjectAutomationForm struct {
+	Event    string `binding:"Required;In(issue_added,pull_added,pull_linked,closed,merged,reopened,label_added)"`
+	LabelID  int64
+	ColumnID int64 `binding:"Required"`
+}
+
+// Validate validates the fields
+func (f *ProjectAutomationForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
 // CreateMilestoneForm form for creating milestone
 type CreateMilestoneForm struct {
 	Title    string `binding:"Required;MaxSize(50)"`
@@ -465,6 +584,20 @@ func (f *CodeCommentForm) Validate(req *http.Request, errs binding.Errors) bindi
 	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
 }
 
+// CommitCommentForm form for commenting on a commit
+type CommitCommentForm struct {
+	Content  string `binding:"Required"`
+	TreePath string `form:"path"`
+	Side     string `binding:"In(previous,proposed)"`
+	Line     int64
+}
+
+// Validate validates the fields
+func (f *CommitCommentForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
 // SubmitReviewForm for submitting a finished code review
 type SubmitReviewForm struct {
 	Content  string
@@ -0,0 +1,215 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package gitdiff
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	pull_model "code.gitea.io/gitea/models/pull"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+)
+
+// RangeDiffStatus is the status of a commit of a range-diff
+type RangeDiffStatus string
+
+const (
+	// RangeDiffEqual means the commit is in both ranges with the same patch
+	RangeDiffEqual RangeDiffStatus = "="
+	// RangeDiffChanged means the commit is in both ranges but its patch or message changed
+	RangeDiffChanged RangeDiffStatus = "!"
+	// RangeDiffRemoved means the commit is only in the old range
+	RangeDiffRemoved RangeDiffStatus = "<"
+	// RangeDiffAdded means the commit is only in the new range
+	RangeDiffAdded RangeDiffStatus = ">"
+)
+
+// RangeDiffEntry is a pair of matching commits of two ranges as reported by git range-diff
+type RangeDiffEntry struct {
+	// OldIndex and NewIndex are the 1-based positions of the commits in their range, 0 if absent
+	OldIndex    int
+	OldCommitID string
+	NewIndex    int
+	NewCommitID string
+	Status      RangeDiffStatus
+	Title       string
+	// Lines is the diff between the patches of the commits if the status is RangeDiffChanged
+	Lines []string
+}
+
+// IsChanged returns true if the patch or the message of the commit changed
+func (e *RangeDiffEntry) IsChanged() bool {
+	return e.Status == RangeDiffChanged
+}
+
+// IsRemoved returns true if the commit is only in the old range
+func (e *RangeDiffEntry) IsRemoved() bool {
+	return e.Status == RangeDiffRemoved
+}
+
+// IsAdded returns true if the commit is only in the new range
+func (e *RangeDiffEntry) IsAdded() bool {
+	return e.Status == RangeDiffAdded
+}
+
+var rangeDiffHeaderPattern = regexp.MustCompile(`^\s*(\d+|-):\s+([0-9a-f]+|-+)\s+([=!<>])\s+(\d+|-):\s+([0-9a-f]+|-+)\s?(.*)$`)
+
+// GetRangeDiff compares the commits of oldBase..oldHead with the commits of newBase..newHead with git range-diff,
+// which pairs commits rebased or amended between the two ranges
+func GetRangeDiff(ctx context.Context, repoPath, oldBase, oldHead, newBase, newHead string) ([]*RangeDiffEntry, error) {
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	if err := git.NewCommand(ctx, "range-diff", "--no-color").
+		AddDynamicArguments(oldBase+".."+oldHead, newBase+".."+newHead).
+		Run(&git.RunOpts{
+			Timeout: time.Duration(setting.Git.Timeout.Default) * time.Second,
+			Dir:     repoPath,
+			Stdout:  stdout,
+			Stderr:  stderr,
+		}); err != nil {
+		return nil, fmt.Errorf("git range-diff: %w - %s", err, stderr.String())
+	}
+	return ParseRangeDiff(stdout)
+}
+
+// ParseRangeDiff parses the output of git range-diff --no-color
+func ParseRangeDiff(r io.Reader) ([]*RangeDiffEntry, error) {
+	entries := make([]*RangeDiffEntry, 0, 5)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), setting.Git.MaxGitDiffLineCharacters+1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if m := rangeDiffHeaderPattern.FindStringSubmatch(line); m != nil {
+			entry := &RangeDiffEntry{Status: RangeDiffStatus(m[3]), Title: m[6]}
+			if m[1] != "-" {
+				entry.OldIndex, _ = strconv.Atoi(m[1])
+				entry.OldCommitID = m[2]
+			}
+			if m[4] != "-" {
+				entry.NewIndex, _ = strconv.Atoi(m[4])
+				entry.NewCommitID = m[5]
+			}
+			entries = append(entries, entry)
+			continue
+		}
+		if len(entries) == 0 {
+			continue
+		}
+		// the diff of the patches is indented by 4 spaces
+		entry := entries[len(entries)-1]
+		entry.Lines = append(entry.Lines, strings.TrimPrefix(line, "    "))
+	}
+	return entries, scanner.Err()
+}
+
+// GetInterdiffChangedFiles returns the files whose changes differ between the diff of oldBase..oldHead
+// and the diff of newBase..newHead. Unlike the files changed between oldHead and newHead, the files
+// only changed by rebasing the commits onto a new base are not included.
+func GetInterdiffChangedFiles(ctx context.Context, gitRepo *git.Repository, oldBase, oldHead, newBase, newHead string) ([]string, error) {
+	oldDiff, err := getFileChangeSignatures(ctx, gitRepo, oldBase, oldHead)
+	if err != nil {
+		return nil, err
+	}
+	newDiff, err := getFileChangeSignatures(ctx, gitRepo, newBase, newHead)
+	if err != nil {
+		return nil, err
+	}
+
+	changedFiles := make([]string, 0, 5)
+	for name, signature := range newDiff {
+		if oldSignature, ok := oldDiff[name]; !ok || signature == "" || oldSignature != signature {
+			changedFiles = append(changedFiles, name)
+		}
+	}
+	for name := range oldDiff {
+		if _, ok := newDiff[name]; !ok {
+			changedFiles = append(changedFiles, name)
+		}
+	}
+	return changedFiles, nil
+}
+
+// getFileChangeSignatures returns for each file changed between base and head a string representing
+// its changes independently of their line numbers, empty if the changes could not be fully parsed
+func getFileChangeSignatures(ctx context.Context, gitRepo *git.Repository, base, head string) (map[string]string, error) {
+	reader, writer := io.Pipe()
+	defer func() {
+		_ = reader.Close()
+		_ = writer.Close()
+	}()
+
+	go func() {
+		stderr := &bytes.Buffer{}
+		if err := git.NewCommand(ctx, "diff", "--no-color", "--no-ext-diff", "--src-prefix=\\a/", "--dst-prefix=\\b/", "-M").
+			AddDynamicArguments(base, head).
+			Run(&git.RunOpts{
+				Timeout: time.Duration(setting.Git.Timeout.Default) * time.Second,
+				Dir:     gitRepo.Path,
+				Stdout:  writer,
+				Stderr:  stderr,
+			}); err != nil {
+			log.Error("error during getFileChangeSignatures(git diff dir: %s): %v, stderr: %s", gitRepo.Path, err, stderr.String())
+		}
+		_ = writer.Close()
+	}()
+
+	diff, err := ParsePatch(ctx, setting.Git.MaxGitDiffLines, setting.Git.MaxGitDiffLineCharacters, -1, reader, "")
+	if err != nil {
+		return nil, fmt.Errorf("unable to ParsePatch: %w", err)
+	}
+
+	signatures := make(map[string]string, len(diff.Files))
+	for _, file := range diff.Files {
+		if file.IsIncomplete || file.IsBin {
+			signatures[file.GetDiffFileName()] = ""
+			continue
+		}
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "%s %s %s\n", file.OldName, file.OldMode, file.Mode)
+		for _, section := range file.Sections {
+			for _, line := range section.Lines {
+				if line.Type == DiffLineSection {
+					// drop the line numbers of the hunk header, they change when the base changes
+					if _, hunkContext, ok := strings.Cut(strings.TrimPrefix(line.Content, "@@"), "@@"); ok {
+						sb.WriteString("@@" + hunkContext + "\n")
+					}
+					continue
+				}
+				fmt.Fprintf(&sb, "%d%s\n", line.Type, line.Content)
+			}
+		}
+		signatures[file.GetDiffFileName()] = sb.String()
+	}
+	return signatures, nil
+}
+
+// getFilesChangedSinceReview returns the files changed between the commit a user reviewed and the latest commit
+// of the pull request. If both are recorded revisions, the files are compared by their changes to the merge base,
+// so that the files only changed by rebasing the pull request are not reported.
+func getFilesChangedSinceReview(ctx context.Context, pull *issues_model.PullRequest, gitRepo *git.Repository, reviewedCommit, latestCommit string) ([]string, error) {
+	reviewedRevision, err := pull_model.GetRevisionByCommitID(ctx, pull.ID, reviewedCommit)
+	if err != nil {
+		return nil, err
+	}
+	if reviewedRevision != nil && reviewedRevision.MergeBase != "" {
+		latestRevision, err := pull_model.GetRevisionByCommitID(ctx, pull.ID, latestCommit)
+		if err != nil {
+			return nil, err
+		}
+		if latestRevision != nil && latestRevision.MergeBase != "" {
+			return GetInterdiffChangedFiles(ctx, gitRepo, reviewedRevision.MergeBase, reviewedCommit, latestRevision.MergeBase, latestCommit)
+		}
+	}
+	return gitRepo.GetFilesChangedBetween(reviewedCommit, latestCommit)
+}
@@ -0,0 +1,58 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package gitdiff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRangeDiff(t *testing.T) {
+	output := `1:  0123456 = 1:  89abcde Add the README
+2:  1111111 ! 2:  2222222 Fix the parser
+    @@ main.go
+     func parse() {
+    -	return nil
+    +	return errors.New("invalid")
+     }
+3:  3333333 < -:  ------- Remove the debug output
+-:  ------- > 3:  4444444 Add tests
+`
+	entries, err := ParseRangeDiff(strings.NewReader(output))
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+
+	assert.Equal(t, RangeDiffEqual, entries[0].Status)
+	assert.Equal(t, 1, entries[0].OldIndex)
+	assert.Equal(t, "0123456", entries[0].OldCommitID)
+	assert.Equal(t, 1, entries[0].NewIndex)
+	assert.Equal(t, "89abcde", entries[0].NewCommitID)
+	assert.Equal(t, "Add the README", entries[0].Title)
+	assert.Empty(t, entries[0].Lines)
+
+	assert.True(t, entries[1].IsChanged())
+	assert.Equal(t, "Fix the parser", entries[1].Title)
+	assert.Equal(t, []string{
+		"@@ main.go",
+		" func parse() {",
+		"-\treturn nil",
+		"+\treturn errors.New(\"invalid\")",
+		" }",
+	}, entries[1].Lines)
+
+	assert.True(t, entries[2].IsRemoved())
+	assert.Equal(t, 3, entries[2].OldIndex)
+	assert.Equal(t, 0, entries[2].NewIndex)
+	assert.Empty(t, entries[2].NewCommitID)
+
+	assert.True(t, entries[3].IsAdded())
+	assert.Equal(t, 0, entries[3].OldIndex)
+	assert.Empty(t, entries[3].OldCommitID)
+	assert.Equal(t, 3, entries[3].NewIndex)
+	assert.Equal(t, "4444444", entries[3].NewCommitID)
+	assert.Equal(t, "Add tests", entries[3].Title)
+}
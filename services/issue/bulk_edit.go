@@ -0,0 +1,313 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"context"
+	"fmt"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/container"
+	issue_indexer "code.gitea.io/gitea/modules/indexer/issues"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/util"
+	notify_service "code.gitea.io/gitea/services/notify"
+)
+
+const (
+	// MaxBulkEditIssues is the maximum number of issues edited at once
+	MaxBulkEditIssues = 1000
+
+	// bulkEditNotifyBatchSize is the number of issues notified in a single summary, and between two progress reports
+	bulkEditNotifyBatchSize = 50
+)
+
+// BulkEditOptions are the changes applied to all the issues of a bulk edit
+type BulkEditOptions struct {
+	AddLabelIDs    []int64
+	RemoveLabelIDs []int64
+	// MilestoneID is the new milestone of the issues, 0 to remove their milestone, nil to keep it
+	MilestoneID       *int64
+	AddAssigneeIDs    []int64
+	RemoveAssigneeIDs []int64
+	// IsClosed closes or reopens the issues, nil to keep their state
+	IsClosed *bool
+}
+
+// IsEmpty returns true if the options don't change anything
+func (opts *BulkEditOptions) IsEmpty() bool {
+	return len(opts.AddLabelIDs) == 0 && len(opts.RemoveLabelIDs) == 0 && opts.MilestoneID == nil &&
+		len(opts.AddAssigneeIDs) == 0 && len(opts.RemoveAssigneeIDs) == 0 && opts.IsClosed == nil
+}
+
+// FindBulkEditIssueIDs returns the IDs of the issues of a repository matching a structured search query,
+// restricted to the issues or to the pull requests by isPull. It fails if there are more than MaxBulkEditIssues issues.
+func FindBulkEditIssueIDs(ctx context.Context, repo *repo_model.Repository, doer *user_model.User, query string, isPull optional.Option[bool]) ([]int64, error) {
+	q, err := issue_indexer.ParseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	applyQuery, err := ResolveSearchQuery(ctx, q, repo, doer)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := (&issue_indexer.SearchOptions{
+		RepoIDs:   []int64{repo.ID},
+		Paginator: &db.ListOptions{Page: 1, PageSize: MaxBulkEditIssues},
+		SortBy:    issue_indexer.SortByCreatedAsc,
+	}).Copy(func(o *issue_indexer.SearchOptions) {
+		applyQuery(o)
+		if isPull.Has() {
+			o.IsPull = isPull
+		}
+	})
+	ids, total, err := issue_indexer.SearchIssues(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	if total > MaxBulkEditIssues {
+		return nil, util.NewInvalidArgumentErrorf("the query matches %d issues, at most %d issues can be edited at once", total, MaxBulkEditIssues)
+	}
+	return ids, nil
+}
+
+// bulkEditChange records the changes made to an issue by a bulk edit, to notify them
+type bulkEditChange struct {
+	issue            *issues_model.Issue
+	addedLabels      []*issues_model.Label
+	removedLabels    []*issues_model.Label
+	oldMilestoneID   int64
+	milestoneChanged bool
+	assignees        []*bulkEditAssigneeChange
+	statusComment    *issues_model.Comment
+}
+
+type bulkEditAssigneeChange struct {
+	assignee *user_model.User
+	removed  bool
+	comment  *issues_model.Comment
+}
+
+// bulkEditTargets are the labels, milestone and users of the options of a bulk edit
+type bulkEditTargets struct {
+	addLabels       []*issues_model.Label
+	removeLabels    []*issues_model.Label
+	addAssignees    []*user_model.User
+	removeAssignees []*user_model.User
+}
+
+func loadBulkEditTargets(ctx context.Context, repo *repo_model.Repository, opts *BulkEditOptions) (*bulkEditTargets, error) {
+	if err := repo.LoadOwner(ctx); err != nil {
+		return nil, err
+	}
+	loadLabels := func(ids []int64) ([]*issues_model.Label, error) {
+		labels, err := issues_model.GetLabelsByIDs(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		for _, label := range labels {
+			if label.RepoID != repo.ID && (label.OrgID == 0 || label.OrgID != repo.OwnerID) {
+				return nil, util.NewInvalidArgumentErrorf("label %d does not exist in the repository", label.ID)
+			}
+		}
+		if len(labels) != len(container.SetOf(ids...)) {
+			return nil, util.NewInvalidArgumentErrorf("some labels do not exist")
+		}
+		return labels, nil
+	}
+	loadUsers := func(ids []int64) ([]*user_model.User, error) {
+		users, err := user_model.GetUsersByIDs(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		if len(users) != len(container.SetOf(ids...)) {
+			return nil, util.NewInvalidArgumentErrorf("some assignees do not exist")
+		}
+		return users, nil
+	}
+
+	t := &bulkEditTargets{}
+	var err error
+	if t.addLabels, err = loadLabels(opts.AddLabelIDs); err != nil {
+		return nil, err
+	}
+	if t.removeLabels, err = loadLabels(opts.RemoveLabelIDs); err != nil {
+		return nil, err
+	}
+	if opts.MilestoneID != nil && *opts.MilestoneID > 0 {
+		if has, err := issues_model.HasMilestoneByRepoID(ctx, repo.ID, *opts.MilestoneID); err != nil {
+			return nil, err
+		} else if !has {
+			return nil, util.NewInvalidArgumentErrorf("milestone %d does not exist in the repository", *opts.MilestoneID)
+		}
+	}
+	if t.addAssignees, err = loadUsers(opts.AddAssigneeIDs); err != nil {
+		return nil, err
+	}
+	for _, assignee := range t.addAssignees {
+		if ok, err := access_model.CanBeAssigned(ctx, assignee, repo, false); err != nil {
+			return nil, err
+		} else if !ok {
+			return nil, util.NewInvalidArgumentErrorf("user %s cannot be assigned to the issues of the repository", assignee.Name)
+		}
+	}
+	if t.removeAssignees, err = loadUsers(opts.RemoveAssigneeIDs); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// BulkEditIssues applies the same changes to issues of a repository. The changes are applied in a single transaction,
+// so that either all the issues are edited or none is, then the notifications are sent by batches of issues,
+// calling progress with the number of issues notified after each batch. The changes of each issue are notified
+// as usual, except to the notifiers writing to users, which get a single summary of each batch.
+func BulkEditIssues(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, issueIDs []int64, opts *BulkEditOptions, progress func(done int)) error {
+	targets, err := loadBulkEditTargets(ctx, repo, opts)
+	if err != nil {
+		return err
+	}
+
+	changes := make([]*bulkEditChange, 0, len(issueIDs))
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		issues, err := issues_model.GetIssuesByIDs(ctx, issueIDs, true)
+		if err != nil {
+			return err
+		}
+		for _, issue := range issues {
+			if issue.RepoID != repo.ID {
+				return issues_model.ErrIssueNotExist{ID: issue.ID, RepoID: repo.ID}
+			}
+			issue.Repo = repo
+			change, err := bulkEditIssue(ctx, doer, issue, targets, opts)
+			if err != nil {
+				return fmt.Errorf("edit issue #%d: %w", issue.Index, err)
+			}
+			changes = append(changes, change)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	bulkCtx := notify_service.WithBulkEdit(ctx)
+	for start := 0; start < len(changes); start += bulkEditNotifyBatchSize {
+		batch := changes[start:min(start+bulkEditNotifyBatchSize, len(changes))]
+		issues := make([]*issues_model.Issue, 0, len(batch))
+		for _, change := range batch {
+			notifyBulkEditChange(bulkCtx, doer, change)
+			issues = append(issues, change.issue)
+		}
+		notify_service.IssuesBulkEdited(ctx, doer, repo, issues)
+		if progress != nil {
+			progress(start + len(batch))
+		}
+	}
+	return nil
+}
+
+func bulkEditIssue(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, targets *bulkEditTargets, opts *BulkEditOptions) (*bulkEditChange, error) {
+	change := &bulkEditChange{issue: issue}
+
+	for _, label := range targets.removeLabels {
+		if !issues_model.HasIssueLabel(ctx, issue.ID, label.ID) {
+			continue
+		}
+		if err := issues_model.DeleteIssueLabel(ctx, issue, label, doer); err != nil {
+			return nil, err
+		}
+		change.removedLabels = append(change.removedLabels, label)
+	}
+	for _, label := range targets.addLabels {
+		if !issues_model.HasIssueLabel(ctx, issue.ID, label.ID) {
+			change.addedLabels = append(change.addedLabels, label)
+		}
+	}
+	if len(change.addedLabels) > 0 {
+		if err := issues_model.NewIssueLabels(ctx, issue, change.addedLabels, doer); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.MilestoneID != nil && *opts.MilestoneID != issue.MilestoneID {
+		change.oldMilestoneID = issue.MilestoneID
+		change.milestoneChanged = true
+		issue.MilestoneID = *opts.MilestoneID
+		if err := changeMilestoneAssign(ctx, doer, issue, change.oldMilestoneID); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(targets.addAssignees) > 0 || len(targets.removeAssignees) > 0 {
+		// toggling an assignee relies on the loaded assignees
+		if err := issue.LoadAssignees(ctx); err != nil {
+			return nil, err
+		}
+	}
+	toggleAssignee := func(assignee *user_model.User, assign bool) error {
+		isAssigned, err := issues_model.IsUserAssignedToIssue(ctx, issue, assignee)
+		if err != nil || isAssigned == assign {
+			return err
+		}
+		removed, comment, err := issues_model.ToggleIssueAssignee(ctx, issue, doer, assignee.ID)
+		if err != nil {
+			return err
+		}
+		change.assignees = append(change.assignees, &bulkEditAssigneeChange{assignee: assignee, removed: removed, comment: comment})
+		return nil
+	}
+	for _, assignee := range targets.removeAssignees {
+		if err := toggleAssignee(assignee, false); err != nil {
+			return nil, err
+		}
+	}
+	for _, assignee := range targets.addAssignees {
+		if err := toggleAssignee(assignee, true); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.IsClosed != nil && *opts.IsClosed != issue.IsClosed {
+		if issue.IsPull {
+			if err := issue.LoadPullRequest(ctx); err != nil {
+				return nil, err
+			}
+		}
+		// the state of a merged pull request cannot change
+		if !issue.IsPull || !issue.PullRequest.HasMerged {
+			comment, err := issues_model.ChangeIssueStatus(ctx, issue, doer, *opts.IsClosed)
+			if err != nil {
+				return nil, err
+			}
+			if *opts.IsClosed {
+				if err := issues_model.FinishIssueStopwatchIfPossible(ctx, doer, issue); err != nil {
+					return nil, err
+				}
+			}
+			change.statusComment = comment
+		}
+	}
+
+	return change, nil
+}
+
+func notifyBulkEditChange(ctx context.Context, doer *user_model.User, change *bulkEditChange) {
+	issue := change.issue
+	if len(change.addedLabels) > 0 || len(change.removedLabels) > 0 {
+		notify_service.IssueChangeLabels(ctx, doer, issue, change.addedLabels, change.removedLabels)
+	}
+	if change.milestoneChanged {
+		notify_service.IssueChangeMilestone(ctx, doer, issue, change.oldMilestoneID)
+	}
+	for _, a := range change.assignees {
+		notify_service.IssueChangeAssignee(ctx, doer, issue, a.assignee, a.removed, a.comment)
+	}
+	if change.statusComment != nil {
+		notify_service.IssueChangeStatus(ctx, doer, "", issue, change.statusComment, issue.IsClosed)
+	}
+}
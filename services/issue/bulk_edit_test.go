@@ -0,0 +1,89 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindBulkEditIssueIDs(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	ids, err := FindBulkEditIssueIDs(db.DefaultContext, repo, doer, "is:open", optional.Some(false))
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1}, ids)
+
+	ids, err = FindBulkEditIssueIDs(db.DefaultContext, repo, doer, "label:label1", optional.None[bool]())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int64{1, 2}, ids)
+
+	_, err = FindBulkEditIssueIDs(db.DefaultContext, repo, doer, "label:unknown", optional.None[bool]())
+	require.ErrorIs(t, err, util.ErrInvalidArgument)
+}
+
+func TestBulkEditIssues(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	milestoneID := int64(2)
+	isClosed := false
+	var done []int
+	require.NoError(t, BulkEditIssues(db.DefaultContext, doer, repo, []int64{1, 5}, &BulkEditOptions{
+		AddLabelIDs:       []int64{2},
+		RemoveLabelIDs:    []int64{1},
+		MilestoneID:       &milestoneID,
+		AddAssigneeIDs:    []int64{2},
+		RemoveAssigneeIDs: []int64{1},
+		IsClosed:          &isClosed,
+	}, func(n int) { done = append(done, n) }))
+	assert.Equal(t, []int{2}, done)
+
+	for _, id := range []int64{1, 5} {
+		issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: id})
+		assert.False(t, issue.IsClosed)
+		assert.EqualValues(t, 2, issue.MilestoneID)
+		assert.True(t, issues_model.HasIssueLabel(db.DefaultContext, id, 2))
+		assert.False(t, issues_model.HasIssueLabel(db.DefaultContext, id, 1))
+		unittest.AssertExistsAndLoadBean(t, &issues_model.IssueAssignees{IssueID: id, AssigneeID: 2})
+		unittest.AssertNotExistsBean(t, &issues_model.IssueAssignees{IssueID: id, AssigneeID: 1})
+	}
+	// the label already on the issue is not added again
+	unittest.AssertCount(t, &issues_model.Comment{IssueID: 5, Type: issues_model.CommentTypeLabel, LabelID: 2}, 0)
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{IssueID: 1, Type: issues_model.CommentTypeLabel, LabelID: 2})
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{IssueID: 5, Type: issues_model.CommentTypeReopen})
+	unittest.CheckConsistencyFor(t, &issues_model.Milestone{}, &issues_model.Issue{})
+}
+
+func TestBulkEditIssuesTransaction(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	// the issue 4 belongs to another repository, the issue 1 must not be edited either
+	err := BulkEditIssues(db.DefaultContext, doer, repo, []int64{1, 4}, &BulkEditOptions{AddLabelIDs: []int64{2}}, nil)
+	require.ErrorIs(t, err, util.ErrNotExist)
+	assert.False(t, issues_model.HasIssueLabel(db.DefaultContext, 1, 2))
+
+	// the labels must belong to the repository or to its owner
+	err = BulkEditIssues(db.DefaultContext, doer, repo, []int64{1}, &BulkEditOptions{AddLabelIDs: []int64{3}}, nil)
+	require.ErrorIs(t, err, util.ErrInvalidArgument)
+
+	milestoneID := int64(4)
+	err = BulkEditIssues(db.DefaultContext, doer, repo, []int64{1}, &BulkEditOptions{MilestoneID: &milestoneID}, nil)
+	require.ErrorIs(t, err, util.ErrInvalidArgument)
+}
@@ -0,0 +1,83 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	user_model "code.gitea.io/gitea/models/user"
+	issue_indexer "code.gitea.io/gitea/modules/indexer/issues"
+	notify_service "code.gitea.io/gitea/services/notify"
+)
+
+// SetCustomFieldValues sets the values of custom fields on an issue, by field ID.
+// An empty value removes the value of the field.
+func SetCustomFieldValues(ctx context.Context, issue *issues_model.Issue, doer *user_model.User, values map[int64]string) error {
+	if len(values) == 0 {
+		return nil
+	}
+	if err := issue.LoadRepo(ctx); err != nil {
+		return err
+	}
+
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		for fieldID, value := range values {
+			field, err := issues_model.GetCustomFieldInRepoByID(ctx, issue.Repo.OwnerID, issue.RepoID, fieldID)
+			if err != nil {
+				return err
+			}
+			if err := issues_model.SetIssueCustomFieldValue(ctx, issue.ID, field, value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	notify_service.IssueChangeCustomFields(ctx, doer, issue)
+	return nil
+}
+
+// UpdateCustomField updates the definition of a custom field and reindexes the issues which lost their value
+func UpdateCustomField(ctx context.Context, field *issues_model.CustomField) error {
+	issueIDs, err := issues_model.GetIssueIDsByCustomFieldID(ctx, field.ID)
+	if err != nil {
+		return err
+	}
+	if err := issues_model.UpdateCustomField(ctx, field); err != nil {
+		return err
+	}
+
+	remaining, err := issues_model.GetIssueIDsByCustomFieldID(ctx, field.ID)
+	if err != nil {
+		return err
+	}
+	if len(remaining) != len(issueIDs) {
+		reindexIssues(ctx, issueIDs)
+	}
+	return nil
+}
+
+// DeleteCustomField deletes a custom field and reindexes the issues which had a value for it
+func DeleteCustomField(ctx context.Context, field *issues_model.CustomField) error {
+	issueIDs, err := issues_model.GetIssueIDsByCustomFieldID(ctx, field.ID)
+	if err != nil {
+		return err
+	}
+	if err := issues_model.DeleteCustomField(ctx, field); err != nil {
+		return err
+	}
+
+	reindexIssues(ctx, issueIDs)
+	return nil
+}
+
+func reindexIssues(ctx context.Context, issueIDs []int64) {
+	for _, id := range issueIDs {
+		issue_indexer.UpdateIssueIndexer(ctx, id)
+	}
+}
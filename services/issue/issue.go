@@ -13,6 +13,7 @@ import (
 	issues_model "code.gitea.io/gitea/models/issues"
 	access_model "code.gitea.io/gitea/models/perm/access"
 	project_model "code.gitea.io/gitea/models/project"
+	pull_model "code.gitea.io/gitea/models/pull"
 	repo_model "code.gitea.io/gitea/models/repo"
 	system_model "code.gitea.io/gitea/models/system"
 	user_model "code.gitea.io/gitea/models/user"
@@ -170,6 +171,13 @@ func DeleteIssue(ctx context.Context, doer *user_model.User, gitRepo *git.Reposi
 	if err := issue.LoadPullRequest(ctx); err != nil {
 		return err
 	}
+	var numRevisions int64
+	if issue.IsPull {
+		var err error
+		if numRevisions, err = pull_model.CountRevisions(ctx, issue.PullRequest.ID); err != nil {
+			return err
+		}
+	}
 
 	// delete entries in database
 	if err := deleteIssue(ctx, issue); err != nil {
@@ -181,6 +189,11 @@ func DeleteIssue(ctx context.Context, doer *user_model.User, gitRepo *git.Reposi
 		if err := gitRepo.RemoveReference(fmt.Sprintf("%s%d/head", git.PullPrefix, issue.PullRequest.Index)); err != nil {
 			return err
 		}
+		for i := int64(1); i <= numRevisions; i++ {
+			if err := gitRepo.RemoveReference(pull_model.RevisionRefName(issue.PullRequest.Index, i)); err != nil {
+				return err
+			}
+		}
 	}
 
 	// If the Issue is pinned, we should unpin it before deletion to avoid problems with other pinned Issues
@@ -285,12 +298,30 @@ func deleteIssue(ctx context.Context, issue *issues_model.Issue) error {
 		system_model.RemoveStorageWithNotice(ctx, storage.Attachments, "Delete issue attachment", issue.Attachments[i].RelativePath())
 	}
 
+	if issue.IsPull {
+		if err := issue.LoadPullRequest(ctx); err != nil {
+			return err
+		}
+		if err := db.DeleteBeans(ctx,
+			&pull_model.MergeQueueEntry{PullID: issue.PullRequest.ID},
+			&pull_model.Revision{PullID: issue.PullRequest.ID},
+			&pull_model.ReviewedRevision{PullID: issue.PullRequest.ID},
+		); err != nil {
+			return err
+		}
+	}
+
 	// delete all database data still assigned to this issue
 	if err := db.DeleteBeans(ctx,
 		&issues_model.ContentHistory{IssueID: issue.ID},
 		&issues_model.Comment{IssueID: issue.ID},
 		&issues_model.IssueLabel{IssueID: issue.ID},
+		&issues_model.IssueCustomFieldValue{IssueID: issue.ID},
+		&issues_model.IssueRedirect{IssueID: issue.ID},
+		&issues_model.IssueSLABreach{IssueID: issue.ID},
+		&issues_model.IssueForm{IssueID: issue.ID},
 		&issues_model.IssueDependency{IssueID: issue.ID},
+		&issues_model.SubIssue{IssueID: issue.ID},
 		&issues_model.IssueAssignees{IssueID: issue.ID},
 		&issues_model.IssueUser{IssueID: issue.ID},
 		&activities_model.Notification{IssueID: issue.ID},
@@ -303,6 +334,7 @@ func deleteIssue(ctx context.Context, issue *issues_model.Issue) error {
 		&issues_model.PullRequest{IssueID: issue.ID},
 		&issues_model.Comment{RefIssueID: issue.ID},
 		&issues_model.IssueDependency{DependencyID: issue.ID},
+		&issues_model.SubIssue{ParentID: issue.ID},
 		&issues_model.Comment{DependentIssueID: issue.ID},
 	); err != nil {
 		return err
@@ -8,6 +8,7 @@ import (
 
 	"code.gitea.io/gitea/models/db"
 	issues_model "code.gitea.io/gitea/models/issues"
+	pull_model "code.gitea.io/gitea/models/pull"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unittest"
 	user_model "code.gitea.io/gitea/models/user"
@@ -79,9 +80,15 @@ func TestIssue_DeleteIssue(t *testing.T) {
 	require.NoError(t, err)
 	assert.False(t, left)
 
+	// check pull request revision removal
+	require.NoError(t, db.Insert(db.DefaultContext, &pull_model.Revision{PullID: 1, Index: 1, CommitID: "65f1bf27bc3bf70f64657658635e66094edbcb4d"}))
+	require.NoError(t, db.Insert(db.DefaultContext, &pull_model.ReviewedRevision{PullID: 1, UserID: 1, RevisionIndex: 1}))
+
 	err = deleteIssue(db.DefaultContext, issue2)
 	require.NoError(t, err)
 	left, err = issues_model.IssueNoDependenciesLeft(db.DefaultContext, issue1)
 	require.NoError(t, err)
 	assert.True(t, left)
+	unittest.AssertNotExistsBean(t, &pull_model.Revision{PullID: 1})
+	unittest.AssertNotExistsBean(t, &pull_model.ReviewedRevision{PullID: 1})
 }
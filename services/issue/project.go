@@ -0,0 +1,33 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"context"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	user_model "code.gitea.io/gitea/models/user"
+	notify_service "code.gitea.io/gitea/services/notify"
+)
+
+// AssignOrRemoveProject changes the project of an issue, and the column of the issue in the new project.
+// If newProjectID is 0 the issue is removed from its project, if newColumnID is 0 the issue is added to the default column.
+func AssignOrRemoveProject(ctx context.Context, issue *issues_model.Issue, doer *user_model.User, newProjectID, newColumnID int64) error {
+	issue.Project = nil
+	if err := issue.LoadProject(ctx); err != nil {
+		return err
+	}
+	var oldProjectID int64
+	if issue.Project != nil {
+		oldProjectID = issue.Project.ID
+	}
+
+	if err := issues_model.IssueAssignOrRemoveProject(ctx, issue, doer, newProjectID, newColumnID); err != nil {
+		return err
+	}
+	issue.Project = nil
+
+	notify_service.IssueChangeProject(ctx, doer, issue, oldProjectID)
+	return nil
+}
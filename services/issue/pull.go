@@ -40,9 +40,38 @@ type ReviewRequestNotifier struct {
 	ReviewTeam *org_model.Team
 }
 
-func PullRequestCodeOwnersReview(ctx context.Context, issue *issues_model.Issue, pr *issues_model.PullRequest) ([]*ReviewRequestNotifier, error) {
-	files := []string{"CODEOWNERS", "docs/CODEOWNERS", ".gitea/CODEOWNERS"}
+// codeOwnersFiles are the paths where the CODEOWNERS file is looked up, in order
+var codeOwnersFiles = []string{"CODEOWNERS", "docs/CODEOWNERS", ".gitea/CODEOWNERS"}
+
+// GetCodeOwnersRules returns the rules of the CODEOWNERS file of the commit
+func GetCodeOwnersRules(ctx context.Context, commit *git.Commit) []*issues_model.CodeOwnerRule {
+	var data string
+	for _, file := range codeOwnersFiles {
+		if blob, err := commit.GetBlobByPath(file); err == nil {
+			data, err = blob.GetBlobContent(setting.UI.MaxDisplayFileSize)
+			if err == nil {
+				break
+			}
+		}
+	}
+
+	rules, _ := issues_model.GetCodeOwnersFromContent(ctx, data)
+	return rules
+}
+
+// GetPullRequestChangedFiles returns the files changed by the pull request since its merge base
+func GetPullRequestChangedFiles(repo *git.Repository, pr *issues_model.PullRequest) ([]string, error) {
+	mergeBase, err := getMergeBase(repo, pr, git.BranchPrefix+pr.BaseBranch, pr.GetGitRefName())
+	if err != nil {
+		return nil, err
+	}
+
+	// https://github.com/go-gitea/gitea/issues/29763, we need to get the files changed
+	// between the merge base and the head commit but not the base branch and the head commit
+	return repo.GetFilesChangedBetween(mergeBase, pr.GetGitRefName())
+}
 
+func PullRequestCodeOwnersReview(ctx context.Context, issue *issues_model.Issue, pr *issues_model.PullRequest) ([]*ReviewRequestNotifier, error) {
 	if pr.IsWorkInProgress(ctx) {
 		return nil, nil
 	}
@@ -70,27 +99,9 @@ func PullRequestCodeOwnersReview(ctx context.Context, issue *issues_model.Issue,
 		return nil, err
 	}
 
-	var data string
-	for _, file := range files {
-		if blob, err := commit.GetBlobByPath(file); err == nil {
-			data, err = blob.GetBlobContent(setting.UI.MaxDisplayFileSize)
-			if err == nil {
-				break
-			}
-		}
-	}
-
-	rules, _ := issues_model.GetCodeOwnersFromContent(ctx, data)
-
-	// get the mergebase
-	mergeBase, err := getMergeBase(repo, pr, git.BranchPrefix+pr.BaseBranch, pr.GetGitRefName())
-	if err != nil {
-		return nil, err
-	}
+	rules := GetCodeOwnersRules(ctx, commit)
 
-	// https://github.com/go-gitea/gitea/issues/29763, we need to get the files changed
-	// between the merge base and the head commit but not the base branch and the head commit
-	changedFiles, err := repo.GetFilesChangedBetween(mergeBase, pr.GetGitRefName())
+	changedFiles, err := GetPullRequestChangedFiles(repo, pr)
 	if err != nil {
 		return nil, err
 	}
@@ -99,7 +110,7 @@ func PullRequestCodeOwnersReview(ctx context.Context, issue *issues_model.Issue,
 	uniqTeams := make(map[string]*org_model.Team)
 	for _, rule := range rules {
 		for _, f := range changedFiles {
-			if (rule.Rule.MatchString(f) && !rule.Negative) || (!rule.Rule.MatchString(f) && rule.Negative) {
+			if rule.Match(f) {
 				for _, u := range rule.Users {
 					uniqUsers[u.ID] = u
 				}
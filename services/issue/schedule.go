@@ -0,0 +1,222 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/organization"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/issue/template"
+	"code.gitea.io/gitea/modules/log"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// CreateScheduledIssues creates the issues of the schedules which are due. A schedule whose issue cannot be
+// created moves on to its next issue all the same, so that it is not retried at every run.
+func CreateScheduledIssues(ctx context.Context) error {
+	now := timeutil.TimeStampNow()
+	schedules, err := issues_model.FindDueIssueSchedules(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	for _, schedule := range schedules {
+		select {
+		case <-ctx.Done():
+			return db.ErrCancelledf("during scheduled issues creation of schedule %d", schedule.ID)
+		default:
+		}
+
+		issue, err := createScheduledIssue(ctx, schedule)
+		if err != nil {
+			log.Error("createScheduledIssue [schedule: %d]: %v", schedule.ID, err)
+		} else if issue != nil {
+			schedule.LastIssueID = issue.ID
+		}
+
+		schedule.LastRunUnix = now
+		schedule.NextRunUnix = schedule.NextRunAfter(now)
+		schedule.IsActive = schedule.NextRunUnix > 0
+		if err := issues_model.UpdateIssueScheduleRun(ctx, schedule); err != nil {
+			return fmt.Errorf("UpdateIssueScheduleRun: %w", err)
+		}
+	}
+	return nil
+}
+
+// createScheduledIssue creates the issue of a schedule, it returns nil if the repository no longer accepts issues
+func createScheduledIssue(ctx context.Context, schedule *issues_model.IssueSchedule) (*issues_model.Issue, error) {
+	repo, err := repo_model.GetRepositoryByID(ctx, schedule.RepoID)
+	if err != nil {
+		return nil, fmt.Errorf("GetRepositoryByID: %w", err)
+	}
+	if repo.IsArchived || !repo.UnitEnabled(ctx, unit.TypeIssues) {
+		return nil, nil
+	}
+
+	creator, err := user_model.GetUserByID(ctx, schedule.CreatorID)
+	if err != nil {
+		return nil, fmt.Errorf("GetUserByID: %w", err)
+	}
+	perm, err := access_model.GetUserRepoPermission(ctx, repo, creator)
+	if err != nil {
+		return nil, fmt.Errorf("GetUserRepoPermission: %w", err)
+	}
+	if !perm.CanWrite(unit.TypeIssues) {
+		return nil, fmt.Errorf("%s can no longer write the issues of %s", creator.Name, repo.FullName())
+	}
+
+	issue := &issues_model.Issue{
+		RepoID:   repo.ID,
+		Repo:     repo,
+		PosterID: creator.ID,
+		Poster:   creator,
+		Title:    schedule.Title,
+		Content:  schedule.Content,
+	}
+
+	var labelIDs []int64
+	if schedule.TemplateFile != "" {
+		tmpl, err := LoadIssueScheduleTemplate(ctx, repo, schedule.TemplateFile)
+		if err != nil {
+			return nil, err
+		}
+		if issue.Title == "" {
+			issue.Title = tmpl.Title
+		}
+		if issue.Title == "" {
+			issue.Title = tmpl.Name
+		}
+		if issue.Content == "" {
+			if tmpl.Type() == api.IssueTemplateTypeYaml {
+				issue.Content = template.RenderToMarkdown(tmpl, url.Values{})
+			} else {
+				issue.Content = tmpl.Content
+			}
+		}
+		if tmpl.Ref != "" {
+			issue.Ref = tmpl.Ref
+			if !strings.HasPrefix(issue.Ref, "refs/") {
+				issue.Ref = git.BranchPrefix + issue.Ref
+			}
+		}
+		if labelIDs, err = getTemplateLabelIDs(ctx, repo, tmpl.Labels); err != nil {
+			return nil, err
+		}
+	}
+
+	var assigneeIDs []int64
+	if assignee, err := nextScheduledIssueAssignee(ctx, repo, schedule); err != nil {
+		return nil, err
+	} else if assignee != nil {
+		assigneeIDs = []int64{assignee.ID}
+	}
+
+	if err := NewIssue(ctx, repo, issue, labelIDs, nil, assigneeIDs); err != nil {
+		return nil, fmt.Errorf("NewIssue: %w", err)
+	}
+	return issue, nil
+}
+
+// LoadIssueScheduleTemplate loads an issue template of the default branch of a repository for a schedule
+func LoadIssueScheduleTemplate(ctx context.Context, repo *repo_model.Repository, filename string) (*api.IssueTemplate, error) {
+	if repo.IsEmpty {
+		return nil, util.NewInvalidArgumentErrorf("the repository has no issue templates")
+	}
+
+	gitRepo, closer, err := gitrepo.RepositoryFromContextOrOpen(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+
+	tmpl, err := template.UnmarshalFromRepo(gitRepo, repo.DefaultBranch, filename)
+	if err != nil {
+		return nil, util.NewInvalidArgumentErrorf("invalid issue template %s: %v", filename, err)
+	}
+	return tmpl, nil
+}
+
+// getTemplateLabelIDs returns the IDs of the labels of the repository and of its owner named by an issue template
+func getTemplateLabelIDs(ctx context.Context, repo *repo_model.Repository, names []string) ([]int64, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	labels, err := issues_model.GetLabelsByRepoID(ctx, repo.ID, "", db.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("GetLabelsByRepoID: %w", err)
+	}
+	if err := repo.LoadOwner(ctx); err != nil {
+		return nil, err
+	}
+	if repo.Owner.IsOrganization() {
+		orgLabels, err := issues_model.GetLabelsByOrgID(ctx, repo.OwnerID, "", db.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("GetLabelsByOrgID: %w", err)
+		}
+		labels = append(labels, orgLabels...)
+	}
+
+	labelIDs := make([]int64, 0, len(names))
+	for _, name := range names {
+		for _, label := range labels {
+			if strings.EqualFold(label.Name, name) {
+				labelIDs = append(labelIDs, label.ID)
+				break
+			}
+		}
+	}
+	return labelIDs, nil
+}
+
+// nextScheduledIssueAssignee returns the member of the assignee team of a schedule whose turn it is,
+// skipping the members who cannot be assigned to the issues of the repository
+func nextScheduledIssueAssignee(ctx context.Context, repo *repo_model.Repository, schedule *issues_model.IssueSchedule) (*user_model.User, error) {
+	if schedule.AssigneeTeamID == 0 {
+		return nil, nil
+	}
+
+	team, err := organization.GetTeamByID(ctx, schedule.AssigneeTeamID)
+	if err != nil {
+		if organization.IsErrTeamNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("GetTeamByID: %w", err)
+	}
+	if team.OrgID != repo.OwnerID {
+		// the repository was transferred to another owner
+		return nil, nil
+	}
+
+	members, err := organization.GetTeamMembers(ctx, &organization.SearchMembersOptions{TeamID: team.ID})
+	if err != nil {
+		return nil, fmt.Errorf("GetTeamMembers: %w", err)
+	}
+	for i := range members {
+		member := members[(schedule.RotationIndex+int64(i))%int64(len(members))]
+		canBeAssigned, err := access_model.CanBeAssigned(ctx, member, repo, false)
+		if err != nil {
+			return nil, fmt.Errorf("CanBeAssigned: %w", err)
+		}
+		if canBeAssigned {
+			schedule.RotationIndex += int64(i) + 1
+			return member, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateScheduledIssues(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	start := timeutil.TimeStampNow() - 60
+	recurring := &issues_model.IssueSchedule{
+		RepoID:         3,
+		CreatorID:      2,
+		Title:          "Rotate certificates",
+		Content:        "Rotate the certificates of the servers",
+		Interval:       issues_model.IssueScheduleIntervalQuarterly,
+		StartUnix:      start,
+		NextRunUnix:    start,
+		AssigneeTeamID: 2,
+		IsActive:       true,
+	}
+	once := &issues_model.IssueSchedule{
+		RepoID:      3,
+		CreatorID:   2,
+		Title:       "Renew the domain",
+		Interval:    issues_model.IssueScheduleIntervalOnce,
+		StartUnix:   start,
+		NextRunUnix: start,
+		IsActive:    true,
+	}
+	require.NoError(t, db.Insert(db.DefaultContext, recurring, once))
+
+	assignees := make([]int64, 0, 3)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, CreateScheduledIssues(db.DefaultContext))
+
+		recurring = unittest.AssertExistsAndLoadBean(t, &issues_model.IssueSchedule{ID: recurring.ID})
+		assert.True(t, recurring.IsActive)
+		assert.Greater(t, recurring.NextRunUnix, timeutil.TimeStampNow())
+		issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: recurring.LastIssueID, RepoID: 3, PosterID: 2})
+		assert.Equal(t, "Rotate certificates", issue.Title)
+		assert.Equal(t, "Rotate the certificates of the servers", issue.Content)
+		require.NoError(t, issue.LoadAssignees(db.DefaultContext))
+		require.Len(t, issue.Assignees, 1)
+		assignees = append(assignees, issue.Assignees[0].ID)
+
+		// make the next issue due
+		recurring.NextRunUnix = start
+		_, err := db.GetEngine(db.DefaultContext).ID(recurring.ID).Cols("next_run_unix").Update(recurring)
+		require.NoError(t, err)
+	}
+	// the members of the team are assigned in turn
+	assert.NotEqual(t, assignees[0], assignees[1])
+	assert.ElementsMatch(t, []int64{2, 4}, assignees[:2])
+	assert.Equal(t, assignees[0], assignees[2])
+
+	// a one-time schedule creates a single issue
+	once = unittest.AssertExistsAndLoadBean(t, &issues_model.IssueSchedule{ID: once.ID})
+	assert.False(t, once.IsActive)
+	unittest.AssertCount(t, &issues_model.Issue{RepoID: 3, Title: "Renew the domain"}, 1)
+}
@@ -0,0 +1,263 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"context"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	project_model "code.gitea.io/gitea/models/project"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	issue_indexer "code.gitea.io/gitea/modules/indexer/issues"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// ResolveSearchQuery resolves the names of a structured search query of the issues of a repository
+// and returns the function applying the query to the options of the search, see SearchOptions.Copy.
+// If repo is nil, the query searches across repositories: the labels and the milestones are
+// matched by name in all the repositories, only one label can be required and projects are not supported.
+func ResolveSearchQuery(ctx context.Context, q *issue_indexer.Query, repo *repo_model.Repository, doer *user_model.User) (func(*issue_indexer.SearchOptions), error) {
+	if repo != nil {
+		if err := repo.LoadOwner(ctx); err != nil {
+			return nil, err
+		}
+	} else if len(q.Labels) > 1 {
+		return nil, util.NewInvalidArgumentErrorf("only one label can be required when searching across repositories")
+	}
+	r := &queryResolver{ctx: ctx, repo: repo, doer: doer}
+
+	includedLabelIDs := r.labelIDs(q.Labels)
+	excludedLabelIDs := r.labelIDs(q.ExcludedLabels)
+	milestoneIDs := r.milestoneIDs(q.Milestones)
+	projectID := r.projectID(q.Project)
+	assigneeID := r.userID("assignee", q.Assignee)
+	posterID := r.userID("author", q.Author)
+	mentionID := r.userID("mentions", q.Mention)
+	reviewRequestedID := r.userID("review-requested", q.ReviewRequested)
+	reviewedID := r.userID("reviewed-by", q.ReviewedBy)
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	return func(o *issue_indexer.SearchOptions) {
+		o.Keyword = q.Keyword
+		if q.IsClosed.Has() {
+			o.IsClosed = q.IsClosed
+		}
+		if q.IsPull.Has() {
+			o.IsPull = q.IsPull
+		}
+
+		if q.NoLabel {
+			o.NoLabelOnly = true
+		}
+		if len(includedLabelIDs) > 0 {
+			if repo == nil {
+				// the labels with the same name in all the repositories
+				o.IncludedAnyLabelIDs = includedLabelIDs
+			} else {
+				o.IncludedLabelIDs = append(o.IncludedLabelIDs, includedLabelIDs...)
+			}
+		}
+		if len(excludedLabelIDs) > 0 {
+			o.ExcludedLabelIDs = append(o.ExcludedLabelIDs, excludedLabelIDs...)
+		}
+
+		if q.NoMilestone {
+			o.MilestoneIDs = []int64{0}
+		} else if len(milestoneIDs) > 0 {
+			o.MilestoneIDs = milestoneIDs
+		}
+
+		if q.NoProject {
+			o.ProjectID = optional.Some[int64](0)
+		} else if projectID.Has() {
+			o.ProjectID = projectID
+		}
+
+		if q.NoAssignee {
+			o.AssigneeID = optional.Some[int64](0)
+		} else if assigneeID.Has() {
+			o.AssigneeID = assigneeID
+		}
+		if posterID.Has() {
+			o.PosterID = posterID
+		}
+		if mentionID.Has() {
+			o.MentionID = mentionID
+		}
+		if reviewRequestedID.Has() {
+			o.ReviewRequestedID = reviewRequestedID
+		}
+		if reviewedID.Has() {
+			o.ReviewedID = reviewedID
+		}
+
+		if q.UpdatedAfterUnix.Has() {
+			o.UpdatedAfterUnix = q.UpdatedAfterUnix
+		}
+		if q.UpdatedBeforeUnix.Has() {
+			o.UpdatedBeforeUnix = q.UpdatedBeforeUnix
+		}
+		if q.SortBy != "" {
+			o.SortBy = q.SortBy
+		}
+	}, nil
+}
+
+// GetLabelIDsByNames returns the IDs of labels of a repository, or of its owner, by name
+func GetLabelIDsByNames(ctx context.Context, repo *repo_model.Repository, names []string) ([]int64, error) {
+	if err := repo.LoadOwner(ctx); err != nil {
+		return nil, err
+	}
+	r := &queryResolver{ctx: ctx, repo: repo}
+	ids := r.labelIDs(names)
+	return ids, r.err
+}
+
+// queryResolver resolves the names of a query, keeping the first error.
+// The names are resolved in all the repositories if repo is nil.
+type queryResolver struct {
+	ctx  context.Context
+	repo *repo_model.Repository
+	doer *user_model.User
+	err  error
+}
+
+// labelIDs returns the IDs of labels of the repository, or of its owner, by name
+func (r *queryResolver) labelIDs(names []string) []int64 {
+	if r.err != nil || len(names) == 0 {
+		return nil
+	}
+	if r.repo == nil {
+		return r.idsByNames("label", names, issues_model.GetLabelIDsByNames)
+	}
+
+	cond := builder.NewCond().Or(builder.Eq{"repo_id": r.repo.ID})
+	if r.repo.Owner != nil && r.repo.Owner.IsOrganization() {
+		cond = cond.Or(builder.Eq{"org_id": r.repo.OwnerID})
+	}
+	labels := make([]*issues_model.Label, 0, len(names))
+	if r.err = db.GetEngine(r.ctx).Where(cond).In("name", names).Find(&labels); r.err != nil {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(names))
+	for _, name := range names {
+		var id int64
+		for _, label := range labels {
+			// the labels of the repository take precedence over the labels of its owner with the same name
+			if label.Name == name && (id == 0 || label.RepoID != 0) {
+				id = label.ID
+			}
+		}
+		if id == 0 {
+			r.err = util.NewInvalidArgumentErrorf("label %q does not exist", name)
+			return nil
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// milestoneIDs returns the IDs of milestones of the repository by name
+func (r *queryResolver) milestoneIDs(names []string) []int64 {
+	if r.err != nil || len(names) == 0 {
+		return nil
+	}
+	if r.repo == nil {
+		return r.idsByNames("milestone", names, issues_model.GetMilestoneIDsByNames)
+	}
+
+	ids := make([]int64, 0, len(names))
+	for _, name := range names {
+		milestone, err := issues_model.GetMilestoneByRepoIDANDName(r.ctx, r.repo.ID, name)
+		if err != nil {
+			if issues_model.IsErrMilestoneNotExist(err) {
+				err = util.NewInvalidArgumentErrorf("milestone %q does not exist", name)
+			}
+			r.err = err
+			return nil
+		}
+		ids = append(ids, milestone.ID)
+	}
+	return ids
+}
+
+// idsByNames returns the IDs of the objects with the names in all the repositories
+func (r *queryResolver) idsByNames(kind string, names []string, find func(context.Context, []string) ([]int64, error)) []int64 {
+	var ids []int64
+	for _, name := range names {
+		found, err := find(r.ctx, []string{name})
+		if err != nil {
+			r.err = err
+			return nil
+		} else if len(found) == 0 {
+			r.err = util.NewInvalidArgumentErrorf("%s %q does not exist", kind, name)
+			return nil
+		}
+		ids = append(ids, found...)
+	}
+	return ids
+}
+
+// projectID returns the ID of a project of the repository, or of its owner, by title
+func (r *queryResolver) projectID(title string) optional.Option[int64] {
+	if r.err != nil || title == "" {
+		return optional.None[int64]()
+	}
+	if r.repo == nil {
+		r.err = util.NewInvalidArgumentErrorf("projects cannot be searched across repositories")
+		return optional.None[int64]()
+	}
+
+	for _, opts := range []project_model.SearchOptions{
+		{RepoID: r.repo.ID, Title: title},
+		{OwnerID: r.repo.OwnerID, Title: title},
+	} {
+		projects, err := db.Find[project_model.Project](r.ctx, opts)
+		if err != nil {
+			r.err = err
+			return optional.None[int64]()
+		}
+		for _, project := range projects {
+			if strings.EqualFold(project.Title, title) {
+				return optional.Some(project.ID)
+			}
+		}
+	}
+	r.err = util.NewInvalidArgumentErrorf("project %q does not exist", title)
+	return optional.None[int64]()
+}
+
+// userID returns the ID of a user by name, or of the doer for issue_indexer.QueryUserMe
+func (r *queryResolver) userID(qualifier, name string) optional.Option[int64] {
+	if r.err != nil || name == "" {
+		return optional.None[int64]()
+	}
+
+	if name == issue_indexer.QueryUserMe {
+		if r.doer == nil {
+			r.err = util.NewInvalidArgumentErrorf("%s:%s needs a signed in user", qualifier, name)
+			return optional.None[int64]()
+		}
+		return optional.Some(r.doer.ID)
+	}
+
+	user, err := user_model.GetUserByName(r.ctx, strings.TrimPrefix(name, "@"))
+	if err != nil {
+		if user_model.IsErrUserNotExist(err) {
+			err = util.NewInvalidArgumentErrorf("user %q does not exist", name)
+		}
+		r.err = err
+		return optional.None[int64]()
+	}
+	return optional.Some(user.ID)
+}
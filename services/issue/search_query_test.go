@@ -0,0 +1,107 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	issue_indexer "code.gitea.io/gitea/modules/indexer/issues"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveSearchQuery(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	repo3 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 3})
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	resolve := func(repo *repo_model.Repository, doer *user_model.User, query string) (*issue_indexer.SearchOptions, error) {
+		t.Helper()
+		q, err := issue_indexer.ParseQuery(query)
+		require.NoError(t, err)
+		apply, err := ResolveSearchQuery(db.DefaultContext, q, repo, doer)
+		if err != nil {
+			return nil, err
+		}
+		return (&issue_indexer.SearchOptions{RepoIDs: []int64{repo.ID}}).Copy(apply), nil
+	}
+
+	opts, err := resolve(repo1, user2, `crash is:open label:label1 -label:label2 milestone:milestone1 project:"first project" assignee:@me author:user1`)
+	require.NoError(t, err)
+	assert.Equal(t, "crash", opts.Keyword)
+	assert.Equal(t, optional.Some(false), opts.IsClosed)
+	assert.Equal(t, []int64{1}, opts.IncludedLabelIDs)
+	assert.Equal(t, []int64{2}, opts.ExcludedLabelIDs)
+	assert.Equal(t, []int64{1}, opts.MilestoneIDs)
+	assert.Equal(t, optional.Some[int64](1), opts.ProjectID)
+	assert.Equal(t, optional.Some[int64](2), opts.AssigneeID)
+	assert.Equal(t, optional.Some[int64](1), opts.PosterID)
+
+	// the projects and the labels of the owner
+	opts, err = resolve(repo1, user2, `project:"project on user2" no:milestone no:assignee`)
+	require.NoError(t, err)
+	assert.Equal(t, optional.Some[int64](4), opts.ProjectID)
+	assert.Equal(t, []int64{0}, opts.MilestoneIDs)
+	assert.Equal(t, optional.Some[int64](0), opts.AssigneeID)
+	opts, err = resolve(repo3, user2, "label:orglabel3")
+	require.NoError(t, err)
+	assert.Equal(t, []int64{3}, opts.IncludedLabelIDs)
+
+	for _, query := range []string{
+		"label:orglabel3",
+		"label:nothing",
+		"milestone:nothing",
+		"project:nothing",
+		"author:nobody",
+	} {
+		_, err := resolve(repo1, user2, query)
+		assert.ErrorIs(t, err, util.ErrInvalidArgument, query)
+	}
+	_, err = resolve(repo1, nil, "assignee:@me")
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+}
+
+func TestResolveSearchQueryAcrossRepositories(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	resolve := func(query string) (*issue_indexer.SearchOptions, error) {
+		t.Helper()
+		q, err := issue_indexer.ParseQuery(query)
+		require.NoError(t, err)
+		apply, err := ResolveSearchQuery(db.DefaultContext, q, nil, user2)
+		if err != nil {
+			return nil, err
+		}
+		return (&issue_indexer.SearchOptions{}).Copy(apply), nil
+	}
+
+	opts, err := resolve(`crash is:pr label:label1 -label:orglabel3 milestone:milestone1 author:@me`)
+	require.NoError(t, err)
+	assert.Equal(t, "crash", opts.Keyword)
+	assert.Equal(t, optional.Some(true), opts.IsPull)
+	assert.Empty(t, opts.IncludedLabelIDs)
+	assert.Equal(t, []int64{1}, opts.IncludedAnyLabelIDs)
+	assert.Equal(t, []int64{3}, opts.ExcludedLabelIDs)
+	assert.Equal(t, []int64{1}, opts.MilestoneIDs)
+	assert.Equal(t, optional.Some[int64](2), opts.PosterID)
+
+	for _, query := range []string{
+		"label:label1 label:label2",
+		"label:nothing",
+		"milestone:nothing",
+		`project:"first project"`,
+	} {
+		_, err := resolve(query)
+		assert.ErrorIs(t, err, util.ErrInvalidArgument, query)
+	}
+}
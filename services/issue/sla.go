@@ -0,0 +1,62 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"context"
+	"fmt"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	"code.gitea.io/gitea/modules/log"
+	notify_service "code.gitea.io/gitea/services/notify"
+)
+
+// CheckSLABreaches records the targets of the SLA policies missed by the open issues and notifies each of them once
+func CheckSLABreaches(ctx context.Context) error {
+	repoIDs, err := issues_model.GetRepoIDsWithSLAPolicies(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, repoID := range repoIDs {
+		select {
+		case <-ctx.Done():
+			return db.ErrCancelledf("during SLA breaches check of repo %d", repoID)
+		default:
+		}
+		if err := checkRepoSLABreaches(ctx, repoID); err != nil {
+			log.Error("checkRepoSLABreaches [repo: %d]: %v", repoID, err)
+		}
+	}
+	return nil
+}
+
+func checkRepoSLABreaches(ctx context.Context, repoID int64) error {
+	repo, err := repo_model.GetRepositoryByID(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("GetRepositoryByID: %w", err)
+	}
+	if repo.IsArchived || !repo.UnitEnabled(ctx, unit.TypeIssues) {
+		return nil
+	}
+
+	breaches, err := issues_model.FindNewSLABreaches(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("FindNewSLABreaches: %w", err)
+	}
+	for _, breach := range breaches {
+		recorded, err := issues_model.RecordSLABreach(ctx, breach)
+		if err != nil {
+			return fmt.Errorf("RecordSLABreach: %w", err)
+		}
+		if recorded {
+			breach.Issue.Repo = repo
+			notify_service.IssueSLABreached(ctx, breach)
+		}
+	}
+	return nil
+}
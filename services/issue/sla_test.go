@@ -0,0 +1,32 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckSLABreaches(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	policy := &issues_model.SLAPolicy{RepoID: 1, Name: "support", FirstResponseHours: 1, ResolutionHours: 24}
+	require.NoError(t, issues_model.CreateSLAPolicy(db.DefaultContext, policy))
+
+	require.NoError(t, CheckSLABreaches(db.DefaultContext))
+	// the issue 1 got a response in time but is still open
+	unittest.AssertExistsAndLoadBean(t, &issues_model.IssueSLABreach{IssueID: 1, PolicyID: policy.ID, Kind: issues_model.SLAKindResolution})
+	unittest.AssertNotExistsBean(t, &issues_model.IssueSLABreach{IssueID: 1, PolicyID: policy.ID, Kind: issues_model.SLAKindFirstResponse})
+	// the closed issues are not checked
+	unittest.AssertNotExistsBean(t, &issues_model.IssueSLABreach{IssueID: 5})
+
+	// the breaches are recorded once
+	require.NoError(t, CheckSLABreaches(db.DefaultContext))
+	unittest.AssertCount(t, &issues_model.IssueSLABreach{PolicyID: policy.ID}, 1)
+}
@@ -8,13 +8,17 @@ import (
 	"io"
 	"net/url"
 	"path"
+	"regexp"
+	"strconv"
 	"strings"
 
+	issues_model "code.gitea.io/gitea/models/issues"
 	"code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/issue/template"
 	"code.gitea.io/gitea/modules/log"
 	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
 
 	"gopkg.in/yaml.v3"
 )
@@ -191,3 +195,90 @@ func HasTemplatesOrContactLinks(repo *repo.Repository, gitRepo *git.Repository)
 	issueConfig, _ := GetTemplateConfigFromDefaultBranch(repo, gitRepo)
 	return len(issueConfig.ContactLinks) > 0
 }
+
+// NewIssueForm validates the values posted for the fields of an issue form and returns the answers
+// to record with the new issue along with its content rendered as markdown.
+func NewIssueForm(tmpl *api.IssueTemplate, values url.Values) (*issues_model.IssueForm, string, error) {
+	if tmpl.Type() != api.IssueTemplateTypeYaml {
+		return nil, "", util.NewInvalidArgumentErrorf("%s is not an issue form", tmpl.FileName)
+	}
+
+	answers := template.ToAnswers(tmpl, values)
+	if err := ValidateIssueFormAnswers(tmpl, answers); err != nil {
+		return nil, "", err
+	}
+
+	return &issues_model.IssueForm{
+		TemplateFile: tmpl.FileName,
+		Answers:      answers,
+	}, template.RenderToMarkdown(tmpl, values), nil
+}
+
+// ValidateIssueFormAnswers checks the answers to the fields of an issue form against their validations,
+// and returns an invalid argument error for the first field which does not pass them.
+func ValidateIssueFormAnswers(tmpl *api.IssueTemplate, answers []*api.IssueFormAnswer) error {
+	answerByID := make(map[string]*api.IssueFormAnswer, len(answers))
+	for _, answer := range answers {
+		answerByID[answer.ID] = answer
+	}
+
+	for _, field := range tmpl.Fields {
+		if field.ID == "" || !field.VisibleOnForm() {
+			continue
+		}
+		answer, ok := answerByID[field.ID]
+		if !ok {
+			answer = &api.IssueFormAnswer{ID: field.ID, Type: field.Type}
+		}
+		label, _ := field.Attributes["label"].(string)
+
+		switch field.Type {
+		case api.IssueFormFieldTypeInput, api.IssueFormFieldTypeTextarea:
+			value := strings.TrimSpace(answer.Value)
+			if required, _ := field.Validations["required"].(bool); required && value == "" {
+				return util.NewInvalidArgumentErrorf("%q is required", label)
+			}
+			if value == "" || field.Type != api.IssueFormFieldTypeInput {
+				continue
+			}
+			if isNumber, _ := field.Validations["is_number"].(bool); isNumber {
+				if _, err := strconv.ParseFloat(value, 64); err != nil {
+					return util.NewInvalidArgumentErrorf("%q should be a number", label)
+				}
+			}
+			if pattern, _ := field.Validations["regex"].(string); pattern != "" {
+				re, err := regexp.Compile("^(?:" + pattern + ")$")
+				if err != nil {
+					return fmt.Errorf("compile the pattern of %q: %w", label, err)
+				}
+				if !re.MatchString(answer.Value) {
+					return util.NewInvalidArgumentErrorf("%q does not match the pattern %s", label, pattern)
+				}
+			}
+		case api.IssueFormFieldTypeDropdown:
+			if required, _ := field.Validations["required"].(bool); required && len(answer.Options) == 0 {
+				return util.NewInvalidArgumentErrorf("%q is required", label)
+			}
+		case api.IssueFormFieldTypeCheckboxes:
+			options, _ := field.Attributes["options"].([]any)
+			for _, option := range options {
+				opt, _ := option.(map[string]any)
+				if required, _ := opt["required"].(bool); !required {
+					continue
+				}
+				optLabel, _ := opt["label"].(string)
+				checked := false
+				for _, checkedLabel := range answer.Options {
+					if checkedLabel == optLabel {
+						checked = true
+						break
+					}
+				}
+				if !checked {
+					return util.NewInvalidArgumentErrorf("%q of %q is required", optLabel, label)
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"net/url"
+	"testing"
+
+	"code.gitea.io/gitea/modules/issue/template"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewIssueForm(t *testing.T) {
+	tmpl, err := template.Unmarshal(".forgejo/issue_template/bug.yaml", []byte(`
+name: Bug
+about: Report a bug
+body:
+  - type: input
+    id: version
+    attributes:
+      label: Version
+    validations:
+      required: true
+      regex: "[0-9]+\\.[0-9]+"
+  - type: input
+    id: count
+    attributes:
+      label: Count
+    validations:
+      is_number: true
+  - type: dropdown
+    id: os
+    attributes:
+      label: OS
+      options:
+        - Linux
+        - Windows
+    validations:
+      required: true
+  - type: checkboxes
+    id: terms
+    attributes:
+      label: Terms
+      options:
+        - label: Code of conduct
+          required: true
+        - label: Searched
+`))
+	require.NoError(t, err)
+
+	valid := url.Values{
+		"form-field-version":   {"1.2"},
+		"form-field-count":     {"3"},
+		"form-field-os":        {"1"},
+		"form-field-terms-0":   {"on"},
+		"form-field-unrelated": {"ignored"},
+	}
+	form, content, err := NewIssueForm(tmpl, valid)
+	require.NoError(t, err)
+	assert.Equal(t, ".forgejo/issue_template/bug.yaml", form.TemplateFile)
+	assert.Equal(t, []*api.IssueFormAnswer{
+		{ID: "version", Type: api.IssueFormFieldTypeInput, Label: "Version", Value: "1.2"},
+		{ID: "count", Type: api.IssueFormFieldTypeInput, Label: "Count", Value: "3"},
+		{ID: "os", Type: api.IssueFormFieldTypeDropdown, Label: "OS", Options: []string{"Windows"}},
+		{ID: "terms", Type: api.IssueFormFieldTypeCheckboxes, Label: "Terms", Options: []string{"Code of conduct"}},
+	}, form.Answers)
+	assert.Equal(t, template.RenderToMarkdown(tmpl, valid), content)
+
+	for name, tc := range map[string]struct {
+		key, value string
+		err        string
+	}{
+		"required input":    {"form-field-version", "", `"Version" is required`},
+		"regex":             {"form-field-version", "1.2.3", `"Version" does not match the pattern`},
+		"number":            {"form-field-count", "three", `"Count" should be a number`},
+		"required dropdown": {"form-field-os", "", `"OS" is required`},
+		"required checkbox": {"form-field-terms-0", "", `"Code of conduct" of "Terms" is required`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			values := url.Values{}
+			for k, v := range valid {
+				values[k] = v
+			}
+			values.Set(tc.key, tc.value)
+
+			_, _, err := NewIssueForm(tmpl, values)
+			require.ErrorIs(t, err, util.ErrInvalidArgument)
+			assert.ErrorContains(t, err, tc.err)
+		})
+	}
+
+	// markdown templates are not forms
+	tmpl, err = template.Unmarshal("bug.md", []byte("---\nname: Bug\nabout: Report a bug\n---\nDescribe the bug"))
+	require.NoError(t, err)
+	_, _, err = NewIssueForm(tmpl, valid)
+	require.ErrorIs(t, err, util.ErrInvalidArgument)
+}
@@ -0,0 +1,65 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"context"
+
+	activities_model "code.gitea.io/gitea/models/activities"
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/util"
+	notify_service "code.gitea.io/gitea/services/notify"
+)
+
+// TransferIssue moves an issue to another repository in which the doer can write issues.
+// The issue keeps its comments, reactions, attachments, tracked times and subscriptions,
+// and its former index redirects to its new location.
+func TransferIssue(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, newRepo *repo_model.Repository) error {
+	if issue.IsPull {
+		return util.NewInvalidArgumentErrorf("pull requests cannot be transferred")
+	}
+	if err := issue.LoadRepo(ctx); err != nil {
+		return err
+	}
+	oldRepo := issue.Repo
+	if oldRepo.ID == newRepo.ID {
+		return util.NewInvalidArgumentErrorf("the issue already belongs to %s", newRepo.FullName())
+	}
+	if newRepo.IsArchived {
+		return util.NewInvalidArgumentErrorf("%s is archived", newRepo.FullName())
+	}
+	if !newRepo.UnitEnabled(ctx, unit.TypeIssues) {
+		return util.NewInvalidArgumentErrorf("%s does not have issues", newRepo.FullName())
+	}
+	for _, repo := range []*repo_model.Repository{oldRepo, newRepo} {
+		perm, err := access_model.GetUserRepoPermission(ctx, repo, doer)
+		if err != nil {
+			return err
+		}
+		if !perm.CanWrite(unit.TypeIssues) {
+			return util.NewPermissionDeniedErrorf("cannot write the issues of %s", repo.FullName())
+		}
+	}
+
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := issues_model.TransferIssue(ctx, doer, issue, newRepo); err != nil {
+			return err
+		}
+		if _, err := db.GetEngine(ctx).Where("issue_id = ?", issue.ID).Cols("repo_id").
+			Update(&activities_model.Notification{RepoID: newRepo.ID}); err != nil {
+			return err
+		}
+		return issue.TransferCrossReferences(ctx, oldRepo)
+	}); err != nil {
+		return err
+	}
+
+	notify_service.IssueTransfer(ctx, doer, issue, oldRepo)
+	return nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferIssue(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	newRepo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 2})
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+
+	label := &issues_model.Label{RepoID: newRepo.ID, Name: "label1", Color: "#000000"}
+	require.NoError(t, issues_model.NewLabel(db.DefaultContext, label))
+	require.NoError(t, issue.LoadRepo(db.DefaultContext))
+	comment, err := CreateIssueComment(db.DefaultContext, doer, issue.Repo, issue, "duplicate of #2", nil)
+	require.NoError(t, err)
+
+	require.NoError(t, TransferIssue(db.DefaultContext, doer, issue, newRepo))
+
+	issue = unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	assert.EqualValues(t, newRepo.ID, issue.RepoID)
+	assert.EqualValues(t, 3, issue.Index)
+	issueID, err := issues_model.LookupIssueRedirect(db.DefaultContext, 1, 1)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, issueID)
+
+	// the label is replaced by the label of the same name of the new repository
+	assert.True(t, issues_model.HasIssueLabel(db.DefaultContext, issue.ID, label.ID))
+	assert.False(t, issues_model.HasIssueLabel(db.DefaultContext, issue.ID, 1))
+
+	// the comments follow the issue and the references to the former repository are qualified
+	comment = unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{ID: comment.ID, IssueID: issue.ID})
+	assert.Equal(t, "duplicate of user2/repo1#2", comment.Content)
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{
+		IssueID: issue.ID,
+		Type:    issues_model.CommentTypeTransferIssue,
+		OldRef:  "user2/repo1#1",
+		NewRef:  "user2/repo2#3",
+	})
+	unittest.CheckConsistencyFor(t, &issues_model.Issue{}, &issues_model.Label{}, &repo_model.Repository{})
+}
+
+func TestTransferIssueInvalid(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	newRepo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 2})
+
+	require.ErrorIs(t, TransferIssue(db.DefaultContext, doer, issue, repo), util.ErrInvalidArgument)
+
+	pull := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 2})
+	require.ErrorIs(t, TransferIssue(db.DefaultContext, doer, pull, newRepo), util.ErrInvalidArgument)
+
+	// the user 4 cannot write the issues of the private repository of the user 2
+	user4 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+	require.ErrorIs(t, TransferIssue(db.DefaultContext, user4, issue, newRepo), util.ErrPermissionDenied)
+
+	// the user 4 can write the issues of the repository of the organization 3, but not the issues of the repository 1
+	orgRepo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 3})
+	require.ErrorIs(t, TransferIssue(db.DefaultContext, user4, issue, orgRepo), util.ErrPermissionDenied)
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1, RepoID: 1, Index: 1})
+}
@@ -0,0 +1,154 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	git_model "code.gitea.io/gitea/models/git"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/base"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/markup"
+	"code.gitea.io/gitea/modules/markup/markdown"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/translation"
+)
+
+const (
+	tplCommitCommentMail base.TplName = "commit_comment"
+)
+
+// MailCommitComment sends a new commit comment to the author of the commit and to the mentioned users.
+func MailCommitComment(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, comment *git_model.CommitComment, mentions []*user_model.User) {
+	if setting.MailService == nil {
+		// No mail service configured
+		return
+	}
+
+	mentionIDs := make([]int64, 0, len(mentions))
+	for _, u := range mentions {
+		if u.ID != doer.ID {
+			mentionIDs = append(mentionIDs, u.ID)
+		}
+	}
+	recipients, err := user_model.GetMaileableUsersByIDs(ctx, mentionIDs, true)
+	if err != nil {
+		log.Error("user_model.GetMaileableUsersByIDs: %v", err)
+		return
+	}
+
+	if author := getCommitCommentAuthor(ctx, repo, comment); author != nil && author.ID != doer.ID {
+		authors, err := user_model.GetMaileableUsersByIDs(ctx, []int64{author.ID}, false)
+		if err != nil {
+			log.Error("user_model.GetMaileableUsersByIDs: %v", err)
+			return
+		}
+		for _, author := range authors {
+			if perm, err := access_model.GetUserRepoPermission(ctx, repo, author); err != nil {
+				log.Error("GetUserRepoPermission [%d]: %v", author.ID, err)
+			} else if perm.CanRead(unit.TypeCode) && !hasUser(recipients, author.ID) {
+				recipients = append(recipients, author)
+			}
+		}
+	}
+
+	langMap := make(map[string][]*user_model.User)
+	for _, user := range recipients {
+		langMap[user.Language] = append(langMap[user.Language], user)
+	}
+
+	for lang, tos := range langMap {
+		mailCommitComment(ctx, lang, tos, doer, repo, comment)
+	}
+}
+
+// getCommitCommentAuthor returns the user who authored the commented commit, nil if unknown
+func getCommitCommentAuthor(ctx context.Context, repo *repo_model.Repository, comment *git_model.CommitComment) *user_model.User {
+	gitRepo, err := gitrepo.OpenRepository(ctx, repo)
+	if err != nil {
+		log.Error("OpenRepository [%d]: %v", repo.ID, err)
+		return nil
+	}
+	defer gitRepo.Close()
+
+	commit, err := gitRepo.GetCommit(comment.CommitSHA)
+	if err != nil {
+		log.Error("GetCommit [%s]: %v", comment.CommitSHA, err)
+		return nil
+	}
+	author, err := user_model.GetUserByEmail(ctx, commit.Author.Email)
+	if err != nil {
+		if !user_model.IsErrUserNotExist(err) {
+			log.Error("GetUserByEmail: %v", err)
+		}
+		return nil
+	}
+	return author
+}
+
+func hasUser(users []*user_model.User, id int64) bool {
+	for _, u := range users {
+		if u.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func mailCommitComment(ctx context.Context, lang string, tos []*user_model.User, doer *user_model.User, repo *repo_model.Repository, comment *git_model.CommitComment) {
+	locale := translation.NewLocale(lang)
+
+	var err error
+	comment.RenderedContent, err = markdown.RenderString(&markup.RenderContext{
+		Ctx: ctx,
+		Links: markup.Links{
+			Base: repo.HTMLURL(),
+		},
+		Metas: repo.ComposeMetas(ctx),
+	}, comment.Content)
+	if err != nil {
+		log.Error("markdown.RenderString(%d): %v", comment.ID, err)
+		return
+	}
+
+	commitLink := repo.HTMLURL() + "/commit/" + comment.CommitSHA
+	subject := locale.TrString("mail.commit_comment.subject", base.ShortSha(comment.CommitSHA), repo.FullName())
+	mailMeta := map[string]any{
+		"locale":     locale,
+		"Doer":       doer,
+		"Repo":       repo,
+		"Comment":    comment,
+		"CommitLink": commitLink,
+		"ShortSha":   base.ShortSha(comment.CommitSHA),
+		"Subject":    subject,
+		"Language":   locale.Language(),
+		"Link":       commitLink + "#" + comment.HashTag(),
+	}
+
+	var mailBody bytes.Buffer
+
+	if err := bodyTemplates.ExecuteTemplate(&mailBody, string(tplCommitCommentMail), mailMeta); err != nil {
+		log.Error("ExecuteTemplate [%s]: %v", string(tplCommitCommentMail)+"/body", err)
+		return
+	}
+
+	msgs := make([]*Message, 0, len(tos))
+	doerName := fromDisplayName(doer)
+	msgID := fmt.Sprintf("<%s/commit/%s/comments/%d@%s>", repo.FullName(), comment.CommitSHA, comment.ID, setting.Domain)
+	for _, to := range tos {
+		msg := NewMessageFrom(to.EmailTo(), doerName, setting.MailService.FromEmail, subject, mailBody.String())
+		msg.Info = subject
+		msg.SetHeader("Message-ID", msgID)
+		msgs = append(msgs, msg)
+	}
+
+	SendAsync(msgs...)
+}
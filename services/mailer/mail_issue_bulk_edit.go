@@ -0,0 +1,175 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/base"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/translation"
+)
+
+const (
+	tplIssuesBulkEditedMail base.TplName = "issue/bulk_edit"
+)
+
+// MailIssuesBulkEdited sends a single mail listing the issues edited at once to each user who would have been
+// mailed about the changes of one of these issues: its poster, assignees, participants and watchers.
+func MailIssuesBulkEdited(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, issues []*issues_model.Issue) {
+	if setting.MailService == nil {
+		// No mail service configured
+		return
+	}
+
+	repoWatcherIDs, err := repo_model.GetRepoWatchersIDs(ctx, repo.ID)
+	if err != nil {
+		log.Error("GetRepoWatchersIDs(%d): %v", repo.ID, err)
+		return
+	}
+
+	recipientIssues := make(map[int64][]*issues_model.Issue)
+	for _, issue := range issues {
+		ids, err := getIssueMailRecipientIDs(ctx, issue, repoWatcherIDs)
+		if err != nil {
+			log.Error("getIssueMailRecipientIDs(%d): %v", issue.ID, err)
+			return
+		}
+		for id := range ids {
+			recipientIssues[id] = append(recipientIssues[id], issue)
+		}
+	}
+	// Avoid mailing the doer
+	if doer.EmailNotificationsPreference != user_model.EmailNotificationsAndYourOwn {
+		delete(recipientIssues, doer.ID)
+	}
+
+	ids := make([]int64, 0, len(recipientIssues))
+	for id := range recipientIssues {
+		ids = append(ids, id)
+	}
+	recipients, err := user_model.GetMaileableUsersByIDs(ctx, ids, false)
+	if err != nil {
+		log.Error("user_model.GetMaileableUsersByIDs: %v", err)
+		return
+	}
+
+	// the recipients reading the same issues in the same language get the same mail
+	type mailGroup struct {
+		lang   string
+		issues []*issues_model.Issue
+		tos    []*user_model.User
+	}
+	groups := make(map[string]*mailGroup)
+	var keys []string
+	for _, user := range recipients {
+		canRead := map[bool]bool{
+			false: access_model.CheckRepoUnitUser(ctx, repo, user, unit.TypeIssues),
+			true:  access_model.CheckRepoUnitUser(ctx, repo, user, unit.TypePullRequests),
+		}
+		var readable []*issues_model.Issue
+		var key strings.Builder
+		key.WriteString(user.Language)
+		for _, issue := range recipientIssues[user.ID] {
+			if canRead[issue.IsPull] {
+				readable = append(readable, issue)
+				fmt.Fprintf(&key, ",%d", issue.ID)
+			}
+		}
+		if len(readable) == 0 {
+			continue
+		}
+		group, ok := groups[key.String()]
+		if !ok {
+			group = &mailGroup{lang: user.Language, issues: readable}
+			groups[key.String()] = group
+			keys = append(keys, key.String())
+		}
+		group.tos = append(group.tos, user)
+	}
+
+	for _, key := range keys {
+		group := groups[key]
+		mailIssuesBulkEdited(ctx, group.lang, group.tos, doer, repo, group.issues)
+	}
+}
+
+// getIssueMailRecipientIDs returns the users mailed about the changes of an issue, like mailIssueCommentToParticipants
+func getIssueMailRecipientIDs(ctx context.Context, issue *issues_model.Issue, repoWatcherIDs []int64) (container.Set[int64], error) {
+	if err := issue.LoadPullRequest(ctx); err != nil {
+		return nil, fmt.Errorf("LoadPullRequest: %w", err)
+	}
+
+	ids := container.SetOf(issue.PosterID)
+	assigneeIDs, err := issues_model.GetAssigneeIDsByIssue(ctx, issue.ID)
+	if err != nil {
+		return nil, fmt.Errorf("GetAssigneeIDsByIssue: %w", err)
+	}
+	ids.AddMultiple(assigneeIDs...)
+	participantIDs, err := issues_model.GetParticipantsIDsByIssueID(ctx, issue.ID)
+	if err != nil {
+		return nil, fmt.Errorf("GetParticipantsIDsByIssueID: %w", err)
+	}
+	ids.AddMultiple(participantIDs...)
+	watcherIDs, err := issues_model.GetIssueWatchersIDs(ctx, issue.ID, true)
+	if err != nil {
+		return nil, fmt.Errorf("GetIssueWatchersIDs: %w", err)
+	}
+	ids.AddMultiple(watcherIDs...)
+	if !(issue.IsPull && issue.PullRequest.IsWorkInProgress(ctx)) {
+		ids.AddMultiple(repoWatcherIDs...)
+	}
+
+	// Avoid mailing explicit unwatched
+	unwatcherIDs, err := issues_model.GetIssueWatchersIDs(ctx, issue.ID, false)
+	if err != nil {
+		return nil, fmt.Errorf("GetIssueWatchersIDs: %w", err)
+	}
+	for _, id := range unwatcherIDs {
+		ids.Remove(id)
+	}
+	return ids, nil
+}
+
+func mailIssuesBulkEdited(ctx context.Context, lang string, tos []*user_model.User, doer *user_model.User, repo *repo_model.Repository, issues []*issues_model.Issue) {
+	locale := translation.NewLocale(lang)
+
+	subject := locale.TrString("mail.issue.bulk_edit.subject", repo.FullName(), doer.Name, len(issues))
+	mailMeta := map[string]any{
+		"locale":   locale,
+		"Doer":     doer,
+		"Repo":     repo,
+		"Issues":   issues,
+		"Subject":  subject,
+		"Language": locale.Language(),
+		"Link":     repo.HTMLURL() + "/issues",
+	}
+
+	var mailBody bytes.Buffer
+
+	if err := bodyTemplates.ExecuteTemplate(&mailBody, string(tplIssuesBulkEditedMail), mailMeta); err != nil {
+		log.Error("ExecuteTemplate [%s]: %v", string(tplIssuesBulkEditedMail)+"/body", err)
+		return
+	}
+
+	msgs := make([]*Message, 0, len(tos))
+	doerName := fromDisplayName(doer)
+	for _, to := range tos {
+		msg := NewMessageFrom(to.EmailTo(), doerName, setting.MailService.FromEmail, subject, mailBody.String())
+		msg.Info = subject
+		msgs = append(msgs, msg)
+	}
+
+	SendAsync(msgs...)
+}
@@ -0,0 +1,60 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package mailer
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/container"
+	notify_service "code.gitea.io/gitea/services/notify"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMailIssuesBulkEdited(t *testing.T) {
+	doer, repo, issue, _ := prepareMailerTest(t)
+	pull := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 2})
+	pull.Repo = repo
+	user1 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+
+	var msgs []*Message
+	defer MockMailSettings(func(sent ...*Message) {
+		msgs = append(msgs, sent...)
+	})()
+
+	MailIssuesBulkEdited(db.DefaultContext, doer, repo, []*issues_model.Issue{issue, pull})
+	require.NotEmpty(t, msgs)
+
+	recipients := make(container.Set[string])
+	for _, msg := range msgs {
+		assert.True(t, recipients.Add(msg.To), "%s is mailed more than once", msg.To)
+		assert.NotEqual(t, doer.EmailTo(), msg.To)
+		AssertTranslatedLocale(t, msg.Body, "mail.issue")
+	}
+
+	// a repository watcher gets a single mail listing both issues
+	for _, msg := range msgs {
+		if msg.To != user1.EmailTo() {
+			continue
+		}
+		assert.Equal(t, "[user2/repo1] user2 edited 2 issues and pull requests", msg.Subject)
+		assert.Contains(t, msg.Body, issue.HTMLURL())
+		assert.Contains(t, msg.Body, pull.HTMLURL())
+	}
+	assert.True(t, recipients.Contains(user1.EmailTo()))
+
+	t.Run("SkipIssueChanges", func(t *testing.T) {
+		msgs = nil
+		comment := &issues_model.Comment{Type: issues_model.CommentTypeClose}
+		ctx := notify_service.WithBulkEdit(db.DefaultContext)
+		NewNotifier().IssueChangeStatus(ctx, doer, "", issue, comment, true)
+		NewNotifier().IssueChangeAssignee(ctx, doer, issue, user1, false, nil)
+		assert.Empty(t, msgs)
+	})
+}
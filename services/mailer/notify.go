@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	activities_model "code.gitea.io/gitea/models/activities"
+	git_model "code.gitea.io/gitea/models/git"
 	issues_model "code.gitea.io/gitea/models/issues"
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
@@ -54,6 +55,11 @@ func (m *mailNotifier) NewIssue(ctx context.Context, issue *issues_model.Issue,
 }
 
 func (m *mailNotifier) IssueChangeStatus(ctx context.Context, doer *user_model.User, commitID string, issue *issues_model.Issue, actionComment *issues_model.Comment, isClosed bool) {
+	if notify_service.IsBulkEdit(ctx) {
+		// summarized by IssuesBulkEdited
+		return
+	}
+
 	var actionType activities_model.ActionType
 	if issue.IsPull {
 		if isClosed {
@@ -113,6 +119,11 @@ func (m *mailNotifier) PullRequestCodeComment(ctx context.Context, pr *issues_mo
 }
 
 func (m *mailNotifier) IssueChangeAssignee(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, assignee *user_model.User, removed bool, comment *issues_model.Comment) {
+	if notify_service.IsBulkEdit(ctx) {
+		// summarized by IssuesBulkEdited
+		return
+	}
+
 	// mail only sent to added assignees and not self-assignee
 	if !removed && doer.ID != assignee.ID && assignee.EmailNotificationsPreference != user_model.EmailNotificationsDisabled {
 		ct := fmt.Sprintf("Assigned #%d.", issue.Index)
@@ -122,6 +133,10 @@ func (m *mailNotifier) IssueChangeAssignee(ctx context.Context, doer *user_model
 	}
 }
 
+func (m *mailNotifier) IssuesBulkEdited(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, issues []*issues_model.Issue) {
+	MailIssuesBulkEdited(ctx, doer, repo, issues)
+}
+
 func (m *mailNotifier) PullRequestReviewRequest(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, reviewer *user_model.User, isRequest bool, comment *issues_model.Comment) {
 	if isRequest && doer.ID != reviewer.ID && reviewer.EmailNotificationsPreference != user_model.EmailNotificationsDisabled {
 		ct := fmt.Sprintf("Requested to review %s.", issue.HTMLURL())
@@ -197,6 +212,10 @@ func (m *mailNotifier) NewRelease(ctx context.Context, rel *repo_model.Release)
 	MailNewRelease(ctx, rel)
 }
 
+func (m *mailNotifier) CreateCommitComment(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, comment *git_model.CommitComment, mentions []*user_model.User) {
+	MailCommitComment(ctx, doer, repo, comment, mentions)
+}
+
 func (m *mailNotifier) RepoPendingTransfer(ctx context.Context, doer, newOwner *user_model.User, repo *repo_model.Repository) {
 	if err := SendRepoTransferNotifyMail(ctx, doer, newOwner, repo); err != nil {
 		log.Error("SendRepoTransferNotifyMail: %v", err)
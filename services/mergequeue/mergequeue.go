@@ -0,0 +1,360 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package mergequeue
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"code.gitea.io/gitea/models"
+	"code.gitea.io/gitea/models/db"
+	git_model "code.gitea.io/gitea/models/git"
+	issues_model "code.gitea.io/gitea/models/issues"
+	pull_model "code.gitea.io/gitea/models/pull"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/process"
+	"code.gitea.io/gitea/modules/queue"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/sync"
+	"code.gitea.io/gitea/modules/util"
+	notify_service "code.gitea.io/gitea/services/notify"
+	pull_service "code.gitea.io/gitea/services/pull"
+)
+
+// Reasons for removing a pull request from the merge queue, stored as content of the timeline comment.
+// A manual removal has no reason.
+const (
+	ReasonConflict      = "conflict"
+	ReasonChecksFailed  = "checks_failed"
+	ReasonHeadUpdated   = "head_updated"
+	ReasonClosed        = "closed"
+	ReasonMerged        = "merged"
+	ReasonTargetChanged = "target_changed"
+	ReasonRejected      = "rejected"
+	ReasonDisabled      = "disabled"
+	ReasonError         = "error"
+)
+
+// ErrMergeQueueNotEnabled is returned if the base branch of a pull request does not require a merge queue
+var ErrMergeQueueNotEnabled = util.NewInvalidArgumentErrorf("the base branch does not use a merge queue")
+
+// mergeQueue processes the merge queues of branches, the items are "repoID:branch"
+var mergeQueue *queue.WorkerPoolQueue[string]
+
+// branchWorkingPool makes sure a merge queue is processed by one worker at a time
+var branchWorkingPool = sync.NewExclusivePool()
+
+// Init runs the task queue that processes the merge queues
+func Init() error {
+	notify_service.RegisterNotifier(NewNotifier())
+
+	mergeQueue = queue.CreateUniqueQueue(graceful.GetManager().ShutdownContext(), "pr_merge_queue", handler)
+	if mergeQueue == nil {
+		return fmt.Errorf("unable to create pr_merge_queue queue")
+	}
+	go graceful.GetManager().RunWithCancel(mergeQueue)
+	return nil
+}
+
+func handler(items ...string) []string {
+	for _, s := range items {
+		id, branch, ok := strings.Cut(s, ":")
+		repoID, err := strconv.ParseInt(id, 10, 64)
+		if !ok || err != nil {
+			log.Error("could not parse data from pr_merge_queue queue (%v)", s)
+			continue
+		}
+		processMergeQueue(repoID, branch)
+	}
+	return nil
+}
+
+func addToQueue(repoID int64, branch string) {
+	log.Trace("Adding merge queue of branch %s in repo %d to the pr_merge_queue queue", branch, repoID)
+	if err := mergeQueue.Push(fmt.Sprintf("%d:%s", repoID, branch)); err != nil {
+		log.Error("Error adding merge queue of branch %s in repo %d to the pr_merge_queue queue: %v", branch, repoID, err)
+	}
+}
+
+// IsEnabled returns true if the base branch of the pull request requires a merge queue
+func IsEnabled(ctx context.Context, pr *issues_model.PullRequest) (bool, error) {
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return false, err
+	}
+	pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, pr.BaseRepo, pr.BaseBranch)
+	if err != nil {
+		return false, err
+	}
+	return pb != nil && pb.EnableMergeQueue, nil
+}
+
+// Add appends a pull request to the merge queue of its base branch.
+// The caller has to check that the pull request is mergeable.
+func Add(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest, style repo_model.MergeStyle, message string) error {
+	if enabled, err := IsEnabled(ctx, pr); err != nil {
+		return err
+	} else if !enabled {
+		return ErrMergeQueueNotEnabled
+	}
+
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		if err := pull_model.AddToMergeQueue(ctx, &pull_model.MergeQueueEntry{
+			RepoID:     pr.BaseRepoID,
+			BaseBranch: pr.BaseBranch,
+			PullID:     pr.ID,
+			DoerID:     doer.ID,
+			MergeStyle: style,
+			Message:    message,
+		}); err != nil {
+			return err
+		}
+
+		_, err := issues_model.CreateMergeQueueComment(ctx, issues_model.CommentTypePRAddedToMergeQueue, pr, doer, "")
+		return err
+	}); err != nil {
+		return err
+	}
+
+	addToQueue(pr.BaseRepoID, pr.BaseBranch)
+	return nil
+}
+
+// Remove removes a pull request from the merge queue, the pull requests behind it are tested again
+func Remove(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) error {
+	exists, e, err := pull_model.GetMergeQueueEntryByPullID(ctx, pr.ID)
+	if err != nil {
+		return err
+	} else if !exists {
+		return db.ErrNotExist{Resource: "merge_queue", ID: pr.ID}
+	}
+	return remove(ctx, doer, pr, e, "")
+}
+
+func remove(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest, e *pull_model.MergeQueueEntry, reason string) error {
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		if err := pull_model.DeleteMergeQueueEntry(ctx, pr.ID); err != nil {
+			return err
+		}
+
+		_, err := issues_model.CreateMergeQueueComment(ctx, issues_model.CommentTypePRRemovedFromMergeQueue, pr, doer, reason)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	deleteSpeculativeBranch(ctx, doer, pr, e)
+
+	addToQueue(e.RepoID, e.BaseBranch)
+	return nil
+}
+
+func deleteSpeculativeBranch(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest, e *pull_model.MergeQueueEntry) {
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		log.Error("%-v LoadBaseRepo: %v", pr, err)
+		return
+	}
+	if err := pull_service.DeleteSpeculativeBranch(ctx, pr.BaseRepo, doer, e.SpeculativeBranch(pr.Index)); err != nil {
+		log.Error("%-v DeleteSpeculativeBranch: %v", pr, err)
+	}
+}
+
+// StartCheckBySHA processes the merge queues containing a speculative merge with the commit again
+func StartCheckBySHA(ctx context.Context, sha string, repo *repo_model.Repository) error {
+	entries, err := pull_model.GetMergeQueueEntriesBySpeculativeCommitID(ctx, repo.ID, sha)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		addToQueue(e.RepoID, e.BaseBranch)
+	}
+	return nil
+}
+
+// StartCheck processes the merge queue of a branch again if it is not empty
+func StartCheck(ctx context.Context, repoID int64, branch string) error {
+	has, err := pull_model.HasMergeQueue(ctx, repoID, branch)
+	if err != nil {
+		return err
+	}
+	if has {
+		addToQueue(repoID, branch)
+	}
+	return nil
+}
+
+// removalReason returns the reason for removing a pull request whose speculative merge or merge failed
+func removalReason(err error) string {
+	switch {
+	case models.IsErrMergeConflicts(err), models.IsErrRebaseConflicts(err),
+		models.IsErrMergeUnrelatedHistories(err), models.IsErrMergeDivergingFastForwardOnly(err):
+		return ReasonConflict
+	case git.IsErrPushRejected(err):
+		return ReasonRejected
+	}
+	return ReasonError
+}
+
+// speculativeCommitStatus returns the combined state of the required status checks of a speculative merge
+func speculativeCommitStatus(ctx context.Context, repoID int64, pb *git_model.ProtectedBranch, sha string) (api.CommitStatusState, error) {
+	if !pb.EnableStatusCheck {
+		return api.CommitStatusSuccess, nil
+	}
+
+	commitStatuses, _, err := git_model.GetLatestCommitStatus(ctx, repoID, sha, db.ListOptionsAll)
+	if err != nil {
+		return "", err
+	}
+	return pull_service.MergeRequiredContextsCommitStatus(commitStatuses, pb.StatusCheckContexts), nil
+}
+
+// processMergeQueue builds the missing speculative merges of a merge queue and merges
+// the pull requests at the front of the queue whose status checks succeeded
+func processMergeQueue(repoID int64, branch string) {
+	ctx, _, finished := process.GetManager().AddContext(graceful.GetManager().HammerContext(),
+		fmt.Sprintf("Handle merge queue of branch %s in repo %d", branch, repoID))
+	defer finished()
+
+	key := fmt.Sprintf("%d:%s", repoID, branch)
+	branchWorkingPool.CheckIn(key)
+	defer branchWorkingPool.CheckOut(key)
+
+	entries, err := pull_model.GetMergeQueue(ctx, repoID, branch)
+	if err != nil {
+		log.Error("GetMergeQueue[%d:%s]: %v", repoID, branch, err)
+		return
+	}
+	if len(entries) == 0 {
+		return
+	}
+
+	repo, err := repo_model.GetRepositoryByID(ctx, repoID)
+	if err != nil {
+		log.Error("GetRepositoryByID[%d]: %v", repoID, err)
+		return
+	}
+
+	pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, repo, branch)
+	if err != nil {
+		log.Error("GetFirstMatchProtectedBranchRule[%d:%s]: %v", repoID, branch, err)
+		return
+	}
+
+	gitRepo, err := gitrepo.OpenRepository(ctx, repo)
+	if err != nil {
+		log.Error("OpenRepository %-v: %v", repo, err)
+		return
+	}
+	defer gitRepo.Close()
+
+	baseCommitID, err := gitRepo.GetBranchCommitID(branch)
+	if err != nil {
+		log.Error("GetBranchCommitID[%s] %-v: %v", branch, repo, err)
+		return
+	}
+
+	// parentCommitID is the commit the speculative merge of the current entry has to be built on
+	parentCommitID := baseCommitID
+	for _, e := range entries {
+		pr, err := issues_model.GetPullRequestByID(ctx, e.PullID)
+		if err != nil {
+			if issues_model.IsErrPullRequestNotExist(err) {
+				// the pull request has been deleted
+				if err := pull_model.DeleteMergeQueueEntry(ctx, e.PullID); err != nil {
+					log.Error("DeleteMergeQueueEntry[%d]: %v", e.PullID, err)
+				}
+				continue
+			}
+			log.Error("GetPullRequestByID[%d]: %v", e.PullID, err)
+			return
+		}
+		if err := e.LoadDoer(ctx); err != nil {
+			log.Error("LoadDoer[%d]: %v", e.DoerID, err)
+			return
+		}
+
+		if pb == nil || !pb.EnableMergeQueue {
+			if err := remove(ctx, e.Doer, pr, e, ReasonDisabled); err != nil {
+				log.Error("%-v remove from merge queue: %v", pr, err)
+			}
+			continue
+		}
+
+		if pr.HasMerged {
+			if err := remove(ctx, e.Doer, pr, e, ReasonMerged); err != nil {
+				log.Error("%-v remove from merge queue: %v", pr, err)
+			}
+			continue
+		}
+
+		if e.SpeculativeCommitID == "" || e.ParentCommitID != parentCommitID {
+			sha, err := pull_service.PushSpeculativeMerge(ctx, pr, e.Doer, e.MergeStyle, parentCommitID, e.SpeculativeBranch(pr.Index), e.Message)
+			if err != nil {
+				log.Info("%-v speculative merge failed: %v", pr, err)
+				if err := remove(ctx, e.Doer, pr, e, removalReason(err)); err != nil {
+					log.Error("%-v remove from merge queue: %v", pr, err)
+				}
+				continue
+			}
+
+			e.ParentCommitID = parentCommitID
+			e.SpeculativeCommitID = sha
+			e.Status = pull_model.MergeQueueStatusTesting
+			if err := pull_model.UpdateMergeQueueEntry(ctx, e); err != nil {
+				log.Error("%-v UpdateMergeQueueEntry: %v", pr, err)
+				return
+			}
+		}
+
+		// only the front of the queue can be merged, the others wait for the pull requests ahead of them
+		if e.ParentCommitID == baseCommitID {
+			state, err := speculativeCommitStatus(ctx, repoID, pb, e.SpeculativeCommitID)
+			if err != nil {
+				log.Error("%-v speculativeCommitStatus: %v", pr, err)
+				return
+			}
+
+			if state.IsError() || state.IsFailure() {
+				log.Info("Speculative merge of %-v has failed status checks", pr)
+				if err := remove(ctx, e.Doer, pr, e, ReasonChecksFailed); err != nil {
+					log.Error("%-v remove from merge queue: %v", pr, err)
+				}
+				continue
+			}
+
+			if state.IsSuccess() {
+				if err := pull_service.MergeSpeculativeCommit(ctx, pr, e.Doer, e.SpeculativeCommitID); err != nil {
+					if git.IsErrPushOutOfDate(err) {
+						// the base branch has been updated in the meantime, all speculative merges are rebuilt
+						log.Info("Base branch of %-v was updated while merging the merge queue", pr)
+						addToQueue(repoID, branch)
+						return
+					}
+					log.Info("%-v merge of speculative commit failed: %v", pr, err)
+					if err := remove(ctx, e.Doer, pr, e, removalReason(err)); err != nil {
+						log.Error("%-v remove from merge queue: %v", pr, err)
+					}
+					continue
+				}
+
+				if err := pull_model.DeleteMergeQueueEntry(ctx, pr.ID); err != nil && !db.IsErrNotExist(err) {
+					log.Error("%-v DeleteMergeQueueEntry: %v", pr, err)
+				}
+				deleteSpeculativeBranch(ctx, e.Doer, pr, e)
+
+				baseCommitID = e.SpeculativeCommitID
+				parentCommitID = baseCommitID
+				continue
+			}
+		}
+
+		parentCommitID = e.SpeculativeCommitID
+	}
+}
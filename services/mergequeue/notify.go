@@ -0,0 +1,94 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package mergequeue
+
+import (
+	"context"
+	"strings"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	pull_model "code.gitea.io/gitea/models/pull"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/repository"
+	notify_service "code.gitea.io/gitea/services/notify"
+)
+
+type mergeQueueNotifier struct {
+	notify_service.NullNotifier
+}
+
+var _ notify_service.Notifier = &mergeQueueNotifier{}
+
+// NewNotifier create a new mergeQueueNotifier notifier
+func NewNotifier() notify_service.Notifier {
+	return &mergeQueueNotifier{}
+}
+
+// removeIfQueued removes the pull request from the merge queue if it is queued
+func removeIfQueued(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest, reason string) {
+	exists, e, err := pull_model.GetMergeQueueEntryByPullID(ctx, pr.ID)
+	if err != nil {
+		log.Error("GetMergeQueueEntryByPullID[%d]: %v", pr.ID, err)
+		return
+	}
+	if !exists {
+		return
+	}
+	if err := remove(ctx, doer, pr, e, reason); err != nil {
+		log.Error("%-v remove from merge queue: %v", pr, err)
+	}
+}
+
+func (n *mergeQueueNotifier) PullRequestSynchronized(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) {
+	// the tested speculative merges do not contain the new commits
+	removeIfQueued(ctx, doer, pr, ReasonHeadUpdated)
+}
+
+func (n *mergeQueueNotifier) PullRequestChangeTargetBranch(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest, oldBranch string) {
+	removeIfQueued(ctx, doer, pr, ReasonTargetChanged)
+}
+
+func (n *mergeQueueNotifier) IssueChangeStatus(ctx context.Context, doer *user_model.User, commitID string, issue *issues_model.Issue, actionComment *issues_model.Comment, isClosed bool) {
+	if !issue.IsPull || !isClosed {
+		return
+	}
+	if err := issue.LoadPullRequest(ctx); err != nil {
+		log.Error("LoadPullRequest: %v", err)
+		return
+	}
+	removeIfQueued(ctx, doer, issue.PullRequest, ReasonClosed)
+}
+
+func (n *mergeQueueNotifier) DeleteIssue(ctx context.Context, doer *user_model.User, issue *issues_model.Issue) {
+	if !issue.IsPull || issue.PullRequest == nil {
+		return
+	}
+	// the merge queue entry was deleted with the pull request, the pull requests behind it are tested again
+	pr := issue.PullRequest
+	deleteSpeculativeBranch(ctx, doer, pr, &pull_model.MergeQueueEntry{RepoID: pr.BaseRepoID, BaseBranch: pr.BaseBranch})
+	if err := StartCheck(ctx, pr.BaseRepoID, pr.BaseBranch); err != nil {
+		log.Error("StartCheck[%d:%s]: %v", pr.BaseRepoID, pr.BaseBranch, err)
+	}
+}
+
+func (n *mergeQueueNotifier) MergePullRequest(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) {
+	// the pull request has been merged bypassing the merge queue
+	removeIfQueued(ctx, doer, pr, ReasonMerged)
+}
+
+func (n *mergeQueueNotifier) PushCommits(ctx context.Context, pusher *user_model.User, repo *repo_model.Repository, opts *repository.PushUpdateOptions, commits *repository.PushCommits) {
+	if !opts.RefFullName.IsBranch() {
+		return
+	}
+	branch := opts.RefFullName.BranchName()
+	if strings.HasPrefix(branch, pull_model.MergeQueueBranchPrefix) {
+		return
+	}
+	// the speculative merges have to be rebuilt on top of the new head of the base branch
+	if err := StartCheck(ctx, repo.ID, branch); err != nil {
+		log.Error("StartCheck[%d:%s]: %v", repo.ID, branch, err)
+	}
+}
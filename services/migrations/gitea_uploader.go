@@ -511,6 +511,8 @@ func (g *GiteaLocalUploader) CreateComments(comments ...*base.Comment) error {
 			cm.Content = ""
 		case issues_model.CommentTypePRScheduledToAutoMerge, issues_model.CommentTypePRUnScheduledToAutoMerge:
 			cm.Content = ""
+		case issues_model.CommentTypePRAddedToMergeQueue:
+			cm.Content = ""
 		default:
 		}
 
@@ -0,0 +1,21 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package notify
+
+import "context"
+
+type bulkEditCtxKey struct{}
+
+// WithBulkEdit marks the notifications sent with the returned context as part of a bulk edit of issues.
+// The notifiers writing to users skip the changes of the single issues and wait for IssuesBulkEdited
+// to send a summary of the whole batch instead.
+func WithBulkEdit(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bulkEditCtxKey{}, true)
+}
+
+// IsBulkEdit returns whether the notifications sent with the context are part of a bulk edit of issues
+func IsBulkEdit(ctx context.Context) bool {
+	v, _ := ctx.Value(bulkEditCtxKey{}).(bool)
+	return v
+}
@@ -6,6 +6,7 @@ package notify
 import (
 	"context"
 
+	git_model "code.gitea.io/gitea/models/git"
 	issues_model "code.gitea.io/gitea/models/issues"
 	packages_model "code.gitea.io/gitea/models/packages"
 	repo_model "code.gitea.io/gitea/models/repo"
@@ -39,6 +40,11 @@ type Notifier interface {
 	IssueChangeRef(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldRef string)
 	IssueChangeLabels(ctx context.Context, doer *user_model.User, issue *issues_model.Issue,
 		addedLabels, removedLabels []*issues_model.Label)
+	IssueChangeCustomFields(ctx context.Context, doer *user_model.User, issue *issues_model.Issue)
+	IssueChangeProject(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldProjectID int64)
+	IssueTransfer(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldRepo *repo_model.Repository)
+	IssueSLABreached(ctx context.Context, breach *issues_model.IssueSLABreach)
+	IssuesBulkEdited(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, issues []*issues_model.Issue)
 
 	NewPullRequest(ctx context.Context, pr *issues_model.PullRequest, mentions []*user_model.User)
 	MergePullRequest(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest)
@@ -55,6 +61,10 @@ type Notifier interface {
 	UpdateComment(ctx context.Context, doer *user_model.User, c *issues_model.Comment, oldContent string)
 	DeleteComment(ctx context.Context, doer *user_model.User, c *issues_model.Comment)
 
+	CreateCommitComment(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, c *git_model.CommitComment, mentions []*user_model.User)
+	UpdateCommitComment(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, c *git_model.CommitComment, oldContent string)
+	DeleteCommitComment(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, c *git_model.CommitComment)
+
 	NewWikiPage(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, page, comment string)
 	EditWikiPage(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, page, comment string)
 	DeleteWikiPage(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, page string)
@@ -74,6 +84,7 @@ type Notifier interface {
 
 	PackageCreate(ctx context.Context, doer *user_model.User, pd *packages_model.PackageDescriptor)
 	PackageDelete(ctx context.Context, doer *user_model.User, pd *packages_model.PackageDescriptor)
+	PackagePromote(ctx context.Context, doer *user_model.User, source, target *packages_model.PackageDescriptor)
 
 	ChangeDefaultBranch(ctx context.Context, repo *repo_model.Repository)
 }
@@ -6,6 +6,7 @@ package notify
 import (
 	"context"
 
+	git_model "code.gitea.io/gitea/models/git"
 	issues_model "code.gitea.io/gitea/models/issues"
 	packages_model "code.gitea.io/gitea/models/packages"
 	repo_model "code.gitea.io/gitea/models/repo"
@@ -166,6 +167,27 @@ func DeleteComment(ctx context.Context, doer *user_model.User, c *issues_model.C
 	}
 }
 
+// CreateCommitComment notifies commit comment creation to notifiers
+func CreateCommitComment(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, c *git_model.CommitComment, mentions []*user_model.User) {
+	for _, notifier := range notifiers {
+		notifier.CreateCommitComment(ctx, doer, repo, c, mentions)
+	}
+}
+
+// UpdateCommitComment notifies commit comment update to notifiers
+func UpdateCommitComment(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, c *git_model.CommitComment, oldContent string) {
+	for _, notifier := range notifiers {
+		notifier.UpdateCommitComment(ctx, doer, repo, c, oldContent)
+	}
+}
+
+// DeleteCommitComment notifies commit comment deletion to notifiers
+func DeleteCommitComment(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, c *git_model.CommitComment) {
+	for _, notifier := range notifiers {
+		notifier.DeleteCommitComment(ctx, doer, repo, c)
+	}
+}
+
 // NewRelease notifies new release to notifiers
 func NewRelease(ctx context.Context, rel *repo_model.Release) {
 	if err := rel.LoadAttributes(ctx); err != nil {
@@ -249,6 +271,41 @@ func IssueChangeLabels(ctx context.Context, doer *user_model.User, issue *issues
 	}
 }
 
+// IssueChangeCustomFields notifies change of the custom field values of an issue to notifiers
+func IssueChangeCustomFields(ctx context.Context, doer *user_model.User, issue *issues_model.Issue) {
+	for _, notifier := range notifiers {
+		notifier.IssueChangeCustomFields(ctx, doer, issue)
+	}
+}
+
+// IssueChangeProject notifies change of the project of an issue to notifiers
+func IssueChangeProject(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldProjectID int64) {
+	for _, notifier := range notifiers {
+		notifier.IssueChangeProject(ctx, doer, issue, oldProjectID)
+	}
+}
+
+// IssueTransfer notifies the transfer of an issue to another repository to notifiers
+func IssueTransfer(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldRepo *repo_model.Repository) {
+	for _, notifier := range notifiers {
+		notifier.IssueTransfer(ctx, doer, issue, oldRepo)
+	}
+}
+
+// IssueSLABreached notifies that an issue missed a target of an SLA policy to notifiers
+func IssueSLABreached(ctx context.Context, breach *issues_model.IssueSLABreach) {
+	for _, notifier := range notifiers {
+		notifier.IssueSLABreached(ctx, breach)
+	}
+}
+
+// IssuesBulkEdited notifies a batch of issues edited at once to notifiers
+func IssuesBulkEdited(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, issues []*issues_model.Issue) {
+	for _, notifier := range notifiers {
+		notifier.IssuesBulkEdited(ctx, doer, repo, issues)
+	}
+}
+
 // CreateRepository notifies create repository to notifiers
 func CreateRepository(ctx context.Context, doer, u *user_model.User, repo *repo_model.Repository) {
 	for _, notifier := range notifiers {
@@ -368,6 +425,13 @@ func PackageDelete(ctx context.Context, doer *user_model.User, pd *packages_mode
 	}
 }
 
+// PackagePromote notifies the promotion of a package version to another owner to notifiers
+func PackagePromote(ctx context.Context, doer *user_model.User, source, target *packages_model.PackageDescriptor) {
+	for _, notifier := range notifiers {
+		notifier.PackagePromote(ctx, doer, source, target)
+	}
+}
+
 // ChangeDefaultBranch notifies change default branch to notifiers
 func ChangeDefaultBranch(ctx context.Context, repo *repo_model.Repository) {
 	for _, notifier := range notifiers {
@@ -6,6 +6,7 @@ package notify
 import (
 	"context"
 
+	git_model "code.gitea.io/gitea/models/git"
 	issues_model "code.gitea.io/gitea/models/issues"
 	packages_model "code.gitea.io/gitea/models/packages"
 	repo_model "code.gitea.io/gitea/models/repo"
@@ -96,6 +97,18 @@ func (*NullNotifier) EditWikiPage(ctx context.Context, doer *user_model.User, re
 func (*NullNotifier) DeleteWikiPage(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, page string) {
 }
 
+// CreateCommitComment places a place holder function
+func (*NullNotifier) CreateCommitComment(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, c *git_model.CommitComment, mentions []*user_model.User) {
+}
+
+// UpdateCommitComment places a place holder function
+func (*NullNotifier) UpdateCommitComment(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, c *git_model.CommitComment, oldContent string) {
+}
+
+// DeleteCommitComment places a place holder function
+func (*NullNotifier) DeleteCommitComment(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, c *git_model.CommitComment) {
+}
+
 // NewRelease places a place holder function
 func (*NullNotifier) NewRelease(ctx context.Context, rel *repo_model.Release) {
 }
@@ -141,6 +154,26 @@ func (*NullNotifier) IssueChangeLabels(ctx context.Context, doer *user_model.Use
 	addedLabels, removedLabels []*issues_model.Label) {
 }
 
+// IssueChangeCustomFields places a place holder function
+func (*NullNotifier) IssueChangeCustomFields(ctx context.Context, doer *user_model.User, issue *issues_model.Issue) {
+}
+
+// IssueChangeProject places a place holder function
+func (*NullNotifier) IssueChangeProject(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldProjectID int64) {
+}
+
+// IssueTransfer places a place holder function
+func (*NullNotifier) IssueTransfer(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldRepo *repo_model.Repository) {
+}
+
+// IssueSLABreached places a place holder function
+func (*NullNotifier) IssueSLABreached(ctx context.Context, breach *issues_model.IssueSLABreach) {
+}
+
+// IssuesBulkEdited places a place holder function
+func (*NullNotifier) IssuesBulkEdited(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, issues []*issues_model.Issue) {
+}
+
 // CreateRepository places a place holder function
 func (*NullNotifier) CreateRepository(ctx context.Context, doer, u *user_model.User, repo *repo_model.Repository) {
 }
@@ -208,6 +241,10 @@ func (*NullNotifier) PackageCreate(ctx context.Context, doer *user_model.User, p
 func (*NullNotifier) PackageDelete(ctx context.Context, doer *user_model.User, pd *packages_model.PackageDescriptor) {
 }
 
+// PackagePromote places a place holder function
+func (*NullNotifier) PackagePromote(ctx context.Context, doer *user_model.User, source, target *packages_model.PackageDescriptor) {
+}
+
 // ChangeDefaultBranch places a place holder function
 func (*NullNotifier) ChangeDefaultBranch(ctx context.Context, repo *repo_model.Repository) {
 }
@@ -9,6 +9,8 @@ import (
 
 	"code.gitea.io/gitea/models"
 	"code.gitea.io/gitea/models/db"
+	git_model "code.gitea.io/gitea/models/git"
+	issues_model "code.gitea.io/gitea/models/issues"
 	org_model "code.gitea.io/gitea/models/organization"
 	packages_model "code.gitea.io/gitea/models/packages"
 	repo_model "code.gitea.io/gitea/models/repo"
@@ -48,6 +50,18 @@ func DeleteOrganization(ctx context.Context, org *org_model.Organization, purge
 		return models.ErrUserOwnPackages{UID: org.ID}
 	}
 
+	if _, err := db.DeleteByBean(ctx, &git_model.PushRule{OwnerID: org.ID}); err != nil {
+		return fmt.Errorf("DeleteByBean: %w", err)
+	}
+
+	if _, err := db.DeleteByBean(ctx, &issues_model.CustomField{OwnerID: org.ID}); err != nil {
+		return fmt.Errorf("DeleteByBean: %w", err)
+	}
+
+	if err := git_model.DeleteOrgRulesetsByOrgID(ctx, org.ID); err != nil {
+		return fmt.Errorf("DeleteOrgRulesetsByOrgID: %w", err)
+	}
+
 	if err := org_model.DeleteOrganization(ctx, org); err != nil {
 		return fmt.Errorf("DeleteOrganization: %w", err)
 	}
@@ -8,6 +8,7 @@ import (
 
 	"code.gitea.io/gitea/models"
 	"code.gitea.io/gitea/models/db"
+	git_model "code.gitea.io/gitea/models/git"
 	"code.gitea.io/gitea/models/organization"
 	"code.gitea.io/gitea/models/unittest"
 	user_model "code.gitea.io/gitea/models/user"
@@ -23,10 +24,15 @@ func TestMain(m *testing.M) {
 func TestDeleteOrganization(t *testing.T) {
 	require.NoError(t, unittest.PrepareTestDatabase())
 	org := unittest.AssertExistsAndLoadBean(t, &organization.Organization{ID: 6})
+	rs := &git_model.OrgRuleset{OrgID: 6, Name: "main", RefPattern: "main"}
+	require.NoError(t, git_model.CreateOrgRuleset(db.DefaultContext, rs))
+	require.NoError(t, git_model.AddOrgRulesetViolation(db.DefaultContext, &git_model.OrgRulesetViolation{RulesetID: rs.ID}))
 	require.NoError(t, DeleteOrganization(db.DefaultContext, org, false))
 	unittest.AssertNotExistsBean(t, &organization.Organization{ID: 6})
 	unittest.AssertNotExistsBean(t, &organization.OrgUser{OrgID: 6})
 	unittest.AssertNotExistsBean(t, &organization.Team{OrgID: 6})
+	unittest.AssertNotExistsBean(t, &git_model.OrgRuleset{OrgID: 6})
+	unittest.AssertNotExistsBean(t, &git_model.OrgRulesetViolation{RulesetID: rs.ID})
 
 	org = unittest.AssertExistsAndLoadBean(t, &organization.Organization{ID: 3})
 	err := DeleteOrganization(db.DefaultContext, org, false)
@@ -236,7 +236,7 @@ func GetPackageFile(ctx context.Context, distro, file string, ownerID int64) (io
 		return nil, err
 	}
 
-	filestream, _, _, err := packages_service.GetPackageFileStream(ctx, pf)
+	filestream, _, _, err := packages_service.GetPackageFileStream(ctx, pf, nil)
 	return filestream, err
 }
 
@@ -273,7 +273,7 @@ func GetPackageDBFile(ctx context.Context, distro, arch string, ownerID int64, s
 	if err != nil {
 		return nil, err
 	}
-	filestream, _, _, err := packages_service.GetPackageFileStream(ctx, file)
+	filestream, _, _, err := packages_service.GetPackageFileStream(ctx, file, nil)
 	return filestream, err
 }
 
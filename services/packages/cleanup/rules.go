@@ -0,0 +1,215 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package container
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/optional"
+	npm_module "code.gitea.io/gitea/modules/packages/npm"
+	container_service "code.gitea.io/gitea/services/packages/container"
+
+	"github.com/hashicorp/go-version"
+	digest "github.com/opencontainers/go-digest"
+)
+
+const (
+	// ReasonRule is used for versions matched by the removal settings of a rule
+	ReasonRule = "rule"
+	// ReasonUntagged is used for container manifests which are neither tagged nor referenced
+	ReasonUntagged = "untagged"
+)
+
+// UntaggedGracePeriod is the minimum age of an untagged container manifest before it gets removed,
+// manifests are pushed before the tags or the manifest lists referencing them
+const UntaggedGracePeriod = 24 * time.Hour
+
+// CleanupCandidate is a package version which gets removed by a cleanup rule
+type CleanupCandidate struct {
+	Package *packages_model.Package
+	Version *packages_model.PackageVersion
+	Reason  string
+}
+
+// GetCleanupCandidates evaluates a cleanup rule without removing anything and returns the versions it would remove
+func GetCleanupCandidates(ctx context.Context, pcr *packages_model.PackageCleanupRule) ([]*CleanupCandidate, error) {
+	if err := pcr.CompiledPattern(); err != nil {
+		return nil, fmt.Errorf("CleanupRule [%d]: CompilePattern failed: %w", pcr.ID, err)
+	}
+
+	packages, err := packages_model.GetPackagesByType(ctx, pcr.OwnerID, pcr.Type)
+	if err != nil {
+		return nil, fmt.Errorf("CleanupRule [%d]: GetPackagesByType failed: %w", pcr.ID, err)
+	}
+
+	candidates := make([]*CleanupCandidate, 0, 10)
+	for _, p := range packages {
+		pvs, _, err := packages_model.SearchVersions(ctx, &packages_model.PackageSearchOptions{
+			PackageID:  p.ID,
+			IsInternal: optional.Some(false),
+			Sort:       packages_model.SortCreatedDesc,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("CleanupRule [%d]: SearchVersions failed: %w", pcr.ID, err)
+		}
+
+		pcs, err := getPackageCleanupCandidates(ctx, pcr, p, pvs)
+		if err != nil {
+			return nil, fmt.Errorf("CleanupRule [%d]: %w", pcr.ID, err)
+		}
+		candidates = append(candidates, pcs...)
+	}
+
+	return candidates, nil
+}
+
+// getPackageCleanupCandidates evaluates the rule for the versions of a package (sorted by creation date, newest first)
+func getPackageCleanupCandidates(ctx context.Context, pcr *packages_model.PackageCleanupRule, p *packages_model.Package, pvs []*packages_model.PackageVersion) ([]*CleanupCandidate, error) {
+	now := time.Now()
+	olderThan := now.AddDate(0, 0, -pcr.RemoveDays)
+	downloadedAfter := now.AddDate(0, 0, -pcr.KeepDownloadedDays)
+	untaggedOlderThan := now.Add(-UntaggedGracePeriod)
+	if olderThan.Before(untaggedOlderThan) {
+		untaggedOlderThan = olderThan
+	}
+
+	var keepPerMajor map[int64]bool
+	if pcr.KeepPerMajorCount > 0 {
+		keepPerMajor = keepLatestPerMajorVersion(pvs, pcr.KeepPerMajorCount)
+	}
+
+	candidates := make([]*CleanupCandidate, 0, len(pvs))
+	for i, pv := range pvs {
+		if pcr.Type == packages_model.TypeContainer {
+			if pcr.RemoveUntagged && pv.CreatedUnix.AsLocalTime().Before(untaggedOlderThan) {
+				if untagged, err := container_service.IsUntaggedManifest(ctx, p, pv); err != nil {
+					return nil, fmt.Errorf("container.IsUntaggedManifest failed: %w", err)
+				} else if untagged {
+					log.Debug("Rule[%d]: remove '%s/%s' (untagged)", pcr.ID, p.Name, pv.Version)
+					candidates = append(candidates, &CleanupCandidate{Package: p, Version: pv, Reason: ReasonUntagged})
+					continue
+				}
+			}
+
+			if skip, err := container_service.ShouldBeSkipped(ctx, pcr, p, pv); err != nil {
+				return nil, fmt.Errorf("container.ShouldBeSkipped failed: %w", err)
+			} else if skip {
+				log.Debug("Rule[%d]: keep '%s/%s' (container)", pcr.ID, p.Name, pv.Version)
+				continue
+			}
+		}
+
+		if i < pcr.KeepCount {
+			log.Debug("Rule[%d]: keep '%s/%s' (keep count)", pcr.ID, p.Name, pv.Version)
+			continue
+		}
+
+		toMatch := pv.LowerVersion
+		if pcr.MatchFullName {
+			toMatch = p.LowerName + "/" + pv.LowerVersion
+		}
+
+		if pcr.KeepPatternMatcher != nil && pcr.KeepPatternMatcher.MatchString(toMatch) {
+			log.Debug("Rule[%d]: keep '%s/%s' (keep pattern)", pcr.ID, p.Name, pv.Version)
+			continue
+		}
+		if pcr.KeepTagPatternMatcher != nil {
+			tags, err := getVersionTags(ctx, p, pv)
+			if err != nil {
+				return nil, fmt.Errorf("getVersionTags failed: %w", err)
+			}
+			if matchesAny(pcr, tags) {
+				log.Debug("Rule[%d]: keep '%s/%s' (keep tag pattern)", pcr.ID, p.Name, pv.Version)
+				continue
+			}
+		}
+		if keepPerMajor[pv.ID] {
+			log.Debug("Rule[%d]: keep '%s/%s' (keep per major version)", pcr.ID, p.Name, pv.Version)
+			continue
+		}
+		if pcr.KeepDownloadedDays > 0 && pv.LastDownloadUnix.AsLocalTime().After(downloadedAfter) {
+			log.Debug("Rule[%d]: keep '%s/%s' (recently downloaded)", pcr.ID, p.Name, pv.Version)
+			continue
+		}
+		if pv.CreatedUnix.AsLocalTime().After(olderThan) {
+			log.Debug("Rule[%d]: keep '%s/%s' (remove days)", pcr.ID, p.Name, pv.Version)
+			continue
+		}
+		if pcr.RemovePatternMatcher != nil && !pcr.RemovePatternMatcher.MatchString(toMatch) {
+			log.Debug("Rule[%d]: keep '%s/%s' (remove pattern)", pcr.ID, p.Name, pv.Version)
+			continue
+		}
+
+		log.Debug("Rule[%d]: remove '%s/%s'", pcr.ID, p.Name, pv.Version)
+		candidates = append(candidates, &CleanupCandidate{Package: p, Version: pv, Reason: ReasonRule})
+	}
+
+	return candidates, nil
+}
+
+func matchesAny(pcr *packages_model.PackageCleanupRule, tags []string) bool {
+	for _, tag := range tags {
+		if pcr.KeepTagPatternMatcher.MatchString(tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// keepLatestPerMajorVersion returns the ids of the n highest versions of every semver major version.
+// Versions which are no valid semver are ignored.
+func keepLatestPerMajorVersion(pvs []*packages_model.PackageVersion, n int) map[int64]bool {
+	type parsedVersion struct {
+		ID      int64
+		Version *version.Version
+	}
+
+	byMajor := make(map[int64][]*parsedVersion)
+	for _, pv := range pvs {
+		v, err := version.NewSemver(pv.Version)
+		if err != nil {
+			continue
+		}
+		major := v.Segments64()[0]
+		byMajor[major] = append(byMajor[major], &parsedVersion{pv.ID, v})
+	}
+
+	keep := make(map[int64]bool)
+	for _, versions := range byMajor {
+		sort.Slice(versions, func(i, j int) bool {
+			return versions[i].Version.GreaterThan(versions[j].Version)
+		})
+		for i := 0; i < n && i < len(versions); i++ {
+			keep[versions[i].ID] = true
+		}
+	}
+	return keep
+}
+
+// getVersionTags returns the tags referencing a version
+func getVersionTags(ctx context.Context, p *packages_model.Package, pv *packages_model.PackageVersion) ([]string, error) {
+	switch p.Type {
+	case packages_model.TypeNpm:
+		pps, err := packages_model.GetPropertiesByName(ctx, packages_model.PropertyTypeVersion, pv.ID, npm_module.TagProperty)
+		if err != nil {
+			return nil, err
+		}
+		tags := make([]string, 0, len(pps))
+		for _, pp := range pps {
+			tags = append(tags, pp.Value)
+		}
+		return tags, nil
+	case packages_model.TypeContainer:
+		// container versions are either tags or digests
+		if digest.Digest(pv.LowerVersion).Validate() != nil {
+			return []string{pv.LowerVersion}, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,94 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package container
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeepLatestPerMajorVersion(t *testing.T) {
+	versions := []string{"1.0.0", "1.2.0", "1.10.0", "2.0.0-rc1", "2.0.0", "2.1.0", "3.0.0", "latest"}
+
+	pvs := make([]*packages_model.PackageVersion, 0, len(versions))
+	for i, v := range versions {
+		pvs = append(pvs, &packages_model.PackageVersion{ID: int64(i + 1), Version: v})
+	}
+
+	keep := keepLatestPerMajorVersion(pvs, 1)
+	assert.Equal(t, map[int64]bool{3: true, 6: true, 7: true}, keep)
+
+	keep = keepLatestPerMajorVersion(pvs, 2)
+	assert.Equal(t, map[int64]bool{2: true, 3: true, 5: true, 6: true, 7: true}, keep)
+}
+
+func TestRemoveUntaggedManifests(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	p, err := packages_model.TryInsertPackage(db.DefaultContext, &packages_model.Package{
+		OwnerID:   2,
+		Type:      packages_model.TypeContainer,
+		Name:      "image",
+		LowerName: "image",
+	})
+	require.NoError(t, err)
+
+	// the versions are sorted by creation date, newest first
+	ages := []time.Duration{time.Hour, 2 * 24 * time.Hour, 10 * 24 * time.Hour}
+	pvs := make([]*packages_model.PackageVersion, 0, len(ages))
+	for i, age := range ages {
+		digest := "sha256:" + strings.Repeat(string(rune('a'+i)), 64)
+		pv, err := packages_model.GetOrInsertVersion(db.DefaultContext, &packages_model.PackageVersion{
+			PackageID:    p.ID,
+			Version:      digest,
+			LowerVersion: digest,
+		})
+		require.NoError(t, err)
+		pv.CreatedUnix = timeutil.TimeStamp(time.Now().Add(-age).Unix())
+		_, err = db.GetEngine(db.DefaultContext).Exec("UPDATE package_version SET created_unix = ? WHERE id = ?", pv.CreatedUnix, pv.ID)
+		require.NoError(t, err)
+		pvs = append(pvs, pv)
+	}
+
+	getRemovedVersions := func(t *testing.T, pcr *packages_model.PackageCleanupRule) map[int64]string {
+		require.NoError(t, pcr.CompiledPattern())
+		candidates, err := getPackageCleanupCandidates(db.DefaultContext, pcr, p, pvs)
+		require.NoError(t, err)
+
+		removed := make(map[int64]string, len(candidates))
+		for _, c := range candidates {
+			removed[c.Version.ID] = c.Reason
+		}
+		return removed
+	}
+
+	t.Run("GracePeriod", func(t *testing.T) {
+		// the recent manifest is not removed as untagged and is kept by the keep count
+		removed := getRemovedVersions(t, &packages_model.PackageCleanupRule{
+			Type:           packages_model.TypeContainer,
+			KeepCount:      10,
+			RemoveUntagged: true,
+		})
+		assert.Equal(t, map[int64]string{pvs[1].ID: ReasonUntagged, pvs[2].ID: ReasonUntagged}, removed)
+	})
+
+	t.Run("RemoveDays", func(t *testing.T) {
+		removed := getRemovedVersions(t, &packages_model.PackageCleanupRule{
+			Type:           packages_model.TypeContainer,
+			KeepCount:      10,
+			RemoveDays:     7,
+			RemoveUntagged: true,
+		})
+		assert.Equal(t, map[int64]string{pvs[2].ID: ReasonUntagged}, removed)
+	})
+}
@@ -87,22 +87,28 @@ func ShouldBeSkipped(ctx context.Context, pcr *packages_model.PackageCleanupRule
 
 	// Check if the version is a digest (or untagged)
 	if digest.Digest(pv.LowerVersion).Validate() == nil {
-		// Check if there is another manifest referencing this version
-		has, err := packages_model.ExistVersion(ctx, &packages_model.PackageSearchOptions{
-			PackageID: p.ID,
-			Properties: map[string]string{
-				container_module.PropertyManifestReference: pv.LowerVersion,
-			},
-		})
-		if err != nil {
-			return false, err
-		}
-
-		// Skip it if the version is referenced
-		if has {
-			return true, nil
-		}
+		// Skip it if the version is referenced by another manifest
+		return isReferencedByManifest(ctx, p, pv)
 	}
 
 	return false, nil
 }
+
+// IsUntaggedManifest checks if the version is a manifest which is neither tagged nor referenced by another manifest
+func IsUntaggedManifest(ctx context.Context, p *packages_model.Package, pv *packages_model.PackageVersion) (bool, error) {
+	if digest.Digest(pv.LowerVersion).Validate() != nil {
+		return false, nil
+	}
+
+	referenced, err := isReferencedByManifest(ctx, p, pv)
+	return !referenced, err
+}
+
+func isReferencedByManifest(ctx context.Context, p *packages_model.Package, pv *packages_model.PackageVersion) (bool, error) {
+	return packages_model.ExistVersion(ctx, &packages_model.PackageSearchOptions{
+		PackageID: p.ID,
+		Properties: map[string]string{
+			container_module.PropertyManifestReference: pv.LowerVersion,
+		},
+	})
+}
@@ -29,6 +29,8 @@ var (
 	ErrQuotaTypeSize   = errors.New("maximum allowed package type size exceeded")
 	ErrQuotaTotalSize  = errors.New("maximum allowed package storage quota exceeded")
 	ErrQuotaTotalCount = errors.New("maximum allowed package count exceeded")
+
+	ErrPackageVersionImmutable = errors.New("published package versions of this registry are immutable")
 )
 
 // PackageInfo describes a package
@@ -260,9 +262,42 @@ func addFileToPackageVersion(ctx context.Context, pv *packages_model.PackageVers
 		return nil, nil, false, err
 	}
 
+	if pfci.OverwriteExisting {
+		if err := checkFileOverwriteAllowed(ctx, pv, pvi, pfci); err != nil {
+			return nil, nil, false, err
+		}
+	}
+
 	return addFileToPackageVersionUnchecked(ctx, pv, pfci)
 }
 
+// checkFileOverwriteAllowed returns ErrPackageVersionImmutable if the file exists with a different content and the registry is immutable
+func checkFileOverwriteAllowed(ctx context.Context, pv *packages_model.PackageVersion, pvi *PackageInfo, pfci *PackageFileCreationInfo) error {
+	immutable, err := packages_model.IsRegistryImmutable(ctx, pvi.Owner.ID, pvi.PackageType)
+	if err != nil || !immutable {
+		return err
+	}
+
+	pf, err := packages_model.GetFileForVersionByName(ctx, pv.ID, pfci.Filename, pfci.CompositeKey)
+	if err != nil {
+		if err == packages_model.ErrPackageFileNotExist {
+			return nil
+		}
+		return err
+	}
+
+	pb, err := packages_model.GetBlobByID(ctx, pf.BlobID)
+	if err != nil {
+		return err
+	}
+
+	_, _, hashSHA256, _ := pfci.Data.Sums()
+	if pb.HashSHA256 != hex.EncodeToString(hashSHA256) {
+		return ErrPackageVersionImmutable
+	}
+	return nil
+}
+
 func addFileToPackageVersionUnchecked(ctx context.Context, pv *packages_model.PackageVersion, pfci *PackageFileCreationInfo) (*packages_model.PackageFile, *packages_model.PackageBlob, bool, error) {
 	log.Trace("Adding package file: %v, %s", pv.ID, pfci.Filename)
 
@@ -293,6 +328,9 @@ func addFileToPackageVersionUnchecked(ctx context.Context, pv *packages_model.Pa
 			if err := packages_model.DeleteAllProperties(ctx, packages_model.PropertyTypeFile, pf.ID); err != nil {
 				return nil, pb, !exists, err
 			}
+			if err := packages_model.DeleteSignaturesByFileID(ctx, pf.ID); err != nil {
+				return nil, pb, !exists, err
+			}
 			if err := packages_model.DeleteFileByID(ctx, pf.ID); err != nil {
 				return nil, pb, !exists, err
 			}
@@ -547,6 +585,14 @@ func DeletePackageVersionAndReferences(ctx context.Context, pv *packages_model.P
 		return err
 	}
 
+	if err := packages_model.DeleteSBOMByVersionID(ctx, pv.ID); err != nil {
+		return err
+	}
+
+	if err := packages_model.DeleteDownloadStatsByVersionID(ctx, pv.ID); err != nil {
+		return err
+	}
+
 	pfs, err := packages_model.GetFilesByVersionID(ctx, pv.ID)
 	if err != nil {
 		return err
@@ -566,11 +612,21 @@ func DeletePackageFile(ctx context.Context, pf *packages_model.PackageFile) erro
 	if err := packages_model.DeleteAllProperties(ctx, packages_model.PropertyTypeFile, pf.ID); err != nil {
 		return err
 	}
+	if err := packages_model.DeleteSignaturesByFileID(ctx, pf.ID); err != nil {
+		return err
+	}
 	return packages_model.DeleteFileByID(ctx, pf.ID)
 }
 
+// DownloadClient describes the client of a package file download for the download statistics
+type DownloadClient struct {
+	// Family is the client family of the user agent like "npm" or "docker"
+	Family        string
+	Authenticated bool
+}
+
 // GetFileStreamByPackageNameAndVersion returns the content of the specific package file
-func GetFileStreamByPackageNameAndVersion(ctx context.Context, pvi *PackageInfo, pfi *PackageFileInfo) (io.ReadSeekCloser, *url.URL, *packages_model.PackageFile, error) {
+func GetFileStreamByPackageNameAndVersion(ctx context.Context, pvi *PackageInfo, pfi *PackageFileInfo, dc *DownloadClient) (io.ReadSeekCloser, *url.URL, *packages_model.PackageFile, error) {
 	log.Trace("Getting package file stream: %v, %v, %s, %s, %s, %s", pvi.Owner.ID, pvi.PackageType, pvi.Name, pvi.Version, pfi.Filename, pfi.CompositeKey)
 
 	pv, err := packages_model.GetVersionByNameAndVersion(ctx, pvi.Owner.ID, pvi.PackageType, pvi.Name, pvi.Version)
@@ -582,32 +638,34 @@ func GetFileStreamByPackageNameAndVersion(ctx context.Context, pvi *PackageInfo,
 		return nil, nil, nil, err
 	}
 
-	return GetFileStreamByPackageVersion(ctx, pv, pfi)
+	return GetFileStreamByPackageVersion(ctx, pv, pfi, dc)
 }
 
 // GetFileStreamByPackageVersion returns the content of the specific package file
-func GetFileStreamByPackageVersion(ctx context.Context, pv *packages_model.PackageVersion, pfi *PackageFileInfo) (io.ReadSeekCloser, *url.URL, *packages_model.PackageFile, error) {
+func GetFileStreamByPackageVersion(ctx context.Context, pv *packages_model.PackageVersion, pfi *PackageFileInfo, dc *DownloadClient) (io.ReadSeekCloser, *url.URL, *packages_model.PackageFile, error) {
 	pf, err := packages_model.GetFileForVersionByName(ctx, pv.ID, pfi.Filename, pfi.CompositeKey)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
-	return GetPackageFileStream(ctx, pf)
+	return GetPackageFileStream(ctx, pf, dc)
 }
 
 // GetPackageFileStream returns the content of the specific package file
-func GetPackageFileStream(ctx context.Context, pf *packages_model.PackageFile) (io.ReadSeekCloser, *url.URL, *packages_model.PackageFile, error) {
+// The download is recorded for the client dc, which is nil for internal reads.
+func GetPackageFileStream(ctx context.Context, pf *packages_model.PackageFile, dc *DownloadClient) (io.ReadSeekCloser, *url.URL, *packages_model.PackageFile, error) {
 	pb, err := packages_model.GetBlobByID(ctx, pf.BlobID)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
-	return GetPackageBlobStream(ctx, pf, pb)
+	return GetPackageBlobStream(ctx, pf, pb, dc)
 }
 
 // GetPackageBlobStream returns the content of the specific package blob
 // If the storage supports direct serving and it's enabled, only the direct serving url is returned.
-func GetPackageBlobStream(ctx context.Context, pf *packages_model.PackageFile, pb *packages_model.PackageBlob) (io.ReadSeekCloser, *url.URL, *packages_model.PackageFile, error) {
+// The download is recorded for the client dc, internal reads with a nil dc are not recorded.
+func GetPackageBlobStream(ctx context.Context, pf *packages_model.PackageFile, pb *packages_model.PackageBlob, dc *DownloadClient) (io.ReadSeekCloser, *url.URL, *packages_model.PackageFile, error) {
 	key := packages_module.BlobHash256Key(pb.HashSHA256)
 
 	cs := packages_module.NewContentStore()
@@ -626,11 +684,15 @@ func GetPackageBlobStream(ctx context.Context, pf *packages_model.PackageFile, p
 		s, err = cs.Get(key)
 	}
 
-	if err == nil {
+	// internal reads are not downloads
+	if err == nil && dc != nil {
 		if pf.IsLead {
 			if err := packages_model.IncrementDownloadCounter(ctx, pf.VersionID); err != nil {
 				log.Error("Error incrementing download counter: %v", err)
 			}
+			if err := packages_model.IncrementDownloadStat(ctx, pf.VersionID, dc.Family, dc.Authenticated); err != nil {
+				log.Error("Error incrementing download statistics: %v", err)
+			}
 		}
 	}
 	return s, u, pf, err
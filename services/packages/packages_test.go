@@ -0,0 +1,75 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	packages_module "code.gitea.io/gitea/modules/packages"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPackageFileStreamDownloadStats(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	buf, err := packages_module.CreateHashedBufferFromReader(bytes.NewReader([]byte("package content")))
+	require.NoError(t, err)
+	defer buf.Close()
+
+	pv, pf, err := CreatePackageAndAddFile(db.DefaultContext,
+		&PackageCreationInfo{
+			PackageInfo: PackageInfo{
+				Owner:       owner,
+				PackageType: packages_model.TypeGeneric,
+				Name:        "downloaded",
+				Version:     "1.0.0",
+			},
+			Creator: owner,
+		},
+		&PackageFileCreationInfo{
+			PackageFileInfo: PackageFileInfo{Filename: "downloaded.tar.gz"},
+			Creator:         owner,
+			Data:            buf,
+			IsLead:          true,
+		},
+	)
+	require.NoError(t, err)
+
+	read := func(t *testing.T, dc *DownloadClient) {
+		s, _, _, err := GetPackageFileStream(db.DefaultContext, pf, dc)
+		require.NoError(t, err)
+		defer s.Close()
+		content, err := io.ReadAll(s)
+		require.NoError(t, err)
+		assert.Equal(t, "package content", string(content))
+	}
+
+	// an internal read is not a download
+	read(t, nil)
+	pv = unittest.AssertExistsAndLoadBean(t, &packages_model.PackageVersion{ID: pv.ID})
+	assert.EqualValues(t, 0, pv.DownloadCount)
+	stats, err := packages_model.FindDownloadStats(db.DefaultContext, &packages_model.FindDownloadStatsOptions{VersionID: pv.ID})
+	require.NoError(t, err)
+	assert.Empty(t, stats)
+
+	read(t, &DownloadClient{Family: "curl", Authenticated: true})
+	pv = unittest.AssertExistsAndLoadBean(t, &packages_model.PackageVersion{ID: pv.ID})
+	assert.EqualValues(t, 1, pv.DownloadCount)
+	stats, err = packages_model.FindDownloadStats(db.DefaultContext, &packages_model.FindDownloadStatsOptions{VersionID: pv.ID})
+	require.NoError(t, err)
+	require.Len(t, stats, 1)
+	assert.Equal(t, "curl", stats[0].Client)
+	assert.True(t, stats[0].Authenticated)
+	assert.EqualValues(t, 1, stats[0].Count)
+}
@@ -0,0 +1,175 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	packages_model "code.gitea.io/gitea/models/packages"
+	user_model "code.gitea.io/gitea/models/user"
+	container_module "code.gitea.io/gitea/modules/packages/container"
+	npm_module "code.gitea.io/gitea/modules/packages/npm"
+	"code.gitea.io/gitea/modules/util"
+	notify_service "code.gitea.io/gitea/services/notify"
+)
+
+// CanBePromoted checks if versions of the package type can be promoted.
+// Types with owner wide repository indexes are excluded because the index of the target owner would need to be rebuilt.
+func CanBePromoted(packageType packages_model.Type) bool {
+	switch packageType {
+	case packages_model.TypeAlpine, packages_model.TypeArch, packages_model.TypeCargo, packages_model.TypeDebian, packages_model.TypeRpm:
+		return false
+	}
+	return true
+}
+
+// PromotePackageVersion copies a package version to another owner.
+// The files of the new version reference the existing blobs, so no content is uploaded again.
+func PromotePackageVersion(ctx context.Context, doer, target *user_model.User, pd *packages_model.PackageDescriptor) (*packages_model.PackageDescriptor, error) {
+	if !CanBePromoted(pd.Package.Type) {
+		return nil, util.NewInvalidArgumentErrorf("%s packages can not be promoted", pd.Package.Type.Name())
+	}
+	if pd.Owner.ID == target.ID {
+		return nil, util.NewInvalidArgumentErrorf("the package version belongs to the target owner already")
+	}
+	if pd.Package.Type == packages_model.TypeContainer {
+		if metadata, ok := pd.Metadata.(*container_module.Metadata); ok && len(metadata.Manifests) > 0 {
+			return nil, util.NewInvalidArgumentErrorf("container image indexes can not be promoted")
+		}
+	}
+
+	dbCtx, committer, err := db.TxContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer committer.Close()
+
+	pv, err := promotePackageVersion(dbCtx, doer, target, pd)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := committer.Commit(); err != nil {
+		return nil, err
+	}
+
+	promoted, err := packages_model.GetPackageDescriptor(ctx, pv)
+	if err != nil {
+		return nil, err
+	}
+
+	notify_service.PackagePromote(ctx, doer, pd, promoted)
+
+	return promoted, nil
+}
+
+func promotePackageVersion(ctx context.Context, doer, target *user_model.User, pd *packages_model.PackageDescriptor) (*packages_model.PackageVersion, error) {
+	packageCreated := true
+	p := &packages_model.Package{
+		OwnerID:          target.ID,
+		Type:             pd.Package.Type,
+		Name:             pd.Package.Name,
+		LowerName:        pd.Package.LowerName,
+		SemverCompatible: pd.Package.SemverCompatible,
+	}
+	var err error
+	if p, err = packages_model.TryInsertPackage(ctx, p); err != nil {
+		if err != packages_model.ErrDuplicatePackage {
+			return nil, err
+		}
+		packageCreated = false
+	}
+
+	if packageCreated {
+		for _, pp := range pd.PackageProperties {
+			value := pp.Value
+			if pp.Name == container_module.PropertyRepository {
+				value = target.LowerName + "/" + p.LowerName
+			}
+			if _, err := packages_model.InsertProperty(ctx, packages_model.PropertyTypePackage, p.ID, pp.Name, value); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	pv, err := packages_model.GetOrInsertVersion(ctx, &packages_model.PackageVersion{
+		PackageID:    p.ID,
+		CreatorID:    doer.ID,
+		Version:      pd.Version.Version,
+		LowerVersion: pd.Version.LowerVersion,
+		MetadataJSON: pd.Version.MetadataJSON,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := CheckCountQuotaExceeded(ctx, doer, target); err != nil {
+		return nil, err
+	}
+
+	for _, pp := range pd.VersionProperties {
+		// dist-tags point to a single version per package and must be set explicitly in the target registry
+		if pd.Package.Type == packages_model.TypeNpm && pp.Name == npm_module.TagProperty {
+			continue
+		}
+		if _, err := packages_model.InsertProperty(ctx, packages_model.PropertyTypeVersion, pv.ID, pp.Name, pp.Value); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, pfd := range pd.Files {
+		if err := CheckSizeQuotaExceeded(ctx, doer, target, pd.Package.Type, pfd.Blob.Size); err != nil {
+			return nil, err
+		}
+
+		pf, err := packages_model.TryInsertFile(ctx, &packages_model.PackageFile{
+			VersionID:    pv.ID,
+			BlobID:       pfd.Blob.ID,
+			Name:         pfd.File.Name,
+			LowerName:    pfd.File.LowerName,
+			CompositeKey: pfd.File.CompositeKey,
+			IsLead:       pfd.File.IsLead,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pp := range pfd.Properties {
+			if _, err := packages_model.InsertProperty(ctx, packages_model.PropertyTypeFile, pf.ID, pp.Name, pp.Value); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	ps, err := packages_model.GetSBOMByVersionID(ctx, pd.Version.ID)
+	if err != nil && err != packages_model.ErrPackageSBOMNotExist {
+		return nil, err
+	}
+	if ps != nil {
+		if err := packages_model.SetSBOM(ctx, &packages_model.PackageSBOM{
+			VersionID: pv.ID,
+			Format:    ps.Format,
+			Content:   ps.Content,
+			CreatorID: ps.CreatorID,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := packages_model.InsertPromotion(ctx, &packages_model.PackagePromotion{
+		Type:            pd.Package.Type,
+		Name:            pd.Package.Name,
+		Version:         pd.Version.Version,
+		SourceOwnerID:   pd.Owner.ID,
+		SourceVersionID: pd.Version.ID,
+		TargetOwnerID:   target.ID,
+		TargetVersionID: pv.ID,
+		DoerID:          doer.ID,
+	}); err != nil {
+		return nil, err
+	}
+
+	return pv, nil
+}
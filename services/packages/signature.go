@@ -0,0 +1,66 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"context"
+	"strings"
+
+	asymkey_model "code.gitea.io/gitea/models/asymkey"
+	packages_model "code.gitea.io/gitea/models/packages"
+	user_model "code.gitea.io/gitea/models/user"
+	packages_module "code.gitea.io/gitea/modules/packages"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// AddFileSignature verifies a detached signature of a package file against the keys of its uploader
+// and stores it. Only the uploader can sign a file, and signatures which can not be verified are rejected.
+func AddFileSignature(ctx context.Context, doer, uploader *user_model.User, pf *packages_model.PackageFile, signatureType packages_model.SignatureType, signature string) (*packages_model.PackageSignature, error) {
+	if doer.ID != uploader.ID {
+		return nil, util.NewPermissionDeniedErrorf("only the uploader of a package file can sign it")
+	}
+	if !signatureType.IsValid() {
+		return nil, util.NewInvalidArgumentErrorf("unsupported signature type: %s", signatureType)
+	}
+	signature = strings.TrimSpace(signature)
+	if signature == "" {
+		return nil, util.NewInvalidArgumentErrorf("signature is empty")
+	}
+
+	pb, err := packages_model.GetBlobByID(ctx, pf.BlobID)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := packages_module.NewContentStore().Get(packages_module.BlobHash256Key(pb.HashSHA256))
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close()
+
+	var keyID string
+	switch signatureType {
+	case packages_model.SignatureTypePGP:
+		key, err := asymkey_model.VerifyDetachedGPGSignature(ctx, uploader.ID, s, signature)
+		if err != nil {
+			return nil, err
+		}
+		keyID = key.KeyID
+	case packages_model.SignatureTypeSSH:
+		key, err := asymkey_model.VerifyDetachedSSHSignature(ctx, uploader.ID, s, signature)
+		if err != nil {
+			return nil, err
+		}
+		keyID = key.Fingerprint
+	}
+
+	return packages_model.InsertSignature(ctx, &packages_model.PackageSignature{
+		VersionID: pf.VersionID,
+		FileID:    pf.ID,
+		Type:      signatureType,
+		SignerID:  uploader.ID,
+		KeyID:     keyID,
+		Content:   signature,
+	})
+}
@@ -0,0 +1,101 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	asymkey_model "code.gitea.io/gitea/models/asymkey"
+	"code.gitea.io/gitea/models/db"
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/perm"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	packages_module "code.gitea.io/gitea/modules/packages"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/42wim/sshsig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestAddFileSignature(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	uploader := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	other := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+
+	content := []byte("package content")
+	buf, err := packages_module.CreateHashedBufferFromReader(bytes.NewReader(content))
+	require.NoError(t, err)
+	defer buf.Close()
+
+	_, pf, err := CreatePackageAndAddFile(db.DefaultContext,
+		&PackageCreationInfo{
+			PackageInfo: PackageInfo{
+				Owner:       uploader,
+				PackageType: packages_model.TypeGeneric,
+				Name:        "signed",
+				Version:     "1.0.0",
+			},
+			Creator: uploader,
+		},
+		&PackageFileCreationInfo{
+			PackageFileInfo: PackageFileInfo{Filename: "signed.tar.gz"},
+			Creator:         uploader,
+			Data:            buf,
+			IsLead:          true,
+		},
+	)
+	require.NoError(t, err)
+
+	sign := func(t *testing.T, ownerID int64) string {
+		_, priv, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		block, err := ssh.MarshalPrivateKey(priv, "")
+		require.NoError(t, err)
+		signer, err := ssh.NewSignerFromKey(priv)
+		require.NoError(t, err)
+
+		require.NoError(t, db.Insert(db.DefaultContext, &asymkey_model.PublicKey{
+			OwnerID:  ownerID,
+			Name:     "package-signing",
+			Content:  strings.TrimSpace(string(ssh.MarshalAuthorizedKey(signer.PublicKey()))),
+			Mode:     perm.AccessModeWrite,
+			Type:     asymkey_model.KeyTypeUser,
+			Verified: true,
+		}))
+
+		signature, err := sshsig.Sign(pem.EncodeToMemory(block), bytes.NewReader(content), asymkey_model.DetachedSSHSignatureNamespace)
+		require.NoError(t, err)
+		return string(signature)
+	}
+
+	t.Run("Other user", func(t *testing.T) {
+		_, err := AddFileSignature(db.DefaultContext, other, uploader, pf, packages_model.SignatureTypeSSH, sign(t, other.ID))
+		require.ErrorIs(t, err, util.ErrPermissionDenied)
+	})
+
+	t.Run("Key of another user", func(t *testing.T) {
+		_, err := AddFileSignature(db.DefaultContext, uploader, uploader, pf, packages_model.SignatureTypeSSH, sign(t, other.ID))
+		require.ErrorIs(t, err, asymkey_model.ErrDetachedSignatureNotVerified)
+	})
+
+	t.Run("Uploader", func(t *testing.T) {
+		ps, err := AddFileSignature(db.DefaultContext, uploader, uploader, pf, packages_model.SignatureTypeSSH, sign(t, uploader.ID))
+		require.NoError(t, err)
+		assert.Equal(t, uploader.ID, ps.SignerID)
+
+		pfd, err := packages_model.GetPackageFileDescriptor(db.DefaultContext, pf)
+		require.NoError(t, err)
+		require.Len(t, pfd.Signatures, 1)
+		assert.Equal(t, uploader.ID, pfd.Signatures[0].Signer.ID)
+	})
+}
@@ -0,0 +1,273 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package vulnerability
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	user_model "code.gitea.io/gitea/models/user"
+	cargo_module "code.gitea.io/gitea/modules/packages/cargo"
+	composer_module "code.gitea.io/gitea/modules/packages/composer"
+	maven_module "code.gitea.io/gitea/modules/packages/maven"
+	npm_module "code.gitea.io/gitea/modules/packages/npm"
+	sbom_module "code.gitea.io/gitea/modules/packages/sbom"
+
+	"github.com/hashicorp/go-version"
+)
+
+// Sources of a dependency
+const (
+	SourceMetadata = "metadata"
+	SourceSBOM     = "sbom"
+)
+
+// Scopes of a dependency declared in the package metadata
+const (
+	ScopeRuntime     = "runtime"
+	ScopeDevelopment = "development"
+	ScopeOptional    = "optional"
+	ScopePeer        = "peer"
+	ScopeBuild       = "build"
+)
+
+// Dependency is a dependency of a package version
+type Dependency struct {
+	// Ecosystem is the OSV ecosystem of the dependency, empty if unknown
+	Ecosystem string
+	Name      string
+	// Version is the exact version listed in a SBOM or the lower bound of the declared requirement
+	Version     string
+	Requirement string
+	Scope       string
+	Source      string
+	Licenses    []string
+}
+
+// EcosystemForPackageType returns the OSV ecosystem of a package type, empty if there is none
+func EcosystemForPackageType(packageType packages_model.Type) string {
+	switch packageType {
+	case packages_model.TypeCargo:
+		return "crates.io"
+	case packages_model.TypeComposer:
+		return "Packagist"
+	case packages_model.TypeConan:
+		return "ConanCenter"
+	case packages_model.TypeCran:
+		return "CRAN"
+	case packages_model.TypeGo:
+		return "Go"
+	case packages_model.TypeMaven:
+		return "Maven"
+	case packages_model.TypeNpm:
+		return "npm"
+	case packages_model.TypeNuGet:
+		return "NuGet"
+	case packages_model.TypePub:
+		return "Pub"
+	case packages_model.TypePyPI:
+		return "PyPI"
+	case packages_model.TypeRubyGems:
+		return "RubyGems"
+	}
+	return ""
+}
+
+// PackageName returns the name of the package as used by the OSV ecosystem
+func PackageName(pd *packages_model.PackageDescriptor) string {
+	if m, ok := pd.Metadata.(*maven_module.Metadata); ok && m.GroupID != "" && m.ArtifactID != "" {
+		return m.GroupID + ":" + m.ArtifactID
+	}
+	return pd.Package.Name
+}
+
+// ecosystemForPackageURL returns the OSV ecosystem and package name of a package URL
+func ecosystemForPackageURL(purl *sbom_module.PackageURL) (string, string) {
+	joined := purl.Name
+	if purl.Namespace != "" {
+		joined = purl.Namespace + "/" + purl.Name
+	}
+
+	switch purl.Type {
+	case "cargo":
+		return "crates.io", purl.Name
+	case "composer":
+		return "Packagist", joined
+	case "conan":
+		return "ConanCenter", purl.Name
+	case "cran":
+		return "CRAN", purl.Name
+	case "gem":
+		return "RubyGems", purl.Name
+	case "golang":
+		return "Go", joined
+	case "maven":
+		if purl.Namespace == "" {
+			return "Maven", purl.Name
+		}
+		return "Maven", purl.Namespace + ":" + purl.Name
+	case "npm":
+		return "npm", joined
+	case "nuget":
+		return "NuGet", purl.Name
+	case "pub":
+		return "Pub", purl.Name
+	case "pypi":
+		return "PyPI", purl.Name
+	}
+	return "", joined
+}
+
+// requirementLowerBound returns the lowest version matching a requirement or an empty string if there is none
+func requirementLowerBound(requirement string) string {
+	requirement, _, _ = strings.Cut(requirement, "||")
+	requirement = strings.TrimSpace(requirement)
+	if requirement == "" {
+		return ""
+	}
+
+	// maven ranges like [1.0,2.0)
+	if requirement[0] == '[' {
+		requirement = strings.TrimLeft(requirement, "[")
+	}
+
+	fields := strings.FieldsFunc(requirement, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+	if len(fields) == 0 {
+		return ""
+	}
+
+	bound := fields[0]
+	switch {
+	case strings.HasPrefix(bound, "<"), strings.HasPrefix(bound, "!"), strings.HasPrefix(bound, "("), strings.HasPrefix(bound, ">") && !strings.HasPrefix(bound, ">="):
+		return ""
+	}
+	bound = strings.TrimLeft(bound, "^~>=v")
+
+	if _, err := version.NewVersion(bound); err != nil {
+		return ""
+	}
+	return bound
+}
+
+// GetDependencies returns the dependencies declared in the metadata and the components listed in the SBOM of a version
+func GetDependencies(ctx context.Context, pd *packages_model.PackageDescriptor) ([]*Dependency, error) {
+	dependencies := getMetadataDependencies(pd)
+
+	ps, err := packages_model.GetSBOMByVersionID(ctx, pd.Version.ID)
+	if err != nil {
+		if err == packages_model.ErrPackageSBOMNotExist {
+			return dependencies, nil
+		}
+		return nil, err
+	}
+
+	s, err := sbom_module.ParseSBOM(strings.NewReader(ps.Content))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range s.Components {
+		d := &Dependency{
+			Name:     c.Name,
+			Version:  c.Version,
+			Source:   SourceSBOM,
+			Licenses: c.Licenses,
+		}
+		if c.PURL != "" {
+			if purl, err := sbom_module.ParsePackageURL(c.PURL); err == nil {
+				d.Ecosystem, d.Name = ecosystemForPackageURL(purl)
+				if purl.Version != "" {
+					d.Version = purl.Version
+				}
+			}
+		}
+		dependencies = append(dependencies, d)
+	}
+
+	return dependencies, nil
+}
+
+func getMetadataDependencies(pd *packages_model.PackageDescriptor) []*Dependency {
+	ecosystem := EcosystemForPackageType(pd.Package.Type)
+
+	dependencies := make([]*Dependency, 0, 10)
+	add := func(name, requirement, scope string) {
+		dependencies = append(dependencies, &Dependency{
+			Ecosystem:   ecosystem,
+			Name:        name,
+			Version:     requirementLowerBound(requirement),
+			Requirement: requirement,
+			Scope:       scope,
+			Source:      SourceMetadata,
+		})
+	}
+	addMap := func(m map[string]string, scope string) {
+		names := make([]string, 0, len(m))
+		for name := range m {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			add(name, m[name], scope)
+		}
+	}
+
+	switch m := pd.Metadata.(type) {
+	case *cargo_module.Metadata:
+		for _, d := range m.Dependencies {
+			scope := ScopeRuntime
+			switch d.Kind {
+			case "dev":
+				scope = ScopeDevelopment
+			case "build":
+				scope = ScopeBuild
+			}
+			add(d.Name, d.Req, scope)
+		}
+	case *composer_module.Metadata:
+		// platform packages like php or ext-json are no Packagist packages
+		packagist := func(require map[string]string) map[string]string {
+			filtered := make(map[string]string, len(require))
+			for name, requirement := range require {
+				if strings.Contains(name, "/") {
+					filtered[name] = requirement
+				}
+			}
+			return filtered
+		}
+		addMap(packagist(m.Require), ScopeRuntime)
+		addMap(packagist(m.RequireDev), ScopeDevelopment)
+	case *maven_module.Metadata:
+		for _, d := range m.Dependencies {
+			add(d.GroupID+":"+d.ArtifactID, d.Version, ScopeRuntime)
+		}
+	case *npm_module.Metadata:
+		addMap(m.Dependencies, ScopeRuntime)
+		addMap(m.OptionalDependencies, ScopeOptional)
+		addMap(m.PeerDependencies, ScopePeer)
+		addMap(m.DevelopmentDependencies, ScopeDevelopment)
+	}
+
+	return dependencies
+}
+
+// SetSBOM validates and stores the SBOM of a version
+func SetSBOM(ctx context.Context, doer *user_model.User, pv *packages_model.PackageVersion, content []byte) error {
+	s, err := sbom_module.ParseSBOM(bytes.NewReader(content))
+	if err != nil {
+		return err
+	}
+
+	return packages_model.SetSBOM(ctx, &packages_model.PackageSBOM{
+		VersionID: pv.ID,
+		Format:    string(s.Format),
+		Content:   string(content),
+		CreatorID: doer.ID,
+	})
+}
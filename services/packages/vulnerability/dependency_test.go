@@ -0,0 +1,52 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package vulnerability
+
+import (
+	"testing"
+
+	sbom_module "code.gitea.io/gitea/modules/packages/sbom"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequirementLowerBound(t *testing.T) {
+	cases := map[string]string{
+		"1.2.3":              "1.2.3",
+		"^1.2.3":             "1.2.3",
+		"~1.2":               "1.2",
+		">=1.0.0 <2.0.0":     "1.0.0",
+		"^1.0 || ^2.0":       "1.0",
+		"[1.0,2.0)":          "1.0",
+		"(,1.0]":             "",
+		"<2.0":               "",
+		">1.0":               "",
+		"*":                  "",
+		"latest":             "",
+		"${project.version}": "",
+		"":                   "",
+	}
+	for requirement, expected := range cases {
+		assert.Equal(t, expected, requirementLowerBound(requirement), requirement)
+	}
+}
+
+func TestEcosystemForPackageURL(t *testing.T) {
+	cases := []struct {
+		PURL      sbom_module.PackageURL
+		Ecosystem string
+		Name      string
+	}{
+		{sbom_module.PackageURL{Type: "npm", Namespace: "@angular", Name: "core"}, "npm", "@angular/core"},
+		{sbom_module.PackageURL{Type: "maven", Namespace: "org.apache.commons", Name: "commons-text"}, "Maven", "org.apache.commons:commons-text"},
+		{sbom_module.PackageURL{Type: "golang", Namespace: "github.com/gorilla", Name: "mux"}, "Go", "github.com/gorilla/mux"},
+		{sbom_module.PackageURL{Type: "gem", Name: "rails"}, "RubyGems", "rails"},
+		{sbom_module.PackageURL{Type: "deb", Namespace: "debian", Name: "curl"}, "", "debian/curl"},
+	}
+	for _, c := range cases {
+		ecosystem, name := ecosystemForPackageURL(&c.PURL)
+		assert.Equal(t, c.Ecosystem, ecosystem)
+		assert.Equal(t, c.Name, name)
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package vulnerability
+
+import (
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	osv_module "code.gitea.io/gitea/modules/packages/osv"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// Finding is a known vulnerability affecting a package version or one of its dependencies
+type Finding struct {
+	Vulnerability *packages_model.PackageVulnerability
+	Ecosystem     string
+	Name          string
+	Version       string
+	// Direct is true if the package version itself is affected
+	Direct bool
+	// Source is the source of the affected dependency, empty if the package version itself is affected
+	Source string
+}
+
+// ImportOSVDatabase imports a zip archive of OSV entries in a single transaction. Existing entries are replaced.
+// It returns the number of imported entries.
+func ImportOSVDatabase(ctx context.Context, r io.ReaderAt, size int64) (int, error) {
+	imported := 0
+	err := db.WithTx(ctx, func(ctx context.Context) error {
+		return osv_module.ReadDatabase(r, size, func(v *osv_module.Vulnerability) error {
+			var modified timeutil.TimeStamp
+			if t, err := time.Parse(time.RFC3339, v.Modified); err == nil {
+				modified = timeutil.TimeStamp(t.Unix())
+			}
+
+			for _, affected := range v.AffectedPackages() {
+				affectedJSON, err := json.Marshal(affected)
+				if err != nil {
+					return err
+				}
+
+				if err := packages_model.UpsertVulnerability(ctx, &packages_model.PackageVulnerability{
+					OSVID:        v.ID,
+					Ecosystem:    affected.Package.Ecosystem,
+					LowerName:    affected.Package.Name,
+					Aliases:      v.Aliases,
+					Summary:      v.Summary,
+					Severity:     v.SeverityName(),
+					AffectedJSON: string(affectedJSON),
+					ModifiedUnix: modified,
+				}); err != nil {
+					return err
+				}
+			}
+
+			imported++
+			return nil
+		})
+	})
+	if err != nil {
+		return 0, err
+	}
+	return imported, nil
+}
+
+// GetVulnerabilities returns the known vulnerabilities of a package version and of its dependencies
+func GetVulnerabilities(ctx context.Context, pd *packages_model.PackageDescriptor) ([]*Finding, error) {
+	dependencies, err := GetDependencies(ctx, pd)
+	if err != nil {
+		return nil, err
+	}
+
+	cache := make(map[string][]*packages_model.PackageVulnerability)
+	lookup := func(ecosystem, name string) ([]*packages_model.PackageVulnerability, error) {
+		key := ecosystem + "/" + strings.ToLower(name)
+		if pvs, ok := cache[key]; ok {
+			return pvs, nil
+		}
+		pvs, err := packages_model.GetVulnerabilitiesByPackage(ctx, ecosystem, name)
+		if err != nil {
+			return nil, err
+		}
+		cache[key] = pvs
+		return pvs, nil
+	}
+
+	findings := make([]*Finding, 0, 5)
+	check := func(ecosystem, name, version, source string, direct bool) error {
+		if ecosystem == "" || name == "" || version == "" {
+			return nil
+		}
+		pvs, err := lookup(ecosystem, name)
+		if err != nil {
+			return err
+		}
+		for _, pv := range pvs {
+			var affected *osv_module.Affected
+			if err := json.Unmarshal([]byte(pv.AffectedJSON), &affected); err != nil {
+				log.Error("Invalid affected data of vulnerability %s: %v", pv.OSVID, err)
+				continue
+			}
+			if affected.IsAffected(version) {
+				findings = append(findings, &Finding{
+					Vulnerability: pv,
+					Ecosystem:     ecosystem,
+					Name:          name,
+					Version:       version,
+					Direct:        direct,
+					Source:        source,
+				})
+			}
+		}
+		return nil
+	}
+
+	if err := check(EcosystemForPackageType(pd.Package.Type), PackageName(pd), pd.Version.Version, "", true); err != nil {
+		return nil, err
+	}
+	for _, d := range dependencies {
+		if err := check(d.Ecosystem, d.Name, d.Version, d.Source, false); err != nil {
+			return nil, err
+		}
+	}
+
+	return findings, nil
+}
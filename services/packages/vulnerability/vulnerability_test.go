@@ -0,0 +1,81 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package vulnerability
+
+import (
+	"archive/zip"
+	"bytes"
+	"slices"
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/json"
+	osv_module "code.gitea.io/gitea/modules/packages/osv"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// osvDatabase returns a zip archive of the entries, in the order of their names
+func osvDatabase(t *testing.T, entries map[string]string) *bytes.Reader {
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, name := range names {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(entries[name]))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return bytes.NewReader(buf.Bytes())
+}
+
+func TestImportOSVDatabase(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	// one block per major version of the same package
+	r := osvDatabase(t, map[string]string{
+		"GHSA-test-0001.json": `{
+  "id": "GHSA-test-0001",
+  "affected": [{
+    "package": {"ecosystem": "npm", "name": "lodash"},
+    "ranges": [{"type": "SEMVER", "events": [{"introduced": "1.0.0"}, {"fixed": "1.2.0"}]}]
+  }, {
+    "package": {"ecosystem": "npm", "name": "lodash"},
+    "ranges": [{"type": "SEMVER", "events": [{"introduced": "2.0.0"}, {"fixed": "2.3.0"}]}]
+  }]
+}`,
+	})
+	imported, err := ImportOSVDatabase(db.DefaultContext, r, r.Size())
+	require.NoError(t, err)
+	assert.Equal(t, 1, imported)
+
+	pvs, err := packages_model.GetVulnerabilitiesByPackage(db.DefaultContext, "npm", "lodash")
+	require.NoError(t, err)
+	require.Len(t, pvs, 1)
+	var affected *osv_module.Affected
+	require.NoError(t, json.Unmarshal([]byte(pvs[0].AffectedJSON), &affected))
+	assert.True(t, affected.IsAffected("1.1.0"))
+	assert.True(t, affected.IsAffected("2.2.0"))
+
+	// the entries imported before an invalid one are rolled back
+	t.Run("Rollback", func(t *testing.T) {
+		r := osvDatabase(t, map[string]string{
+			"GHSA-test-0002.json": `{"id": "GHSA-test-0002", "affected": [{"package": {"ecosystem": "npm", "name": "left-pad"}, "versions": ["1.0.0"]}]}`,
+			"GHSA-test-0003.json": `invalid`,
+		})
+		_, err := ImportOSVDatabase(db.DefaultContext, r, r.Size())
+		require.ErrorIs(t, err, osv_module.ErrInvalidDatabase)
+
+		unittest.AssertNotExistsBean(t, &packages_model.PackageVulnerability{OSVID: "GHSA-test-0002"})
+	})
+}
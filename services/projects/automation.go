@@ -0,0 +1,152 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package projects
+
+import (
+	"context"
+	"slices"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	project_model "code.gitea.io/gitea/models/project"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/references"
+	"code.gitea.io/gitea/modules/util"
+	notify_service "code.gitea.io/gitea/services/notify"
+)
+
+func init() {
+	notify_service.RegisterNotifier(&automationNotifier{})
+}
+
+// automationNotifier applies the automation rules of the projects to their issues, whether the issues
+// are changed from the UI, the API or by a push
+type automationNotifier struct {
+	notify_service.NullNotifier
+}
+
+var _ notify_service.Notifier = &automationNotifier{}
+
+func applyAutomation(ctx context.Context, issueID int64, event project_model.AutomationEvent, labelID int64) {
+	a, err := project_model.ApplyAutomation(ctx, issueID, event, labelID)
+	if err != nil {
+		log.Error("ApplyAutomation[issue: %d, event: %s]: %v", issueID, event, err)
+		return
+	}
+	if a != nil {
+		log.Trace("Issue %d moved to column %d of project %d on %s", issueID, a.ColumnID, a.ProjectID, event)
+	}
+}
+
+func (n *automationNotifier) IssueChangeProject(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldProjectID int64) {
+	if issue.IsPull {
+		applyAutomation(ctx, issue.ID, project_model.AutomationEventPullAdded, 0)
+	} else {
+		applyAutomation(ctx, issue.ID, project_model.AutomationEventIssueAdded, 0)
+	}
+}
+
+func (n *automationNotifier) IssueChangeStatus(ctx context.Context, doer *user_model.User, commitID string, issue *issues_model.Issue, actionComment *issues_model.Comment, closeOrReopen bool) {
+	if issue.IsClosed {
+		applyAutomation(ctx, issue.ID, project_model.AutomationEventClosed, 0)
+	} else {
+		applyAutomation(ctx, issue.ID, project_model.AutomationEventReopened, 0)
+	}
+}
+
+func (n *automationNotifier) IssueChangeLabels(ctx context.Context, doer *user_model.User, issue *issues_model.Issue,
+	addedLabels, removedLabels []*issues_model.Label,
+) {
+	for _, label := range addedLabels {
+		applyAutomation(ctx, issue.ID, project_model.AutomationEventLabelAdded, label.ID)
+	}
+}
+
+func (n *automationNotifier) MergePullRequest(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) {
+	applyAutomation(ctx, pr.IssueID, project_model.AutomationEventMerged, 0)
+}
+
+func (n *automationNotifier) AutoMergePullRequest(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) {
+	applyAutomation(ctx, pr.IssueID, project_model.AutomationEventMerged, 0)
+}
+
+func (n *automationNotifier) NewPullRequest(ctx context.Context, pr *issues_model.PullRequest, mentions []*user_model.User) {
+	applyLinkedIssuesAutomation(ctx, pr)
+}
+
+func (n *automationNotifier) IssueChangeContent(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldContent string) {
+	if !issue.IsPull || issue.IsClosed {
+		return
+	}
+	if err := issue.LoadPullRequest(ctx); err != nil {
+		log.Error("LoadPullRequest[%d]: %v", issue.ID, err)
+		return
+	}
+	applyLinkedIssuesAutomation(ctx, issue.PullRequest)
+}
+
+// applyLinkedIssuesAutomation applies AutomationEventPullLinked to the open issues the pull request closes
+func applyLinkedIssuesAutomation(ctx context.Context, pr *issues_model.PullRequest) {
+	if err := pr.LoadIssue(ctx); err != nil {
+		log.Error("LoadIssue[%d]: %v", pr.ID, err)
+		return
+	}
+	refs, err := pr.ResolveCrossReferences(ctx)
+	if err != nil {
+		log.Error("ResolveCrossReferences[%d]: %v", pr.ID, err)
+		return
+	}
+	for _, ref := range refs {
+		if ref.RefAction != references.XRefActionCloses {
+			continue
+		}
+		issue, err := issues_model.GetIssueByID(ctx, ref.IssueID)
+		if err != nil {
+			log.Error("GetIssueByID[%d]: %v", ref.IssueID, err)
+			continue
+		}
+		if !issue.IsClosed {
+			applyAutomation(ctx, issue.ID, project_model.AutomationEventPullLinked, 0)
+		}
+	}
+}
+
+// GetAutomationLabels returns the labels which can trigger the automation rules of a project: the labels of the repository
+// and of its owner for the projects of a repository, the labels of the owner for the projects of an owner
+func GetAutomationLabels(ctx context.Context, project *project_model.Project) ([]*issues_model.Label, error) {
+	ownerID := project.OwnerID
+	var labels []*issues_model.Label
+	if project.IsRepositoryProject() {
+		if err := project.LoadRepo(ctx); err != nil {
+			return nil, err
+		}
+		repoLabels, err := issues_model.GetLabelsByRepoID(ctx, project.RepoID, "", db.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		labels = append(labels, repoLabels...)
+		ownerID = project.Repo.OwnerID
+	}
+	ownerLabels, err := issues_model.GetLabelsByOrgID(ctx, ownerID, "", db.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return append(labels, ownerLabels...), nil
+}
+
+// CreateAutomation creates an automation rule of a project, the label of the rule being one of GetAutomationLabels
+func CreateAutomation(ctx context.Context, project *project_model.Project, a *project_model.Automation) error {
+	a.ProjectID = project.ID
+	if a.Event == project_model.AutomationEventLabelAdded {
+		labels, err := GetAutomationLabels(ctx, project)
+		if err != nil {
+			return err
+		}
+		if !slices.ContainsFunc(labels, func(label *issues_model.Label) bool { return label.ID == a.LabelID }) {
+			return util.NewInvalidArgumentErrorf("label %d cannot be used in the project", a.LabelID)
+		}
+	}
+	return project_model.NewAutomation(ctx, a)
+}
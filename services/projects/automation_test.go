@@ -0,0 +1,60 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package projects
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	project_model "code.gitea.io/gitea/models/project"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateAutomation(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	project := unittest.AssertExistsAndLoadBean(t, &project_model.Project{ID: 1})
+
+	// label 3 is a label of another owner
+	err := CreateAutomation(db.DefaultContext, project, &project_model.Automation{Event: project_model.AutomationEventLabelAdded, LabelID: 3, ColumnID: 2})
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+
+	a := &project_model.Automation{Event: project_model.AutomationEventLabelAdded, LabelID: 1, ColumnID: 2}
+	require.NoError(t, CreateAutomation(db.DefaultContext, project, a))
+	assert.EqualValues(t, 1, a.ProjectID)
+}
+
+func TestAutomationNotifier(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	project := unittest.AssertExistsAndLoadBean(t, &project_model.Project{ID: 1})
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	label := unittest.AssertExistsAndLoadBean(t, &issues_model.Label{ID: 1})
+	columnID := func() int64 {
+		return unittest.AssertExistsAndLoadBean(t, &project_model.ProjectIssue{IssueID: issue.ID}).ProjectColumnID
+	}
+
+	require.NoError(t, CreateAutomation(db.DefaultContext, project, &project_model.Automation{Event: project_model.AutomationEventLabelAdded, LabelID: label.ID, ColumnID: 2}))
+	require.NoError(t, CreateAutomation(db.DefaultContext, project, &project_model.Automation{Event: project_model.AutomationEventClosed, ColumnID: 3}))
+	require.NoError(t, CreateAutomation(db.DefaultContext, project, &project_model.Automation{Event: project_model.AutomationEventReopened, ColumnID: 1}))
+
+	n := &automationNotifier{}
+	n.IssueChangeLabels(db.DefaultContext, doer, issue, nil, []*issues_model.Label{label})
+	assert.EqualValues(t, 1, columnID())
+	n.IssueChangeLabels(db.DefaultContext, doer, issue, []*issues_model.Label{label}, nil)
+	assert.EqualValues(t, 2, columnID())
+
+	issue.IsClosed = true
+	n.IssueChangeStatus(db.DefaultContext, doer, "", issue, nil, true)
+	assert.EqualValues(t, 3, columnID())
+
+	issue.IsClosed = false
+	n.IssueChangeStatus(db.DefaultContext, doer, "", issue, nil, false)
+	assert.EqualValues(t, 1, columnID())
+}
@@ -0,0 +1,384 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package projects
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	project_model "code.gitea.io/gitea/models/project"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/container"
+	issue_indexer "code.gitea.io/gitea/modules/indexer/issues"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/util"
+	issue_service "code.gitea.io/gitea/services/issue"
+
+	"xorm.io/builder"
+)
+
+// ViewGroup is a group of the issues of a project view
+type ViewGroup struct {
+	// Key identifies the group: the ID of the repository, column, user, label or milestone,
+	// the value of the custom field or the status, empty for the issues without value
+	Key    string
+	Title  string
+	Color  string
+	Issues issues_model.IssueList
+}
+
+// ParseViewFilter parses the filter of a project view, which is a structured issue search query
+// without the qualifiers which don't apply to the issues of a project
+func ParseViewFilter(filter string) (*issue_indexer.Query, error) {
+	q, err := issue_indexer.ParseQuery(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var unsupported string
+	switch {
+	case q.Project != "" || q.NoProject:
+		unsupported = "project"
+	case q.Mention != "":
+		unsupported = "mentions"
+	case q.ReviewRequested != "":
+		unsupported = "review-requested"
+	case q.ReviewedBy != "":
+		unsupported = "reviewed-by"
+	case q.SortBy != "":
+		unsupported = "sort"
+	}
+	if unsupported != "" {
+		return nil, util.NewInvalidArgumentErrorf("the qualifier %q is not supported in the filter of a project view", unsupported)
+	}
+	return q, nil
+}
+
+// GetViewCustomFields returns the custom fields a view of a project can be grouped or sorted by:
+// the fields of the owner for the projects of an owner, and the fields usable in the repository for the projects of a repository
+func GetViewCustomFields(ctx context.Context, project *project_model.Project) ([]*issues_model.CustomField, error) {
+	if !project.IsRepositoryProject() {
+		return issues_model.GetOwnerCustomFields(ctx, project.OwnerID)
+	}
+	if err := project.LoadRepo(ctx); err != nil {
+		return nil, err
+	}
+	return issues_model.GetCustomFieldsForRepo(ctx, project.Repo.OwnerID, project.RepoID)
+}
+
+// ValidateView checks and normalizes the attributes of a view of a project
+func ValidateView(ctx context.Context, project *project_model.Project, view *project_model.View) error {
+	if err := view.Validate(); err != nil {
+		return err
+	}
+	if _, err := ParseViewFilter(view.Filter); err != nil {
+		return err
+	}
+
+	var fieldIDs []int64
+	if view.GroupBy == project_model.ViewGroupByCustomField {
+		fieldIDs = append(fieldIDs, view.GroupFieldID)
+	}
+	if view.SortBy == project_model.ViewSortByCustomField {
+		fieldIDs = append(fieldIDs, view.SortFieldID)
+	}
+	if len(fieldIDs) == 0 {
+		return nil
+	}
+	fields, err := GetViewCustomFields(ctx, project)
+	if err != nil {
+		return err
+	}
+	for _, id := range fieldIDs {
+		if !slices.ContainsFunc(fields, func(f *issues_model.CustomField) bool { return f.ID == id }) {
+			return util.NewInvalidArgumentErrorf("custom field %d cannot be used in the project", id)
+		}
+	}
+	return nil
+}
+
+// readableIssuesCond returns the condition of the issues, and pull requests, the doer can read
+func readableIssuesCond(doer *user_model.User) builder.Cond {
+	return builder.Or(
+		builder.Eq{"issue.is_pull": false}.And(builder.In("issue.repo_id",
+			builder.Select("id").From("repository").Where(repo_model.AccessibleRepositoryCondition(doer, unit.TypeIssues)))),
+		builder.Eq{"issue.is_pull": true}.And(builder.In("issue.repo_id",
+			builder.Select("id").From("repository").Where(repo_model.AccessibleRepositoryCondition(doer, unit.TypePullRequests)))),
+	)
+}
+
+// LoadViewIssues returns the issues of a project the doer can read, filtered and sorted as defined by the view
+func LoadViewIssues(ctx context.Context, project *project_model.Project, view *project_model.View, doer *user_model.User) (issues_model.IssueList, error) {
+	issues, err := issues_model.Issues(ctx, &issues_model.IssuesOptions{
+		ProjectID: project.ID,
+		RepoCond:  readableIssuesCond(doer),
+		SortType:  "project-column-sorting",
+	})
+	if err != nil {
+		return nil, err
+	}
+	if issues, err = FilterViewIssues(ctx, project, view, issues, doer); err != nil {
+		return nil, err
+	}
+	if err := sortViewIssues(ctx, view, issues); err != nil {
+		return nil, err
+	}
+	return issues, nil
+}
+
+// FilterViewIssues returns the issues of the project matching the filter of a view. The filter is applied by
+// the issue indexer to the issues of the project in the repositories of the issues, the order of the issues being kept.
+func FilterViewIssues(ctx context.Context, project *project_model.Project, view *project_model.View, issues issues_model.IssueList, doer *user_model.User) (issues_model.IssueList, error) {
+	if view.Filter == "" || len(issues) == 0 {
+		return issues, nil
+	}
+	q, err := ParseViewFilter(view.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// the names of the filter are resolved in the repository of a repository project, and in all the repositories otherwise
+	var repo *repo_model.Repository
+	if project.IsRepositoryProject() {
+		if err := project.LoadRepo(ctx); err != nil {
+			return nil, err
+		}
+		repo = project.Repo
+	}
+	applyQuery, err := issue_service.ResolveSearchQuery(ctx, q, repo, doer)
+	if err != nil {
+		return nil, err
+	}
+
+	repoIDs := make(container.Set[int64])
+	for _, issue := range issues {
+		repoIDs.Add(issue.RepoID)
+	}
+	// no paginator, all the matching issues of the project are needed
+	ids, _, err := issue_indexer.SearchIssues(ctx, (&issue_indexer.SearchOptions{
+		RepoIDs:   repoIDs.Values(),
+		ProjectID: optional.Some(project.ID),
+	}).Copy(applyQuery))
+	if err != nil {
+		return nil, err
+	}
+
+	matched := container.SetOf(ids...)
+	return slices.DeleteFunc(slices.Clone(issues), func(issue *issues_model.Issue) bool {
+		return !matched.Contains(issue.ID)
+	}), nil
+}
+
+// sortViewIssues sorts the issues as defined by the view, the issues keeping the order of the project without sort
+func sortViewIssues(ctx context.Context, view *project_model.View, issues issues_model.IssueList) error {
+	var key func(issue *issues_model.Issue) (value float64, hasValue bool)
+	switch view.SortBy {
+	case project_model.ViewSortByNone:
+		return nil
+	case project_model.ViewSortByTitle:
+		slices.SortStableFunc(issues, func(a, b *issues_model.Issue) int {
+			c := strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+			if view.SortDesc {
+				return -c
+			}
+			return c
+		})
+		return nil
+	case project_model.ViewSortByCreated:
+		key = func(issue *issues_model.Issue) (float64, bool) { return float64(issue.CreatedUnix), true }
+	case project_model.ViewSortByUpdated:
+		key = func(issue *issues_model.Issue) (float64, bool) { return float64(issue.UpdatedUnix), true }
+	case project_model.ViewSortByComments:
+		key = func(issue *issues_model.Issue) (float64, bool) { return float64(issue.NumComments), true }
+	case project_model.ViewSortByDeadline:
+		key = func(issue *issues_model.Issue) (float64, bool) {
+			return float64(issue.DeadlineUnix), issue.DeadlineUnix != 0
+		}
+	case project_model.ViewSortByCustomField:
+		values, err := issues_model.GetIssuesCustomFieldValues(ctx, issueIDs(issues))
+		if err != nil {
+			return err
+		}
+		key = func(issue *issues_model.Issue) (float64, bool) {
+			for _, v := range values[issue.ID] {
+				if v.FieldID == view.SortFieldID {
+					return v.NumValue, true
+				}
+			}
+			return 0, false
+		}
+	}
+
+	// the issues without value come last whatever the direction
+	slices.SortStableFunc(issues, func(a, b *issues_model.Issue) int {
+		va, oka := key(a)
+		vb, okb := key(b)
+		switch {
+		case oka != okb && oka:
+			return -1
+		case oka != okb:
+			return 1
+		case view.SortDesc:
+			return cmp.Compare(vb, va)
+		default:
+			return cmp.Compare(va, vb)
+		}
+	})
+	return nil
+}
+
+// GroupViewIssues groups the issues of a table view, the groups being in display order and the group of the issues
+// without value being the last one. An issue with several assignees or labels is in the group of each of them.
+func GroupViewIssues(ctx context.Context, project *project_model.Project, view *project_model.View, issues issues_model.IssueList) ([]*ViewGroup, error) {
+	groups := make(map[string]*ViewGroup)
+	var order []string
+	var sortKeys map[string]float64
+	add := func(key, title, color string, issue *issues_model.Issue) {
+		group, ok := groups[key]
+		if !ok {
+			group = &ViewGroup{Key: key, Title: title, Color: color}
+			groups[key] = group
+			order = append(order, key)
+		}
+		group.Issues = append(group.Issues, issue)
+	}
+
+	switch view.GroupBy {
+	case project_model.ViewGroupByNone:
+		return []*ViewGroup{{Issues: issues}}, nil
+	case project_model.ViewGroupByStatus:
+		for _, issue := range issues {
+			if issue.IsClosed {
+				add("closed", "closed", "", issue)
+			} else {
+				add("open", "open", "", issue)
+			}
+		}
+		sortKeys = map[string]float64{"open": 0, "closed": 1}
+	case project_model.ViewGroupByRepository:
+		for _, issue := range issues {
+			add(strconv.FormatInt(issue.RepoID, 10), issue.Repo.FullName(), "", issue)
+		}
+	case project_model.ViewGroupByColumn:
+		columns, err := project.GetColumns(ctx)
+		if err != nil {
+			return nil, err
+		}
+		columnIDs, err := project_model.GetIssueColumnIDs(ctx, project.ID)
+		if err != nil {
+			return nil, err
+		}
+		sortKeys = make(map[string]float64, len(columns))
+		var defaultColumn *project_model.Column
+		for _, column := range columns {
+			sortKeys[strconv.FormatInt(column.ID, 10)] = float64(column.Sorting)
+			if column.Default {
+				defaultColumn = column
+			}
+		}
+		for _, issue := range issues {
+			column := defaultColumn
+			if i := slices.IndexFunc(columns, func(c *project_model.Column) bool { return c.ID == columnIDs[issue.ID] }); i >= 0 {
+				column = columns[i]
+			}
+			if column == nil {
+				add("", "", "", issue)
+			} else {
+				add(strconv.FormatInt(column.ID, 10), column.Title, column.Color, issue)
+			}
+		}
+	case project_model.ViewGroupByAssignee:
+		for _, issue := range issues {
+			if len(issue.Assignees) == 0 {
+				add("", "", "", issue)
+			}
+			for _, assignee := range issue.Assignees {
+				add(strconv.FormatInt(assignee.ID, 10), assignee.Name, "", issue)
+			}
+		}
+	case project_model.ViewGroupByLabel:
+		for _, issue := range issues {
+			if len(issue.Labels) == 0 {
+				add("", "", "", issue)
+			}
+			for _, label := range issue.Labels {
+				add(strconv.FormatInt(label.ID, 10), label.Name, label.Color, issue)
+			}
+		}
+	case project_model.ViewGroupByMilestone:
+		for _, issue := range issues {
+			if issue.Milestone == nil {
+				add("", "", "", issue)
+			} else {
+				add(strconv.FormatInt(issue.MilestoneID, 10), issue.Milestone.Name, "", issue)
+			}
+		}
+	case project_model.ViewGroupByCustomField:
+		values, err := issues_model.GetIssuesCustomFieldValues(ctx, issueIDs(issues))
+		if err != nil {
+			return nil, err
+		}
+		sortKeys = make(map[string]float64)
+		for _, issue := range issues {
+			i := slices.IndexFunc(values[issue.ID], func(v *issues_model.IssueCustomFieldValue) bool {
+				return v.FieldID == view.GroupFieldID
+			})
+			if i < 0 {
+				add("", "", "", issue)
+				continue
+			}
+			v := values[issue.ID][i]
+			add(v.Value, v.DisplayValue(), "", issue)
+			sortKeys[v.Value] = v.NumValue
+		}
+	}
+
+	slices.SortStableFunc(order, func(a, b string) int {
+		// the group of the issues without value is the last one
+		switch {
+		case a == "":
+			return 1
+		case b == "":
+			return -1
+		case sortKeys != nil:
+			return cmp.Compare(sortKeys[a], sortKeys[b])
+		default:
+			return strings.Compare(strings.ToLower(groups[a].Title), strings.ToLower(groups[b].Title))
+		}
+	})
+	result := make([]*ViewGroup, 0, len(order))
+	for _, key := range order {
+		result = append(result, groups[key])
+	}
+	return result, nil
+}
+
+// GetViewIssueGroups returns the issues of a project the doer can read, filtered, sorted and grouped as defined by the view,
+// the issues of a board view being grouped by column
+func GetViewIssueGroups(ctx context.Context, project *project_model.Project, view *project_model.View, doer *user_model.User) ([]*ViewGroup, error) {
+	issues, err := LoadViewIssues(ctx, project, view, doer)
+	if err != nil {
+		return nil, err
+	}
+	if !view.IsTable() {
+		boardView := *view
+		boardView.GroupBy = project_model.ViewGroupByColumn
+		view = &boardView
+	}
+	return GroupViewIssues(ctx, project, view, issues)
+}
+
+func issueIDs(issues issues_model.IssueList) []int64 {
+	ids := make([]int64, 0, len(issues))
+	for _, issue := range issues {
+		ids = append(ids, issue.ID)
+	}
+	return ids
+}
@@ -0,0 +1,145 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package projects
+
+import (
+	"slices"
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	project_model "code.gitea.io/gitea/models/project"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	issue_indexer "code.gitea.io/gitea/modules/indexer/issues"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateView(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	project := unittest.AssertExistsAndLoadBean(t, &project_model.Project{ID: 1})
+
+	require.NoError(t, ValidateView(db.DefaultContext, project, &project_model.View{Name: "Bugs", Type: project_model.ViewTypeTable, Filter: "is:open label:bug"}))
+
+	for _, filter := range []string{"project:other", "no:project", "mentions:user1", "sort:updated-desc", "is:draft"} {
+		err := ValidateView(db.DefaultContext, project, &project_model.View{Name: "View", Type: project_model.ViewTypeTable, Filter: filter})
+		assert.ErrorIs(t, err, util.ErrInvalidArgument, filter)
+	}
+
+	// the custom fields of another owner cannot be used
+	field := &issues_model.CustomField{OwnerID: 3, Name: "Priority", Type: issues_model.CustomFieldTypeNumber}
+	require.NoError(t, issues_model.NewCustomField(db.DefaultContext, field))
+	err := ValidateView(db.DefaultContext, project, &project_model.View{Name: "View", Type: project_model.ViewTypeTable, GroupBy: project_model.ViewGroupByCustomField, GroupFieldID: field.ID})
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+}
+
+func TestViewIssues(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	// the filters of the views are applied by the issue indexer
+	defer test.MockVariableValue(&setting.Indexer.IssueType, "db")()
+	issue_indexer.InitIssueIndexer(true)
+	project := unittest.AssertExistsAndLoadBean(t, &project_model.Project{ID: 1})
+	user1 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	load := func(t *testing.T, view *project_model.View, doer *user_model.User) []int64 {
+		t.Helper()
+		issues, err := LoadViewIssues(db.DefaultContext, project, view, doer)
+		require.NoError(t, err)
+		return issueIDs(issues)
+	}
+	groups := func(t *testing.T, view *project_model.View) map[string][]int64 {
+		t.Helper()
+		groups, err := GetViewIssueGroups(db.DefaultContext, project, view, user2)
+		require.NoError(t, err)
+		result := make(map[string][]int64, len(groups))
+		for _, group := range groups {
+			// the issues keep the order of the project, which isn't tested here
+			result[group.Title] = issueIDs(group.Issues)
+			slices.Sort(result[group.Title])
+		}
+		// the group of the issues without value is the last one
+		for _, group := range groups[:len(groups)-1] {
+			assert.NotEmpty(t, group.Key)
+		}
+		return result
+	}
+
+	t.Run("Filter", func(t *testing.T) {
+		view := &project_model.View{Type: project_model.ViewTypeTable}
+		assert.ElementsMatch(t, []int64{1, 2, 3, 5}, load(t, view, user2))
+
+		view.Filter = "label:label1"
+		assert.ElementsMatch(t, []int64{1, 2}, load(t, view, user2))
+		view.Filter = "is:open -label:label1"
+		assert.ElementsMatch(t, []int64{3}, load(t, view, user2))
+		view.Filter = "label:nothing"
+		_, err := LoadViewIssues(db.DefaultContext, project, view, user2)
+		assert.ErrorIs(t, err, util.ErrInvalidArgument)
+		view.Filter = "is:closed"
+		assert.ElementsMatch(t, []int64{5}, load(t, view, user2))
+		view.Filter = "is:pr milestone:milestone1"
+		assert.ElementsMatch(t, []int64{2}, load(t, view, user2))
+		view.Filter = "issue2"
+		assert.ElementsMatch(t, []int64{2}, load(t, view, user2))
+		view.Filter = "author:user2"
+		assert.ElementsMatch(t, []int64{5}, load(t, view, user2))
+
+		view.Filter = "assignee:@me"
+		assert.ElementsMatch(t, []int64{1}, load(t, view, user1))
+		assert.Empty(t, load(t, view, user2))
+		_, err = LoadViewIssues(db.DefaultContext, project, view, nil)
+		assert.ErrorIs(t, err, util.ErrInvalidArgument)
+	})
+
+	t.Run("Sort", func(t *testing.T) {
+		view := &project_model.View{Type: project_model.ViewTypeTable, SortBy: project_model.ViewSortByTitle, SortDesc: true}
+		assert.Equal(t, []int64{5, 3, 2, 1}, load(t, view, user2))
+
+		view = &project_model.View{Type: project_model.ViewTypeTable, SortBy: project_model.ViewSortByCreated}
+		assert.Equal(t, []int64{1, 2, 3, 5}, load(t, view, user2))
+
+		view = &project_model.View{Type: project_model.ViewTypeTable, SortBy: project_model.ViewSortByComments, SortDesc: true}
+		assert.EqualValues(t, 1, load(t, view, user2)[0])
+	})
+
+	t.Run("Group", func(t *testing.T) {
+		view := &project_model.View{Type: project_model.ViewTypeTable, GroupBy: project_model.ViewGroupByStatus}
+		assert.Equal(t, map[string][]int64{"open": {1, 2, 3}, "closed": {5}}, groups(t, view))
+
+		view = &project_model.View{Type: project_model.ViewTypeTable, GroupBy: project_model.ViewGroupByLabel}
+		assert.Equal(t, map[string][]int64{"label1": {1, 2}, "label2": {5}, "orglabel4": {2}, "": {3}}, groups(t, view))
+
+		view = &project_model.View{Type: project_model.ViewTypeTable, GroupBy: project_model.ViewGroupByAssignee}
+		assert.Equal(t, map[string][]int64{"user1": {1}, "": {2, 3, 5}}, groups(t, view))
+
+		view = &project_model.View{Type: project_model.ViewTypeTable, GroupBy: project_model.ViewGroupByMilestone, Filter: "is:open"}
+		assert.Equal(t, map[string][]int64{"milestone1": {2}, "milestone3": {3}, "": {1}}, groups(t, view))
+
+		field := &issues_model.CustomField{OwnerID: 2, Name: "Estimate", Type: issues_model.CustomFieldTypeNumber}
+		require.NoError(t, issues_model.NewCustomField(db.DefaultContext, field))
+		for issueID, value := range map[int64]string{1: "10", 5: "2"} {
+			require.NoError(t, issues_model.SetIssueCustomFieldValue(db.DefaultContext, issueID, field, value))
+		}
+		view = &project_model.View{Type: project_model.ViewTypeTable, GroupBy: project_model.ViewGroupByCustomField, GroupFieldID: field.ID}
+		require.NoError(t, ValidateView(db.DefaultContext, project, &project_model.View{Name: "Estimates", Type: view.Type, GroupBy: view.GroupBy, GroupFieldID: view.GroupFieldID}))
+		customFieldGroups, err := GetViewIssueGroups(db.DefaultContext, project, view, user2)
+		require.NoError(t, err)
+		// the groups are ordered by value
+		if assert.Len(t, customFieldGroups, 3) {
+			assert.Equal(t, "2", customFieldGroups[0].Title)
+			assert.Equal(t, "10", customFieldGroups[1].Title)
+			assert.ElementsMatch(t, []int64{2, 3}, issueIDs(customFieldGroups[2].Issues))
+		}
+
+		// the issues of a board view are grouped by column, the issues without column being in the default column
+		view = &project_model.View{Type: project_model.ViewTypeBoard, Filter: "is:open"}
+		assert.Equal(t, map[string][]int64{"To Do": {1, 2}, "In Progress": {3}}, groups(t, view))
+	})
+}
@@ -154,7 +154,10 @@ func CheckPullMergeable(stdCtx context.Context, doer *user_model.User, perm *acc
 
 // isSignedIfRequired check if merge will be signed if required
 func isSignedIfRequired(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.User) (bool, error) {
-	pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, pr.BaseRepoID, pr.BaseBranch)
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return false, err
+	}
+	pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, pr.BaseRepo, pr.BaseBranch)
 	if err != nil {
 		return false, err
 	}
@@ -0,0 +1,124 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull
+
+import (
+	"context"
+	"slices"
+
+	git_model "code.gitea.io/gitea/models/git"
+	issues_model "code.gitea.io/gitea/models/issues"
+	org_model "code.gitea.io/gitea/models/organization"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/gitrepo"
+	issue_service "code.gitea.io/gitea/services/issue"
+)
+
+// CodeOwnerGroup is a rule of the CODEOWNERS file matching files changed by a pull request
+type CodeOwnerGroup struct {
+	Pattern string
+	Users   []*user_model.User
+	Teams   []*org_model.Team
+	// Files are the changed files matched by the rule
+	Files []string
+	// Approved is true if one of the owners approved the pull request
+	Approved bool
+}
+
+// GetCodeOwnerGroups returns the groups of code owners of the files changed by the pull request and whether they
+// approved it. The CODEOWNERS file of the base branch is used, so that a pull request cannot change its own owners.
+func GetCodeOwnerGroups(ctx context.Context, pb *git_model.ProtectedBranch, pr *issues_model.PullRequest) ([]*CodeOwnerGroup, error) {
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return nil, err
+	}
+	if err := pr.LoadIssue(ctx); err != nil {
+		return nil, err
+	}
+
+	gitRepo, err := gitrepo.OpenRepository(ctx, pr.BaseRepo)
+	if err != nil {
+		return nil, err
+	}
+	defer gitRepo.Close()
+
+	commit, err := gitRepo.GetBranchCommit(pr.BaseBranch)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := issue_service.GetCodeOwnersRules(ctx, commit)
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	changedFiles, err := issue_service.GetPullRequestChangedFiles(gitRepo, pr)
+	if err != nil {
+		return nil, err
+	}
+
+	approverIDs, err := issues_model.GetApprovingReviewerIDs(ctx, pb, pr)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]*CodeOwnerGroup, 0, len(rules))
+	for _, rule := range rules {
+		group := &CodeOwnerGroup{
+			Pattern: rule.Pattern,
+			Users:   rule.Users,
+			Teams:   rule.Teams,
+		}
+		for _, f := range changedFiles {
+			if rule.Match(f) {
+				group.Files = append(group.Files, f)
+			}
+		}
+		if len(group.Files) == 0 {
+			continue
+		}
+
+		group.Approved, err = isApprovedByCodeOwner(ctx, rule, approverIDs, pr.Issue.PosterID)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+// isApprovedByCodeOwner returns true if one of the approvers is an owner of the rule,
+// the poster of the pull request cannot approve it as an owner
+func isApprovedByCodeOwner(ctx context.Context, rule *issues_model.CodeOwnerRule, approverIDs []int64, posterID int64) (bool, error) {
+	for _, approverID := range approverIDs {
+		if approverID == posterID {
+			continue
+		}
+		if slices.ContainsFunc(rule.Users, func(u *user_model.User) bool { return u.ID == approverID }) {
+			return true, nil
+		}
+		for _, team := range rule.Teams {
+			isMember, err := org_model.IsTeamMember(ctx, team.OrgID, team.ID, approverID)
+			if err != nil {
+				return false, err
+			}
+			if isMember {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// MergeBlockedByCodeOwners returns true if the protected branch requires the approval of
+// code owners and some of the groups owning changed files have not approved the pull request
+func MergeBlockedByCodeOwners(ctx context.Context, pb *git_model.ProtectedBranch, pr *issues_model.PullRequest) (bool, error) {
+	if !pb.RequireCodeOwnerApproval {
+		return false, nil
+	}
+	groups, err := GetCodeOwnerGroups(ctx, pb, pr)
+	if err != nil {
+		return false, err
+	}
+	return slices.ContainsFunc(groups, func(g *CodeOwnerGroup) bool { return !g.Approved }), nil
+}
@@ -103,15 +103,22 @@ func IsCommitStatusContextSuccess(commitStatuses []*git_model.CommitStatus, requ
 
 // IsPullCommitStatusPass returns if all required status checks PASS
 func IsPullCommitStatusPass(ctx context.Context, pr *issues_model.PullRequest) (bool, error) {
-	pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, pr.BaseRepoID, pr.BaseBranch)
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return false, fmt.Errorf("LoadBaseRepo: %w", err)
+	}
+	pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, pr.BaseRepo, pr.BaseBranch)
 	if err != nil {
 		return false, fmt.Errorf("GetFirstMatchProtectedBranchRule: %w", err)
 	}
+	return isPullCommitStatusPassForRule(ctx, pr, pb)
+}
+
+func isPullCommitStatusPassForRule(ctx context.Context, pr *issues_model.PullRequest, pb *git_model.ProtectedBranch) (bool, error) {
 	if pb == nil || !pb.EnableStatusCheck {
 		return true, nil
 	}
 
-	state, err := GetPullRequestCommitStatusState(ctx, pr)
+	state, err := getPullRequestCommitStatusState(ctx, pr, pb.StatusCheckContexts)
 	if err != nil {
 		return false, err
 	}
@@ -120,6 +127,21 @@ func IsPullCommitStatusPass(ctx context.Context, pr *issues_model.PullRequest) (
 
 // GetPullRequestCommitStatusState returns pull request merged commit status state
 func GetPullRequestCommitStatusState(ctx context.Context, pr *issues_model.PullRequest) (structs.CommitStatusState, error) {
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return "", fmt.Errorf("LoadBaseRepo: %w", err)
+	}
+	pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, pr.BaseRepo, pr.BaseBranch)
+	if err != nil {
+		return "", fmt.Errorf("GetFirstMatchProtectedBranchRule: %w", err)
+	}
+	var requiredContexts []string
+	if pb != nil {
+		requiredContexts = pb.StatusCheckContexts
+	}
+	return getPullRequestCommitStatusState(ctx, pr, requiredContexts)
+}
+
+func getPullRequestCommitStatusState(ctx context.Context, pr *issues_model.PullRequest, requiredContexts []string) (structs.CommitStatusState, error) {
 	// Ensure HeadRepo is loaded
 	if err := pr.LoadHeadRepo(ctx); err != nil {
 		return "", fmt.Errorf("LoadHeadRepo: %w", err)
@@ -158,14 +180,5 @@ func GetPullRequestCommitStatusState(ctx context.Context, pr *issues_model.PullR
 		return "", fmt.Errorf("GetLatestCommitStatus: %w", err)
 	}
 
-	pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, pr.BaseRepoID, pr.BaseBranch)
-	if err != nil {
-		return "", fmt.Errorf("GetFirstMatchProtectedBranchRule: %w", err)
-	}
-	var requiredContexts []string
-	if pb != nil {
-		requiredContexts = pb.StatusCheckContexts
-	}
-
 	return MergeRequiredContextsCommitStatus(commitStatuses, requiredContexts), nil
 }
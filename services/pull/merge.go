@@ -65,23 +65,11 @@ func getMergeMessage(ctx context.Context, baseGitRepo *git.Repository, pr *issue
 	reviewedBy := pr.GetApprovers(ctx)
 
 	if mergeStyle != "" {
-		commit, err := baseGitRepo.GetBranchCommit(pr.BaseRepo.DefaultBranch)
+		templateContent, addTrailers, found, err := getMergeMessageTemplate(ctx, baseGitRepo, pr, mergeStyle)
 		if err != nil {
 			return "", "", err
 		}
-
-		templateFilepathForgejo := fmt.Sprintf(".forgejo/default_merge_message/%s_TEMPLATE.md", strings.ToUpper(string(mergeStyle)))
-		templateFilepathGitea := fmt.Sprintf(".gitea/default_merge_message/%s_TEMPLATE.md", strings.ToUpper(string(mergeStyle)))
-
-		templateContent, err := commit.GetFileContent(templateFilepathForgejo, setting.Repository.PullRequest.DefaultMergeMessageSize)
-		if _, ok := err.(git.ErrNotExist); ok {
-			templateContent, err = commit.GetFileContent(templateFilepathGitea, setting.Repository.PullRequest.DefaultMergeMessageSize)
-		}
-		if err != nil {
-			if !git.IsErrNotExist(err) {
-				return "", "", err
-			}
-		} else {
+		if found {
 			vars := map[string]string{
 				"BaseRepoOwnerName":      pr.BaseRepo.OwnerName,
 				"BaseRepoName":           pr.BaseRepo.Name,
@@ -107,15 +95,17 @@ func getMergeMessage(ctx context.Context, baseGitRepo *git.Repository, pr *issue
 			refs, err := pr.ResolveCrossReferences(ctx)
 			if err == nil {
 				closeIssueIndexes := make([]string, 0, len(refs))
+				linkedIssueIndexes := make([]string, 0, len(refs))
 				closeWord := "close"
 				if len(setting.Repository.PullRequest.CloseKeywords) > 0 {
 					closeWord = setting.Repository.PullRequest.CloseKeywords[0]
 				}
 				for _, ref := range refs {
+					if err := ref.LoadIssue(ctx); err != nil {
+						return "", "", err
+					}
+					linkedIssueIndexes = append(linkedIssueIndexes, fmt.Sprintf("%s%d", issueReference, ref.Issue.Index))
 					if ref.RefAction == references.XRefActionCloses {
-						if err := ref.LoadIssue(ctx); err != nil {
-							return "", "", err
-						}
 						closeIssueIndexes = append(closeIssueIndexes, fmt.Sprintf("%s %s%d", closeWord, issueReference, ref.Issue.Index))
 					}
 				}
@@ -124,8 +114,32 @@ func getMergeMessage(ctx context.Context, baseGitRepo *git.Repository, pr *issue
 				} else {
 					vars["ClosingIssues"] = ""
 				}
+				vars["LinkedIssues"] = strings.Join(linkedIssueIndexes, ", ")
+			}
+
+			var approvers []*user_model.User
+			if addTrailers || strings.Contains(templateContent, "Reviewers") {
+				if approvers, err = pr.GetApproverUsers(ctx); err != nil {
+					return "", "", err
+				}
+				names := make([]string, 0, len(approvers))
+				for _, approver := range approvers {
+					names = append(names, approver.Name)
+				}
+				vars["Reviewers"] = strings.Join(names, ", ")
+			}
+			var coAuthors []string
+			if addTrailers || strings.Contains(templateContent, "CoAuthors") {
+				if coAuthors, err = getPullCoAuthors(ctx, baseGitRepo, pr); err != nil {
+					return "", "", err
+				}
+				vars["CoAuthors"] = strings.Join(coAuthors, ", ")
 			}
+
 			message, body = expandDefaultMergeMessage(templateContent, vars)
+			if addTrailers {
+				body = appendMergeMessageTrailers(body, approvers, coAuthors)
+			}
 			return message, body, nil
 		}
 	}
@@ -203,8 +217,13 @@ func Merge(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.U
 		return err
 	}
 
+	return afterMerge(ctx, pr.ID, doer, wasAutoMerged)
+}
+
+// afterMerge notifies about the merged pull request and resolves its cross references
+func afterMerge(ctx context.Context, prID int64, doer *user_model.User, wasAutoMerged bool) error {
 	// reload pull request because it has been updated by post receive hook
-	pr, err = issues_model.GetPullRequestByID(ctx, pr.ID)
+	pr, err := issues_model.GetPullRequestByID(ctx, prID)
 	if err != nil {
 		return err
 	}
@@ -229,6 +248,11 @@ func Merge(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.U
 	// Reset cached commit count
 	cache.Remove(pr.Issue.Repo.GetCommitsCountCacheKey(pr.BaseBranch, true))
 
+	// Move the pull requests stacked on this one to its base branch
+	if err := UpdateStackedPullRequests(ctx, doer, pr); err != nil {
+		log.Error("UpdateStackedPullRequests %-v: %v", pr, err)
+	}
+
 	// Resolve cross references
 	refs, err := pr.ResolveCrossReferences(ctx)
 	if err != nil {
@@ -266,25 +290,8 @@ func doMergeAndPush(ctx context.Context, pr *issues_model.PullRequest, doer *use
 	defer cancel()
 
 	// Merge commits.
-	switch mergeStyle {
-	case repo_model.MergeStyleMerge:
-		if err := doMergeStyleMerge(mergeCtx, message); err != nil {
-			return "", err
-		}
-	case repo_model.MergeStyleRebase, repo_model.MergeStyleRebaseMerge:
-		if err := doMergeStyleRebase(mergeCtx, mergeStyle, message); err != nil {
-			return "", err
-		}
-	case repo_model.MergeStyleSquash:
-		if err := doMergeStyleSquash(mergeCtx, message); err != nil {
-			return "", err
-		}
-	case repo_model.MergeStyleFastForwardOnly:
-		if err := doMergeStyleFastForwardOnly(mergeCtx); err != nil {
-			return "", err
-		}
-	default:
-		return "", models.ErrInvalidMergeStyle{ID: pr.BaseRepo.ID, Style: mergeStyle}
+	if err := doMergeStyle(mergeCtx, mergeStyle, message); err != nil {
+		return "", err
 	}
 
 	// OK we should cache our current head and origin/headbranch
@@ -338,22 +345,7 @@ func doMergeAndPush(ctx context.Context, pr *issues_model.PullRequest, doer *use
 	// This cause an api call to "/api/internal/hook/post-receive/...",
 	// If it's merge, all db transaction and operations should be there but not here to prevent deadlock.
 	if err := pushCmd.Run(mergeCtx.RunOpts()); err != nil {
-		if strings.Contains(mergeCtx.errbuf.String(), "non-fast-forward") {
-			return "", &git.ErrPushOutOfDate{
-				StdOut: mergeCtx.outbuf.String(),
-				StdErr: mergeCtx.errbuf.String(),
-				Err:    err,
-			}
-		} else if strings.Contains(mergeCtx.errbuf.String(), "! [remote rejected]") {
-			err := &git.ErrPushRejected{
-				StdOut: mergeCtx.outbuf.String(),
-				StdErr: mergeCtx.errbuf.String(),
-				Err:    err,
-			}
-			err.GenerateMessage()
-			return "", err
-		}
-		return "", fmt.Errorf("git push: %s", mergeCtx.errbuf.String())
+		return "", toPushError(err, mergeCtx.outbuf.String(), mergeCtx.errbuf.String())
 	}
 	mergeCtx.outbuf.Reset()
 	mergeCtx.errbuf.Reset()
@@ -361,6 +353,42 @@ func doMergeAndPush(ctx context.Context, pr *issues_model.PullRequest, doer *use
 	return mergeCommitID, nil
 }
 
+// doMergeStyle merges the tracking branch into the base branch of the temporary repository
+func doMergeStyle(mergeCtx *mergeContext, mergeStyle repo_model.MergeStyle, message string) error {
+	switch mergeStyle {
+	case repo_model.MergeStyleMerge:
+		return doMergeStyleMerge(mergeCtx, message)
+	case repo_model.MergeStyleRebase, repo_model.MergeStyleRebaseMerge:
+		return doMergeStyleRebase(mergeCtx, mergeStyle, message)
+	case repo_model.MergeStyleSquash:
+		return doMergeStyleSquash(mergeCtx, message)
+	case repo_model.MergeStyleFastForwardOnly:
+		return doMergeStyleFastForwardOnly(mergeCtx)
+	default:
+		return models.ErrInvalidMergeStyle{ID: mergeCtx.pr.BaseRepo.ID, Style: mergeStyle}
+	}
+}
+
+// toPushError converts the error of a push to the base repository
+func toPushError(err error, stdout, stderr string) error {
+	if strings.Contains(stderr, "non-fast-forward") {
+		return &git.ErrPushOutOfDate{
+			StdOut: stdout,
+			StdErr: stderr,
+			Err:    err,
+		}
+	} else if strings.Contains(stderr, "! [remote rejected]") {
+		err := &git.ErrPushRejected{
+			StdOut: stdout,
+			StdErr: stderr,
+			Err:    err,
+		}
+		err.GenerateMessage()
+		return err
+	}
+	return fmt.Errorf("git push: %s", stderr)
+}
+
 func commitAndSignNoAuthor(ctx *mergeContext, message string) error {
 	cmdCommit := git.NewCommand(ctx, "commit").AddOptionFormat("--message=%s", message)
 	if ctx.signKeyID == "" {
@@ -420,7 +448,10 @@ func IsUserAllowedToMerge(ctx context.Context, pr *issues_model.PullRequest, p a
 		return false, nil
 	}
 
-	pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, pr.BaseRepoID, pr.BaseBranch)
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return false, err
+	}
+	pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, pr.BaseRepo, pr.BaseBranch)
 	if err != nil {
 		return false, err
 	}
@@ -439,7 +470,7 @@ func CheckPullBranchProtections(ctx context.Context, pr *issues_model.PullReques
 		return nil, fmt.Errorf("LoadBaseRepo: %w", err)
 	}
 
-	pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, pr.BaseRepoID, pr.BaseBranch)
+	pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, pr.BaseRepo, pr.BaseBranch)
 	if err != nil {
 		return nil, fmt.Errorf("LoadProtectedBranch: %v", err)
 	}
@@ -447,7 +478,12 @@ func CheckPullBranchProtections(ctx context.Context, pr *issues_model.PullReques
 		return nil, nil
 	}
 
-	isPass, err := IsPullCommitStatusPass(ctx, pr)
+	return CheckPullBranchProtectionRule(ctx, pr, pb, skipProtectedFilesCheck)
+}
+
+// CheckPullBranchProtectionRule checks whether the pull request satisfies the given protected branch rule
+func CheckPullBranchProtectionRule(ctx context.Context, pr *issues_model.PullRequest, pb *git_model.ProtectedBranch, skipProtectedFilesCheck bool) (protectedBranchRule *git_model.ProtectedBranch, err error) {
+	isPass, err := isPullCommitStatusPassForRule(ctx, pr, pb)
 	if err != nil {
 		return nil, err
 	}
@@ -472,6 +508,13 @@ func CheckPullBranchProtections(ctx context.Context, pr *issues_model.PullReques
 			Reason: "There are official review requests",
 		}
 	}
+	if blocked, err := MergeBlockedByCodeOwners(ctx, pb, pr); err != nil {
+		return nil, err
+	} else if blocked {
+		return pb, models.ErrDisallowedToMerge{
+			Reason: "Not all code owners approved",
+		}
+	}
 
 	if issues_model.MergeBlockedByOutdatedBranch(pb, pr) {
 		return pb, models.ErrDisallowedToMerge{
@@ -44,6 +44,12 @@ func (ctx *mergeContext) RunOpts() *git.RunOpts {
 }
 
 func createTemporaryRepoForMerge(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.User, expectedHeadCommitID string) (mergeCtx *mergeContext, cancel context.CancelFunc, err error) {
+	return createTemporaryRepoForMergeOnto(ctx, pr, doer, expectedHeadCommitID, "")
+}
+
+// createTemporaryRepoForMergeOnto is like createTemporaryRepoForMerge but uses baseCommitID instead of the head of the base branch if it is not empty.
+// The commit must exist in the base repository.
+func createTemporaryRepoForMergeOnto(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.User, expectedHeadCommitID, baseCommitID string) (mergeCtx *mergeContext, cancel context.CancelFunc, err error) {
 	// Clone base repo.
 	prCtx, cancel, err := createTemporaryRepoForPR(ctx, pr)
 	if err != nil {
@@ -72,6 +78,18 @@ func createTemporaryRepoForMerge(ctx context.Context, pr *issues_model.PullReque
 		}
 	}
 
+	if baseCommitID != "" {
+		// the base repository is an alternate of the temporary repository, so the commit is available
+		for _, branch := range []string{baseBranch, "original_" + baseBranch} {
+			if err := git.NewCommand(ctx, "update-ref").AddDynamicArguments(git.BranchPrefix+branch, baseCommitID).
+				Run(mergeCtx.RunOpts()); err != nil {
+				defer cancel()
+				log.Error("%-v Unable to set %s to %s in %s: %v\n%s\n%s", pr, branch, baseCommitID, mergeCtx.tmpBasePath, err, mergeCtx.outbuf.String(), mergeCtx.errbuf.String())
+				return nil, nil, fmt.Errorf("Unable to set %s to %s in tmpBasePath: %w\n%s\n%s", branch, baseCommitID, err, mergeCtx.outbuf.String(), mergeCtx.errbuf.String())
+			}
+		}
+	}
+
 	mergeCtx.outbuf.Reset()
 	mergeCtx.errbuf.Reset()
 	if err := prepareTemporaryRepoForMerge(mergeCtx); err != nil {
@@ -236,6 +254,12 @@ func getDiffTree(ctx context.Context, repoPath, baseBranch, headBranch string, o
 // rebaseTrackingOnToBase checks out the tracking branch as staging and rebases it on to the base branch
 // if there is a conflict it will return a models.ErrRebaseConflicts
 func rebaseTrackingOnToBase(ctx *mergeContext, mergeStyle repo_model.MergeStyle) error {
+	return rebaseTrackingOnto(ctx, mergeStyle, "")
+}
+
+// rebaseTrackingOnto rebases the commits of the tracking branch that are not reachable from upstream
+// on the base branch as the staging branch, an empty upstream stands for the base branch
+func rebaseTrackingOnto(ctx *mergeContext, mergeStyle repo_model.MergeStyle, upstream string) error {
 	// Checkout head branch
 	if err := git.NewCommand(ctx, "checkout", "-b").AddDynamicArguments(stagingBranch, trackingBranch).
 		Run(ctx.RunOpts()); err != nil {
@@ -245,8 +269,13 @@ func rebaseTrackingOnToBase(ctx *mergeContext, mergeStyle repo_model.MergeStyle)
 	ctx.errbuf.Reset()
 
 	// Rebase before merging
-	if err := git.NewCommand(ctx, "rebase").AddDynamicArguments(baseBranch).
-		Run(ctx.RunOpts()); err != nil {
+	rebaseCmd := git.NewCommand(ctx, "rebase")
+	if upstream == "" {
+		rebaseCmd.AddDynamicArguments(baseBranch)
+	} else {
+		rebaseCmd.AddArguments("--onto").AddDynamicArguments(baseBranch, upstream)
+	}
+	if err := rebaseCmd.Run(ctx.RunOpts()); err != nil {
 		// Rebase will leave a REBASE_HEAD file in .git if there is a conflict
 		if _, statErr := os.Stat(filepath.Join(ctx.tmpBasePath, ".git", "REBASE_HEAD")); statErr == nil {
 			var commitSha string
@@ -0,0 +1,119 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/log"
+	repo_module "code.gitea.io/gitea/modules/repository"
+	"code.gitea.io/gitea/modules/setting"
+)
+
+// PushSpeculativeMerge merges the pull request onto parentCommitID with the merge style and force pushes
+// the result to the branch of the base repository, so that status checks and workflows run on it.
+// It returns the commit ID of the speculative merge.
+func PushSpeculativeMerge(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.User, mergeStyle repo_model.MergeStyle, parentCommitID, branch, message string) (string, error) {
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return "", fmt.Errorf("unable to load base repo: %w", err)
+	}
+
+	pullWorkingPool.CheckIn(fmt.Sprint(pr.ID))
+	defer pullWorkingPool.CheckOut(fmt.Sprint(pr.ID))
+
+	mergeCtx, cancel, err := createTemporaryRepoForMergeOnto(ctx, pr, doer, "", parentCommitID)
+	if err != nil {
+		return "", err
+	}
+	defer cancel()
+
+	if err := doMergeStyle(mergeCtx, mergeStyle, message); err != nil {
+		return "", err
+	}
+
+	commitID, err := git.GetFullCommitID(ctx, mergeCtx.tmpBasePath, baseBranch)
+	if err != nil {
+		return "", fmt.Errorf("Failed to get full commit id for the speculative merge: %w", err)
+	}
+
+	if setting.LFS.StartServer {
+		if err := LFSPush(ctx, mergeCtx.tmpBasePath, commitID, parentCommitID, pr); err != nil {
+			return "", err
+		}
+	}
+
+	mergeCtx.env = repo_module.PushingEnvironment(doer, pr.BaseRepo)
+	pushCmd := git.NewCommand(ctx, "push", "--force", "origin").AddDynamicArguments(baseBranch + ":" + git.BranchPrefix + branch)
+	if err := pushCmd.Run(mergeCtx.RunOpts()); err != nil {
+		return "", toPushError(err, mergeCtx.outbuf.String(), mergeCtx.errbuf.String())
+	}
+
+	return commitID, nil
+}
+
+// MergeSpeculativeCommit fast-forwards the base branch of the pull request to commitID, which must be
+// a speculative merge of the pull request built on the current head of the base branch.
+func MergeSpeculativeCommit(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.User, commitID string) error {
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return fmt.Errorf("unable to load base repo: %w", err)
+	} else if err := pr.LoadHeadRepo(ctx); err != nil {
+		return fmt.Errorf("unable to load head repo: %w", err)
+	}
+
+	pullWorkingPool.CheckIn(fmt.Sprint(pr.ID))
+	defer pullWorkingPool.CheckOut(fmt.Sprint(pr.ID))
+
+	defer func() {
+		AddTestPullRequestTask(ctx, doer, pr.BaseRepo.ID, pr.BaseBranch, false, "", "", 0)
+	}()
+
+	prCtx, cancel, err := createTemporaryRepoForPR(ctx, pr)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	headUser := doer
+	if err := pr.HeadRepo.LoadOwner(ctx); err != nil {
+		if !user_model.IsErrUserNotExist(err) {
+			return err
+		}
+		log.Warn("Can't find user: %d for head repository in %-v - defaulting to doer: %s - %v", pr.HeadRepo.OwnerID, pr, doer.Name, err)
+	} else {
+		headUser = pr.HeadRepo.Owner
+	}
+
+	env := repo_module.FullPushingEnvironment(headUser, doer, pr.BaseRepo, pr.BaseRepo.Name, pr.ID)
+	env = append(env, repo_module.EnvPushTrigger+"="+string(repo_module.PushTriggerPRMergeToBase))
+
+	outbuf, errbuf := &strings.Builder{}, &strings.Builder{}
+	if err := git.NewCommand(ctx, "push", "origin").AddDynamicArguments(commitID + ":" + git.BranchPrefix + pr.BaseBranch).
+		Run(&git.RunOpts{Dir: prCtx.tmpBasePath, Env: env, Stdout: outbuf, Stderr: errbuf}); err != nil {
+		return toPushError(err, outbuf.String(), errbuf.String())
+	}
+
+	return afterMerge(ctx, pr.ID, doer, true)
+}
+
+// DeleteSpeculativeBranch deletes a branch created by PushSpeculativeMerge.
+// The deletion is pushed like any other so that the hooks keep the branch list in sync.
+func DeleteSpeculativeBranch(ctx context.Context, repo *repo_model.Repository, doer *user_model.User, branch string) error {
+	repoPath := repo.RepoPath()
+	if !git.IsBranchExist(ctx, repoPath, branch) {
+		return nil
+	}
+
+	outbuf, errbuf := &strings.Builder{}, &strings.Builder{}
+	if err := git.NewCommand(ctx, "push", "--delete").AddDynamicArguments(repoPath, git.BranchPrefix+branch).
+		Run(&git.RunOpts{Dir: repoPath, Env: repo_module.PushingEnvironment(doer, repo), Stdout: outbuf, Stderr: errbuf}); err != nil {
+		return fmt.Errorf("git push --delete %s: %w\n%s", branch, err, errbuf.String())
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	git_model "code.gitea.io/gitea/models/git"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/setting"
+)
+
+// getMergeMessageTemplate returns the template of the merge message of the pull request.
+// The template configured for the base branch in the repository settings wins over
+// the template file of the default branch.
+func getMergeMessageTemplate(ctx context.Context, baseGitRepo *git.Repository, pr *issues_model.PullRequest, mergeStyle repo_model.MergeStyle) (content string, addTrailers, found bool, err error) {
+	tmpl, err := git_model.FindMergeMessageTemplate(ctx, pr.BaseRepoID, pr.BaseBranch, mergeStyle)
+	if err != nil {
+		return "", false, false, err
+	} else if tmpl != nil {
+		return tmpl.Template, tmpl.AddTrailers, true, nil
+	}
+
+	commit, err := baseGitRepo.GetBranchCommit(pr.BaseRepo.DefaultBranch)
+	if err != nil {
+		return "", false, false, err
+	}
+
+	templateFilepathForgejo := fmt.Sprintf(".forgejo/default_merge_message/%s_TEMPLATE.md", strings.ToUpper(string(mergeStyle)))
+	templateFilepathGitea := fmt.Sprintf(".gitea/default_merge_message/%s_TEMPLATE.md", strings.ToUpper(string(mergeStyle)))
+
+	content, err = commit.GetFileContent(templateFilepathForgejo, setting.Repository.PullRequest.DefaultMergeMessageSize)
+	if _, ok := err.(git.ErrNotExist); ok {
+		content, err = commit.GetFileContent(templateFilepathGitea, setting.Repository.PullRequest.DefaultMergeMessageSize)
+	}
+	if err != nil {
+		if !git.IsErrNotExist(err) {
+			return "", false, false, err
+		}
+		return "", false, false, nil
+	}
+	return content, false, true, nil
+}
+
+// getPullCoAuthors returns the signatures of the authors of the commits of the pull request, except its poster
+func getPullCoAuthors(ctx context.Context, baseGitRepo *git.Repository, pr *issues_model.PullRequest) ([]string, error) {
+	if err := pr.LoadIssue(ctx); err != nil {
+		return nil, err
+	}
+	if err := pr.Issue.LoadPoster(ctx); err != nil {
+		return nil, err
+	}
+
+	headCommitID, err := baseGitRepo.GetRefCommitID(pr.GetGitRefName())
+	if err != nil {
+		return nil, fmt.Errorf("GetRefCommitID(%s): %w", pr.GetGitRefName(), err)
+	}
+	headCommit, err := baseGitRepo.GetCommit(headCommitID)
+	if err != nil {
+		return nil, err
+	}
+	mergeBase, err := baseGitRepo.GetCommit(pr.MergeBase)
+	if err != nil {
+		return nil, err
+	}
+	commits, err := baseGitRepo.CommitsBetweenLimit(headCommit, mergeBase, setting.Repository.PullRequest.DefaultMergeMessageCommitsLimit, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	posterSig := pr.Issue.Poster.NewGitSig().String()
+	uniqueAuthors := make(container.Set[string])
+	authors := make([]string, 0, len(commits))
+	// commits list is in reverse chronological order
+	for i := len(commits) - 1; i >= 0; i-- {
+		authorString := commits[i].Author.String()
+		if !uniqueAuthors.Add(authorString) || authorString == posterSig {
+			continue
+		}
+		// Compare the accounts as well, the poster may have used another email address
+		commitUser, _ := user_model.GetUserByEmail(ctx, commits[i].Author.Email)
+		if commitUser == nil || commitUser.ID != pr.Issue.Poster.ID {
+			authors = append(authors, authorString)
+		}
+	}
+	return authors, nil
+}
+
+// appendMergeMessageTrailers appends the Reviewed-by and Co-authored-by trailers to the body of a merge message,
+// except the ones already written by the template
+func appendMergeMessageTrailers(body string, approvers []*user_model.User, coAuthors []string) string {
+	existing := make(container.Set[string])
+	for _, line := range strings.Split(body, "\n") {
+		existing.Add(strings.ToLower(strings.TrimSpace(line)))
+	}
+
+	trailers := make([]string, 0, len(approvers)+len(coAuthors))
+	addTrailer := func(trailer string) {
+		if existing.Add(strings.ToLower(trailer)) {
+			trailers = append(trailers, trailer)
+		}
+	}
+	for _, approver := range approvers {
+		addTrailer("Reviewed-by: " + approver.NewGitSig().String())
+	}
+	for _, coAuthor := range coAuthors {
+		addTrailer("Co-authored-by: " + coAuthor)
+	}
+	if len(trailers) == 0 {
+		return body
+	}
+
+	body = strings.TrimRight(body, "\n")
+	switch {
+	case body == "":
+	case commitMessageTrailersPattern.MatchString(body + "\n"):
+		body += "\n"
+	default:
+		body += "\n\n"
+	}
+	return body + strings.Join(trailers, "\n")
+}
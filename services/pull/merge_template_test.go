@@ -0,0 +1,32 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull
+
+import (
+	"testing"
+
+	user_model "code.gitea.io/gitea/models/user"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppendMergeMessageTrailers(t *testing.T) {
+	approvers := []*user_model.User{
+		{Name: "alice", Email: "alice@example.com"},
+		{Name: "bob", Email: "bob@example.com"},
+	}
+	coAuthors := []string{"Carol <carol@example.com>"}
+
+	assert.Equal(t, "Fixes a bug", appendMergeMessageTrailers("Fixes a bug", nil, nil))
+
+	assert.Equal(t, "Reviewed-by: alice <alice@example.com>\nReviewed-by: bob <bob@example.com>\nCo-authored-by: Carol <carol@example.com>",
+		appendMergeMessageTrailers("", approvers, coAuthors))
+
+	assert.Equal(t, "Fixes a bug\n\nReviewed-by: alice <alice@example.com>\nReviewed-by: bob <bob@example.com>",
+		appendMergeMessageTrailers("Fixes a bug\n", approvers, nil))
+
+	// the trailers written by the template are kept and not repeated
+	assert.Equal(t, "Fixes a bug\n\nReviewed-on: https://example.com/pulls/1\nReviewed-by: alice <alice@example.com>\nReviewed-by: bob <bob@example.com>\nCo-authored-by: Carol <carol@example.com>",
+		appendMergeMessageTrailers("Fixes a bug\n\nReviewed-on: https://example.com/pulls/1\nReviewed-by: alice <alice@example.com>", approvers, coAuthors))
+}
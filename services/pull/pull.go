@@ -75,6 +75,10 @@ func NewPullRequest(ctx context.Context, repo *repo_model.Repository, issue *iss
 	}
 	defer baseGitRepo.Close()
 
+	if err := inferStackParent(ctx, pr); err != nil {
+		return err
+	}
+
 	var reviewNotifers []*issue_service.ReviewRequestNotifier
 	if err := db.WithTx(ctx, func(ctx context.Context) error {
 		if err := issues_model.NewPullRequest(ctx, repo, issue, labelIDs, uuids, pr); err != nil {
@@ -150,6 +154,10 @@ func NewPullRequest(ctx context.Context, repo *repo_model.Repository, issue *iss
 	}
 	baseGitRepo.Close() // close immediately to avoid notifications will open the repository again
 
+	if err := RecordRevision(ctx, issue.Poster, pr, false); err != nil {
+		log.Error("RecordRevision %-v: %v", pr, err)
+	}
+
 	issue_service.ReviewRequestNotify(ctx, issue, issue.Poster, reviewNotifers)
 
 	mentions, err := issues_model.FindAndUpdateIssueMentions(ctx, issue, issue.Poster, issue.Content)
@@ -235,6 +243,11 @@ func ChangeTargetBranch(ctx context.Context, pr *issues_model.PullRequest, doer
 	oldBranch := pr.BaseBranch
 	pr.BaseBranch = targetBranch
 
+	// The pull request is now stacked on the pull request of the new target branch, if there is one
+	if err := inferStackParent(ctx, pr); err != nil {
+		return err
+	}
+
 	// Refresh patch
 	if err := TestPatch(pr); err != nil {
 		return err
@@ -254,7 +267,7 @@ func ChangeTargetBranch(ctx context.Context, pr *issues_model.PullRequest, doer
 	pr.CommitsAhead = divergence.Ahead
 	pr.CommitsBehind = divergence.Behind
 
-	if err := pr.UpdateColsIfNotMerged(ctx, "merge_base", "status", "conflicted_files", "changed_protected_files", "base_branch", "commits_ahead", "commits_behind"); err != nil {
+	if err := pr.UpdateColsIfNotMerged(ctx, "merge_base", "status", "conflicted_files", "changed_protected_files", "base_branch", "commits_ahead", "commits_behind", "parent_pull_id"); err != nil {
 		return err
 	}
 
@@ -274,6 +287,26 @@ func ChangeTargetBranch(ctx context.Context, pr *issues_model.PullRequest, doer
 	return nil
 }
 
+// inferStackParent stacks the pull request on the open pull request whose head branch is its base branch
+func inferStackParent(ctx context.Context, pr *issues_model.PullRequest) error {
+	pr.ParentPullID = 0
+	pr.ParentPull = nil
+
+	parent, err := issues_model.GetStackParentCandidate(ctx, pr.BaseRepoID, pr.BaseBranch)
+	if err != nil || parent == nil {
+		return err
+	}
+	if err := issues_model.CheckStackParent(ctx, pr, parent); err != nil {
+		if issues_model.IsErrInvalidStackParent(err) {
+			return nil
+		}
+		return err
+	}
+	pr.ParentPullID = parent.ID
+	pr.ParentPull = parent
+	return nil
+}
+
 func checkForInvalidation(ctx context.Context, requests issues_model.PullRequestList, repoID int64, doer *user_model.User, branch string) error {
 	repo, err := repo_model.GetRepositoryByID(ctx, repoID)
 	if err != nil {
@@ -344,6 +377,9 @@ func TestPullRequest(ctx context.Context, doer *user_model.User, repoID, olderTh
 		if err == nil && comment != nil {
 			notify_service.PullRequestPushCommits(ctx, doer, pr, comment)
 		}
+		if err := RecordRevision(ctx, doer, pr, comment != nil && comment.IsForcePush); err != nil {
+			log.Error("RecordRevision %-v: %v", pr, err)
+		}
 	}
 
 	if isSync {
@@ -369,7 +405,7 @@ func TestPullRequest(ctx context.Context, doer *user_model.User, repoID, olderTh
 						}
 
 						// dismiss all approval reviews if protected branch rule item enabled.
-						pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, pr.BaseRepoID, pr.BaseBranch)
+						pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, pr.BaseRepo, pr.BaseBranch)
 						if err != nil {
 							log.Error("GetFirstMatchProtectedBranchRule: %v", err)
 						}
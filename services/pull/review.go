@@ -14,6 +14,7 @@ import (
 
 	"code.gitea.io/gitea/models/db"
 	issues_model "code.gitea.io/gitea/models/issues"
+	pull_model "code.gitea.io/gitea/models/pull"
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/git"
@@ -321,6 +322,15 @@ func SubmitReview(ctx context.Context, doer *user_model.User, gitRepo *git.Repos
 		return nil, nil, err
 	}
 
+	// remember the revision the reviewer has seen, to show them what changed since
+	if revision, err := pull_model.GetRevisionByCommitID(ctx, pr.ID, commitID); err != nil {
+		return nil, nil, err
+	} else if revision != nil {
+		if err := pull_model.SetReviewedRevision(ctx, doer.ID, pr.ID, revision.Index); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	mentions, err := issues_model.FindAndUpdateIssueMentions(ctx, issue, doer, comm.Content)
 	if err != nil {
 		return nil, nil, err
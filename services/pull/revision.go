@@ -0,0 +1,62 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull
+
+import (
+	"context"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	pull_model "code.gitea.io/gitea/models/pull"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/gitrepo"
+)
+
+// RecordRevision stores the current head commit of the pull request as a new revision, unless it is
+// already the latest one, and keeps the commit reachable in the base repository after later force pushes.
+func RecordRevision(ctx context.Context, pusher *user_model.User, pr *issues_model.PullRequest, isForcePush bool) error {
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return err
+	}
+	gitRepo, err := gitrepo.OpenRepository(ctx, pr.BaseRepo)
+	if err != nil {
+		return err
+	}
+	defer gitRepo.Close()
+
+	headCommitID, err := gitRepo.GetRefCommitID(pr.GetGitRefName())
+	if err != nil {
+		return err
+	}
+
+	latest, err := pull_model.GetLatestRevision(ctx, pr.ID)
+	if err != nil {
+		return err
+	} else if latest != nil && latest.CommitID == headCommitID {
+		return nil
+	}
+
+	mergeBase, _, err := gitRepo.GetMergeBase("", git.BranchPrefix+pr.BaseBranch, headCommitID)
+	if err != nil {
+		return err
+	}
+
+	revision := &pull_model.Revision{
+		PullID:      pr.ID,
+		CommitID:    headCommitID,
+		MergeBase:   mergeBase,
+		IsForcePush: isForcePush,
+	}
+	if pusher != nil {
+		revision.PusherID = pusher.ID
+	}
+	if err := pull_model.AddRevision(ctx, revision); err != nil {
+		return err
+	}
+
+	_, _, err = git.NewCommand(ctx, "update-ref").
+		AddDynamicArguments(pull_model.RevisionRefName(pr.Index, revision.Index), headCommitID).
+		RunStdString(&git.RunOpts{Dir: pr.BaseRepo.RepoPath()})
+	return err
+}
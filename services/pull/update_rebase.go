@@ -8,10 +8,13 @@ import (
 	"fmt"
 	"strings"
 
+	"code.gitea.io/gitea/models"
+	git_model "code.gitea.io/gitea/models/git"
 	issues_model "code.gitea.io/gitea/models/issues"
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/gitrepo"
 	"code.gitea.io/gitea/modules/log"
 	repo_module "code.gitea.io/gitea/modules/repository"
 	"code.gitea.io/gitea/modules/setting"
@@ -19,6 +22,12 @@ import (
 
 // updateHeadByRebaseOnToBase handles updating a PR's head branch by rebasing it on the PR current base branch
 func updateHeadByRebaseOnToBase(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.User) error {
+	return updateHeadByRebaseOnto(ctx, pr, doer, "")
+}
+
+// updateHeadByRebaseOnto rebases the commits of the PR's head branch that are not reachable from upstream
+// on the PR current base branch. If upstream is empty, the commits not reachable from the base branch are rebased.
+func updateHeadByRebaseOnto(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.User, upstream string) error {
 	// "Clone" base repo and add the cache headers for the head repo and branch
 	mergeCtx, cancel, err := createTemporaryRepoForMerge(ctx, pr, doer, "")
 	if err != nil {
@@ -31,7 +40,7 @@ func updateHeadByRebaseOnToBase(ctx context.Context, pr *issues_model.PullReques
 	oldMergeBase = strings.TrimSpace(oldMergeBase)
 
 	// Rebase the tracking branch on to the base as the staging branch
-	if err := rebaseTrackingOnToBase(mergeCtx, repo_model.MergeStyleRebaseUpdate); err != nil {
+	if err := rebaseTrackingOnto(mergeCtx, repo_model.MergeStyleRebaseUpdate, upstream); err != nil {
 		return err
 	}
 
@@ -105,3 +114,87 @@ func updateHeadByRebaseOnToBase(ctx context.Context, pr *issues_model.PullReques
 
 	return nil
 }
+
+// UpdateStackedPullRequests retargets the pull requests stacked on a merged pull request to its base branch.
+// The commits of the merged pull request are dropped from the stacked pull requests by rebasing them,
+// so that their diff does not contain them even if the merged pull request was squashed or rebased.
+func UpdateStackedPullRequests(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) error {
+	children, err := issues_model.GetStackedPullRequests(ctx, pr.ID)
+	if err != nil {
+		return err
+	} else if len(children) == 0 {
+		return nil
+	}
+	if err := children.LoadAttributes(ctx); err != nil {
+		return err
+	}
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return err
+	}
+
+	gitRepo, err := gitrepo.OpenRepository(ctx, pr.BaseRepo)
+	if err != nil {
+		return err
+	}
+	defer gitRepo.Close()
+
+	// the head of the merged pull request, its commits are the ones the stacked pull requests have to drop
+	oldHeadCommitID, err := gitRepo.GetRefCommitID(pr.GetGitRefName())
+	if err != nil {
+		return err
+	}
+
+	var errs errlist
+	for _, child := range children {
+		if err := child.Issue.LoadRepo(ctx); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := ChangeTargetBranch(ctx, child, doer, pr.BaseBranch); err != nil {
+			if !issues_model.IsErrPullRequestAlreadyExists(err) && !git_model.IsErrBranchesEqual(err) {
+				errs = append(errs, err)
+			}
+			continue
+		}
+
+		// the head branch of a pull request from a fork is left to its owner
+		if child.HeadRepoID != child.BaseRepoID {
+			continue
+		}
+
+		// nothing to drop if the commits of the merged pull request are part of the base branch
+		newBaseCommitID, err := gitRepo.GetBranchCommitID(pr.BaseBranch)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := git.NewCommand(ctx, "merge-base", "--is-ancestor").AddDynamicArguments(oldHeadCommitID, newBaseCommitID).
+			Run(&git.RunOpts{Dir: pr.BaseRepo.RepoPath()}); err == nil {
+			continue
+		}
+
+		if err := rebaseStackedPullRequest(ctx, child, doer, oldHeadCommitID); err != nil {
+			if models.IsErrRebaseConflicts(err) {
+				log.Info("%-v cannot be rebased after %-v was merged: %v", child, pr, err)
+				continue
+			}
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func rebaseStackedPullRequest(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.User, upstream string) error {
+	pullWorkingPool.CheckIn(fmt.Sprint(pr.ID))
+	defer pullWorkingPool.CheckOut(fmt.Sprint(pr.ID))
+
+	defer func() {
+		AddTestPullRequestTask(ctx, doer, pr.BaseRepo.ID, pr.BaseBranch, false, "", "", 0)
+	}()
+
+	return updateHeadByRebaseOnto(ctx, pr, doer, upstream)
+}
@@ -0,0 +1,234 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pushrule
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	git_model "code.gitea.io/gitea/models/git"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/base"
+	"code.gitea.io/gitea/modules/git"
+)
+
+// ErrViolation represents a push rejected by a push rule
+type ErrViolation struct {
+	CommitID string
+	Reason   string
+}
+
+// IsErrViolation checks if an error is a ErrViolation.
+func IsErrViolation(err error) bool {
+	_, ok := err.(ErrViolation)
+	return ok
+}
+
+func (err ErrViolation) Error() string {
+	return fmt.Sprintf("commit %s: %s", base.ShortSha(err.CommitID), err.Reason)
+}
+
+// checker holds the state of the check of a push
+type checker struct {
+	ctx   context.Context
+	rules []*git_model.PushRule
+	opts  *git.RunOpts
+
+	checkedEmails map[string]bool
+	blobSizes     map[string]int64
+}
+
+// CheckPush checks the commits received by a push against the push rules of the repository.
+// Only the commits which are not reachable from the existing references are checked.
+// env is the environment of the pre-receive hook, so that the quarantined objects are found.
+func CheckPush(ctx context.Context, repo *repo_model.Repository, rules []*git_model.PushRule, newCommitID string, env []string) error {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	c := &checker{
+		ctx:           ctx,
+		rules:         rules,
+		opts:          &git.RunOpts{Dir: repo.RepoPath(), Env: env},
+		checkedEmails: map[string]bool{},
+		blobSizes:     map[string]int64{},
+	}
+
+	stdout, _, err := git.NewCommand(ctx, "rev-list").AddDynamicArguments(newCommitID).AddArguments("--not", "--all").RunStdString(c.opts)
+	if err != nil {
+		return fmt.Errorf("git rev-list: %w", err)
+	}
+	for _, commitID := range strings.Fields(stdout) {
+		if err := c.checkCommit(commitID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *checker) needsFiles() bool {
+	for _, rule := range c.rules {
+		if rule.MaxFileSize > 0 || rule.DetectSecrets || len(rule.GetForbiddenPathPatterns()) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *checker) checkCommit(commitID string) error {
+	stdout, _, runErr := git.NewCommand(c.ctx, "cat-file", "commit").AddDynamicArguments(commitID).RunStdBytes(c.opts)
+	if runErr != nil {
+		return fmt.Errorf("git cat-file: %w", runErr)
+	}
+	objectID, err := git.NewIDFromString(commitID)
+	if err != nil {
+		return err
+	}
+	commit, err := git.CommitFromReader(nil, objectID, bytes.NewReader(stdout))
+	if err != nil {
+		return fmt.Errorf("CommitFromReader: %w", err)
+	}
+
+	for _, rule := range c.rules {
+		if !rule.MatchCommitMessage(commit.CommitMessage) {
+			return ErrViolation{
+				CommitID: commitID,
+				Reason:   fmt.Sprintf("the commit message does not match the required pattern %q", rule.CommitMessagePattern),
+			}
+		}
+		if rule.RequireVerifiedEmail {
+			for _, sig := range []*git.Signature{commit.Author, commit.Committer} {
+				if err := c.checkEmail(commitID, sig); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if !c.needsFiles() {
+		return nil
+	}
+	return c.checkFiles(commitID)
+}
+
+func (c *checker) checkEmail(commitID string, sig *git.Signature) error {
+	if sig == nil {
+		return nil
+	}
+	verified, ok := c.checkedEmails[sig.Email]
+	if !ok {
+		var err error
+		if verified, err = isVerifiedEmail(c.ctx, sig.Email); err != nil {
+			return err
+		}
+		c.checkedEmails[sig.Email] = verified
+	}
+	if !verified {
+		return ErrViolation{
+			CommitID: commitID,
+			Reason:   fmt.Sprintf("%s is not a verified email address of a user", sig.Email),
+		}
+	}
+	return nil
+}
+
+// isVerifiedEmail checks if the email is an activated email address of a user, or the no-reply address of a user
+func isVerifiedEmail(ctx context.Context, email string) (bool, error) {
+	emailAddress, err := user_model.GetEmailAddressByEmail(ctx, email)
+	if err == nil {
+		return emailAddress.IsActivated, nil
+	} else if !user_model.IsErrEmailAddressNotExist(err) {
+		return false, err
+	}
+
+	if _, err := user_model.GetUserByEmail(ctx, email); err != nil {
+		if user_model.IsErrUserNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// checkFiles checks the files added or modified by the commit. The merge commits are skipped,
+// their changes come from their parents.
+func (c *checker) checkFiles(commitID string) error {
+	stdout, _, err := git.NewCommand(c.ctx, "diff-tree", "-r", "--root", "--no-commit-id", "--no-renames", "-z").AddDynamicArguments(commitID).RunStdString(c.opts)
+	if err != nil {
+		return fmt.Errorf("git diff-tree: %w", err)
+	}
+
+	fields := strings.Split(stdout, "\x00")
+	for i := 0; i+1 < len(fields); i += 2 {
+		// :<old mode> <new mode> <old blob> <new blob> <status>
+		info := strings.Fields(strings.TrimPrefix(fields[i], ":"))
+		path := fields[i+1]
+		if len(info) != 5 || info[4] == "D" || info[1] == "160000" {
+			continue
+		}
+		if err := c.checkFile(commitID, path, info[3]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *checker) checkFile(commitID, path, blobID string) error {
+	size, err := c.blobSize(blobID)
+	if err != nil {
+		return err
+	}
+
+	detectSecrets := false
+	for _, rule := range c.rules {
+		if rule.IsPathForbidden(path) {
+			return ErrViolation{
+				CommitID: commitID,
+				Reason:   fmt.Sprintf("the path %s is forbidden", path),
+			}
+		}
+		if rule.MaxFileSize > 0 && size > rule.MaxFileSize {
+			return ErrViolation{
+				CommitID: commitID,
+				Reason:   fmt.Sprintf("the file %s (%s) exceeds the maximum file size of %s", path, base.FileSize(size), base.FileSize(rule.MaxFileSize)),
+			}
+		}
+		detectSecrets = detectSecrets || rule.DetectSecrets
+	}
+
+	if !detectSecrets || size > maxSecretScanSize {
+		return nil
+	}
+	content, _, err := git.NewCommand(c.ctx, "cat-file", "blob").AddDynamicArguments(blobID).RunStdBytes(c.opts)
+	if err != nil {
+		return fmt.Errorf("git cat-file: %w", err)
+	}
+	if kind := DetectSecret(content); kind != "" {
+		return ErrViolation{
+			CommitID: commitID,
+			Reason:   fmt.Sprintf("the file %s seems to contain a secret (%s)", path, kind),
+		}
+	}
+	return nil
+}
+
+func (c *checker) blobSize(blobID string) (int64, error) {
+	if size, ok := c.blobSizes[blobID]; ok {
+		return size, nil
+	}
+	stdout, _, runErr := git.NewCommand(c.ctx, "cat-file", "-s").AddDynamicArguments(blobID).RunStdString(c.opts)
+	if runErr != nil {
+		return 0, fmt.Errorf("git cat-file: %w", runErr)
+	}
+	size, err := strconv.ParseInt(strings.TrimSpace(stdout), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	c.blobSizes[blobID] = size
+	return size, nil
+}
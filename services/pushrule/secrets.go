@@ -0,0 +1,42 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pushrule
+
+import (
+	"bytes"
+	"regexp"
+)
+
+// maxSecretScanSize is the size above which the files are not scanned for secrets
+const maxSecretScanSize = 1024 * 1024
+
+type secretPattern struct {
+	name    string
+	pattern *regexp.Regexp
+}
+
+// secretPatterns are the patterns of the common secrets, they are chosen to have few false positives
+var secretPatterns = []secretPattern{
+	{"private key", regexp.MustCompile(`-----BEGIN ((RSA|DSA|EC|OPENSSH|PGP|ENCRYPTED) )?PRIVATE KEY( BLOCK)?-----`)},
+	{"AWS access key", regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"GitHub token", regexp.MustCompile(`\b(gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})\b`)},
+	{"GitLab token", regexp.MustCompile(`\bglpat-[A-Za-z0-9_\-]{20,}`)},
+	{"Slack token", regexp.MustCompile(`\bxox[baprs]-[A-Za-z0-9\-]{10,}`)},
+	{"Google API key", regexp.MustCompile(`\bAIza[0-9A-Za-z_\-]{35}\b`)},
+	{"Stripe secret key", regexp.MustCompile(`\b[sr]k_live_[0-9A-Za-z]{24,}\b`)},
+}
+
+// DetectSecret returns the kind of the first secret found in the content, empty if there is none.
+// Binary content is not scanned.
+func DetectSecret(content []byte) string {
+	if bytes.IndexByte(content[:min(len(content), 8000)], 0) != -1 {
+		return ""
+	}
+	for _, p := range secretPatterns {
+		if p.pattern.Match(content) {
+			return p.name
+		}
+	}
+	return ""
+}
@@ -421,7 +421,7 @@ func DeleteBranch(ctx context.Context, doer *user_model.User, repo *repo_model.R
 		return ErrBranchIsDefault
 	}
 
-	isProtected, err := git_model.IsBranchProtected(ctx, repo.ID, branchName)
+	isProtected, err := git_model.IsBranchProtected(ctx, repo, branchName)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,142 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"context"
+	"strings"
+
+	git_model "code.gitea.io/gitea/models/git"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/references"
+	"code.gitea.io/gitea/modules/util"
+	notify_service "code.gitea.io/gitea/services/notify"
+)
+
+// CreateCommitCommentOptions are the options to comment on a commit
+type CreateCommitCommentOptions struct {
+	CommitSHA string
+	// TreePath is the file to comment on, empty to comment on the whole commit
+	TreePath string
+	// Line is the line to comment on, negative for a line of the parent version, 0 for the whole file
+	Line    int64
+	Content string
+}
+
+// CreateCommitComment comments on a commit of a repository and notifies the commit author and the mentioned users
+func CreateCommitComment(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, gitRepo *git.Repository, opts CreateCommitCommentOptions) (*git_model.CommitComment, error) {
+	if user_model.IsBlocked(ctx, repo.OwnerID, doer.ID) {
+		return nil, user_model.ErrBlockedByUser
+	}
+
+	commit, err := gitRepo.GetCommit(opts.CommitSHA)
+	if err != nil {
+		return nil, err
+	}
+
+	treePath := strings.Trim(opts.TreePath, "/")
+	if treePath == "" && opts.Line != 0 {
+		return nil, util.NewInvalidArgumentErrorf("a line comment needs a file")
+	}
+	if treePath != "" {
+		// the commented version of the file is the one of the parent for the negative lines
+		fileCommit := commit
+		if opts.Line < 0 {
+			if fileCommit, err = commit.Parent(0); err != nil {
+				return nil, util.NewInvalidArgumentErrorf("commit %s has no parent", commit.ID)
+			}
+		}
+		if _, err := fileCommit.GetTreeEntryByPath(treePath); err != nil {
+			if git.IsErrNotExist(err) {
+				return nil, util.NewInvalidArgumentErrorf("file %s does not exist in commit %s", treePath, fileCommit.ID)
+			}
+			return nil, err
+		}
+	}
+
+	comment := &git_model.CommitComment{
+		RepoID:    repo.ID,
+		CommitSHA: commit.ID.String(),
+		PosterID:  doer.ID,
+		Poster:    doer,
+		TreePath:  treePath,
+		Line:      opts.Line,
+		Content:   opts.Content,
+	}
+	if err := git_model.CreateCommitComment(ctx, comment); err != nil {
+		return nil, err
+	}
+
+	mentions, err := findCommitCommentMentions(ctx, doer, repo, comment.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	notify_service.CreateCommitComment(ctx, doer, repo, comment, mentions)
+
+	return comment, nil
+}
+
+// UpdateCommitComment updates the content of a commit comment
+func UpdateCommitComment(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, comment *git_model.CommitComment, oldContent string) error {
+	if err := git_model.UpdateCommitCommentContent(ctx, comment); err != nil {
+		return err
+	}
+
+	notify_service.UpdateCommitComment(ctx, doer, repo, comment, oldContent)
+
+	return nil
+}
+
+// DeleteCommitComment deletes a commit comment
+func DeleteCommitComment(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, comment *git_model.CommitComment) error {
+	if err := git_model.DeleteCommitComment(ctx, comment); err != nil {
+		return err
+	}
+
+	notify_service.DeleteCommitComment(ctx, doer, repo, comment)
+
+	return nil
+}
+
+// findCommitCommentMentions returns the users mentioned in a commit comment who can read the code of the repository
+func findCommitCommentMentions(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, content string) ([]*user_model.User, error) {
+	names := references.FindAllMentionsMarkdown(content)
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	seen := make(container.Set[string], len(names))
+	mentions := make([]*user_model.User, 0, len(names))
+	for _, name := range names {
+		if !seen.Add(strings.ToLower(name)) || strings.EqualFold(name, doer.Name) {
+			continue
+		}
+		u, err := user_model.GetUserByName(ctx, name)
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		if !u.IsActive || u.ProhibitLogin || u.IsOrganization() || user_model.IsBlocked(ctx, u.ID, doer.ID) {
+			continue
+		}
+		perm, err := access_model.GetUserRepoPermission(ctx, repo, u)
+		if err != nil {
+			log.Error("GetUserRepoPermission [%d]: %v", u.ID, err)
+			continue
+		}
+		if perm.CanRead(unit.TypeCode) {
+			mentions = append(mentions, u)
+		}
+	}
+	return mentions, nil
+}
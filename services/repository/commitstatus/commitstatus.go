@@ -20,6 +20,7 @@ import (
 	"code.gitea.io/gitea/modules/log"
 	api "code.gitea.io/gitea/modules/structs"
 	"code.gitea.io/gitea/services/automerge"
+	"code.gitea.io/gitea/services/mergequeue"
 )
 
 func getCacheKey(repoID int64, brancheName string) string {
@@ -122,6 +123,12 @@ func CreateCommitStatus(ctx context.Context, repo *repo_model.Repository, creato
 		}
 	}
 
+	if status.State.IsSuccess() || status.State.IsFailure() || status.State.IsError() {
+		if err := mergequeue.StartCheckBySHA(ctx, sha, repo); err != nil {
+			return fmt.Errorf("StartCheckBySHA[repo_id: %d, sha: %s]: %w", repo.ID, sha, err)
+		}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,212 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package files
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// ErrSuggestionNotApplicable represents a "SuggestionNotApplicable" kind of error.
+type ErrSuggestionNotApplicable struct {
+	CommentID int64
+	Reason    string
+}
+
+// IsErrSuggestionNotApplicable checks if an error is a ErrSuggestionNotApplicable.
+func IsErrSuggestionNotApplicable(err error) bool {
+	_, ok := err.(ErrSuggestionNotApplicable)
+	return ok
+}
+
+func (err ErrSuggestionNotApplicable) Error() string {
+	return fmt.Sprintf("suggestion cannot be applied [comment_id: %d]: %s", err.CommentID, err.Reason)
+}
+
+func (err ErrSuggestionNotApplicable) Unwrap() error {
+	return util.ErrInvalidArgument
+}
+
+// ErrSuggestionConversationsNotResolved represents a "SuggestionConversationsNotResolved" kind of error,
+// the suggestions have been committed but their conversations could not be resolved.
+type ErrSuggestionConversationsNotResolved struct {
+	Err error
+}
+
+// IsErrSuggestionConversationsNotResolved checks if an error is a ErrSuggestionConversationsNotResolved.
+func IsErrSuggestionConversationsNotResolved(err error) bool {
+	_, ok := err.(ErrSuggestionConversationsNotResolved)
+	return ok
+}
+
+func (err ErrSuggestionConversationsNotResolved) Error() string {
+	return fmt.Sprintf("suggestions applied but their conversations could not be resolved: %v", err.Err)
+}
+
+func (err ErrSuggestionConversationsNotResolved) Unwrap() error {
+	return err.Err
+}
+
+// ApplySuggestions applies the suggestions of code comments to the head branch of the pull request
+// with a single commit and resolves their conversations once the commit succeeded.
+// If the conversations cannot be resolved, the response of the commit is returned together with
+// an ErrSuggestionConversationsNotResolved.
+// The authors of the suggestions are added as co-authors of the commit.
+func ApplySuggestions(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest, comments []*issues_model.Comment, message string) (*structs.FilesResponse, error) {
+	if len(comments) == 0 {
+		return nil, util.NewInvalidArgumentErrorf("no suggestion to apply")
+	}
+	if err := pr.LoadIssue(ctx); err != nil {
+		return nil, err
+	}
+	if pr.HasMerged || pr.Issue.IsClosed {
+		return nil, util.NewInvalidArgumentErrorf("pull request is closed")
+	}
+	if err := pr.LoadHeadRepo(ctx); err != nil {
+		return nil, err
+	}
+
+	commentsByPath := make(map[string][]*issues_model.Comment)
+	for _, comment := range comments {
+		if err := comment.LoadReview(ctx); err != nil {
+			return nil, err
+		}
+		switch {
+		case comment.IssueID != pr.IssueID || comment.Suggestion() == nil:
+			return nil, ErrSuggestionNotApplicable{CommentID: comment.ID, Reason: "the comment has no suggestion"}
+		case comment.Review == nil || comment.Review.Type == issues_model.ReviewTypePending:
+			return nil, ErrSuggestionNotApplicable{CommentID: comment.ID, Reason: "the review is pending"}
+		case comment.Invalidated:
+			return nil, ErrSuggestionNotApplicable{CommentID: comment.ID, Reason: "the comment is outdated"}
+		case comment.ResolveDoerID != 0:
+			return nil, ErrSuggestionNotApplicable{CommentID: comment.ID, Reason: "the conversation is resolved"}
+		}
+		commentsByPath[comment.TreePath] = append(commentsByPath[comment.TreePath], comment)
+	}
+
+	gitRepo, err := gitrepo.OpenRepository(ctx, pr.HeadRepo)
+	if err != nil {
+		return nil, err
+	}
+	defer gitRepo.Close()
+
+	commit, err := gitRepo.GetBranchCommit(pr.HeadBranch)
+	if err != nil {
+		return nil, err
+	}
+
+	treePaths := make([]string, 0, len(commentsByPath))
+	for treePath := range commentsByPath {
+		treePaths = append(treePaths, treePath)
+	}
+	sort.Strings(treePaths)
+
+	files := make([]*ChangeRepoFile, 0, len(treePaths))
+	for _, treePath := range treePaths {
+		entry, err := commit.GetTreeEntryByPath(treePath)
+		if err != nil {
+			return nil, err
+		}
+		content, err := entry.Blob().GetBlobContent(entry.Blob().Size())
+		if err != nil {
+			return nil, err
+		}
+
+		content, err = applySuggestionsToContent(content, commentsByPath[treePath])
+		if err != nil {
+			return nil, err
+		}
+
+		files = append(files, &ChangeRepoFile{
+			Operation:     "update",
+			TreePath:      treePath,
+			ContentReader: strings.NewReader(content),
+			SHA:           entry.ID.String(),
+		})
+	}
+
+	if strings.TrimSpace(message) == "" {
+		message = "Apply suggestions from code review"
+	}
+	message = strings.TrimRight(message, "\n") + "\n"
+	coAuthors := make(container.Set[int64])
+	for _, comment := range comments {
+		if err := comment.LoadPoster(ctx); err != nil {
+			return nil, err
+		}
+		if comment.PosterID == doer.ID || comment.Poster.IsGhost() || !coAuthors.Add(comment.PosterID) {
+			continue
+		}
+		if len(coAuthors) == 1 {
+			message += "\n"
+		}
+		message += "Co-authored-by: " + comment.Poster.NewGitSig().String() + "\n"
+	}
+
+	filesResponse, err := ChangeRepoFiles(ctx, pr.HeadRepo, doer, &ChangeRepoFilesOptions{
+		LastCommitID: commit.ID.String(),
+		OldBranch:    pr.HeadBranch,
+		NewBranch:    pr.HeadBranch,
+		Message:      message,
+		Files:        files,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		for _, comment := range comments {
+			if err := issues_model.MarkConversation(ctx, comment, doer, true); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return filesResponse, ErrSuggestionConversationsNotResolved{Err: err}
+	}
+	return filesResponse, nil
+}
+
+// applySuggestionsToContent replaces the commented lines of the content by the suggestions of the comments
+func applySuggestionsToContent(content string, comments []*issues_model.Comment) (string, error) {
+	// apply the suggestions from the bottom so that the line numbers of the others do not change
+	comments = slices.Clone(comments)
+	sort.Slice(comments, func(i, j int) bool {
+		return comments[i].Line > comments[j].Line
+	})
+
+	lines := strings.Split(content, "\n")
+	for i, comment := range comments {
+		if i > 0 && comments[i-1].Line == comment.Line {
+			return "", ErrSuggestionNotApplicable{CommentID: comment.ID, Reason: "another suggestion changes the same line"}
+		}
+
+		suggestion := comment.Suggestion()
+		idx := int(comment.Line) - 1
+		if idx >= len(lines) || strings.TrimSuffix(lines[idx], "\r") != suggestion.OldLine {
+			return "", ErrSuggestionNotApplicable{CommentID: comment.ID, Reason: "the line has changed"}
+		}
+
+		newLines := suggestion.NewLines
+		if strings.HasSuffix(lines[idx], "\r") {
+			newLines = make([]string, 0, len(suggestion.NewLines))
+			for _, line := range suggestion.NewLines {
+				newLines = append(newLines, line+"\r")
+			}
+		}
+		lines = slices.Replace(lines, idx, idx+1, newLines...)
+	}
+	return strings.Join(lines, "\n"), nil
+}
@@ -0,0 +1,60 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package files
+
+import (
+	"testing"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSuggestionComment(id, line int64, oldLine, suggestion string) *issues_model.Comment {
+	return &issues_model.Comment{
+		ID:      id,
+		Type:    issues_model.CommentTypeCode,
+		Line:    line,
+		Patch:   "@@ -1,1 +1,1 @@\n+" + oldLine,
+		Content: "```suggestion\n" + suggestion + "```",
+	}
+}
+
+func TestApplySuggestionsToContent(t *testing.T) {
+	content := "one\ntwo\nthree\nfour\n"
+
+	t.Run("Batch", func(t *testing.T) {
+		result, err := applySuggestionsToContent(content, []*issues_model.Comment{
+			newSuggestionComment(1, 1, "one", "1\n"),
+			newSuggestionComment(2, 3, "three", "3\n3.5\n"),
+			newSuggestionComment(3, 4, "four", ""),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "1\ntwo\n3\n3.5\n", result)
+	})
+
+	t.Run("CRLF", func(t *testing.T) {
+		result, err := applySuggestionsToContent("one\r\ntwo\r\n", []*issues_model.Comment{
+			newSuggestionComment(1, 2, "two", "2\n2.5\n"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "one\r\n2\r\n2.5\r\n", result)
+	})
+
+	t.Run("Changed", func(t *testing.T) {
+		_, err := applySuggestionsToContent(content, []*issues_model.Comment{
+			newSuggestionComment(1, 2, "deux", "2\n"),
+		})
+		assert.True(t, IsErrSuggestionNotApplicable(err))
+	})
+
+	t.Run("SameLine", func(t *testing.T) {
+		_, err := applySuggestionsToContent(content, []*issues_model.Comment{
+			newSuggestionComment(1, 2, "two", "2\n"),
+			newSuggestionComment(2, 2, "two", "II\n"),
+		})
+		assert.True(t, IsErrSuggestionNotApplicable(err))
+	})
+}
@@ -239,6 +239,14 @@ func transferOwnership(ctx context.Context, doer *user_model.User, newOwnerName
 		}
 	}
 
+	if err := issues_model.TransferRepoCustomFields(ctx, repo.ID, newOwner.ID); err != nil {
+		return fmt.Errorf("TransferRepoCustomFields: %w", err)
+	}
+
+	if err := issues_model.TransferRepoSubIssues(ctx, repo.ID); err != nil {
+		return fmt.Errorf("TransferRepoSubIssues: %w", err)
+	}
+
 	// Rename remote repository to new path and delete local copy.
 	dir := user_model.UserPath(newOwner.Name)
 
@@ -0,0 +1,102 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package task
+
+import (
+	"context"
+	"fmt"
+
+	admin_model "code.gitea.io/gitea/models/admin"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/timeutil"
+	issue_service "code.gitea.io/gitea/services/issue"
+)
+
+// bulkEditIssuesPayload is the payload of a task editing issues
+type bulkEditIssuesPayload struct {
+	IssueIDs []int64
+	Options  issue_service.BulkEditOptions
+}
+
+// BulkEditIssues adds to the tasks the edition of issues of a repository
+func BulkEditIssues(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, issueIDs []int64, opts issue_service.BulkEditOptions) (*admin_model.Task, error) {
+	task, err := CreateBulkEditIssuesTask(ctx, doer, repo, issueIDs, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return task, taskQueue.Push(task)
+}
+
+// CreateBulkEditIssuesTask creates a task editing issues of a repository
+func CreateBulkEditIssuesTask(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, issueIDs []int64, opts issue_service.BulkEditOptions) (*admin_model.Task, error) {
+	bs, err := json.Marshal(&bulkEditIssuesPayload{IssueIDs: issueIDs, Options: opts})
+	if err != nil {
+		return nil, err
+	}
+
+	task := &admin_model.Task{
+		DoerID:         doer.ID,
+		OwnerID:        repo.OwnerID,
+		RepoID:         repo.ID,
+		Type:           structs.TaskTypeBulkEditIssues,
+		Status:         structs.TaskStatusQueued,
+		PayloadContent: string(bs),
+		ProgressTotal:  int64(len(issueIDs)),
+	}
+	if err := admin_model.CreateTask(ctx, task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func runBulkEditIssuesTask(ctx context.Context, t *admin_model.Task) (err error) {
+	defer func(ctx context.Context) {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("PANIC whilst trying to do bulk edit task: %v", e)
+			log.Critical("PANIC during runBulkEditIssuesTask[%d] by DoerID[%d] to RepoID[%d]: %v\nStacktrace: %v", t.ID, t.DoerID, t.RepoID, e, log.Stack(2))
+		}
+
+		t.EndTime = timeutil.TimeStampNow()
+		if err == nil {
+			t.Status = structs.TaskStatusFinished
+		} else {
+			log.Error("runBulkEditIssuesTask[%d] by DoerID[%d] to RepoID[%d] failed: %v", t.ID, t.DoerID, t.RepoID, err)
+			t.Status = structs.TaskStatusFailed
+			t.Message = err.Error()
+		}
+		if err := t.UpdateCols(ctx, "status", "message", "end_time"); err != nil {
+			log.Error("Task UpdateCols failed: %v", err)
+		}
+	}(graceful.GetManager().ShutdownContext()) // even if the parent ctx is canceled, this defer-function still needs to update the task record in database
+
+	if err := t.LoadRepo(ctx); err != nil {
+		return err
+	}
+	if err := t.LoadDoer(ctx); err != nil {
+		return err
+	}
+	var payload bulkEditIssuesPayload
+	if err := json.Unmarshal([]byte(t.PayloadContent), &payload); err != nil {
+		return err
+	}
+
+	t.StartTime = timeutil.TimeStampNow()
+	t.Status = structs.TaskStatusRunning
+	if err := t.UpdateCols(ctx, "start_time", "status"); err != nil {
+		return err
+	}
+
+	return issue_service.BulkEditIssues(ctx, t.Doer, t.Repo, payload.IssueIDs, &payload.Options, func(done int) {
+		t.ProgressDone = int64(done)
+		if err := t.UpdateCols(ctx, "progress_done"); err != nil {
+			log.Error("Task UpdateCols failed: %v", err)
+		}
+	})
+}
@@ -109,6 +109,27 @@ func (ns *notificationService) IssueChangeStatus(ctx context.Context, doer *user
 	})
 }
 
+func (ns *notificationService) IssueSLABreached(ctx context.Context, breach *issues_model.IssueSLABreach) {
+	issue := breach.Issue
+	if err := issue.LoadAssignees(ctx); err != nil {
+		log.Error("issue.LoadAssignees: %v", err)
+		return
+	}
+	// the assignees are responsible for the issue, or else all its watchers and participants
+	if len(issue.Assignees) == 0 {
+		_ = ns.issueQueue.Push(issueNotificationOpts{
+			IssueID: issue.ID,
+		})
+		return
+	}
+	for _, assignee := range issue.Assignees {
+		_ = ns.issueQueue.Push(issueNotificationOpts{
+			IssueID:    issue.ID,
+			ReceiverID: assignee.ID,
+		})
+	}
+}
+
 func (ns *notificationService) IssueChangeTitle(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldTitle string) {
 	if err := issue.LoadPullRequest(ctx); err != nil {
 		log.Error("issue.LoadPullRequest: %v", err)
@@ -208,6 +208,12 @@ func (dc dingtalkConvertor) Package(p *api.PackagePayload) (DingtalkPayload, err
 	return createDingtalkPayload(text, text, "view package", p.Package.HTMLURL), nil
 }
 
+func (dc dingtalkConvertor) CommitComment(p *api.CommitCommentPayload) (DingtalkPayload, error) {
+	text, commitTitle, _ := getCommitCommentPayloadInfo(p, noneLinkFormatter, true)
+
+	return createDingtalkPayload(commitTitle, text+"\r\n\r\n"+p.Comment.Body, "view commit comment", p.Comment.HTMLURL), nil
+}
+
 func createDingtalkPayload(title, text, singleTitle, singleURL string) DingtalkPayload {
 	return DingtalkPayload{
 		MsgType: "actionCard",
@@ -283,6 +283,12 @@ func (d discordConvertor) Package(p *api.PackagePayload) (DiscordPayload, error)
 	return d.createPayload(p.Sender, text, "", p.Package.HTMLURL, color), nil
 }
 
+func (d discordConvertor) CommitComment(p *api.CommitCommentPayload) (DiscordPayload, error) {
+	title, _, color := getCommitCommentPayloadInfo(p, noneLinkFormatter, false)
+
+	return d.createPayload(p.Sender, title, p.Comment.Body, p.Comment.HTMLURL, color), nil
+}
+
 type discordConvertor struct {
 	Username  string
 	AvatarURL string
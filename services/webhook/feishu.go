@@ -191,6 +191,12 @@ func (fc feishuConvertor) Package(p *api.PackagePayload) (FeishuPayload, error)
 	return newFeishuTextPayload(text), nil
 }
 
+func (fc feishuConvertor) CommitComment(p *api.CommitCommentPayload) (FeishuPayload, error) {
+	text, _, _ := getCommitCommentPayloadInfo(p, noneLinkFormatter, true)
+
+	return newFeishuTextPayload(text + "\n\n" + p.Comment.Body), nil
+}
+
 type feishuConvertor struct{}
 
 var _ shared.PayloadConvertor[FeishuPayload] = feishuConvertor{}
@@ -130,6 +130,15 @@ func getIssuesPayloadInfo(p *api.IssuePayload, linkFormatter linkFormatter, with
 			linkFormatter(mileStoneLink, p.Issue.Milestone.Title), titleLink)
 	case api.HookIssueDemilestoned:
 		text = fmt.Sprintf("[%s] Issue milestone cleared: %s", repoLink, titleLink)
+	case api.HookIssueSLABreached:
+		kind := "resolution"
+		if p.SLABreach.Kind == "first_response" {
+			kind = "first response"
+		}
+		text = fmt.Sprintf("[%s] Issue missed the %s target of the SLA policy %s: %s", repoLink, kind, p.SLABreach.Policy, titleLink)
+		color = redColor
+		// the breaches are not caused by a user
+		withSender = false
 	}
 	if withSender {
 		text += fmt.Sprintf(" by %s", linkFormatter(setting.AppURL+url.PathEscape(p.Sender.UserName), p.Sender.UserName))
@@ -293,6 +302,37 @@ func getIssueCommentPayloadInfo(p *api.IssueCommentPayload, linkFormatter linkFo
 	return text, issueTitle, color
 }
 
+func getCommitCommentPayloadInfo(p *api.CommitCommentPayload, linkFormatter linkFormatter, withSender bool) (string, string, int) {
+	repoLink := linkFormatter(p.Repository.HTMLURL, p.Repository.FullName)
+	commitTitle := p.Comment.CommitID[:7]
+	if p.Comment.Path != "" {
+		commitTitle += " " + p.Comment.Path
+		if p.Comment.Line != 0 {
+			commitTitle += fmt.Sprintf(":%d", max(p.Comment.Line, -p.Comment.Line))
+		}
+	}
+	titleLink := linkFormatter(p.Comment.HTMLURL, commitTitle)
+
+	var text string
+	color := yellowColor
+
+	switch p.Action {
+	case api.HookIssueCommentCreated:
+		text = fmt.Sprintf("[%s] New comment on commit %s", repoLink, titleLink)
+		color = greenColorLight
+	case api.HookIssueCommentEdited:
+		text = fmt.Sprintf("[%s] Comment edited on commit %s", repoLink, titleLink)
+	case api.HookIssueCommentDeleted:
+		text = fmt.Sprintf("[%s] Comment deleted on commit %s", repoLink, titleLink)
+		color = redColor
+	}
+	if withSender {
+		text += fmt.Sprintf(" by %s", linkFormatter(setting.AppURL+url.PathEscape(p.Sender.UserName), p.Sender.UserName))
+	}
+
+	return text, commitTitle, color
+}
+
 func getPackagePayloadInfo(p *api.PackagePayload, linkFormatter linkFormatter, withSender bool) (text string, color int) {
 	refLink := linkFormatter(p.Package.HTMLURL, p.Package.Name+":"+p.Package.Version)
 
@@ -303,6 +343,9 @@ func getPackagePayloadInfo(p *api.PackagePayload, linkFormatter linkFormatter, w
 	case api.HookPackageDeleted:
 		text = fmt.Sprintf("Package deleted: %s", refLink)
 		color = redColor
+	case api.HookPackagePromoted:
+		text = fmt.Sprintf("Package promoted: %s", refLink)
+		color = greenColor
 	}
 	if withSender {
 		text += fmt.Sprintf(" by %s", linkFormatter(setting.AppURL+url.PathEscape(p.Sender.UserName), p.Sender.UserName))
@@ -344,8 +344,32 @@ func packageTestPayload() *api.PackagePayload {
 	}
 }
 
+func commitCommentTestPayload() *api.CommitCommentPayload {
+	return &api.CommitCommentPayload{
+		Action: api.HookIssueCommentCreated,
+		Sender: &api.User{
+			UserName:  "user1",
+			AvatarURL: "http://localhost:3000/user1/avatar",
+		},
+		Repository: &api.Repository{
+			HTMLURL:  "http://localhost:3000/test/repo",
+			Name:     "repo",
+			FullName: "test/repo",
+		},
+		Comment: &api.CommitComment{
+			ID:       5,
+			HTMLURL:  "http://localhost:3000/test/repo/commit/2020558fe2e34debb818a514715839cabd25e778#commitcomment-5",
+			CommitID: "2020558fe2e34debb818a514715839cabd25e778",
+			Path:     "README.md",
+			Line:     -3,
+			Body:     "why was this removed?",
+		},
+	}
+}
+
 func TestGetIssuesPayloadInfo(t *testing.T) {
 	p := issueTestPayload()
+	p.SLABreach = &api.SLABreachPayload{Policy: "support", Kind: "first_response"}
 
 	cases := []struct {
 		action         api.HookIssueAction
@@ -431,6 +455,13 @@ func TestGetIssuesPayloadInfo(t *testing.T) {
 			"",
 			yellowColor,
 		},
+		{
+			api.HookIssueSLABreached,
+			"[test/repo] Issue missed the first response target of the SLA policy support: #2 crash",
+			"#2 crash",
+			"",
+			redColor,
+		},
 	}
 
 	for i, c := range cases {
@@ -650,3 +681,46 @@ func TestGetIssueCommentPayloadInfo(t *testing.T) {
 		assert.Equal(t, c.color, color, "case %d", i)
 	}
 }
+
+func TestGetCommitCommentPayloadInfo(t *testing.T) {
+	p := commitCommentTestPayload()
+
+	cases := []struct {
+		action      api.HookIssueCommentAction
+		text        string
+		commitTitle string
+		color       int
+	}{
+		{
+			api.HookIssueCommentCreated,
+			"[test/repo] New comment on commit 2020558 README.md:3 by user1",
+			"2020558 README.md:3",
+			greenColorLight,
+		},
+		{
+			api.HookIssueCommentEdited,
+			"[test/repo] Comment edited on commit 2020558 README.md:3 by user1",
+			"2020558 README.md:3",
+			yellowColor,
+		},
+		{
+			api.HookIssueCommentDeleted,
+			"[test/repo] Comment deleted on commit 2020558 README.md:3 by user1",
+			"2020558 README.md:3",
+			redColor,
+		},
+	}
+
+	for i, c := range cases {
+		p.Action = c.action
+		text, commitTitle, color := getCommitCommentPayloadInfo(p, noneLinkFormatter, true)
+		assert.Equal(t, c.text, text, "case %d", i)
+		assert.Equal(t, c.commitTitle, commitTitle, "case %d", i)
+		assert.Equal(t, c.color, color, "case %d", i)
+	}
+
+	p.Comment.Path = ""
+	p.Comment.Line = 0
+	_, commitTitle, _ := getCommitCommentPayloadInfo(p, noneLinkFormatter, false)
+	assert.Equal(t, "2020558", commitTitle)
+}
@@ -275,11 +275,19 @@ func (m matrixConvertor) Package(p *api.PackagePayload) (MatrixPayload, error) {
 		text = fmt.Sprintf("[%s] Package published by %s", packageLink, senderLink)
 	case api.HookPackageDeleted:
 		text = fmt.Sprintf("[%s] Package deleted by %s", packageLink, senderLink)
+	case api.HookPackagePromoted:
+		text = fmt.Sprintf("[%s] Package promoted by %s", packageLink, senderLink)
 	}
 
 	return m.newPayload(text)
 }
 
+func (m matrixConvertor) CommitComment(p *api.CommitCommentPayload) (MatrixPayload, error) {
+	text, _, _ := getCommitCommentPayloadInfo(p, htmlLinkFormatter, true)
+
+	return m.newPayload(text)
+}
+
 var urlRegex = regexp.MustCompile(`<a [^>]*?href="([^">]*?)">(.*?)</a>`)
 
 func getMessageBody(htmlText string) string {
@@ -327,6 +327,20 @@ func (m msteamsConvertor) Package(p *api.PackagePayload) (MSTeamsPayload, error)
 	), nil
 }
 
+func (m msteamsConvertor) CommitComment(p *api.CommitCommentPayload) (MSTeamsPayload, error) {
+	title, commitTitle, color := getCommitCommentPayloadInfo(p, noneLinkFormatter, false)
+
+	return createMSTeamsPayload(
+		p.Repository,
+		p.Sender,
+		title,
+		p.Comment.Body,
+		p.Comment.HTMLURL,
+		color,
+		&MSTeamsFact{"Commit:", commitTitle},
+	), nil
+}
+
 func createMSTeamsPayload(r *api.Repository, s *api.User, title, text, actionTarget string, color int, fact *MSTeamsFact) MSTeamsPayload {
 	facts := make([]MSTeamsFact, 0, 2)
 	if r != nil {
@@ -6,6 +6,7 @@ package webhook
 import (
 	"context"
 
+	git_model "code.gitea.io/gitea/models/git"
 	issues_model "code.gitea.io/gitea/models/issues"
 	packages_model "code.gitea.io/gitea/models/packages"
 	"code.gitea.io/gitea/models/perm"
@@ -267,6 +268,40 @@ func (m *webhookNotifier) IssueChangeStatus(ctx context.Context, doer *user_mode
 	}
 }
 
+func (m *webhookNotifier) IssueSLABreached(ctx context.Context, breach *issues_model.IssueSLABreach) {
+	issue := breach.Issue
+	if err := issue.LoadRepo(ctx); err != nil {
+		log.Error("LoadRepo: %v", err)
+		return
+	}
+	if err := issue.Repo.LoadOwner(ctx); err != nil {
+		log.Error("LoadOwner: %v", err)
+		return
+	}
+	if err := issue.LoadAttributes(ctx); err != nil {
+		log.Error("LoadAttributes: %v", err)
+		return
+	}
+
+	// the breaches are detected on behalf of the owner of the repository, like the mirror syncs
+	sender := issue.Repo.Owner
+	permission, _ := access_model.GetUserRepoPermission(ctx, issue.Repo, sender)
+	if err := PrepareWebhooks(ctx, EventSource{Repository: issue.Repo}, webhook_module.HookEventIssues, &api.IssuePayload{
+		Action:     api.HookIssueSLABreached,
+		Index:      issue.Index,
+		Issue:      convert.ToAPIIssue(ctx, sender, issue),
+		Repository: convert.ToRepo(ctx, issue.Repo, permission),
+		Sender:     convert.ToUser(ctx, sender, nil),
+		SLABreach: &api.SLABreachPayload{
+			Policy:   breach.Policy.Name,
+			Kind:     string(breach.Kind),
+			Deadline: breach.Policy.Deadline(issue, breach.Kind).AsTime(),
+		},
+	}); err != nil {
+		log.Error("PrepareWebhooks [issue: %d, sla policy: %d]: %v", issue.ID, breach.PolicyID, err)
+	}
+}
+
 func (m *webhookNotifier) NewIssue(ctx context.Context, issue *issues_model.Issue, mentions []*user_model.User) {
 	if err := issue.LoadRepo(ctx); err != nil {
 		log.Error("issue.LoadRepo: %v", err)
@@ -459,6 +494,43 @@ func (m *webhookNotifier) DeleteComment(ctx context.Context, doer *user_model.Us
 	}
 }
 
+func (m *webhookNotifier) CreateCommitComment(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, comment *git_model.CommitComment, mentions []*user_model.User) {
+	notifyCommitComment(ctx, doer, repo, comment, api.HookIssueCommentCreated, nil)
+}
+
+func (m *webhookNotifier) UpdateCommitComment(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, comment *git_model.CommitComment, oldContent string) {
+	if comment.Content == oldContent {
+		return
+	}
+	notifyCommitComment(ctx, doer, repo, comment, api.HookIssueCommentEdited, &api.ChangesPayload{
+		Body: &api.ChangesFromPayload{
+			From: oldContent,
+		},
+	})
+}
+
+func (m *webhookNotifier) DeleteCommitComment(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, comment *git_model.CommitComment) {
+	notifyCommitComment(ctx, doer, repo, comment, api.HookIssueCommentDeleted, nil)
+}
+
+func notifyCommitComment(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, comment *git_model.CommitComment, action api.HookIssueCommentAction, changes *api.ChangesPayload) {
+	if err := comment.LoadPoster(ctx); err != nil {
+		log.Error("LoadPoster: %v", err)
+		return
+	}
+
+	permission, _ := access_model.GetUserRepoPermission(ctx, repo, doer)
+	if err := PrepareWebhooks(ctx, EventSource{Repository: repo}, webhook_module.HookEventCommitComment, &api.CommitCommentPayload{
+		Action:     action,
+		Comment:    convert.ToCommitComment(ctx, repo, comment),
+		Changes:    changes,
+		Repository: convert.ToRepo(ctx, repo, permission),
+		Sender:     convert.ToUser(ctx, doer, nil),
+	}); err != nil {
+		log.Error("PrepareWebhooks [commit_comment_id: %d]: %v", comment.ID, err)
+	}
+}
+
 func (m *webhookNotifier) NewWikiPage(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, page, comment string) {
 	// Add to hook queue for created wiki page.
 	if err := PrepareWebhooks(ctx, EventSource{Repository: repo}, webhook_module.HookEventWiki, &api.WikiPayload{
@@ -858,14 +930,18 @@ func (m *webhookNotifier) SyncDeleteRef(ctx context.Context, pusher *user_model.
 }
 
 func (m *webhookNotifier) PackageCreate(ctx context.Context, doer *user_model.User, pd *packages_model.PackageDescriptor) {
-	notifyPackage(ctx, doer, pd, api.HookPackageCreated)
+	notifyPackage(ctx, doer, pd, api.HookPackageCreated, nil)
 }
 
 func (m *webhookNotifier) PackageDelete(ctx context.Context, doer *user_model.User, pd *packages_model.PackageDescriptor) {
-	notifyPackage(ctx, doer, pd, api.HookPackageDeleted)
+	notifyPackage(ctx, doer, pd, api.HookPackageDeleted, nil)
 }
 
-func notifyPackage(ctx context.Context, sender *user_model.User, pd *packages_model.PackageDescriptor, action api.HookPackageAction) {
+func (m *webhookNotifier) PackagePromote(ctx context.Context, doer *user_model.User, source, target *packages_model.PackageDescriptor) {
+	notifyPackage(ctx, doer, target, api.HookPackagePromoted, source)
+}
+
+func notifyPackage(ctx context.Context, sender *user_model.User, pd *packages_model.PackageDescriptor, action api.HookPackageAction, promotedFrom *packages_model.PackageDescriptor) {
 	source := EventSource{
 		Repository: pd.Repository,
 		Owner:      pd.Owner,
@@ -877,10 +953,19 @@ func notifyPackage(ctx context.Context, sender *user_model.User, pd *packages_mo
 		return
 	}
 
+	var apiPromotedFrom *api.Package
+	if promotedFrom != nil {
+		if apiPromotedFrom, err = convert.ToPackage(ctx, promotedFrom, sender); err != nil {
+			log.Error("Error converting package: %v", err)
+			return
+		}
+	}
+
 	if err := PrepareWebhooks(ctx, source, webhook_module.HookEventPackage, &api.PackagePayload{
-		Action:  action,
-		Package: apiPackage,
-		Sender:  convert.ToUser(ctx, sender, nil),
+		Action:       action,
+		Package:      apiPackage,
+		PromotedFrom: apiPromotedFrom,
+		Sender:       convert.ToUser(ctx, sender, nil),
 	}); err != nil {
 		log.Error("PrepareWebhooks: %v", err)
 	}
@@ -221,6 +221,17 @@ func (s slackConvertor) Package(p *api.PackagePayload) (SlackPayload, error) {
 	return s.createPayload(text, nil), nil
 }
 
+func (s slackConvertor) CommitComment(p *api.CommitCommentPayload) (SlackPayload, error) {
+	text, commitTitle, color := getCommitCommentPayloadInfo(p, SlackLinkFormatter, true)
+
+	return s.createPayload(text, []SlackAttachment{{
+		Color:     fmt.Sprintf("%x", color),
+		Title:     commitTitle,
+		TitleLink: p.Comment.HTMLURL,
+		Text:      SlackTextFormatter(p.Comment.Body),
+	}}), nil
+}
+
 // Push implements payloadConvertor Push method
 func (s slackConvertor) Push(p *api.PushPayload) (SlackPayload, error) {
 	// n new commits
@@ -126,6 +126,15 @@ func TestSlackPayload(t *testing.T) {
 		assert.Equal(t, "Package created: <http://localhost:3000/user1/-/packages/container/GiteaContainer/latest|GiteaContainer:latest> by <https://try.gitea.io/user1|user1>", pl.Text)
 	})
 
+	t.Run("CommitComment", func(t *testing.T) {
+		p := commitCommentTestPayload()
+
+		pl, err := sc.CommitComment(p)
+		require.NoError(t, err)
+
+		assert.Equal(t, "[<http://localhost:3000/test/repo|test/repo>] New comment on commit <http://localhost:3000/test/repo/commit/2020558fe2e34debb818a514715839cabd25e778#commitcomment-5|2020558 README.md:3> by <https://try.gitea.io/user1|user1>", pl.Text)
+	})
+
 	t.Run("Wiki", func(t *testing.T) {
 		p := wikiTestPayload()
 
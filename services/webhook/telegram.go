@@ -212,6 +212,12 @@ func (t telegramConvertor) Package(p *api.PackagePayload) (TelegramPayload, erro
 	return createTelegramPayload(text), nil
 }
 
+func (t telegramConvertor) CommitComment(p *api.CommitCommentPayload) (TelegramPayload, error) {
+	text, _, _ := getCommitCommentPayloadInfo(p, htmlLinkFormatter, true)
+
+	return createTelegramPayload(text + "\n" + p.Comment.Body), nil
+}
+
 func createTelegramPayload(message string) TelegramPayload {
 	return TelegramPayload{
 		Message:           markup.Sanitize(strings.TrimSpace(message)),
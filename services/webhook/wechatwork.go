@@ -202,6 +202,14 @@ func (wc wechatworkConvertor) Package(p *api.PackagePayload) (WechatworkPayload,
 	return newWechatworkMarkdownPayload(text), nil
 }
 
+func (wc wechatworkConvertor) CommitComment(p *api.CommitCommentPayload) (WechatworkPayload, error) {
+	text, commitTitle, _ := getCommitCommentPayloadInfo(p, noneLinkFormatter, true)
+	var content string
+	content += fmt.Sprintf(" ><font color=\"info\">%s</font>\n >%s \n ><font color=\"warning\">%s</font> \n [%s](%s)", text, p.Comment.Body, commitTitle, p.Comment.HTMLURL, p.Comment.HTMLURL)
+
+	return newWechatworkMarkdownPayload(content), nil
+}
+
 type wechatworkConvertor struct{}
 
 var _ shared.PayloadConvertor[WechatworkPayload] = wechatworkConvertor{}
@@ -0,0 +1,83 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	git_model "code.gitea.io/gitea/models/git"
+	"code.gitea.io/gitea/models/unittest"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPICommitComments(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	const sha = "65f1bf27bc3bf70f64657658635e66094edbcb4d"
+	token := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteRepository)
+	commentsURL := fmt.Sprintf("/api/v1/repos/user2/repo1/git/commits/%s/comments", sha[:10])
+
+	// comment on a line of a file of the commit
+	req := NewRequestWithJSON(t, "POST", commentsURL, &api.CreateCommitCommentOption{
+		Body: "shouldn't this be documented?",
+		Path: "README.md",
+		Line: 1,
+	}).AddTokenAuth(token)
+	resp := MakeRequest(t, req, http.StatusCreated)
+	var comment api.CommitComment
+	DecodeJSON(t, resp, &comment)
+	assert.Equal(t, sha, comment.CommitID)
+	assert.Equal(t, "README.md", comment.Path)
+	assert.EqualValues(t, 1, comment.Line)
+	assert.Equal(t, "user2", comment.Poster.UserName)
+	unittest.AssertExistsAndLoadBean(t, &git_model.CommitComment{ID: comment.ID, CommitSHA: sha})
+
+	// the file must exist in the commented version
+	req = NewRequestWithJSON(t, "POST", commentsURL, &api.CreateCommitCommentOption{
+		Body: "nope",
+		Path: "does-not-exist.md",
+	}).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+	// the initial commit has no parent to comment on
+	req = NewRequestWithJSON(t, "POST", commentsURL, &api.CreateCommitCommentOption{
+		Body: "nope",
+		Path: "README.md",
+		Line: -1,
+	}).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+	req = NewRequest(t, "GET", commentsURL).AddTokenAuth(token)
+	resp = MakeRequest(t, req, http.StatusOK)
+	var comments []*api.CommitComment
+	DecodeJSON(t, resp, &comments)
+	require.Len(t, comments, 1)
+	assert.Equal(t, comment.ID, comments[0].ID)
+
+	commentURL := fmt.Sprintf("/api/v1/repos/user2/repo1/git/commits/comments/%d", comment.ID)
+	req = NewRequestWithJSON(t, "PATCH", commentURL, &api.EditCommitCommentOption{
+		Body: "shouldn't this be documented better?",
+	}).AddTokenAuth(token)
+	resp = MakeRequest(t, req, http.StatusOK)
+	DecodeJSON(t, resp, &comment)
+	assert.Equal(t, "shouldn't this be documented better?", comment.Body)
+
+	// only the poster can edit the comment
+	otherToken := getUserToken(t, "user4", auth_model.AccessTokenScopeWriteRepository)
+	req = NewRequestWithJSON(t, "PATCH", commentURL, &api.EditCommitCommentOption{
+		Body: "hijacked",
+	}).AddTokenAuth(otherToken)
+	MakeRequest(t, req, http.StatusForbidden)
+
+	req = NewRequest(t, "DELETE", commentURL).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusNoContent)
+	unittest.AssertNotExistsBean(t, &git_model.CommitComment{ID: comment.ID})
+}
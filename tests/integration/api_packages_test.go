@@ -149,6 +149,21 @@ func TestPackageAPI(t *testing.T) {
 		assert.Equal(t, "cf83e1357eefb8bdf1542850d66d8007d620e4050b5715dc83f4a921d36ce9ce47d0d13c5d85f2b0ff8318d2877eec2f63b931bd47417a81a538327af927da3e", files[0].HashSHA512)
 	})
 
+	t.Run("PromotePackage", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		// the user 2 can write its own packages, but can only read the packages of the user 4
+		other := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		tokenOther := getUserToken(t, other.Name, auth_model.AccessTokenScopeWritePackage)
+
+		req := NewRequestWithJSON(t, "POST", fmt.Sprintf("/api/v1/packages/%s/generic/%s/%s/promote", user.Name, packageName, packageVersion), &api.PromotePackageOption{
+			Owner: other.Name,
+		}).AddTokenAuth(tokenOther)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		unittest.AssertNotExistsBean(t, &packages_model.Package{OwnerID: other.ID, LowerName: packageName})
+	})
+
 	t.Run("DeletePackage", func(t *testing.T) {
 		defer tests.PrintCurrentTest(t)()
 
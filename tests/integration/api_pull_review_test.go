@@ -145,6 +145,94 @@ func TestAPIPullReviewCreateDeleteComment(t *testing.T) {
 	}
 }
 
+func TestAPIPullReviewPendingReview(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	pullIssue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 3})
+	require.NoError(t, pullIssue.LoadAttributes(db.DefaultContext))
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: pullIssue.RepoID})
+
+	session := loginUser(t, "user2")
+	token := getTokenForLoggedInUser(t, session, auth_model.AccessTokenScopeWriteRepository)
+	reviewsURL := fmt.Sprintf("/api/v1/repos/%s/pulls/%d/reviews", repo.FullName(), pullIssue.Index)
+
+	req := NewRequest(t, http.MethodGet, reviewsURL+"/pending").AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusNotFound)
+
+	// start a pending review
+	req = NewRequestWithJSON(t, http.MethodPost, reviewsURL, &api.CreatePullReviewOptions{
+		Comments: []api.CreatePullReviewComment{
+			{
+				Path:       "README.md",
+				Body:       "first new line",
+				NewLineNum: 1,
+			},
+		},
+	}).AddTokenAuth(token)
+	resp := MakeRequest(t, req, http.StatusOK)
+	var review api.PullReview
+	DecodeJSON(t, resp, &review)
+	assert.EqualValues(t, "PENDING", review.State)
+
+	req = NewRequest(t, http.MethodGet, reviewsURL+"/pending").AddTokenAuth(token)
+	resp = MakeRequest(t, req, http.StatusOK)
+	var pending api.PullReview
+	DecodeJSON(t, resp, &pending)
+	assert.EqualValues(t, review.ID, pending.ID)
+
+	req = NewRequestf(t, http.MethodGet, "%s/%d/comments", reviewsURL, review.ID).AddTokenAuth(token)
+	resp = MakeRequest(t, req, http.StatusOK)
+	var comments []*api.PullReviewComment
+	DecodeJSON(t, resp, &comments)
+	require.Len(t, comments, 1)
+
+	// reply to the thread as part of the pending review
+	req = NewRequestWithJSON(t, http.MethodPost, fmt.Sprintf("%s/%d/comments", reviewsURL, review.ID), &api.CreatePullReviewCommentOptions{
+		Body:    "and another thing",
+		ReplyTo: comments[0].ID,
+	}).AddTokenAuth(token)
+	resp = MakeRequest(t, req, http.StatusOK)
+	var reply api.PullReviewComment
+	DecodeJSON(t, resp, &reply)
+	assert.EqualValues(t, review.ID, reply.ReviewID)
+	assert.EqualValues(t, "README.md", reply.Path)
+	assert.EqualValues(t, 1, reply.LineNum)
+
+	req = NewRequestWithJSON(t, http.MethodPost, fmt.Sprintf("%s/%d/comments", reviewsURL, review.ID), &api.CreatePullReviewCommentOptions{
+		Body:    "not a review comment",
+		ReplyTo: 2,
+	}).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+	// edit the reply
+	req = NewRequestWithJSON(t, http.MethodPatch, fmt.Sprintf("%s/%d/comments/%d", reviewsURL, review.ID, reply.ID), &api.EditPullReviewCommentOptions{
+		Body: "and one more thing",
+	}).AddTokenAuth(token)
+	resp = MakeRequest(t, req, http.StatusOK)
+	DecodeJSON(t, resp, &reply)
+	assert.EqualValues(t, "and one more thing", reply.Body)
+
+	// only the reviewer can add comments to the review
+	adminToken := getTokenForLoggedInUser(t, loginUser(t, "user1"), auth_model.AccessTokenScopeWriteRepository)
+	req = NewRequestWithJSON(t, http.MethodPost, fmt.Sprintf("%s/%d/comments", reviewsURL, review.ID), &api.CreatePullReviewCommentOptions{
+		Path:       "README.md",
+		Body:       "hijacked",
+		NewLineNum: 1,
+	}).AddTokenAuth(adminToken)
+	MakeRequest(t, req, http.StatusForbidden)
+
+	// submit the review with all its comments
+	req = NewRequestWithJSON(t, http.MethodPost, fmt.Sprintf("%s/%d", reviewsURL, review.ID), &api.SubmitPullReviewOptions{
+		Event: "COMMENT",
+	}).AddTokenAuth(token)
+	resp = MakeRequest(t, req, http.StatusOK)
+	DecodeJSON(t, resp, &review)
+	assert.EqualValues(t, "COMMENT", review.State)
+	assert.EqualValues(t, 2, review.CodeCommentsCount)
+
+	req = NewRequest(t, http.MethodGet, reviewsURL+"/pending").AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusNotFound)
+}
+
 func TestAPIPullReview(t *testing.T) {
 	defer tests.PrepareTestEnv(t)()
 	pullIssue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 3})
@@ -112,6 +112,7 @@ func TestDoctorPackagesNuget(t *testing.T) {
 			&packages_service.PackageFileInfo{
 				Filename: strings.ToLower(fmt.Sprintf("%s.nuspec", packageName)),
 			},
+			nil,
 		)
 
 		require.NoError(t, err, "Error getting nuspec file stream by package name and version")
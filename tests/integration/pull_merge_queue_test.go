@@ -0,0 +1,158 @@
+// Copyright 2024 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	git_model "code.gitea.io/gitea/models/git"
+	issues_model "code.gitea.io/gitea/models/issues"
+	pull_model "code.gitea.io/gitea/models/pull"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/services/mergequeue"
+	commitstatus_service "code.gitea.io/gitea/services/repository/commitstatus"
+	files_service "code.gitea.io/gitea/services/repository/files"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullMergeQueue(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		session := loginUser(t, user2.Name)
+
+		repo, _, f := CreateDeclarativeRepo(t, user2, "", nil, nil, nil)
+		defer f()
+
+		// two pull requests adding different files, so that they can be merged one after the other
+		prs := make([]*issues_model.PullRequest, 0, 2)
+		for _, branch := range []string{"queue-1", "queue-2"} {
+			_, err := files_service.ChangeRepoFiles(git.DefaultContext, repo, user2, &files_service.ChangeRepoFilesOptions{
+				Files: []*files_service.ChangeRepoFile{
+					{
+						Operation:     "create",
+						TreePath:      branch + ".txt",
+						ContentReader: strings.NewReader(branch),
+					},
+				},
+				Message:   "add " + branch,
+				OldBranch: "main",
+				NewBranch: branch,
+				Author: &files_service.IdentityOptions{
+					Name:  user2.Name,
+					Email: user2.Email,
+				},
+				Committer: &files_service.IdentityOptions{
+					Name:  user2.Name,
+					Email: user2.Email,
+				},
+				Dates: &files_service.CommitDateOptions{
+					Author:    time.Now(),
+					Committer: time.Now(),
+				},
+			})
+			require.NoError(t, err)
+
+			testPullCreate(t, session, user2.Name, repo.Name, true, "main", branch, "Merge queue "+branch)
+			prs = append(prs, unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{BaseRepoID: repo.ID, HeadBranch: branch}))
+		}
+
+		// merge main through a merge queue once the status check succeeded
+		link := fmt.Sprintf("/%s/%s/settings/branches", user2.Name, repo.Name)
+		req := NewRequestWithValues(t, "POST", link+"/edit", map[string]string{
+			"_csrf":                 GetCSRF(t, session, link),
+			"rule_name":             "main",
+			"enable_push":           "true",
+			"enable_status_check":   "true",
+			"status_check_contexts": "ci/test",
+			"enable_merge_queue":    "true",
+		})
+		session.MakeRequest(t, req, http.StatusSeeOther)
+
+		gitRepo, err := git.OpenRepository(git.DefaultContext, repo.RepoPath())
+		require.NoError(t, err)
+		defer gitRepo.Close()
+		mainCommitID, err := gitRepo.GetBranchCommitID("main")
+		require.NoError(t, err)
+
+		for _, pr := range prs {
+			require.NoError(t, mergequeue.Add(db.DefaultContext, user2, pr, repo_model.MergeStyleMerge, ""))
+		}
+
+		// waitForSpeculativeMerge waits until the speculative merge of the pull request is built on the parent commit
+		waitForSpeculativeMerge := func(t *testing.T, pr *issues_model.PullRequest, parentCommitID string) *pull_model.MergeQueueEntry {
+			t.Helper()
+			var e *pull_model.MergeQueueEntry
+			assert.Eventually(t, func() bool {
+				exists, entry, err := pull_model.GetMergeQueueEntryByPullID(db.DefaultContext, pr.ID)
+				if err != nil || !exists {
+					return false
+				}
+				e = entry
+				return e.SpeculativeCommitID != "" && e.ParentCommitID == parentCommitID
+			}, 10*time.Second, 100*time.Millisecond)
+			require.NotNil(t, e)
+			return e
+		}
+
+		setStatus := func(t *testing.T, sha string, state api.CommitStatusState) {
+			t.Helper()
+			require.NoError(t, commitstatus_service.CreateCommitStatus(db.DefaultContext, repo, user2, sha, &git_model.CommitStatus{
+				State:     state,
+				TargetURL: "https://example.com/ci",
+				Context:   "ci/test",
+			}))
+		}
+
+		// the second speculative merge is built on top of the first one
+		first := waitForSpeculativeMerge(t, prs[0], mainCommitID)
+		second := waitForSpeculativeMerge(t, prs[1], first.SpeculativeCommitID)
+		assert.True(t, gitRepo.IsBranchExist(first.SpeculativeBranch(prs[0].Index)))
+		assert.True(t, gitRepo.IsBranchExist(second.SpeculativeBranch(prs[1].Index)))
+
+		// the first pull request fails its status check and leaves the queue
+		setStatus(t, first.SpeculativeCommitID, api.CommitStatusFailure)
+		assert.Eventually(t, func() bool {
+			exists, _, err := pull_model.GetMergeQueueEntryByPullID(db.DefaultContext, prs[0].ID)
+			return err == nil && !exists
+		}, 10*time.Second, 100*time.Millisecond)
+		unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{
+			IssueID: prs[0].IssueID,
+			Type:    issues_model.CommentTypePRRemovedFromMergeQueue,
+			Content: mergequeue.ReasonChecksFailed,
+		})
+		assert.False(t, gitRepo.IsBranchExist(first.SpeculativeBranch(prs[0].Index)))
+
+		// the second pull request is rebuilt on main without the first one
+		rebuilt := waitForSpeculativeMerge(t, prs[1], mainCommitID)
+		assert.NotEqual(t, second.SpeculativeCommitID, rebuilt.SpeculativeCommitID)
+
+		// and merged once its status check succeeded
+		setStatus(t, rebuilt.SpeculativeCommitID, api.CommitStatusSuccess)
+		assert.Eventually(t, func() bool {
+			pr := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: prs[1].ID})
+			return pr.HasMerged
+		}, 10*time.Second, 100*time.Millisecond)
+
+		newCommitID, err := gitRepo.GetBranchCommitID("main")
+		require.NoError(t, err)
+		assert.Equal(t, rebuilt.SpeculativeCommitID, newCommitID)
+		unittest.AssertNotExistsBean(t, &pull_model.MergeQueueEntry{PullID: prs[1].ID})
+		assert.False(t, gitRepo.IsBranchExist(rebuilt.SpeculativeBranch(prs[1].Index)))
+
+		pr := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: prs[0].ID})
+		assert.False(t, pr.HasMerged)
+	})
+}